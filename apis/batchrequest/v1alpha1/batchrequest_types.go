@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// BatchRequestParameters are the configurable fields of a BatchRequest.
+type BatchRequestParameters struct {
+	// BaseURL is the URL of the REST API, e.g. https://api.example.com/v1.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.baseURL' is immutable"
+	BaseURL string `json:"baseURL"`
+
+	// Path is the path of the item collection relative to the base URL, e.g. users. The items are listed with
+	// a GET to the collection and created with a POST to it, and updated and deleted with a PUT and DELETE to
+	// the collection path followed by their ID.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.path' is immutable"
+	Path string `json:"path"`
+
+	// Items are the JSON representations of the desired items of the collection.
+	Items []string `json:"items"`
+
+	// KeyPath is a jq filter extracting the key identifying an item from its desired and remote
+	// representations, e.g. '.name'. Remote items with the key of a desired item are managed by the
+	// BatchRequest, whether or not it created them.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.keyPath' is immutable"
+	KeyPath string `json:"keyPath"`
+
+	// IDPath is a jq filter extracting the ID the server assigned to an item from its remote representation.
+	// +kubebuilder:default=".id"
+	// +optional
+	IDPath string `json:"idPath,omitempty"`
+
+	// ListPath is a jq filter extracting the array of remote items from the body of the list response, e.g.
+	// '.data' for paginated envelopes.
+	// +kubebuilder:default="."
+	// +optional
+	ListPath string `json:"listPath,omitempty"`
+
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+
+	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP requests
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures how the TLS certificate of the server is verified. It is merged over the tls of the ProviderConfig.
+	// Its insecureSkipVerify takes precedence over insecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
+	// StatusCodes declares which response status codes are successful, retryable or terminal.
+	// It replaces the statusCodes of the ProviderConfig.
+	StatusCodes *apisv1alpha1.StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the requests of the BatchRequest. It replaces the auth of the ProviderConfig.
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// PollInterval overrides how often the BatchRequest is observed, e.g. 30m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// A BatchRequestSpec defines the desired state of a BatchRequest.
+type BatchRequestSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	ForProvider BatchRequestParameters `json:"forProvider"`
+}
+
+// ItemStatus is a remote item managed by a BatchRequest.
+type ItemStatus struct {
+	// Key is the key of the item.
+	Key string `json:"key"`
+
+	// ID is the ID the server assigned to the item.
+	ID string `json:"id"`
+}
+
+// A BatchRequestStatus represents the observed state of a BatchRequest.
+type BatchRequestStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Items are the remote items managed by the BatchRequest, as of the last observation. Managed items
+	// removed from the desired items are deleted.
+	Items []ItemStatus `json:"items,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	// LastFailureReason is the reason of the most recent failure.
+	LastFailureReason apisv1alpha1.FailureReason `json:"lastFailureReason,omitempty"`
+
+	// CorrelationID is the value of the correlation header set on the last requests, when the ProviderConfig
+	// sets a correlationHeader.
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Timings break down the duration of the last request.
+	Timings *apisv1alpha1.Timings `json:"timings,omitempty"`
+
+	// LastObservedTime is when the resource was last observed successfully, i.e. when its drift from the desired
+	// state was last checked.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// LastOperationTime is when the resource was last created, updated or deleted successfully.
+	LastOperationTime *metav1.Time `json:"lastOperationTime,omitempty"`
+
+	// NextPollTime is when the resource is reconciled next, unless it changes before.
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BatchRequest manages the items of a collection of a REST API, creating the missing items, updating the
+// drifted ones and deleting the removed ones.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,http}
+type BatchRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BatchRequestSpec   `json:"spec"`
+	Status BatchRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BatchRequestList contains a list of BatchRequest
+type BatchRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BatchRequest `json:"items"`
+}
+
+// BatchRequest type metadata.
+var (
+	BatchRequestKind             = reflect.TypeOf(BatchRequest{}).Name()
+	BatchRequestGroupKind        = schema.GroupKind{Group: Group, Kind: BatchRequestKind}.String()
+	BatchRequestKindAPIVersion   = BatchRequestKind + "." + SchemeGroupVersion.String()
+	BatchRequestGroupVersionKind = SchemeGroupVersion.WithKind(BatchRequestKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&BatchRequest{}, &BatchRequestList{})
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func (b *BatchRequest) SetError(reason apisv1alpha1.FailureReason, err error) {
+	b.Status.LastFailureReason = reason
+	apisv1alpha1.SetRequestFailing(&b.Status.ResourceStatus, reason)
+	b.Status.Error = ""
+	if err != nil {
+		b.Status.Error = err.Error()
+	}
+}
+
+func (b *BatchRequest) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return b.Status.LastFailureReason
+}
+
+func (b *BatchRequest) SetCorrelationID(id string) {
+	b.Status.CorrelationID = id
+}
+
+func (b *BatchRequest) SetTimings(timings *apisv1alpha1.Timings) {
+	b.Status.Timings = timings
+}
+
+func (b *BatchRequest) SetLastObservedTime(t metav1.Time) {
+	b.Status.LastObservedTime = &t
+}
+
+func (b *BatchRequest) GetLastOperationTime() *metav1.Time {
+	return b.Status.LastOperationTime
+}
+
+func (b *BatchRequest) SetLastOperationTime(t metav1.Time) {
+	b.Status.LastOperationTime = &t
+}
+
+func (b *BatchRequest) GetNextPollTime() *metav1.Time {
+	return b.Status.NextPollTime
+}
+
+func (b *BatchRequest) SetNextPollTime(t *metav1.Time) {
+	b.Status.NextPollTime = t
+}
+
+func (b *BatchRequest) GetPollInterval() *metav1.Duration {
+	return b.Spec.ForProvider.PollInterval
+}
@@ -23,8 +23,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 )
 
+// AnnotationKeyResume is the annotation that clears the failures of a DesposibleRequest, e.g. once its rollback
+// retries limit is exhausted, and sends its request again. It is removed once the request is resumed.
+const AnnotationKeyResume = "http.crossplane.io/resume"
+
 // DesposibleRequestParameters are the configurable fields of a DesposibleRequest.
 type DesposibleRequestParameters struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.url' is immutable"
@@ -39,15 +45,42 @@ type DesposibleRequestParameters struct {
 	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
 
 	// RollbackRetriesLimit is max number of attempts to retry HTTP request by sending again the request.
+	// Once exhausted, the Failed condition is set with reason RetriesExhausted until the
+	// http.crossplane.io/resume annotation is set.
 	RollbackRetriesLimit *int32 `json:"rollbackRetriesLimit,omitempty"`
 
+	// RetryBackoff delays the retries of the failed request exponentially, with jitter. The time of the next
+	// retry is recorded in status.nextRetryTime.
+	// +optional
+	RetryBackoff *apisv1alpha1.RetryBackoff `json:"retryBackoff,omitempty"`
+
 	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP request
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
 
+	// TLS configures how the TLS certificate of the server is verified. It is merged over the tls of the ProviderConfig.
+	// Its insecureSkipVerify takes precedence over insecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
 	// ExpectedResponse is a jq filter expression used to evaluate the HTTP response and determine if it matches the expected criteria.
 	// The expression should return a boolean; if true, the response is considered expected.
 	// Example: '.Body.job_status == "success"'
 	ExpectedResponse string `json:"expectedResponse,omitempty"`
+
+	// StatusCodes declares which response status codes are successful, retryable or terminal.
+	// It replaces the statusCodes of the ProviderConfig. Terminal responses are not retried.
+	StatusCodes *apisv1alpha1.StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the request of the DesposibleRequest. It replaces the auth of the ProviderConfig.
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// Schedule sends the request again on the given cron schedule, evaluated in UTC, e.g. "0 3 * * *" to rotate
+	// a token daily. The @yearly, @monthly, @weekly, @daily and @hourly descriptors are supported as well.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// PollInterval overrides how often the DesposibleRequest is observed, e.g. 30m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
 }
 
 // A DesposibleRequestSpec defines the desired state of a DesposibleRequest.
@@ -78,6 +111,42 @@ type DesposibleRequestStatus struct {
 	Error               string   `json:"error,omitempty"`
 	Synced              bool     `json:"synced,omitempty"`
 	RequestDetails      Mapping  `json:"requestDetails,omitempty"`
+
+	// FailureCounts breaks the failed counter down by reason.
+	FailureCounts apisv1alpha1.FailureCounts `json:"failureCounts,omitempty"`
+
+	// LastFailureReason is the reason of the most recent failure.
+	LastFailureReason apisv1alpha1.FailureReason `json:"lastFailureReason,omitempty"`
+
+	// LastRunTime is when the request was last sent.
+	LastRunTime *metav1.Time `json:"lastRunTime,omitempty"`
+
+	// NextRunTime is when the request is sent next according to its schedule.
+	NextRunTime *metav1.Time `json:"nextRunTime,omitempty"`
+
+	// ThrottledUntil is the time until which the remote API asked not to be called, as indicated by the
+	// Retry-After header of a 429 or 503 response.
+	ThrottledUntil *metav1.Time `json:"throttledUntil,omitempty"`
+
+	// NextRetryTime is when the failed request is sent again, according to its retry backoff.
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// CorrelationID is the value of the correlation header set on the last requests, when the ProviderConfig
+	// sets a correlationHeader.
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Timings break down the duration of the last request.
+	Timings *apisv1alpha1.Timings `json:"timings,omitempty"`
+
+	// LastObservedTime is when the resource was last observed successfully, i.e. when its drift from the desired
+	// state was last checked.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// LastOperationTime is when the resource was last created, updated or deleted successfully.
+	LastOperationTime *metav1.Time `json:"lastOperationTime,omitempty"`
+
+	// NextPollTime is when the resource is reconciled next, unless it changes before.
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
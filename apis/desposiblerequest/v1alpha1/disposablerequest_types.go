@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// +kubebuilder:object:root=true
+
+// A DisposableRequest sends a one-shot HTTP request on create, and records its response in its status. Drift is not
+// reconciled, and deleting it does not delete anything remotely. It is the correctly spelled DesposibleRequest,
+// whose spec and status it shares.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,http}
+type DisposableRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DesposibleRequestSpec   `json:"spec"`
+	Status DesposibleRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DisposableRequestList contains a list of DisposableRequest
+type DisposableRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DisposableRequest `json:"items"`
+}
+
+// A OneShotRequest is a managed resource sending a one-shot HTTP request: a DisposableRequest, or a
+// DesposibleRequest.
+// +kubebuilder:object:generate=false
+type OneShotRequest interface {
+	resource.Managed
+
+	// GetParameters returns the desired request.
+	GetParameters() *DesposibleRequestParameters
+
+	// GetRequestStatus returns the observed state of the request.
+	GetRequestStatus() *DesposibleRequestStatus
+
+	SetSynced(synced bool)
+	SetTerminal(statusCode int, terminal bool)
+}
+
+// DisposableRequest type metadata.
+var (
+	DisposableRequestKind             = reflect.TypeOf(DisposableRequest{}).Name()
+	DisposableRequestGroupKind        = schema.GroupKind{Group: Group, Kind: DisposableRequestKind}.String()
+	DisposableRequestKindAPIVersion   = DisposableRequestKind + "." + SchemeGroupVersion.String()
+	DisposableRequestGroupVersionKind = SchemeGroupVersion.WithKind(DisposableRequestKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&DisposableRequest{}, &DisposableRequestList{})
+}
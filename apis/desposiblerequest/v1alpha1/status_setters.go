@@ -1,5 +1,114 @@
 package v1alpha1
 
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func (d *DisposableRequest) GetParameters() *DesposibleRequestParameters {
+	return &d.Spec.ForProvider
+}
+
+func (d *DisposableRequest) GetRequestStatus() *DesposibleRequestStatus {
+	return &d.Status
+}
+
+func (d *DisposableRequest) SetStatusCode(statusCode int) {
+	d.Status.Response.StatusCode = statusCode
+}
+
+func (d *DisposableRequest) SetHeaders(headers map[string][]string) {
+	d.Status.Response.Headers = headers
+}
+
+func (d *DisposableRequest) SetBody(body string) {
+	d.Status.Response.Body = body
+}
+
+func (d *DisposableRequest) SetSynced(synced bool) {
+	d.Status.setSynced(synced)
+}
+
+func (d *DisposableRequest) SetError(err error) {
+	d.Status.setError(err)
+}
+
+func (d *DisposableRequest) RecordFailure(reason apisv1alpha1.FailureReason) {
+	d.Status.recordFailure(reason)
+}
+
+func (d *DisposableRequest) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return d.Status.LastFailureReason
+}
+
+func (d *DisposableRequest) SetRequestDetails(url, method, body string, headers map[string][]string) {
+	d.Status.setRequestDetails(url, method, body, headers)
+}
+
+func (d *DisposableRequest) SetRun(last metav1.Time, next *metav1.Time) {
+	d.Status.LastRunTime = &last
+	d.Status.NextRunTime = next
+}
+
+func (d *DisposableRequest) SetThrottledUntil(until *metav1.Time) {
+	d.Status.setThrottledUntil(until)
+}
+
+func (d *DisposableRequest) GetFailed() int32 {
+	return d.Status.Failed
+}
+
+// SetNextRetryTime records when the failed request is sent again, unless it is not retried anymore.
+func (d *DisposableRequest) SetNextRetryTime(next *metav1.Time) {
+	d.Status.setNextRetryTime(next, d.Spec.ForProvider.RollbackRetriesLimit)
+}
+
+func (d *DisposableRequest) SetTerminal(statusCode int, terminal bool) {
+	d.Status.setTerminal(statusCode, terminal)
+}
+
+func (d *DisposableRequest) SetCorrelationID(id string) {
+	d.Status.CorrelationID = id
+}
+
+func (d *DisposableRequest) SetTimings(timings *apisv1alpha1.Timings) {
+	d.Status.Timings = timings
+}
+
+func (d *DisposableRequest) SetLastObservedTime(t metav1.Time) {
+	d.Status.LastObservedTime = &t
+}
+
+func (d *DisposableRequest) GetLastOperationTime() *metav1.Time {
+	return d.Status.LastOperationTime
+}
+
+func (d *DisposableRequest) SetLastOperationTime(t metav1.Time) {
+	d.Status.LastOperationTime = &t
+}
+
+func (d *DisposableRequest) GetNextPollTime() *metav1.Time {
+	return d.Status.NextPollTime
+}
+
+func (d *DisposableRequest) SetNextPollTime(t *metav1.Time) {
+	d.Status.NextPollTime = t
+}
+
+func (d *DisposableRequest) GetPollInterval() *metav1.Duration {
+	return d.Spec.ForProvider.PollInterval
+}
+
+func (d *DesposibleRequest) GetParameters() *DesposibleRequestParameters {
+	return &d.Spec.ForProvider
+}
+
+func (d *DesposibleRequest) GetRequestStatus() *DesposibleRequestStatus {
+	return &d.Status
+}
+
 func (d *DesposibleRequest) SetStatusCode(statusCode int) {
 	d.Status.Response.StatusCode = statusCode
 }
@@ -13,22 +122,156 @@ func (d *DesposibleRequest) SetBody(body string) {
 }
 
 func (d *DesposibleRequest) SetSynced(synced bool) {
-	d.Status.Synced = synced
-	d.Status.Failed = 0
-	d.Status.Error = ""
+	d.Status.setSynced(synced)
 }
 
 func (d *DesposibleRequest) SetError(err error) {
-	d.Status.Failed++
-	d.Status.Synced = true
+	d.Status.setError(err)
+}
+
+func (d *DesposibleRequest) RecordFailure(reason apisv1alpha1.FailureReason) {
+	d.Status.recordFailure(reason)
+}
+
+func (d *DesposibleRequest) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return d.Status.LastFailureReason
+}
+
+func (d *DesposibleRequest) SetRequestDetails(url, method, body string, headers map[string][]string) {
+	d.Status.setRequestDetails(url, method, body, headers)
+}
+
+func (d *DesposibleRequest) SetRun(last metav1.Time, next *metav1.Time) {
+	d.Status.LastRunTime = &last
+	d.Status.NextRunTime = next
+}
+
+func (d *DesposibleRequest) SetThrottledUntil(until *metav1.Time) {
+	d.Status.setThrottledUntil(until)
+}
+
+func (d *DesposibleRequest) GetFailed() int32 {
+	return d.Status.Failed
+}
+
+// SetNextRetryTime records when the failed request is sent again, unless it is not retried anymore.
+func (d *DesposibleRequest) SetNextRetryTime(next *metav1.Time) {
+	d.Status.setNextRetryTime(next, d.Spec.ForProvider.RollbackRetriesLimit)
+}
+
+func (d *DesposibleRequest) SetTerminal(statusCode int, terminal bool) {
+	d.Status.setTerminal(statusCode, terminal)
+}
+
+func (d *DesposibleRequest) SetCorrelationID(id string) {
+	d.Status.CorrelationID = id
+}
+
+func (d *DesposibleRequest) SetTimings(timings *apisv1alpha1.Timings) {
+	d.Status.Timings = timings
+}
+
+func (d *DesposibleRequest) SetLastObservedTime(t metav1.Time) {
+	d.Status.LastObservedTime = &t
+}
+
+func (d *DesposibleRequest) GetLastOperationTime() *metav1.Time {
+	return d.Status.LastOperationTime
+}
+
+func (d *DesposibleRequest) SetLastOperationTime(t metav1.Time) {
+	d.Status.LastOperationTime = &t
+}
+
+func (d *DesposibleRequest) GetNextPollTime() *metav1.Time {
+	return d.Status.NextPollTime
+}
+
+func (d *DesposibleRequest) SetNextPollTime(t *metav1.Time) {
+	d.Status.NextPollTime = t
+}
+
+func (d *DesposibleRequest) GetPollInterval() *metav1.Duration {
+	return d.Spec.ForProvider.PollInterval
+}
+
+// The setters of DisposableRequests and DesposibleRequests share the methods of their status below.
+
+func (s *DesposibleRequestStatus) setSynced(synced bool) {
+	s.Synced = synced
+	s.Failed = 0
+	s.Error = ""
+	s.FailureCounts = apisv1alpha1.FailureCounts{}
+	s.LastFailureReason = ""
+	s.NextRetryTime = nil
+	s.setCircuitOpen(false)
+	apisv1alpha1.SetRequestFailing(&s.ResourceStatus, "")
+}
+
+func (s *DesposibleRequestStatus) setError(err error) {
+	s.Failed++
+	s.Synced = true
 	if err != nil {
-		d.Status.Error = err.Error()
+		s.Error = err.Error()
 	}
 }
 
-func (d *DesposibleRequest) SetRequestDetails(url, method, body string, headers map[string][]string) {
-	d.Status.RequestDetails.Body = body
-	d.Status.RequestDetails.URL = url
-	d.Status.RequestDetails.Headers = headers
-	d.Status.RequestDetails.Method = method
+func (s *DesposibleRequestStatus) recordFailure(reason apisv1alpha1.FailureReason) {
+	s.FailureCounts.Record(reason)
+	s.LastFailureReason = reason
+	s.setCircuitOpen(reason == apisv1alpha1.FailureReasonCircuitOpen)
+	apisv1alpha1.SetRequestFailing(&s.ResourceStatus, reason)
+}
+
+func (s *DesposibleRequestStatus) setRequestDetails(url, method, body string, headers map[string][]string) {
+	s.RequestDetails.Body = body
+	s.RequestDetails.URL = url
+	s.RequestDetails.Headers = headers
+	s.RequestDetails.Method = method
+}
+
+func (s *DesposibleRequestStatus) setThrottledUntil(until *metav1.Time) {
+	if until == nil {
+		if s.ThrottledUntil != nil {
+			s.ThrottledUntil = nil
+			s.SetConditions(apisv1alpha1.NotThrottled())
+		}
+		return
+	}
+
+	s.ThrottledUntil = until
+	s.SetConditions(apisv1alpha1.Throttled(*until))
+}
+
+// setNextRetryTime records when the failed request is sent again, unless it is not retried anymore with the given
+// rollback retries limit.
+func (s *DesposibleRequestStatus) setNextRetryTime(next *metav1.Time, limit *int32) {
+	if limit == nil || s.Failed >= *limit {
+		next = nil
+	}
+
+	s.NextRetryTime = next
+}
+
+func (s *DesposibleRequestStatus) setTerminal(statusCode int, terminal bool) {
+	if terminal {
+		s.SetConditions(apisv1alpha1.Failed(statusCode))
+		return
+	}
+
+	if s.GetCondition(apisv1alpha1.TypeFailed).Status == corev1.ConditionTrue {
+		s.SetConditions(apisv1alpha1.NotFailed())
+	}
+}
+
+// setCircuitOpen sets the CircuitOpen condition when requests fail fast, and clears it once they are sent again.
+func (s *DesposibleRequestStatus) setCircuitOpen(open bool) {
+	if open {
+		s.SetConditions(apisv1alpha1.CircuitOpen())
+		return
+	}
+
+	if s.GetCondition(apisv1alpha1.TypeCircuitOpen).Status == corev1.ConditionTrue {
+		s.SetConditions(apisv1alpha1.CircuitClosed())
+	}
 }
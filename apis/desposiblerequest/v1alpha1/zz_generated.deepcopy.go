@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -113,6 +114,31 @@ func (in *DesposibleRequestParameters) DeepCopyInto(out *DesposibleRequestParame
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RetryBackoff != nil {
+		in, out := &in.RetryBackoff, &out.RetryBackoff
+		*out = new(apisv1alpha1.RetryBackoff)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(apisv1alpha1.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = new(apisv1alpha1.StatusCodePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(apisv1alpha1.Auth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesposibleRequestParameters.
@@ -148,6 +174,40 @@ func (in *DesposibleRequestStatus) DeepCopyInto(out *DesposibleRequestStatus) {
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.Response.DeepCopyInto(&out.Response)
 	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
+	out.FailureCounts = in.FailureCounts
+	if in.LastRunTime != nil {
+		in, out := &in.LastRunTime, &out.LastRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRunTime != nil {
+		in, out := &in.NextRunTime, &out.NextRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ThrottledUntil != nil {
+		in, out := &in.ThrottledUntil, &out.ThrottledUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Timings != nil {
+		in, out := &in.Timings, &out.Timings
+		*out = new(apisv1alpha1.Timings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastObservedTime != nil {
+		in, out := &in.LastObservedTime, &out.LastObservedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastOperationTime != nil {
+		in, out := &in.LastOperationTime, &out.LastOperationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextPollTime != nil {
+		in, out := &in.NextPollTime, &out.NextPollTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DesposibleRequestStatus.
@@ -160,6 +220,65 @@ func (in *DesposibleRequestStatus) DeepCopy() *DesposibleRequestStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisposableRequest) DeepCopyInto(out *DisposableRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisposableRequest.
+func (in *DisposableRequest) DeepCopy() *DisposableRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DisposableRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisposableRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DisposableRequestList) DeepCopyInto(out *DisposableRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DisposableRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisposableRequestList.
+func (in *DisposableRequestList) DeepCopy() *DisposableRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(DisposableRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DisposableRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mapping) DeepCopyInto(out *Mapping) {
 	*out = *in
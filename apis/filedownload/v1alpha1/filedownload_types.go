@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// Kinds of the objects a FileDownload writes to.
+const (
+	TargetKindSecret    = "Secret"
+	TargetKindConfigMap = "ConfigMap"
+)
+
+// FileDownloadParameters are the configurable fields of a FileDownload.
+type FileDownloadParameters struct {
+	// URL is the location of the file, downloaded with a GET request.
+	URL string `json:"url"`
+
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Target is the key of the Secret or ConfigMap the file is written to.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.target' is immutable"
+	Target Target `json:"target"`
+
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+
+	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP requests
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures how the TLS certificate of the server is verified. It is merged over the tls of the ProviderConfig.
+	// Its insecureSkipVerify takes precedence over insecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
+	// StatusCodes declares which response status codes are successful, retryable or terminal.
+	// It replaces the statusCodes of the ProviderConfig.
+	StatusCodes *apisv1alpha1.StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the requests of the FileDownload. It replaces the auth of the ProviderConfig.
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// PollInterval overrides how often the FileDownload is observed, e.g. 30m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// Target is a key of a Secret or ConfigMap. The object is created when it does not exist.
+type Target struct {
+	// Kind is the kind of the object, Secret or ConfigMap.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind string `json:"kind"`
+
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+}
+
+// A FileDownloadSpec defines the desired state of a FileDownload.
+type FileDownloadSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	ForProvider FileDownloadParameters `json:"forProvider"`
+}
+
+// A FileDownloadStatus represents the observed state of a FileDownload.
+type FileDownloadStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Checksum is the SHA-256 checksum of the file last downloaded, in hexadecimal.
+	Checksum string `json:"checksum,omitempty"`
+
+	// Size is the size of the file last downloaded, in bytes.
+	Size int `json:"size,omitempty"`
+
+	// LastWriteTime is when the file was last written to the target.
+	LastWriteTime *metav1.Time `json:"lastWriteTime,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	// LastFailureReason is the reason of the most recent failure.
+	LastFailureReason apisv1alpha1.FailureReason `json:"lastFailureReason,omitempty"`
+
+	// CorrelationID is the value of the correlation header set on the last requests, when the ProviderConfig
+	// sets a correlationHeader.
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Timings break down the duration of the last request.
+	Timings *apisv1alpha1.Timings `json:"timings,omitempty"`
+
+	// LastObservedTime is when the resource was last observed successfully, i.e. when its drift from the desired
+	// state was last checked.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// LastOperationTime is when the resource was last created, updated or deleted successfully.
+	LastOperationTime *metav1.Time `json:"lastOperationTime,omitempty"`
+
+	// NextPollTime is when the resource is reconciled next, unless it changes before.
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A FileDownload downloads a file from a URL into a key of a Secret or ConfigMap, and downloads it again
+// whenever its content changes.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".spec.forProvider.target.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,http}
+type FileDownload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FileDownloadSpec   `json:"spec"`
+	Status FileDownloadStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FileDownloadList contains a list of FileDownload
+type FileDownloadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FileDownload `json:"items"`
+}
+
+// FileDownload type metadata.
+var (
+	FileDownloadKind             = reflect.TypeOf(FileDownload{}).Name()
+	FileDownloadGroupKind        = schema.GroupKind{Group: Group, Kind: FileDownloadKind}.String()
+	FileDownloadKindAPIVersion   = FileDownloadKind + "." + SchemeGroupVersion.String()
+	FileDownloadGroupVersionKind = SchemeGroupVersion.WithKind(FileDownloadKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&FileDownload{}, &FileDownloadList{})
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func (d *FileDownload) SetError(reason apisv1alpha1.FailureReason, err error) {
+	d.Status.LastFailureReason = reason
+	apisv1alpha1.SetRequestFailing(&d.Status.ResourceStatus, reason)
+	d.Status.Error = ""
+	if err != nil {
+		d.Status.Error = err.Error()
+	}
+}
+
+func (d *FileDownload) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return d.Status.LastFailureReason
+}
+
+func (d *FileDownload) SetCorrelationID(id string) {
+	d.Status.CorrelationID = id
+}
+
+func (d *FileDownload) SetTimings(timings *apisv1alpha1.Timings) {
+	d.Status.Timings = timings
+}
+
+func (d *FileDownload) SetLastObservedTime(t metav1.Time) {
+	d.Status.LastObservedTime = &t
+}
+
+func (d *FileDownload) GetLastOperationTime() *metav1.Time {
+	return d.Status.LastOperationTime
+}
+
+func (d *FileDownload) SetLastOperationTime(t metav1.Time) {
+	d.Status.LastOperationTime = &t
+}
+
+func (d *FileDownload) GetNextPollTime() *metav1.Time {
+	return d.Status.NextPollTime
+}
+
+func (d *FileDownload) SetNextPollTime(t *metav1.Time) {
+	d.Status.NextPollTime = t
+}
+
+func (d *FileDownload) GetPollInterval() *metav1.Duration {
+	return d.Spec.ForProvider.PollInterval
+}
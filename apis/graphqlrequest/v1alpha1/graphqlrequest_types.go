@@ -0,0 +1,180 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// GraphQLRequestParameters are the configurable fields of a GraphQLRequest.
+type GraphQLRequestParameters struct {
+	// URL is the GraphQL endpoint, to which the operations are posted.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.url' is immutable"
+	URL string `json:"url"`
+
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Variables is a JSON object holding the variables of the operations.
+	Variables string `json:"variables,omitempty"`
+
+	// Create is the mutation creating the resource.
+	Create Operation `json:"create"`
+
+	// Observe is the query reading the resource. The resource does not exist when all the fields of the
+	// data of its response are null. When unset, the resource exists once it was created.
+	// +optional
+	Observe *Operation `json:"observe,omitempty"`
+
+	// Update is the mutation updating the resource when it is not up to date.
+	// +optional
+	Update *Operation `json:"update,omitempty"`
+
+	// Delete is the mutation deleting the resource. When unset, the resource is not deleted remotely.
+	// +optional
+	Delete *Operation `json:"delete,omitempty"`
+
+	// IDPath is a jq filter extracting the ID of the resource from the response of the create mutation,
+	// e.g. '.data.createUser.id'. The ID is recorded in the crossplane.io/external-name annotation and passed
+	// to the other operations as the id variable.
+	// +optional
+	IDPath string `json:"idPath,omitempty"`
+
+	// ExpectedResponse is a jq filter evaluated against the response of the observe query, with its data
+	// available as .data and the variables as .variables. The expression should return a boolean; the
+	// resource is updated when it returns false. Example: '.data.user.name == .variables.name'
+	// +optional
+	ExpectedResponse string `json:"expectedResponse,omitempty"`
+
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+
+	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP requests
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures how the TLS certificate of the server is verified. It is merged over the tls of the ProviderConfig.
+	// Its insecureSkipVerify takes precedence over insecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
+	// StatusCodes declares which response status codes are successful, retryable or terminal.
+	// It replaces the statusCodes of the ProviderConfig. Responses holding GraphQL errors fail regardless of
+	// their status code.
+	StatusCodes *apisv1alpha1.StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the requests of the GraphQLRequest. It replaces the auth of the ProviderConfig.
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// PollInterval overrides how often the GraphQLRequest is observed, e.g. 30m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// An Operation is a GraphQL query or mutation.
+type Operation struct {
+	// Query is the GraphQL document of the operation.
+	Query string `json:"query"`
+
+	// OperationName selects the operation to run when the document holds several.
+	// +optional
+	OperationName string `json:"operationName,omitempty"`
+}
+
+// A GraphQLRequestSpec defines the desired state of a GraphQLRequest.
+type GraphQLRequestSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	ForProvider GraphQLRequestParameters `json:"forProvider"`
+}
+
+type Response struct {
+	StatusCode int                 `json:"statusCode,omitempty"`
+	Body       string              `json:"body,omitempty"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+}
+
+// A GraphQLRequestStatus represents the observed state of a GraphQLRequest.
+type GraphQLRequestStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Response is the latest response of the GraphQL endpoint.
+	Response Response `json:"response,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	// LastFailureReason is the reason of the most recent failure.
+	LastFailureReason apisv1alpha1.FailureReason `json:"lastFailureReason,omitempty"`
+
+	// CorrelationID is the value of the correlation header set on the last requests, when the ProviderConfig
+	// sets a correlationHeader.
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Timings break down the duration of the last request.
+	Timings *apisv1alpha1.Timings `json:"timings,omitempty"`
+
+	// LastObservedTime is when the resource was last observed successfully, i.e. when its drift from the desired
+	// state was last checked.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// LastOperationTime is when the resource was last created, updated or deleted successfully.
+	LastOperationTime *metav1.Time `json:"lastOperationTime,omitempty"`
+
+	// NextPollTime is when the resource is reconciled next, unless it changes before.
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A GraphQLRequest manages a resource through the queries and mutations of a GraphQL API.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,http}
+type GraphQLRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GraphQLRequestSpec   `json:"spec"`
+	Status GraphQLRequestStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GraphQLRequestList contains a list of GraphQLRequest
+type GraphQLRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GraphQLRequest `json:"items"`
+}
+
+// GraphQLRequest type metadata.
+var (
+	GraphQLRequestKind             = reflect.TypeOf(GraphQLRequest{}).Name()
+	GraphQLRequestGroupKind        = schema.GroupKind{Group: Group, Kind: GraphQLRequestKind}.String()
+	GraphQLRequestKindAPIVersion   = GraphQLRequestKind + "." + SchemeGroupVersion.String()
+	GraphQLRequestGroupVersionKind = SchemeGroupVersion.WithKind(GraphQLRequestKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&GraphQLRequest{}, &GraphQLRequestList{})
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func (g *GraphQLRequest) SetResponse(statusCode int, body string, headers map[string][]string) {
+	g.Status.Response.StatusCode = statusCode
+	g.Status.Response.Body = body
+	g.Status.Response.Headers = headers
+}
+
+func (g *GraphQLRequest) SetError(reason apisv1alpha1.FailureReason, err error) {
+	g.Status.LastFailureReason = reason
+	apisv1alpha1.SetRequestFailing(&g.Status.ResourceStatus, reason)
+	g.Status.Error = ""
+	if err != nil {
+		g.Status.Error = err.Error()
+	}
+}
+
+func (g *GraphQLRequest) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return g.Status.LastFailureReason
+}
+
+func (g *GraphQLRequest) SetCorrelationID(id string) {
+	g.Status.CorrelationID = id
+}
+
+func (g *GraphQLRequest) SetTimings(timings *apisv1alpha1.Timings) {
+	g.Status.Timings = timings
+}
+
+func (g *GraphQLRequest) SetLastObservedTime(t metav1.Time) {
+	g.Status.LastObservedTime = &t
+}
+
+func (g *GraphQLRequest) GetLastOperationTime() *metav1.Time {
+	return g.Status.LastOperationTime
+}
+
+func (g *GraphQLRequest) SetLastOperationTime(t metav1.Time) {
+	g.Status.LastOperationTime = &t
+}
+
+func (g *GraphQLRequest) GetNextPollTime() *metav1.Time {
+	return g.Status.NextPollTime
+}
+
+func (g *GraphQLRequest) SetNextPollTime(t *metav1.Time) {
+	g.Status.NextPollTime = t
+}
+
+func (g *GraphQLRequest) GetPollInterval() *metav1.Duration {
+	return g.Spec.ForProvider.PollInterval
+}
@@ -20,17 +20,29 @@ package apis
 import (
 	"k8s.io/apimachinery/pkg/runtime"
 
+	batchrequestv1alpha1 "github.com/arielsepton/provider-http/apis/batchrequest/v1alpha1"
 	desposiblerequestv1alpha1 "github.com/arielsepton/provider-http/apis/desposiblerequest/v1alpha1"
+	filedownloadv1alpha1 "github.com/arielsepton/provider-http/apis/filedownload/v1alpha1"
+	graphqlrequestv1alpha1 "github.com/arielsepton/provider-http/apis/graphqlrequest/v1alpha1"
+	httpprobev1alpha1 "github.com/arielsepton/provider-http/apis/httpprobe/v1alpha1"
 	requestv1alpha1 "github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	restresourcev1alpha1 "github.com/arielsepton/provider-http/apis/restresource/v1alpha1"
 	httpv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	workflowv1alpha1 "github.com/arielsepton/provider-http/apis/workflow/v1alpha1"
 )
 
 func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
 	AddToSchemes = append(AddToSchemes,
 		httpv1alpha1.SchemeBuilder.AddToScheme,
+		batchrequestv1alpha1.SchemeBuilder.AddToScheme,
 		desposiblerequestv1alpha1.SchemeBuilder.AddToScheme,
+		filedownloadv1alpha1.SchemeBuilder.AddToScheme,
+		graphqlrequestv1alpha1.SchemeBuilder.AddToScheme,
+		httpprobev1alpha1.SchemeBuilder.AddToScheme,
 		requestv1alpha1.SchemeBuilder.AddToScheme,
+		restresourcev1alpha1.SchemeBuilder.AddToScheme,
+		workflowv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
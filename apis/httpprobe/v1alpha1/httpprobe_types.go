@@ -0,0 +1,168 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// HttpProbeParameters are the configurable fields of a HttpProbe.
+type HttpProbeParameters struct {
+	// URL is the endpoint that is probed with GET requests.
+	URL string `json:"url"`
+
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Period is how often the endpoint is probed. Defaults to the poll interval of the provider.
+	// +optional
+	Period *metav1.Duration `json:"period,omitempty"`
+
+	// MaxLatency is the latency above which the endpoint is unhealthy.
+	// +optional
+	MaxLatency *metav1.Duration `json:"maxLatency,omitempty"`
+
+	// Assertions are checked against the response of the endpoint. The endpoint is unhealthy when one of them
+	// fails.
+	// +optional
+	Assertions []Assertion `json:"assertions,omitempty"`
+
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+
+	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP requests
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures how the TLS certificate of the server is verified. It is merged over the tls of the ProviderConfig.
+	// Its insecureSkipVerify takes precedence over insecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
+	// StatusCodes declares which response status codes are successful. The endpoint is unhealthy when it
+	// responds with another status code. It replaces the statusCodes of the ProviderConfig.
+	StatusCodes *apisv1alpha1.StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the requests of the HttpProbe. It replaces the auth of the ProviderConfig.
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// PollInterval overrides how often the HttpProbe is observed, e.g. 30m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// Assertion is an invariant of the response of a healthy endpoint.
+type Assertion struct {
+	// Expression is a jq filter returning a boolean, evaluated against the response available as
+	// .response.statusCode, .response.headers and .response.body, e.g. '.response.body.status == "ok"'.
+	Expression string `json:"expression"`
+
+	// Message explains the failed assertion, e.g. "the database is not reachable".
+	Message string `json:"message"`
+}
+
+// A HttpProbeSpec defines the desired state of a HttpProbe.
+type HttpProbeSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	ForProvider HttpProbeParameters `json:"forProvider"`
+}
+
+// A HttpProbeStatus represents the observed state of a HttpProbe.
+type HttpProbeStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Healthy is whether the endpoint was healthy when it was last probed. The Ready condition reflects it
+	// as well.
+	Healthy bool `json:"healthy,omitempty"`
+
+	// StatusCode is the status code of the last response.
+	StatusCode int `json:"statusCode,omitempty"`
+
+	// Latency is how long the last request took.
+	Latency *metav1.Duration `json:"latency,omitempty"`
+
+	// LastProbeTime is when the endpoint was last probed.
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// FailedAssertions are the messages of the assertions the last response failed.
+	FailedAssertions []string `json:"failedAssertions,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	// LastFailureReason is the reason of the most recent failure.
+	LastFailureReason apisv1alpha1.FailureReason `json:"lastFailureReason,omitempty"`
+
+	// CorrelationID is the value of the correlation header set on the last requests, when the ProviderConfig
+	// sets a correlationHeader.
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Timings break down the duration of the last request.
+	Timings *apisv1alpha1.Timings `json:"timings,omitempty"`
+
+	// LastObservedTime is when the resource was last observed successfully, i.e. when its drift from the desired
+	// state was last checked.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// LastOperationTime is when the resource was last created, updated or deleted successfully.
+	LastOperationTime *metav1.Time `json:"lastOperationTime,omitempty"`
+
+	// NextPollTime is when the resource is reconciled next, unless it changes before.
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A HttpProbe periodically probes an HTTP endpoint and reflects its health in its Ready condition. It never
+// changes the endpoint.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="STATUS-CODE",type="integer",JSONPath=".status.statusCode"
+// +kubebuilder:printcolumn:name="LATENCY",type="string",JSONPath=".status.latency"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,http}
+type HttpProbe struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HttpProbeSpec   `json:"spec"`
+	Status HttpProbeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HttpProbeList contains a list of HttpProbe
+type HttpProbeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HttpProbe `json:"items"`
+}
+
+// HttpProbe type metadata.
+var (
+	HttpProbeKind             = reflect.TypeOf(HttpProbe{}).Name()
+	HttpProbeGroupKind        = schema.GroupKind{Group: Group, Kind: HttpProbeKind}.String()
+	HttpProbeKindAPIVersion   = HttpProbeKind + "." + SchemeGroupVersion.String()
+	HttpProbeGroupVersionKind = SchemeGroupVersion.WithKind(HttpProbeKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&HttpProbe{}, &HttpProbeList{})
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func (p *HttpProbe) SetError(reason apisv1alpha1.FailureReason, err error) {
+	p.Status.LastFailureReason = reason
+	apisv1alpha1.SetRequestFailing(&p.Status.ResourceStatus, reason)
+	p.Status.Error = ""
+	if err != nil {
+		p.Status.Error = err.Error()
+	}
+}
+
+func (p *HttpProbe) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return p.Status.LastFailureReason
+}
+
+func (p *HttpProbe) SetCorrelationID(id string) {
+	p.Status.CorrelationID = id
+}
+
+func (p *HttpProbe) SetTimings(timings *apisv1alpha1.Timings) {
+	p.Status.Timings = timings
+}
+
+func (p *HttpProbe) SetLastObservedTime(t metav1.Time) {
+	p.Status.LastObservedTime = &t
+}
+
+func (p *HttpProbe) GetLastOperationTime() *metav1.Time {
+	return p.Status.LastOperationTime
+}
+
+func (p *HttpProbe) SetLastOperationTime(t metav1.Time) {
+	p.Status.LastOperationTime = &t
+}
+
+func (p *HttpProbe) GetNextPollTime() *metav1.Time {
+	return p.Status.NextPollTime
+}
+
+func (p *HttpProbe) SetNextPollTime(t *metav1.Time) {
+	p.Status.NextPollTime = t
+}
+
+func (p *HttpProbe) GetPollInterval() *metav1.Duration {
+	return p.Spec.ForProvider.PollInterval
+}
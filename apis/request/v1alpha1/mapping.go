@@ -0,0 +1,23 @@
+package v1alpha1
+
+import "net/http"
+
+// GetAction returns the action of the mapping, or the action implied by its method when it has none.
+func (m *Mapping) GetAction() string {
+	if m.Action != "" {
+		return m.Action
+	}
+
+	switch m.Method {
+	case http.MethodPost:
+		return ActionCreate
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ActionObserve
+	case http.MethodPut, http.MethodPatch:
+		return ActionUpdate
+	case http.MethodDelete:
+		return ActionRemove
+	default:
+		return ""
+	}
+}
@@ -23,31 +23,801 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 )
 
+// AnnotationKeyRemoteRequestID is the annotation holding the ID the remote API assigned to the last
+// POST, PUT or DELETE request.
+const AnnotationKeyRemoteRequestID = "http.crossplane.io/remote-request-id"
+
+// AnnotationKeyDebug is the annotation that, when set to "true", records the round trip of the last request of a
+// Request in its status.
+const AnnotationKeyDebug = "http.crossplane.io/debug"
+
 // RequestParameters are the configurable fields of a Request.
 type RequestParameters struct {
 	Mappings []Mapping           `json:"mappings"`
 	Payload  Payload             `json:"payload"`
 	Headers  map[string][]string `json:"headers,omitempty"`
 
+	// HeaderValues are headers sent with every mapping whose values are either literal or read from a Secret,
+	// so that tokens do not have to be stored in plaintext in headers. Headers set explicitly in headers take
+	// precedence. Their values are not recorded in the status.
+	// +optional
+	HeaderValues []Header `json:"headerValues,omitempty"`
+
 	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
 
 	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP request
 	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures how the TLS certificate of the server is verified for every mapping.
+	// Its insecureSkipVerify takes precedence over insecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
+	// URLNormalization controls how the URLs generated from the mappings are normalized before they are sent.
+	URLNormalization *URLNormalization `json:"urlNormalization,omitempty"`
+
+	// ContentNegotiation sets the default Accept and Accept-Language headers of every mapping.
+	// Headers set explicitly in headers take precedence.
+	ContentNegotiation *ContentNegotiation `json:"contentNegotiation,omitempty"`
+
+	// Compression configures the compression of request bodies.
+	Compression *Compression `json:"compression,omitempty"`
+
+	// CreateResponse declares the status codes with which the remote API acknowledges the POST mapping, and
+	// what happens afterwards.
+	CreateResponse *CreateResponse `json:"createResponse,omitempty"`
+
+	// AsyncOperation polls the operation started by a request answered with "202 Accepted" until it completes,
+	// before the resource is considered created, updated or deleted, for long-running provisioning APIs.
+	// +optional
+	AsyncOperation *AsyncOperation `json:"asyncOperation,omitempty"`
+
+	// Redirects controls how redirect (3xx) responses are handled. By default redirects are followed.
+	Redirects *Redirects `json:"redirects,omitempty"`
+
+	// MaxResponseBodyBytes is the number of bytes of response bodies read and stored, the rest of larger bodies
+	// is dropped, protecting the controller and etcd from large responses. Defaults to the maxResponseBodyBytes
+	// of the ProviderConfig, bodies are not limited when neither sets it.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxResponseBodyBytes int64 `json:"maxResponseBodyBytes,omitempty"`
+
+	// ResponseStream bounds how much of response bodies is read, for endpoints that return NDJSON or chunked
+	// streams, instead of reading them until the server closes them. NDJSON records are collected into a JSON
+	// array, which is recorded as the response body.
+	// +optional
+	ResponseStream *ResponseStream `json:"responseStream,omitempty"`
+
+	// UseCreateLocation records the Location header of a successful response to the CREATE mapping, e.g. of a
+	// "201 Created" response, in status.location. It is then used as the URL of subsequent observations, and of
+	// the mappings that omit their url.
+	// +optional
+	UseCreateLocation bool `json:"useCreateLocation,omitempty"`
+
+	// RequestIDHeader is the response header holding the ID the remote API assigned to a request, e.g.
+	// X-Request-Id. The ID returned by the last POST, PUT or DELETE request is recorded in
+	// status.remoteRequestID and in the http.crossplane.io/remote-request-id annotation.
+	RequestIDHeader string `json:"requestIDHeader,omitempty"`
+
+	// StaleAfter is how long the response of the last OBSERVE request is considered fresh. Reconciles within
+	// this window check for drift against the stored response instead of sending it again. It is
+	// ignored when redact.bodyFields is set, as the stored response is then masked.
+	StaleAfter *metav1.Duration `json:"staleAfter,omitempty"`
+
+	// HonorCacheHeaders, when set to true, considers the response of the last OBSERVE request fresh for as long as
+	// its Cache-Control max-age or Expires header allows. It takes precedence over staleAfter for responses
+	// carrying such headers.
+	HonorCacheHeaders bool `json:"honorCacheHeaders,omitempty"`
+
+	// StatusCodes declares which response status codes are successful, retryable or terminal.
+	// It replaces the statusCodes of the ProviderConfig.
+	StatusCodes *apisv1alpha1.StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the requests of the Request. It replaces the auth of the ProviderConfig.
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// ResponseEncryption, when set, encrypts the response bodies stored in the status.
+	ResponseEncryption *ResponseEncryption `json:"responseEncryption,omitempty"`
+
+	// ConnectionDetails lists the values selected from responses that are written to the connection Secret of
+	// the Request, e.g. generated passwords, tokens and endpoints returned by the API.
+	// +optional
+	ConnectionDetails []ConnectionDetail `json:"connectionDetails,omitempty"`
+
+	// Cookies lists the cookies set by responses that are written to the connection Secret of the Request, e.g. a
+	// session cookie set on creation, so that dependent workloads or other Requests can reuse the session.
+	// +optional
+	Cookies []CookieCapture `json:"cookies,omitempty"`
+
+	// ResponseSecrets lists the Secrets, besides the connection Secret, kept updated with values selected from
+	// responses, for workloads that do not read Crossplane connection Secrets.
+	// +optional
+	ResponseSecrets []ResponseSecret `json:"responseSecrets,omitempty"`
+
+	// ResponseConfigMaps lists the ConfigMaps kept updated with non-sensitive values selected from responses, for
+	// other controllers and workloads to read or mount.
+	// +optional
+	ResponseConfigMaps []ResponseConfigMap `json:"responseConfigMaps,omitempty"`
+
+	// ResponseHeaders selects the response headers recorded in the status. By default, all the headers are
+	// recorded but the sensitive ones: Set-Cookie, Set-Cookie2, Authorization and Proxy-Authorization.
+	// +optional
+	ResponseHeaders *ResponseHeaders `json:"responseHeaders,omitempty"`
+
+	// Redact masks sensitive values in the status, the conditions, the events and the logs of the Request. The
+	// values of the Authorization, Proxy-Authorization, Cookie, Set-Cookie, Set-Cookie2, X-Api-Key, Api-Key and
+	// X-Auth-Token headers are always masked.
+	// +optional
+	Redact *Redaction `json:"redact,omitempty"`
+
+	// ResponseStorage, when set, stores large response bodies in a ConfigMap or a Secret instead of the status,
+	// which only records a reference to them holding their SHA-256 digest.
+	// +optional
+	ResponseStorage *ResponseStorage `json:"responseStorage,omitempty"`
+
+	// ExpectedResponseCheck decides whether the observed response is up to date with the desired state. By
+	// default, the response body should hold the fields of the body of the PUT mapping.
+	// +optional
+	ExpectedResponseCheck *ExpectedResponseCheck `json:"expectedResponseCheck,omitempty"`
+
+	// CompareStrategy decides how the response body is compared with the desired state by the default expected
+	// response check: Subset requires the response to hold the fields of the desired state and ignores extra
+	// fields, Exact requires it to hold no other fields, and SubsetWithWarnings logs the extra fields it holds.
+	// +kubebuilder:validation:Enum=Subset;Exact;SubsetWithWarnings
+	// +kubebuilder:default=Subset
+	// +optional
+	CompareStrategy string `json:"compareStrategy,omitempty"`
+
+	// IgnoreFields are JSON Pointers (e.g. /metadata/etag) or jq paths (e.g. .lastModified) of fields removed from
+	// both the response body and the desired state before the default expected response check compares them,
+	// e.g. server-generated timestamps.
+	// +optional
+	IgnoreFields []string `json:"ignoreFields,omitempty"`
+
+	// Normalization loosens which values the default expected response check considers equal. Numbers are
+	// always compared by value, e.g. 1 and 1.0 are equal.
+	// +optional
+	Normalization *Normalization `json:"normalization,omitempty"`
+
+	// Readiness determines when the Request is marked ready. By default, it is ready once it is observed.
+	// +optional
+	Readiness *Readiness `json:"readiness,omitempty"`
+
+	// Assertions are checked against the observed response. A failed assertion marks the Request as not
+	// synced with its message.
+	Assertions []Assertion `json:"assertions,omitempty"`
+
+	// GeneratedValues are generated once and persisted in status.generatedValues, so that mappings reusing
+	// them across reconciles, e.g. as idempotency keys, always send the same values.
+	// +optional
+	GeneratedValues []GeneratedValue `json:"generatedValues,omitempty"`
+
+	// References expose values of the last responses of other Requests to the mappings, e.g. the ID of a
+	// resource created by another Request, so that chained resources can be modeled declaratively.
+	// +optional
+	References []RequestReference `json:"references,omitempty"`
+
+	// Environment exposes the data of Crossplane EnvironmentConfigs and ConfigMaps to the mappings as
+	// .environment, so that environment specific values such as hosts and IDs are not baked into every Request.
+	// The data of later sources is merged over the data of earlier ones.
+	// +optional
+	Environment []EnvironmentSource `json:"environment,omitempty"`
+
+	// PollInterval overrides how often the Request is observed, e.g. 30m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// EnvironmentSource is an EnvironmentConfig or a ConfigMap whose data is exposed to the mappings. Exactly one of
+// its fields should be set.
+type EnvironmentSource struct {
+	// EnvironmentConfigRef references a Crossplane EnvironmentConfig.
+	// +optional
+	EnvironmentConfigRef *EnvironmentConfigReference `json:"environmentConfigRef,omitempty"`
+
+	// ConfigMapRef references a ConfigMap. Values holding JSON objects are decoded.
+	// +optional
+	ConfigMapRef *apisv1alpha1.ConfigMapReference `json:"configMapRef,omitempty"`
+}
+
+// EnvironmentConfigReference references a Crossplane EnvironmentConfig.
+type EnvironmentConfigReference struct {
+	// Name of the EnvironmentConfig.
+	Name string `json:"name"`
+}
+
+// RequestReference exposes a value of the last response of another Request.
+type RequestReference struct {
+	// Name of the value. Mappings reference it as .references.<name>.
+	Name string `json:"name"`
+
+	// RequestName is the name of the referenced Request.
+	RequestName string `json:"requestName"`
+
+	// Path is a jq query selecting the value from the last response of the referenced Request, available as
+	// .statusCode, .headers and .body, e.g. .body.id.
+	Path string `json:"path"`
+}
+
+// Types of expected response checks.
+const (
+	// ExpectedResponseCheckTypeDefault checks that the response body holds the fields of the desired state.
+	ExpectedResponseCheckTypeDefault = "DEFAULT"
+	// ExpectedResponseCheckTypeCustom evaluates the logic of the check.
+	ExpectedResponseCheckTypeCustom = "CUSTOM"
+	// ExpectedResponseCheckTypeRegex matches the response body with the pattern of the check.
+	ExpectedResponseCheckTypeRegex = "REGEX"
+)
+
+// Normalization loosens which values are considered equal when comparing the response body with the desired state.
+type Normalization struct {
+	// BooleanStrings considers strings holding booleans equal to the booleans, e.g. "true" and true.
+	// +optional
+	BooleanStrings bool `json:"booleanStrings,omitempty"`
+
+	// NumericStrings considers strings holding numbers equal to the numbers, e.g. "1" and 1.
+	// +optional
+	NumericStrings bool `json:"numericStrings,omitempty"`
+
+	// NullAsAbsent considers fields set to null equal to absent fields.
+	// +optional
+	NullAsAbsent bool `json:"nullAsAbsent,omitempty"`
+}
+
+// Strategies comparing the response body with the desired state.
+const (
+	// CompareStrategySubset requires the response body to hold the fields of the desired state.
+	CompareStrategySubset = "Subset"
+	// CompareStrategyExact requires the response body to hold exactly the fields of the desired state.
+	CompareStrategyExact = "Exact"
+	// CompareStrategySubsetWithWarnings compares like CompareStrategySubset and logs the extra fields.
+	CompareStrategySubsetWithWarnings = "SubsetWithWarnings"
+)
+
+// ExpectedResponseCheck decides whether the observed response is up to date with the desired state.
+type ExpectedResponseCheck struct {
+	// Type of the check: DEFAULT checks that the response body holds the fields of the desired state, CUSTOM
+	// evaluates logic instead, e.g. for APIs returning server-managed fields, and REGEX matches the response
+	// body with pattern, e.g. for plain-text APIs.
+	// +kubebuilder:validation:Enum=DEFAULT;CUSTOM;REGEX
+	// +kubebuilder:default=DEFAULT
+	Type string `json:"type,omitempty"`
+
+	// Logic is a jq expression returning true when the resource is up to date. The observed response is
+	// available as .response.statusCode, .response.headers and .response.body, the body of the PUT mapping as
+	// .desiredState and the payload as .payload, e.g. .response.body.name == .desiredState.name.
+	// +optional
+	Logic string `json:"logic,omitempty"`
+
+	// Pattern is a regular expression (RE2 syntax) the response body should match when the resource is up to
+	// date. It matches any part of the body unless it is anchored, e.g. ^enabled=true$.
+	// +optional
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// Readiness determines when a Request is marked ready.
+type Readiness struct {
+	// CEL is a CEL expression returning true when the observed resource is ready. The observed response is
+	// available as statusCode, headers and body, decoded when it is JSON, e.g. body.state == "ACTIVE".
+	CEL string `json:"cel"`
+}
+
+// Types of generated values.
+const (
+	// GeneratedValueUUID is a random UUID.
+	GeneratedValueUUID = "UUID"
+	// GeneratedValueRandomString is a random alphanumeric string.
+	GeneratedValueRandomString = "RandomString"
+)
+
+// GeneratedValue is a value generated once and persisted in the status.
+type GeneratedValue struct {
+	// Name of the value. Mappings reference it as .generated.<name>.
+	Name string `json:"name"`
+
+	// Type of the value: a random UUID, or a random alphanumeric string.
+	// +kubebuilder:validation:Enum=UUID;RandomString
+	Type string `json:"type"`
+
+	// Length of random strings. Defaults to 16.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Length *int `json:"length,omitempty"`
+}
+
+// ResponseEncryption configures the envelope encryption of the response bodies stored in status.response and
+// status.cache. Each body is encrypted with its own random AES-256-GCM data key, which is encrypted with the
+// key encryption key.
+type ResponseEncryption struct {
+	// KeySecretRef references the key encryption key. It may have any length.
+	KeySecretRef xpv1.SecretKeySelector `json:"keySecretRef"`
+}
+
+// ConnectionDetail writes a value selected from responses to a key of the connection Secret of the Request, or
+// of a response Secret or ConfigMap.
+type ConnectionDetail struct {
+	// Key of the Secret the value is written to.
+	Key string `json:"key"`
+
+	// Query is a jq query selecting the value from the response, available as .response.statusCode,
+	// .response.headers and .response.body, e.g. '.response.body.credentials.password'. Strings are written as
+	// they are and other values as JSON. A null result leaves the key as it is.
+	Query string `json:"query"`
+}
+
+// ResponseSecret is a Secret kept updated with values selected from the responses of the Request.
+type ResponseSecret struct {
+	// SecretRef references the Secret, which is created when it does not exist. Its other keys are left as they
+	// are.
+	SecretRef xpv1.SecretReference `json:"secretRef"`
+
+	// Data selects the value of each key of the Secret from the responses.
+	// +kubebuilder:validation:MinItems=1
+	Data []ConnectionDetail `json:"data"`
+}
+
+// ResponseConfigMap is a ConfigMap kept updated with values selected from the responses of the Request.
+type ResponseConfigMap struct {
+	// ConfigMapRef references the ConfigMap, which is created when it does not exist. Its other keys are left as
+	// they are.
+	ConfigMapRef apisv1alpha1.ConfigMapReference `json:"configMapRef"`
+
+	// Data selects the value of each key of the ConfigMap from the responses. Values that are not valid UTF-8 are
+	// stored in its binaryData.
+	// +kubebuilder:validation:MinItems=1
+	Data []ConnectionDetail `json:"data"`
+}
+
+// CookieCapture writes the value of a cookie set by responses to the connection Secret of the Request.
+type CookieCapture struct {
+	// Name of the cookie.
+	Name string `json:"name"`
+
+	// Key of the connection Secret the value of the cookie is written to. Defaults to the name of the cookie.
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+// ResponseHeaders selects the response headers recorded in the status.
+type ResponseHeaders struct {
+	// Include lists the names of the recorded headers, compared case-insensitively, e.g. [Location, ETag,
+	// X-RateLimit-Remaining]. Sensitive headers are only recorded when they are listed, with their values masked.
+	Include []string `json:"include"`
+}
+
+// Redaction lists the sensitive values of requests and responses masked in the status, the conditions, the events
+// and the logs of the Request.
+type Redaction struct {
+	// Headers lists the request and response headers whose values are masked besides the default ones, compared
+	// case-insensitively, e.g. [X-Tenant-Secret].
+	// +optional
+	Headers []string `json:"headers,omitempty"`
+
+	// BodyFields lists jq paths of the JSON request and response body fields whose values are masked, e.g.
+	// ['.credentials.password', '.keys[].value']. Masked response fields are not available from the status to the
+	// mappings afterwards.
+	// +optional
+	BodyFields []string `json:"bodyFields,omitempty"`
+}
+
+// ResponseStorage configures where response bodies too large for the status are stored, e.g. to stay below the
+// size limit of etcd objects. Bodies are stored under their SHA-256 digest in the ConfigMap or the Secret, which is
+// created when it does not exist, and bodies no longer referenced by the status are removed from it.
+type ResponseStorage struct {
+	// MinBodySize is the size in bytes from which response bodies are stored outside of the status.
+	// +kubebuilder:validation:Minimum=0
+	MinBodySize int `json:"minBodySize"`
+
+	// Kind of the object bodies are stored in, a Secret e.g. for sensitive bodies. Defaults to ConfigMap.
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	// +kubebuilder:default=ConfigMap
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the object bodies are stored in.
+	Name string `json:"name"`
+
+	// Namespace of the object bodies are stored in.
+	Namespace string `json:"namespace"`
+}
+
+// Kinds of the objects response bodies are stored in.
+const (
+	// ResponseStorageConfigMap stores response bodies in a ConfigMap.
+	ResponseStorageConfigMap = "ConfigMap"
+	// ResponseStorageSecret stores response bodies in a Secret.
+	ResponseStorageSecret = "Secret"
+)
+
+// Assertion is an invariant of the observed response.
+type Assertion struct {
+	// Expression is a jq filter returning a boolean, evaluated against the observed response available as
+	// .response.statusCode, .response.headers and .response.body, e.g. '.response.body.state != "error"'.
+	Expression string `json:"expression"`
+
+	// Message explains the failed assertion, e.g. "the quota of the account is exhausted".
+	Message string `json:"message"`
+}
+
+// Compression defines when request bodies are gzip compressed. Only enable it for APIs that accept
+// requests with "Content-Encoding: gzip".
+type Compression struct {
+	// MinBodySize is the size in bytes from which request bodies are compressed.
+	// +kubebuilder:validation:Minimum=0
+	MinBodySize int `json:"minBodySize"`
+}
+
+const (
+	// AfterCreateObserve observes the resource on the next reconcile.
+	AfterCreateObserve = "Observe"
+	// AfterCreateWait considers the resource as existing and up to date until the waitFor duration elapsed.
+	AfterCreateWait = "Wait"
+)
+
+// CreateResponse defines how an acknowledged creation is handled.
+// AsyncOperation configures the polling of asynchronous operations. The URL of an operation is selected from the
+// "202 Accepted" response with the url jq query, or read from its urlHeader.
+type AsyncOperation struct {
+	// URLHeader is the response header holding the URL of the operation, e.g. Operation-Location. Defaults to
+	// Location.
+	// +optional
+	URLHeader string `json:"urlHeader,omitempty"`
+
+	// URL is a jq query selecting the URL of the operation from the response, available as .statusCode,
+	// .headers and .body, e.g. .body.links.operation. It takes precedence over urlHeader.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Done is a jq expression returning true once the operation completed, evaluated against the response to
+	// a GET request to its URL, available as .statusCode, .headers and .body, e.g. .body.status == "Succeeded".
+	Done string `json:"done"`
+
+	// Failed is a jq expression returning true when the operation failed, evaluated like done, e.g.
+	// .body.status == "Failed".
+	// +optional
+	Failed string `json:"failed,omitempty"`
+
+	// PollInterval is how often the operation is polled. Defaults to 10s.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// Phases of asynchronous operations.
+const (
+	// OperationPending is an operation that did not complete yet.
+	OperationPending = "Pending"
+	// OperationSucceeded is an operation that completed.
+	OperationSucceeded = "Succeeded"
+	// OperationFailed is an operation that failed.
+	OperationFailed = "Failed"
+)
+
+// Operation is the asynchronous operation started by a request.
+type Operation struct {
+	// URL of the operation.
+	URL string `json:"url"`
+
+	// Action of the mapping whose request started the operation.
+	Action string `json:"action"`
+
+	// Phase of the operation: Pending, Succeeded or Failed.
+	Phase string `json:"phase"`
+}
+
+type CreateResponse struct {
+	// StatusCodes lists the status codes with which the remote API acknowledges a creation, e.g. [201, 202].
+	// The resource is observed after such a response even when its body is empty.
+	StatusCodes []int `json:"statusCodes"`
+
+	// Then controls what happens after an acknowledged creation. Observe observes the resource right away.
+	// Wait does not observe it until waitFor elapsed, for APIs that create resources asynchronously.
+	// +kubebuilder:validation:Enum=Observe;Wait
+	// +kubebuilder:default=Observe
+	Then string `json:"then,omitempty"`
+
+	// WaitFor is how long to wait after an acknowledged creation when then is Wait. Defaults to 30s.
+	WaitFor *metav1.Duration `json:"waitFor,omitempty"`
+}
+
+// Redirects defines which redirect responses are treated as results instead of being followed.
+type Redirects struct {
+	// CaptureStatusCodes lists the 3xx status codes that are not followed, e.g. [303] for APIs that answer
+	// a create with "303 See Other". The Location header of such a response is recorded in status.location
+	// and used as the URL of subsequent observations.
+	CaptureStatusCodes []int `json:"captureStatusCodes,omitempty"`
+}
+
+// ResponseStream defines how far streamed responses are read.
+type ResponseStream struct {
+	// MaxBytes is the number of bytes of the response body after which reading stops, at most
+	// maxResponseBodyBytes. Defaults to maxResponseBodyBytes, or to 1048576 when it is not set.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+
+	// MaxRecords is the number of NDJSON records after which reading stops.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxRecords int `json:"maxRecords,omitempty"`
+
+	// Until is a jq condition evaluated against each NDJSON record, reading stops after the first record
+	// satisfying it, e.g. '.status == "done"'.
+	// +optional
+	Until string `json:"until,omitempty"`
 }
 
+// ContentNegotiation defines the Accept and Accept-Language headers sent with a request.
+type ContentNegotiation struct {
+	// Accept is the value of the Accept header, e.g. "application/json".
+	Accept string `json:"accept,omitempty"`
+
+	// AcceptLanguage is the value of the Accept-Language header, e.g. "en-US".
+	AcceptLanguage string `json:"acceptLanguage,omitempty"`
+}
+
+const (
+	// TrailingSlashKeep leaves the trailing slash of the URL path untouched.
+	TrailingSlashKeep = "Keep"
+	// TrailingSlashAdd appends a trailing slash to the URL path if it is missing.
+	TrailingSlashAdd = "Add"
+	// TrailingSlashRemove strips trailing slashes from the URL path.
+	TrailingSlashRemove = "Remove"
+)
+
+// URLNormalization defines how generated URLs are normalized.
+// The scheme and host of a normalized URL are always lowercased.
+type URLNormalization struct {
+	// TrailingSlash controls how a trailing slash in the URL path is handled.
+	// +kubebuilder:validation:Enum=Keep;Add;Remove
+	// +kubebuilder:default=Keep
+	TrailingSlash string `json:"trailingSlash,omitempty"`
+
+	// CollapseSlashes, when set to true, collapses duplicate slashes in the URL path (e.g. "/users//123" becomes "/users/123").
+	CollapseSlashes bool `json:"collapseSlashes,omitempty"`
+
+	// LowercasePath, when set to true, lowercases the URL path.
+	LowercasePath bool `json:"lowercasePath,omitempty"`
+}
+
+const (
+	// PatchTypeMerge sends a PATCH mapping as a JSON merge patch (RFC 7386).
+	PatchTypeMerge = "MergePatch"
+	// PatchTypeJSON sends a PATCH mapping as a JSON Patch (RFC 6902).
+	PatchTypeJSON = "JSONPatch"
+)
+
+// Template engines of the mappings of a Request.
+const (
+	// TemplateEngineJQ renders mappings as jq queries.
+	TemplateEngineJQ = "JQ"
+	// TemplateEngineGoTemplate renders mappings as Go templates with the sprig function library.
+	TemplateEngineGoTemplate = "GoTemplate"
+	// TemplateEngineCEL evaluates mappings as CEL expressions.
+	TemplateEngineCEL = "CEL"
+)
+
+// Actions of the mappings of a Request.
+const (
+	// ActionCreate creates the resource.
+	ActionCreate = "CREATE"
+	// ActionObserve observes the resource.
+	ActionObserve = "OBSERVE"
+	// ActionUpdate updates the resource, its body is the desired state.
+	ActionUpdate = "UPDATE"
+	// ActionRemove deletes the resource.
+	ActionRemove = "REMOVE"
+)
+
 type Mapping struct {
-	// +kubebuilder:validation:Enum=POST;GET;PUT;DELETE
-	Method  string              `json:"method"`
-	Body    string              `json:"body,omitempty"`
-	URL     string              `json:"url"`
+	// Action is what the mapping does to the resource, for APIs whose methods do not follow REST conventions,
+	// e.g. that use POST for everything. When unset, it is implied by the method: POST creates, GET, HEAD and
+	// OPTIONS observe, PUT and PATCH update, and DELETE removes. Mappings with an explicit action take
+	// precedence over mappings implying it.
+	// +kubebuilder:validation:Enum=CREATE;OBSERVE;UPDATE;REMOVE
+	// +optional
+	Action string `json:"action,omitempty"`
+
+	// Method is the HTTP method of the mapping. Without explicit actions, the resource is observed with the GET
+	// mapping, or with the HEAD or OPTIONS mapping when there is no GET mapping, in which case it is up to date
+	// as long as it exists.
+	// +kubebuilder:validation:Enum=POST;GET;HEAD;OPTIONS;PUT;PATCH;DELETE
+	Method string `json:"method"`
+	Body   string `json:"body,omitempty"`
+
+	// URL of the mapping. It may be omitted once the URL of the resource is recorded in status.location, e.g.
+	// with useCreateLocation, which is then used instead.
+	// +optional
+	URL     string              `json:"url,omitempty"`
 	Headers map[string][]string `json:"headers,omitempty"`
+
+	// SensitiveHeaders lists the headers of the mapping whose values are sensitive, compared case-insensitively.
+	// Their values are masked in status.requestDetails, the errors and the logs of the Request, as well as their
+	// expressions in the errors rendering them fails with. Header values read from Secrets with headerValues are
+	// never recorded.
+	// +optional
+	SensitiveHeaders []string `json:"sensitiveHeaders,omitempty"`
+
+	// BodyJQ is a jq program producing the body of the mapping, used instead of body. It is evaluated against
+	// the parameters of the request and the last response like body, but as a whole program: it may span
+	// several lines, hold comments and definitions, and produce any JSON value, e.g. an array. A string result
+	// is sent as it is, other results are encoded as JSON.
+	// +optional
+	BodyJQ string `json:"bodyJQ,omitempty"`
+
+	// HeaderValues are merged over the header values of the request for this mapping, by name.
+	// +optional
+	HeaderValues []Header `json:"headerValues,omitempty"`
+
+	// TemplateEngine is how the url, body and headers of the mapping are rendered: as jq queries with JQ, as
+	// Go templates with the sprig function library with GoTemplate, e.g. for loops and conditionals, or as
+	// sandboxed CEL expressions with CEL, validated when the Request is admitted. Defaults to JQ.
+	// +kubebuilder:validation:Enum=JQ;GoTemplate;CEL
+	// +optional
+	TemplateEngine string `json:"templateEngine,omitempty"`
+
+	// PatchType is how the body of a PATCH mapping is sent. The body of the mapping is the desired state, from
+	// which a patch setting the fields that differ from the observed response is generated: a JSON merge patch
+	// with MergePatch, or a JSON Patch with JSONPatch. Defaults to MergePatch.
+	// +kubebuilder:validation:Enum=MergePatch;JSONPatch
+	// +optional
+	PatchType string `json:"patchType,omitempty"`
+
+	// ContentNegotiation overrides the default content negotiation headers for this mapping.
+	ContentNegotiation *ContentNegotiation `json:"contentNegotiation,omitempty"`
+
+	// TLS overrides the TLS settings of the request for this mapping, e.g. when it targets a different host.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
+	// Auth overrides the authentication of the request for this mapping, e.g. when its endpoint expects a
+	// different token. Set disabled to send its requests without authentication.
+	// +optional
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// SignedURL, when set, signs the URL of this mapping for APIs using presigned-URL style authentication.
+	SignedURL *URLSigning `json:"signedURL,omitempty"`
+
+	// BodyFrom streams the body of this mapping from a Secret or a ConfigMap with chunked transfer encoding,
+	// instead of generating it from body. Streamed bodies are not considered when checking for drift. The
+	// value is read straight from the Secret or the ConfigMap as it is sent, without being copied into a buffer.
+	BodyFrom *ValueSource `json:"bodyFrom,omitempty"`
+
+	// BodyBase64 is a base64 encoded binary body of this mapping, sent as raw bytes instead of generating the
+	// body from body. It is sent with the Content-Type header of the mapping, application/octet-stream by
+	// default. Binary bodies are neither recorded in status.requestDetails nor considered when checking for
+	// drift.
+	// +optional
+	BodyBase64 []byte `json:"bodyBase64,omitempty"`
+
+	// Multipart sends the body of this mapping as a multipart/form-data form of the given parts, instead of
+	// generating it from body, e.g. to upload certificates or packages. Multipart bodies are neither recorded in
+	// status.requestDetails nor considered when checking for drift.
+	// +optional
+	Multipart []MultipartPart `json:"multipart,omitempty"`
+
+	// GzipBody compresses the body of this mapping with gzip, whatever its size or kind, and sends it with
+	// "Content-Encoding: gzip", for APIs that require or benefit from compressed large payloads. An explicitly
+	// set Content-Encoding header takes precedence. The uncompressed body is recorded in status.requestDetails.
+	// +optional
+	GzipBody bool `json:"gzipBody,omitempty"`
+
+	// BodyEncoding is how the body of this mapping is sent. With JSON, YAML or Form, the rendered body may be
+	// written as YAML or JSON, e.g. with a Go template, and is sent as JSON, as YAML, or as a form of its
+	// fields (application/x-www-form-urlencoded), e.g. for OAuth token endpoints. The matching Content-Type is
+	// set unless it is set explicitly. Without it, the body is sent as it is rendered.
+	// +kubebuilder:validation:Enum=JSON;YAML;Form
+	// +optional
+	BodyEncoding string `json:"bodyEncoding,omitempty"`
+
+	// ResponseSchema is a JSON Schema, encoded as JSON, that successful responses to this mapping should
+	// match. Responses that do not match it are treated as errors, e.g. to catch changes of the API contract
+	// before they affect the comparison with the desired state.
+	// +optional
+	ResponseSchema string `json:"responseSchema,omitempty"`
+}
+
+// Encodings of mapping bodies.
+const (
+	// BodyEncodingJSON sends the body of a mapping as JSON.
+	BodyEncodingJSON = "JSON"
+	// BodyEncodingYAML sends the body of a mapping as YAML.
+	BodyEncodingYAML = "YAML"
+	// BodyEncodingForm sends the fields of the body of a mapping as an application/x-www-form-urlencoded form.
+	BodyEncodingForm = "Form"
+)
+
+// Header is a header whose value is either literal or read from a Secret. Exactly one of value and valueFrom
+// should be set.
+type Header struct {
+	// Name is the name of the header.
+	Name string `json:"name"`
+
+	// Value is the literal value of the header.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom references the value of the header.
+	// +optional
+	ValueFrom *HeaderValueSource `json:"valueFrom,omitempty"`
+}
+
+// HeaderValueSource references the key holding the value of a header.
+type HeaderValueSource struct {
+	// SecretKeyRef references a key of a Secret.
+	SecretKeyRef xpv1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// ValueSource references the key holding a value, e.g. a request body. Exactly one of its fields should be set.
+type ValueSource struct {
+	// SecretKeyRef references a key of a Secret.
+	SecretKeyRef *xpv1.SecretKeySelector `json:"secretKeyRef,omitempty"`
+
+	// ConfigMapKeyRef references a key of a ConfigMap.
+	ConfigMapKeyRef *apisv1alpha1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// MultipartPart is a part of a multipart/form-data body: either a field whose value is rendered like the header
+// values of the mapping, or a file whose content is read from a Secret or a ConfigMap key.
+type MultipartPart struct {
+	// Name is the name of the form field.
+	Name string `json:"name"`
+
+	// Value is the value of a field part, rendered with the template engine of the mapping. Like header
+	// values, jq values that are not valid expressions are sent as they are.
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom references the content of a file part.
+	// +optional
+	ValueFrom *ValueSource `json:"valueFrom,omitempty"`
+
+	// Filename is the file name of a file part. Defaults to the referenced key.
+	// +optional
+	Filename string `json:"filename,omitempty"`
+
+	// ContentType is the content type of a file part. Defaults to application/octet-stream.
+	// +optional
+	ContentType string `json:"contentType,omitempty"`
+}
+
+// URLSigning configures how a URL is presigned. An expiry and an HMAC signature over the canonical request
+// (the method, the escaped path and the sorted query string including the expiry, separated by newlines)
+// are added to the query string of the URL.
+type URLSigning struct {
+	// KeySecretRef references the secret key used to compute the signature.
+	KeySecretRef xpv1.SecretKeySelector `json:"keySecretRef"`
+
+	// Algorithm is the HMAC algorithm used to compute the signature.
+	// +kubebuilder:validation:Enum=HMAC-SHA256;HMAC-SHA512
+	// +kubebuilder:default=HMAC-SHA256
+	Algorithm string `json:"algorithm,omitempty"`
+
+	// Expiry is how long the signed URL is valid. Defaults to 15m.
+	Expiry *metav1.Duration `json:"expiry,omitempty"`
+
+	// ExpiresParam is the query parameter holding the expiry as a unix timestamp.
+	// +kubebuilder:default=expires
+	ExpiresParam string `json:"expiresParam,omitempty"`
+
+	// SignatureParam is the query parameter holding the hex encoded signature.
+	// +kubebuilder:default=signature
+	SignatureParam string `json:"signatureParam,omitempty"`
 }
 
 type Payload struct {
 	BaseUrl string `json:"baseUrl,omitempty"`
 	Body    string `json:"body,omitempty"`
+
+	// BaseUrlFrom reads the base URL from a Secret or a ConfigMap, instead of baseUrl.
+	// +optional
+	BaseUrlFrom *ValueSource `json:"baseUrlFrom,omitempty"`
+
+	// BodyFrom reads a JSON object from a Secret or a ConfigMap, merged over body, e.g. to keep large payloads
+	// or sensitive fragments outside of the Request. A value that is not a JSON object replaces body.
+	// +optional
+	BodyFrom *ValueSource `json:"bodyFrom,omitempty"`
 }
 
 // A RequestSpec defines the desired state of a Request.
@@ -71,6 +841,94 @@ type RequestStatus struct {
 	Failed              int32    `json:"failed,omitempty"`
 	Error               string   `json:"error,omitempty"`
 	RequestDetails      Mapping  `json:"requestDetails,omitempty"`
+
+	// FailureCounts breaks the failed counter down by reason.
+	FailureCounts apisv1alpha1.FailureCounts `json:"failureCounts,omitempty"`
+
+	// LastFailureReason is the reason of the most recent failure.
+	LastFailureReason apisv1alpha1.FailureReason `json:"lastFailureReason,omitempty"`
+
+	// ThrottledUntil is the time until which the remote API asked not to be called, as indicated by the
+	// Retry-After header of a 429 or 503 response.
+	ThrottledUntil *metav1.Time `json:"throttledUntil,omitempty"`
+
+	// TerminalGeneration is the generation of the Request whose CREATE or UPDATE request failed with a terminal
+	// status code. Its requests are not sent again until the Request changes.
+	TerminalGeneration int64 `json:"terminalGeneration,omitempty"`
+
+	// Location is the URL captured from the Location header of a redirect response listed in
+	// redirects.captureStatusCodes. When set, it is used as the URL of the GET mapping. It is cleared after a
+	// successful DELETE request, or a response with status code 404 or 410.
+	Location string `json:"location,omitempty"`
+
+	// LastFetchedTime is when the stored response was returned by the GET mapping, which starts the staleAfter
+	// window. Unlike lastObservedTime, it does not change when the stored response is reused.
+	LastFetchedTime *metav1.Time `json:"lastFetchedTime,omitempty"`
+
+	// RemoteRequestID is the ID the remote API assigned to the last POST, PUT or DELETE request, as
+	// returned in the requestIDHeader response header.
+	RemoteRequestID string `json:"remoteRequestID,omitempty"`
+
+	// GeneratedValues are the values generated for the generatedValues of the request, by name.
+	GeneratedValues map[string]string `json:"generatedValues,omitempty"`
+
+	// CreateResponse is the response of the last successful CREATE request. Unlike response, it is not replaced
+	// by the responses of the following requests, so that the mappings may always use the fields the remote API
+	// assigned on creation, e.g. as .createResponse.body.id.
+	CreateResponse *Response `json:"createResponse,omitempty"`
+
+	// Operation is the asynchronous operation started by the last request answered with "202 Accepted", when
+	// asyncOperation is set.
+	Operation *Operation `json:"operation,omitempty"`
+
+	// Drift lists the fields of the last observed response that differ from the desired state, which is why an
+	// UPDATE request is sent. It is cleared once the response holds the desired state.
+	Drift *apisv1alpha1.Drift `json:"drift,omitempty"`
+
+	// CorrelationID is the value of the correlation header set on the last requests, when the ProviderConfig
+	// sets a correlationHeader.
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Timings break down the duration of the last request.
+	Timings *apisv1alpha1.Timings `json:"timings,omitempty"`
+
+	// LastObservedTime is when the resource was last observed successfully, i.e. when its drift from the desired
+	// state was last checked, whether against a new response or against the stored one.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// LastOperationTime is when the resource was last created, updated or deleted successfully.
+	LastOperationTime *metav1.Time `json:"lastOperationTime,omitempty"`
+
+	// NextPollTime is when the resource is reconciled next, unless it changes before.
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+
+	// Debug is the round trip of the last request, recorded while the http.crossplane.io/debug annotation is set
+	// to "true" to troubleshoot the rendering of the mappings. It is cleared once the annotation is removed.
+	Debug *Debug `json:"debug,omitempty"`
+}
+
+// Debug is the round trip of the last request of a Request, recorded while its debug annotation is set.
+type Debug struct {
+	// Action is the action of the mapping of the request.
+	Action string `json:"action,omitempty"`
+
+	// Request is the request as it was rendered and sent, with its sensitive values masked.
+	Request Mapping `json:"request,omitempty"`
+
+	// Response is the response as it was received, with its sensitive values masked and all of its headers. Its
+	// body is omitted when response bodies are encrypted.
+	// +optional
+	Response *Response `json:"response,omitempty"`
+
+	// Truncated is true when the body of the response is longer than 4KiB, in which case only its beginning is
+	// recorded.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Error is the error of the request, e.g. when it could not be rendered or sent.
+	Error string `json:"error,omitempty"`
+
+	// Time is when the round trip was recorded.
+	Time metav1.Time `json:"time"`
 }
 
 type Cache struct {
@@ -1,6 +1,14 @@
 package v1alpha1
 
-import "time"
+import (
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
 
 func (d *Request) SetStatusCode(statusCode int) {
 	d.Status.Response.StatusCode = statusCode
@@ -21,9 +29,24 @@ func (d *Request) SetError(err error) {
 	}
 }
 
+func (d *Request) RecordFailure(reason apisv1alpha1.FailureReason) {
+	d.Status.FailureCounts.Record(reason)
+	d.Status.LastFailureReason = reason
+	d.setCircuitOpen(reason == apisv1alpha1.FailureReasonCircuitOpen)
+	apisv1alpha1.SetRequestFailing(&d.Status.ResourceStatus, reason)
+}
+
+func (d *Request) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return d.Status.LastFailureReason
+}
+
 func (d *Request) ResetFailures() {
 	d.Status.Failed = 0
 	d.Status.Error = ""
+	d.Status.FailureCounts = apisv1alpha1.FailureCounts{}
+	d.Status.LastFailureReason = ""
+	d.setCircuitOpen(false)
+	apisv1alpha1.SetRequestFailing(&d.Status.ResourceStatus, "")
 }
 
 func (d *Request) SetRequestDetails(url, method, body string, headers map[string][]string) {
@@ -33,9 +56,144 @@ func (d *Request) SetRequestDetails(url, method, body string, headers map[string
 	d.Status.RequestDetails.Method = method
 }
 
+// SetAction records the action of the mapping of the last request.
+func (d *Request) SetAction(action string) {
+	d.Status.RequestDetails.Action = action
+}
+
 func (d *Request) SetCache(statusCode int, headers map[string][]string, body string) {
 	d.Status.Cache.Response.StatusCode = statusCode
 	d.Status.Cache.Response.Headers = headers
 	d.Status.Cache.Response.Body = body
 	d.Status.Cache.LastUpdated = time.Now().UTC().Format(time.RFC3339)
 }
+
+// SetCreateResponse records the response of a CREATE request.
+func (d *Request) SetCreateResponse(statusCode int, headers map[string][]string, body string) {
+	d.Status.CreateResponse = &Response{StatusCode: statusCode, Headers: headers, Body: body}
+}
+
+func (d *Request) SetThrottledUntil(until *metav1.Time) {
+	if until == nil {
+		if d.Status.ThrottledUntil != nil {
+			d.Status.ThrottledUntil = nil
+			d.Status.SetConditions(apisv1alpha1.NotThrottled())
+		}
+		return
+	}
+
+	d.Status.ThrottledUntil = until
+	d.Status.SetConditions(apisv1alpha1.Throttled(*until))
+}
+
+func (d *Request) SetTerminal(statusCode int, terminal bool) {
+	if !terminal {
+		if d.Status.TerminalGeneration != 0 {
+			d.Status.TerminalGeneration = 0
+			d.Status.SetConditions(apisv1alpha1.NotFailed())
+		}
+		return
+	}
+
+	d.Status.TerminalGeneration = d.Generation
+	d.Status.SetConditions(apisv1alpha1.Failed(statusCode))
+}
+
+func (d *Request) SetLocation(statusCode int, location string) {
+	if gone(d.Status.RequestDetails.GetAction(), statusCode) {
+		d.Status.Location = ""
+		return
+	}
+
+	if location == "" {
+		return
+	}
+
+	if d.Spec.ForProvider.UseCreateLocation && d.Status.RequestDetails.GetAction() == ActionCreate && statusCode >= 200 && statusCode < 300 {
+		d.Status.Location = location
+		return
+	}
+
+	if d.Spec.ForProvider.Redirects == nil {
+		return
+	}
+
+	for _, code := range d.Spec.ForProvider.Redirects.CaptureStatusCodes {
+		if code == statusCode {
+			d.Status.Location = location
+			return
+		}
+	}
+}
+
+// gone checks whether the response shows that the object the recorded location points to no longer exists.
+func gone(action string, statusCode int) bool {
+	if statusCode == http.StatusNotFound || statusCode == http.StatusGone {
+		return true
+	}
+
+	return action == ActionRemove && statusCode >= 200 && statusCode < 300
+}
+
+func (d *Request) SetLastFetched(fetched metav1.Time) {
+	d.Status.LastFetchedTime = &fetched
+}
+
+func (d *Request) SetRemoteRequestID(_ string, headers map[string][]string) {
+	if d.Spec.ForProvider.RequestIDHeader == "" || d.Status.RequestDetails.GetAction() == ActionObserve {
+		return
+	}
+
+	if id := http.Header(headers).Get(d.Spec.ForProvider.RequestIDHeader); id != "" {
+		d.Status.RemoteRequestID = id
+	}
+}
+
+// setCircuitOpen sets the CircuitOpen condition when requests fail fast, and clears it once they are sent again.
+func (d *Request) setCircuitOpen(open bool) {
+	if open {
+		d.Status.SetConditions(apisv1alpha1.CircuitOpen())
+		return
+	}
+
+	if d.Status.GetCondition(apisv1alpha1.TypeCircuitOpen).Status == corev1.ConditionTrue {
+		d.Status.SetConditions(apisv1alpha1.CircuitClosed())
+	}
+}
+
+func (d *Request) SetCorrelationID(id string) {
+	d.Status.CorrelationID = id
+}
+
+// SetDebug records the round trip of the last request, or clears it when nil.
+func (d *Request) SetDebug(debug *Debug) {
+	d.Status.Debug = debug
+}
+
+func (d *Request) SetTimings(timings *apisv1alpha1.Timings) {
+	d.Status.Timings = timings
+}
+
+func (d *Request) SetLastObservedTime(t metav1.Time) {
+	d.Status.LastObservedTime = &t
+}
+
+func (d *Request) GetLastOperationTime() *metav1.Time {
+	return d.Status.LastOperationTime
+}
+
+func (d *Request) SetLastOperationTime(t metav1.Time) {
+	d.Status.LastOperationTime = &t
+}
+
+func (d *Request) GetNextPollTime() *metav1.Time {
+	return d.Status.NextPollTime
+}
+
+func (d *Request) SetNextPollTime(t *metav1.Time) {
+	d.Status.NextPollTime = t
+}
+
+func (d *Request) GetPollInterval() *metav1.Duration {
+	return d.Spec.ForProvider.PollInterval
+}
@@ -22,10 +22,47 @@ limitations under the License.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Assertion) DeepCopyInto(out *Assertion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Assertion.
+func (in *Assertion) DeepCopy() *Assertion {
+	if in == nil {
+		return nil
+	}
+	out := new(Assertion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AsyncOperation) DeepCopyInto(out *AsyncOperation) {
+	*out = *in
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AsyncOperation.
+func (in *AsyncOperation) DeepCopy() *AsyncOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(AsyncOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Cache) DeepCopyInto(out *Cache) {
 	*out = *in
@@ -42,6 +79,224 @@ func (in *Cache) DeepCopy() *Cache {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Compression) DeepCopyInto(out *Compression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Compression.
+func (in *Compression) DeepCopy() *Compression {
+	if in == nil {
+		return nil
+	}
+	out := new(Compression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionDetail) DeepCopyInto(out *ConnectionDetail) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionDetail.
+func (in *ConnectionDetail) DeepCopy() *ConnectionDetail {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionDetail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentNegotiation) DeepCopyInto(out *ContentNegotiation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentNegotiation.
+func (in *ContentNegotiation) DeepCopy() *ContentNegotiation {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentNegotiation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CookieCapture) DeepCopyInto(out *CookieCapture) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CookieCapture.
+func (in *CookieCapture) DeepCopy() *CookieCapture {
+	if in == nil {
+		return nil
+	}
+	out := new(CookieCapture)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CreateResponse) DeepCopyInto(out *CreateResponse) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.WaitFor != nil {
+		in, out := &in.WaitFor, &out.WaitFor
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CreateResponse.
+func (in *CreateResponse) DeepCopy() *CreateResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(CreateResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Debug) DeepCopyInto(out *Debug) {
+	*out = *in
+	in.Request.DeepCopyInto(&out.Request)
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(Response)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Debug.
+func (in *Debug) DeepCopy() *Debug {
+	if in == nil {
+		return nil
+	}
+	out := new(Debug)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentConfigReference) DeepCopyInto(out *EnvironmentConfigReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentConfigReference.
+func (in *EnvironmentConfigReference) DeepCopy() *EnvironmentConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSource) DeepCopyInto(out *EnvironmentSource) {
+	*out = *in
+	if in.EnvironmentConfigRef != nil {
+		in, out := &in.EnvironmentConfigRef, &out.EnvironmentConfigRef
+		*out = new(EnvironmentConfigReference)
+		**out = **in
+	}
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(apisv1alpha1.ConfigMapReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSource.
+func (in *EnvironmentSource) DeepCopy() *EnvironmentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpectedResponseCheck) DeepCopyInto(out *ExpectedResponseCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpectedResponseCheck.
+func (in *ExpectedResponseCheck) DeepCopy() *ExpectedResponseCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpectedResponseCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedValue) DeepCopyInto(out *GeneratedValue) {
+	*out = *in
+	if in.Length != nil {
+		in, out := &in.Length, &out.Length
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedValue.
+func (in *GeneratedValue) DeepCopy() *GeneratedValue {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Header) DeepCopyInto(out *Header) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(HeaderValueSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Header.
+func (in *Header) DeepCopy() *Header {
+	if in == nil {
+		return nil
+	}
+	out := new(Header)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderValueSource) DeepCopyInto(out *HeaderValueSource) {
+	*out = *in
+	out.SecretKeyRef = in.SecretKeyRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderValueSource.
+func (in *HeaderValueSource) DeepCopy() *HeaderValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mapping) DeepCopyInto(out *Mapping) {
 	*out = *in
@@ -60,6 +315,55 @@ func (in *Mapping) DeepCopyInto(out *Mapping) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.SensitiveHeaders != nil {
+		in, out := &in.SensitiveHeaders, &out.SensitiveHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeaderValues != nil {
+		in, out := &in.HeaderValues, &out.HeaderValues
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContentNegotiation != nil {
+		in, out := &in.ContentNegotiation, &out.ContentNegotiation
+		*out = new(ContentNegotiation)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(apisv1alpha1.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(apisv1alpha1.Auth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignedURL != nil {
+		in, out := &in.SignedURL, &out.SignedURL
+		*out = new(URLSigning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BodyFrom != nil {
+		in, out := &in.BodyFrom, &out.BodyFrom
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BodyBase64 != nil {
+		in, out := &in.BodyBase64, &out.BodyBase64
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Multipart != nil {
+		in, out := &in.Multipart, &out.Multipart
+		*out = make([]MultipartPart, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Mapping.
@@ -72,9 +376,69 @@ func (in *Mapping) DeepCopy() *Mapping {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MultipartPart) DeepCopyInto(out *MultipartPart) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MultipartPart.
+func (in *MultipartPart) DeepCopy() *MultipartPart {
+	if in == nil {
+		return nil
+	}
+	out := new(MultipartPart)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Normalization) DeepCopyInto(out *Normalization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Normalization.
+func (in *Normalization) DeepCopy() *Normalization {
+	if in == nil {
+		return nil
+	}
+	out := new(Normalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Operation) DeepCopyInto(out *Operation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Operation.
+func (in *Operation) DeepCopy() *Operation {
+	if in == nil {
+		return nil
+	}
+	out := new(Operation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Payload) DeepCopyInto(out *Payload) {
 	*out = *in
+	if in.BaseUrlFrom != nil {
+		in, out := &in.BaseUrlFrom, &out.BaseUrlFrom
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BodyFrom != nil {
+		in, out := &in.BodyFrom, &out.BodyFrom
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Payload.
@@ -87,6 +451,66 @@ func (in *Payload) DeepCopy() *Payload {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Readiness) DeepCopyInto(out *Readiness) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Readiness.
+func (in *Readiness) DeepCopy() *Readiness {
+	if in == nil {
+		return nil
+	}
+	out := new(Readiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redaction) DeepCopyInto(out *Redaction) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BodyFields != nil {
+		in, out := &in.BodyFields, &out.BodyFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redaction.
+func (in *Redaction) DeepCopy() *Redaction {
+	if in == nil {
+		return nil
+	}
+	out := new(Redaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Redirects) DeepCopyInto(out *Redirects) {
+	*out = *in
+	if in.CaptureStatusCodes != nil {
+		in, out := &in.CaptureStatusCodes, &out.CaptureStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Redirects.
+func (in *Redirects) DeepCopy() *Redirects {
+	if in == nil {
+		return nil
+	}
+	out := new(Redirects)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Request) DeepCopyInto(out *Request) {
 	*out = *in
@@ -156,7 +580,7 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Payload = in.Payload
+	in.Payload.DeepCopyInto(&out.Payload)
 	if in.Headers != nil {
 		in, out := &in.Headers, &out.Headers
 		*out = make(map[string][]string, len(*in))
@@ -172,11 +596,166 @@ func (in *RequestParameters) DeepCopyInto(out *RequestParameters) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.HeaderValues != nil {
+		in, out := &in.HeaderValues, &out.HeaderValues
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.WaitTimeout != nil {
 		in, out := &in.WaitTimeout, &out.WaitTimeout
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(apisv1alpha1.TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.URLNormalization != nil {
+		in, out := &in.URLNormalization, &out.URLNormalization
+		*out = new(URLNormalization)
+		**out = **in
+	}
+	if in.ContentNegotiation != nil {
+		in, out := &in.ContentNegotiation, &out.ContentNegotiation
+		*out = new(ContentNegotiation)
+		**out = **in
+	}
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(Compression)
+		**out = **in
+	}
+	if in.CreateResponse != nil {
+		in, out := &in.CreateResponse, &out.CreateResponse
+		*out = new(CreateResponse)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AsyncOperation != nil {
+		in, out := &in.AsyncOperation, &out.AsyncOperation
+		*out = new(AsyncOperation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redirects != nil {
+		in, out := &in.Redirects, &out.Redirects
+		*out = new(Redirects)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseStream != nil {
+		in, out := &in.ResponseStream, &out.ResponseStream
+		*out = new(ResponseStream)
+		**out = **in
+	}
+	if in.StaleAfter != nil {
+		in, out := &in.StaleAfter, &out.StaleAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = new(apisv1alpha1.StatusCodePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(apisv1alpha1.Auth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseEncryption != nil {
+		in, out := &in.ResponseEncryption, &out.ResponseEncryption
+		*out = new(ResponseEncryption)
+		**out = **in
+	}
+	if in.ConnectionDetails != nil {
+		in, out := &in.ConnectionDetails, &out.ConnectionDetails
+		*out = make([]ConnectionDetail, len(*in))
+		copy(*out, *in)
+	}
+	if in.Cookies != nil {
+		in, out := &in.Cookies, &out.Cookies
+		*out = make([]CookieCapture, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResponseSecrets != nil {
+		in, out := &in.ResponseSecrets, &out.ResponseSecrets
+		*out = make([]ResponseSecret, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResponseConfigMaps != nil {
+		in, out := &in.ResponseConfigMaps, &out.ResponseConfigMaps
+		*out = make([]ResponseConfigMap, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = new(ResponseHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Redact != nil {
+		in, out := &in.Redact, &out.Redact
+		*out = new(Redaction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseStorage != nil {
+		in, out := &in.ResponseStorage, &out.ResponseStorage
+		*out = new(ResponseStorage)
+		**out = **in
+	}
+	if in.ExpectedResponseCheck != nil {
+		in, out := &in.ExpectedResponseCheck, &out.ExpectedResponseCheck
+		*out = new(ExpectedResponseCheck)
+		**out = **in
+	}
+	if in.IgnoreFields != nil {
+		in, out := &in.IgnoreFields, &out.IgnoreFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Normalization != nil {
+		in, out := &in.Normalization, &out.Normalization
+		*out = new(Normalization)
+		**out = **in
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(Readiness)
+		**out = **in
+	}
+	if in.Assertions != nil {
+		in, out := &in.Assertions, &out.Assertions
+		*out = make([]Assertion, len(*in))
+		copy(*out, *in)
+	}
+	if in.GeneratedValues != nil {
+		in, out := &in.GeneratedValues, &out.GeneratedValues
+		*out = make([]GeneratedValue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.References != nil {
+		in, out := &in.References, &out.References
+		*out = make([]RequestReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environment != nil {
+		in, out := &in.Environment, &out.Environment
+		*out = make([]EnvironmentSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestParameters.
@@ -189,6 +768,21 @@ func (in *RequestParameters) DeepCopy() *RequestParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestReference) DeepCopyInto(out *RequestReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestReference.
+func (in *RequestReference) DeepCopy() *RequestReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestSpec) DeepCopyInto(out *RequestSpec) {
 	*out = *in
@@ -213,6 +807,59 @@ func (in *RequestStatus) DeepCopyInto(out *RequestStatus) {
 	in.Response.DeepCopyInto(&out.Response)
 	in.Cache.DeepCopyInto(&out.Cache)
 	in.RequestDetails.DeepCopyInto(&out.RequestDetails)
+	out.FailureCounts = in.FailureCounts
+	if in.ThrottledUntil != nil {
+		in, out := &in.ThrottledUntil, &out.ThrottledUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFetchedTime != nil {
+		in, out := &in.LastFetchedTime, &out.LastFetchedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.GeneratedValues != nil {
+		in, out := &in.GeneratedValues, &out.GeneratedValues
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CreateResponse != nil {
+		in, out := &in.CreateResponse, &out.CreateResponse
+		*out = new(Response)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(Operation)
+		**out = **in
+	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = new(apisv1alpha1.Drift)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Timings != nil {
+		in, out := &in.Timings, &out.Timings
+		*out = new(apisv1alpha1.Timings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastObservedTime != nil {
+		in, out := &in.LastObservedTime, &out.LastObservedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastOperationTime != nil {
+		in, out := &in.LastOperationTime, &out.LastOperationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextPollTime != nil {
+		in, out := &in.NextPollTime, &out.NextPollTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Debug != nil {
+		in, out := &in.Debug, &out.Debug
+		*out = new(Debug)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestStatus.
@@ -254,3 +901,172 @@ func (in *Response) DeepCopy() *Response {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseConfigMap) DeepCopyInto(out *ResponseConfigMap) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]ConnectionDetail, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseConfigMap.
+func (in *ResponseConfigMap) DeepCopy() *ResponseConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseEncryption) DeepCopyInto(out *ResponseEncryption) {
+	*out = *in
+	out.KeySecretRef = in.KeySecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseEncryption.
+func (in *ResponseEncryption) DeepCopy() *ResponseEncryption {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseEncryption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseHeaders) DeepCopyInto(out *ResponseHeaders) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseHeaders.
+func (in *ResponseHeaders) DeepCopy() *ResponseHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseSecret) DeepCopyInto(out *ResponseSecret) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make([]ConnectionDetail, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseSecret.
+func (in *ResponseSecret) DeepCopy() *ResponseSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseStorage) DeepCopyInto(out *ResponseStorage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseStorage.
+func (in *ResponseStorage) DeepCopy() *ResponseStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseStream) DeepCopyInto(out *ResponseStream) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseStream.
+func (in *ResponseStream) DeepCopy() *ResponseStream {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLNormalization) DeepCopyInto(out *URLNormalization) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLNormalization.
+func (in *URLNormalization) DeepCopy() *URLNormalization {
+	if in == nil {
+		return nil
+	}
+	out := new(URLNormalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *URLSigning) DeepCopyInto(out *URLSigning) {
+	*out = *in
+	out.KeySecretRef = in.KeySecretRef
+	if in.Expiry != nil {
+		in, out := &in.Expiry, &out.Expiry
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new URLSigning.
+func (in *URLSigning) DeepCopy() *URLSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(URLSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValueSource) DeepCopyInto(out *ValueSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(apisv1alpha1.ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValueSource.
+func (in *ValueSource) DeepCopy() *ValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
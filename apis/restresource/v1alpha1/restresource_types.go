@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// RestResourceParameters are the configurable fields of a RestResource.
+type RestResourceParameters struct {
+	// BaseURL is the URL of the REST API, e.g. https://api.example.com/v1.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.baseURL' is immutable"
+	BaseURL string `json:"baseURL"`
+
+	// Path is the path of the resource collection relative to the base URL, e.g. users. The resource is
+	// created with a POST to the collection, and read, updated and deleted with a GET, PUT and DELETE to
+	// the collection path followed by its ID.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Field 'forProvider.path' is immutable"
+	Path string `json:"path"`
+
+	// Body is the JSON representation of the resource, sent on create and update.
+	Body string `json:"body,omitempty"`
+
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// IDPath is a jq filter extracting the ID the server assigned to the resource from the body of the create
+	// response. The ID is recorded in the crossplane.io/external-name annotation, which can be set beforehand
+	// to manage an existing resource.
+	// +kubebuilder:default=".id"
+	// +optional
+	IDPath string `json:"idPath,omitempty"`
+
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+
+	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP requests
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures how the TLS certificate of the server is verified. It is merged over the tls of the ProviderConfig.
+	// Its insecureSkipVerify takes precedence over insecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
+	// StatusCodes declares which response status codes are successful, retryable or terminal.
+	// It replaces the statusCodes of the ProviderConfig.
+	StatusCodes *apisv1alpha1.StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the requests of the RestResource. It replaces the auth of the ProviderConfig.
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// PollInterval overrides how often the RestResource is observed, e.g. 30m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// A RestResourceSpec defines the desired state of a RestResource.
+type RestResourceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	ForProvider RestResourceParameters `json:"forProvider"`
+}
+
+type Response struct {
+	StatusCode int                 `json:"statusCode,omitempty"`
+	Body       string              `json:"body,omitempty"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+}
+
+// A RestResourceStatus represents the observed state of a RestResource.
+type RestResourceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Response is the latest response of the REST API to a request for the resource.
+	Response Response `json:"response,omitempty"`
+
+	// URL is the URL of the resource, derived from its ID.
+	URL string `json:"url,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	// LastFailureReason is the reason of the most recent failure.
+	LastFailureReason apisv1alpha1.FailureReason `json:"lastFailureReason,omitempty"`
+
+	// CorrelationID is the value of the correlation header set on the last requests, when the ProviderConfig
+	// sets a correlationHeader.
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Timings break down the duration of the last request.
+	Timings *apisv1alpha1.Timings `json:"timings,omitempty"`
+
+	// LastObservedTime is when the resource was last observed successfully, i.e. when its drift from the desired
+	// state was last checked.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// LastOperationTime is when the resource was last created, updated or deleted successfully.
+	LastOperationTime *metav1.Time `json:"lastOperationTime,omitempty"`
+
+	// NextPollTime is when the resource is reconciled next, unless it changes before.
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RestResource is a resource of a REST API, managed with the create, read, update and delete requests
+// derived from REST conventions.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,http}
+type RestResource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RestResourceSpec   `json:"spec"`
+	Status RestResourceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RestResourceList contains a list of RestResource
+type RestResourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RestResource `json:"items"`
+}
+
+// RestResource type metadata.
+var (
+	RestResourceKind             = reflect.TypeOf(RestResource{}).Name()
+	RestResourceGroupKind        = schema.GroupKind{Group: Group, Kind: RestResourceKind}.String()
+	RestResourceKindAPIVersion   = RestResourceKind + "." + SchemeGroupVersion.String()
+	RestResourceGroupVersionKind = SchemeGroupVersion.WithKind(RestResourceKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RestResource{}, &RestResourceList{})
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func (r *RestResource) SetResponse(statusCode int, body string, headers map[string][]string) {
+	r.Status.Response.StatusCode = statusCode
+	r.Status.Response.Body = body
+	r.Status.Response.Headers = headers
+}
+
+func (r *RestResource) SetError(reason apisv1alpha1.FailureReason, err error) {
+	r.Status.LastFailureReason = reason
+	apisv1alpha1.SetRequestFailing(&r.Status.ResourceStatus, reason)
+	r.Status.Error = ""
+	if err != nil {
+		r.Status.Error = err.Error()
+	}
+}
+
+func (r *RestResource) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return r.Status.LastFailureReason
+}
+
+func (r *RestResource) SetCorrelationID(id string) {
+	r.Status.CorrelationID = id
+}
+
+func (r *RestResource) SetTimings(timings *apisv1alpha1.Timings) {
+	r.Status.Timings = timings
+}
+
+func (r *RestResource) SetLastObservedTime(t metav1.Time) {
+	r.Status.LastObservedTime = &t
+}
+
+func (r *RestResource) GetLastOperationTime() *metav1.Time {
+	return r.Status.LastOperationTime
+}
+
+func (r *RestResource) SetLastOperationTime(t metav1.Time) {
+	r.Status.LastOperationTime = &t
+}
+
+func (r *RestResource) GetNextPollTime() *metav1.Time {
+	return r.Status.NextPollTime
+}
+
+func (r *RestResource) SetNextPollTime(t *metav1.Time) {
+	r.Status.NextPollTime = t
+}
+
+func (r *RestResource) GetPollInterval() *metav1.Duration {
+	return r.Spec.ForProvider.PollInterval
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Auth configures how requests are authenticated against the remote API.
+// At most one method can be set.
+type Auth struct {
+	// Basic authenticates requests with a username and password.
+	// +optional
+	Basic *BasicAuth `json:"basic,omitempty"`
+
+	// OAuth2 authenticates requests with a bearer token obtained through the
+	// OAuth2 client credentials flow.
+	// +optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+
+	// Login authenticates requests with a session token obtained from a login endpoint.
+	// +optional
+	Login *LoginFlow `json:"login,omitempty"`
+
+	// APIKey authenticates requests with a key sent in a header or a query parameter.
+	// +optional
+	APIKey *APIKeyAuth `json:"apiKey,omitempty"`
+
+	// NTLM authenticates requests with an NTLMv2 handshake, as required by Windows-integrated
+	// authentication. Kerberos is not supported.
+	// +optional
+	NTLM *NTLMAuth `json:"ntlm,omitempty"`
+
+	// JWT authenticates requests with a JWT signed with a private key, sent as a bearer token or exchanged for
+	// an access token (RFC 7523).
+	// +optional
+	JWT *JWTBearer `json:"jwt,omitempty"`
+
+	// GCPIDToken authenticates requests with a Google-signed ID token, e.g. to call Cloud Run services or
+	// IAP-protected endpoints.
+	// +optional
+	GCPIDToken *GCPIDToken `json:"gcpIDToken,omitempty"`
+
+	// AzureAD authenticates requests with Azure AD access tokens, e.g. to call Azure REST APIs or
+	// applications protected by Azure AD.
+	// +optional
+	AzureAD *AzureAD `json:"azureAD,omitempty"`
+
+	// Disabled sends requests without authentication, e.g. for a mapping of a public endpoint when the
+	// ProviderConfig authenticates.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// BasicAuth configures HTTP basic authentication. The credentials are sent in
+// the Authorization header of requests that do not set one explicitly.
+type BasicAuth struct {
+	// SecretRef references the Secret holding the username and password.
+	SecretRef xpv1.SecretReference `json:"secretRef"`
+
+	// UsernameKey is the key of the username in the Secret.
+	// +kubebuilder:default=username
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key of the password in the Secret.
+	// +kubebuilder:default=password
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+}
+
+// APIKeyAuth configures authentication with an API key, which is sent in a header or a query parameter of
+// requests that do not set it explicitly.
+type APIKeyAuth struct {
+	// SecretRef references the key of a Secret holding the API key.
+	SecretRef xpv1.SecretKeySelector `json:"secretRef"`
+
+	// In is where the API key is sent, either in a Header or in a Query parameter.
+	// +kubebuilder:validation:Enum=Header;Query
+	// +kubebuilder:default=Header
+	// +optional
+	In string `json:"in,omitempty"`
+
+	// Name is the name of the header or query parameter.
+	// +kubebuilder:default=X-API-Key
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Prefix is prepended to the API key in a header, e.g. "ApiKey ".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+}
+
+const (
+	// APIKeyInHeader sends the API key in a header.
+	APIKeyInHeader = "Header"
+
+	// APIKeyInQuery sends the API key in a query parameter.
+	APIKeyInQuery = "Query"
+)
+
+// NTLMAuth configures NTLM authentication.
+type NTLMAuth struct {
+	// SecretRef references the Secret holding the username and password. The username may be qualified
+	// with its domain, e.g. CORP\john_doe.
+	SecretRef xpv1.SecretReference `json:"secretRef"`
+
+	// UsernameKey is the key of the username in the Secret.
+	// +kubebuilder:default=username
+	// +optional
+	UsernameKey string `json:"usernameKey,omitempty"`
+
+	// PasswordKey is the key of the password in the Secret.
+	// +kubebuilder:default=password
+	// +optional
+	PasswordKey string `json:"passwordKey,omitempty"`
+
+	// Domain of the user, unless the username is qualified with it.
+	// +optional
+	Domain string `json:"domain,omitempty"`
+
+	// Scheme is the HTTP authentication scheme the handshake is sent with, either NTLM or Negotiate for
+	// servers that only offer SPNEGO.
+	// +kubebuilder:validation:Enum=NTLM;Negotiate
+	// +kubebuilder:default=NTLM
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// JWTBearer configures authentication with self-signed JWTs, as used by GitHub Apps or Salesforce.
+// A new JWT is signed shortly before the previous one expires.
+type JWTBearer struct {
+	// PrivateKeySecretRef references the key of a Secret holding the PEM encoded RSA or ECDSA private key the
+	// JWT is signed with. RSA keys sign with RS256, P-256 and P-384 keys with ES256 and ES384.
+	PrivateKeySecretRef xpv1.SecretKeySelector `json:"privateKeySecretRef"`
+
+	// KeyID is sent as the kid header of the JWT.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// Issuer is the iss claim of the JWT.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+
+	// Subject is the sub claim of the JWT.
+	// +optional
+	Subject string `json:"subject,omitempty"`
+
+	// Audience is the aud claim of the JWT.
+	// +optional
+	Audience string `json:"audience,omitempty"`
+
+	// Claims are additional string claims of the JWT.
+	// +optional
+	Claims map[string]string `json:"claims,omitempty"`
+
+	// TTL is the lifetime of the JWT.
+	// +kubebuilder:default="5m"
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// TokenURL, when set, is the token endpoint the JWT is exchanged at for an access token with the
+	// urn:ietf:params:oauth:grant-type:jwt-bearer grant. Otherwise the JWT itself is sent as the bearer token.
+	// +optional
+	TokenURL string `json:"tokenURL,omitempty"`
+
+	// Scopes are the scopes requested when exchanging the JWT.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// GCPIDToken configures authentication with Google-signed ID tokens.
+type GCPIDToken struct {
+	// Audience of the ID token, e.g. the URL of a Cloud Run service or the OAuth client ID of an IAP-protected
+	// application.
+	Audience string `json:"audience"`
+
+	// ServiceAccountKeySecretRef references the key of a Secret holding a service account JSON key. When unset,
+	// the ID token is requested from the metadata server, e.g. with GKE workload identity.
+	// +optional
+	ServiceAccountKeySecretRef *xpv1.SecretKeySelector `json:"serviceAccountKeySecretRef,omitempty"`
+}
+
+// AzureAD configures authentication with Azure AD access tokens, acquired with a client secret, a client
+// certificate or, when neither is set, the managed identity of the node.
+type AzureAD struct {
+	// Scope of the access token, e.g. https://management.azure.com/.default.
+	Scope string `json:"scope"`
+
+	// TenantID is the directory the application is registered in. Required with a client secret or certificate.
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+
+	// ClientID of the application. Required with a client secret or certificate, and selects a user-assigned
+	// managed identity otherwise.
+	// +optional
+	ClientID string `json:"clientID,omitempty"`
+
+	// ClientSecretSecretRef references the key of a Secret holding the client secret of the application.
+	// +optional
+	ClientSecretSecretRef *xpv1.SecretKeySelector `json:"clientSecretSecretRef,omitempty"`
+
+	// ClientCertificateSecretRef references a kubernetes.io/tls Secret holding the certificate of the
+	// application and its private key, under the tls.crt and tls.key keys.
+	// +optional
+	ClientCertificateSecretRef *xpv1.SecretReference `json:"clientCertificateSecretRef,omitempty"`
+
+	// AuthorityHost is the Azure AD endpoint, e.g. https://login.microsoftonline.us for Azure Government.
+	// +kubebuilder:default="https://login.microsoftonline.com"
+	// +optional
+	AuthorityHost string `json:"authorityHost,omitempty"`
+}
+
+// OAuth2ClientCredentials configures the OAuth2 client credentials flow.
+// Tokens are cached and refreshed before they expire, and are sent in the
+// Authorization header of requests that do not set one explicitly.
+type OAuth2ClientCredentials struct {
+	// TokenURL is the token endpoint of the authorization server.
+	TokenURL string `json:"tokenURL"`
+
+	// ClientIDSecretRef references the key of a Secret holding the client ID.
+	ClientIDSecretRef xpv1.SecretKeySelector `json:"clientIDSecretRef"`
+
+	// ClientSecretSecretRef references the key of a Secret holding the client secret.
+	ClientSecretSecretRef xpv1.SecretKeySelector `json:"clientSecretSecretRef"`
+
+	// Scopes are the scopes requested for the token.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// LoginFlow configures a login request whose response holds a session token, as used by APIs that do not
+// support standard authentication schemes. Tokens are cached for their TTL, and are sent in the configured
+// header of requests that do not set it explicitly.
+type LoginFlow struct {
+	// URL of the login endpoint.
+	URL string `json:"url"`
+
+	// Method of the login request.
+	// +kubebuilder:default=POST
+	// +optional
+	Method string `json:"method,omitempty"`
+
+	// Headers of the login request.
+	// +optional
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Body of the login request, a jq template in which the keys of the credentials Secret are available
+	// as .credentials, e.g. { username: .credentials.username, password: .credentials.password }.
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// CredentialsSecretRef references the Secret whose keys are available to the body template.
+	// +optional
+	CredentialsSecretRef *xpv1.SecretReference `json:"credentialsSecretRef,omitempty"`
+
+	// TokenPath is a jq expression extracting the token from the login response, which is available as
+	// .response.statusCode, .response.headers and .response.body, e.g. .response.body.token.
+	TokenPath string `json:"tokenPath"`
+
+	// Header is the header the token is sent in.
+	// +kubebuilder:default=Authorization
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Prefix is prepended to the token in the header, e.g. "Bearer ".
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// TokenTTL is how long a token is used before logging in again.
+	// +kubebuilder:default="1h"
+	// +optional
+	TokenTTL *metav1.Duration `json:"tokenTTL,omitempty"`
+}
@@ -0,0 +1,36 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// A RetryBackoff delays the retries of a failed request exponentially, with jitter, so that resources failing
+// together are not retried together.
+type RetryBackoff struct {
+	// Base is the delay before the first retry. It defaults to 10s.
+	// +optional
+	Base *metav1.Duration `json:"base,omitempty"`
+
+	// Factor multiplies the delay after every failed retry, e.g. "1.5". It defaults to "2".
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	// +optional
+	Factor string `json:"factor,omitempty"`
+
+	// Cap is the maximum delay between retries. It defaults to 5m.
+	// +optional
+	Cap *metav1.Duration `json:"cap,omitempty"`
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Condition types.
+const (
+	// TypeThrottled indicates whether the remote API is currently throttling the requests of a resource.
+	TypeThrottled xpv1.ConditionType = "Throttled"
+
+	// TypeCircuitOpen indicates whether the requests of a resource fail fast, as the remote API kept failing.
+	TypeCircuitOpen xpv1.ConditionType = "CircuitOpen"
+
+	// TypeFailed indicates whether the request of a resource failed with a terminal status code or exhausted its
+	// retries, and will not be retried.
+	TypeFailed xpv1.ConditionType = "Failed"
+
+	// TypeRequestFailing indicates whether the last request of a resource failed, its reason being the
+	// FailureReason of the failure.
+	TypeRequestFailing xpv1.ConditionType = "RequestFailing"
+)
+
+// Condition reasons.
+const (
+	ReasonRetryAfter   xpv1.ConditionReason = "RetryAfter"
+	ReasonNotThrottled xpv1.ConditionReason = "NotThrottled"
+
+	ReasonConsecutiveFailures xpv1.ConditionReason = "ConsecutiveFailures"
+	ReasonCircuitClosed       xpv1.ConditionReason = "CircuitClosed"
+
+	ReasonTerminalStatusCode xpv1.ConditionReason = "TerminalStatusCode"
+	ReasonRetriesExhausted   xpv1.ConditionReason = "RetriesExhausted"
+	ReasonNotFailed          xpv1.ConditionReason = "NotFailed"
+
+	ReasonRequestSucceeded xpv1.ConditionReason = "RequestSucceeded"
+)
+
+// failureMessages describe the failure reasons in the message of the RequestFailing condition.
+var failureMessages = map[FailureReason]string{
+	FailureReasonAuth:               "remote API rejected the credentials of the request",
+	FailureReasonTimeout:            "request did not complete in time",
+	FailureReasonClientError:        "remote API rejected the request",
+	FailureReasonServerError:        "remote API failed to serve the request",
+	FailureReasonRenderError:        "request could not be generated from its mapping",
+	FailureReasonUnexpectedResponse: "response does not match what was expected",
+	FailureReasonConnection:         "remote API could not be reached",
+	FailureReasonTLS:                "TLS handshake with the remote API failed",
+	FailureReasonCircuitOpen:        "request was not sent, as the remote API kept failing",
+}
+
+// Throttled returns a condition that indicates the remote API asked not to be called until the given time.
+func Throttled(until metav1.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeThrottled,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRetryAfter,
+		Message:            fmt.Sprintf("remote API asked to retry after %s", until.UTC().Format(time.RFC3339)),
+	}
+}
+
+// NotThrottled returns a condition that indicates the remote API is no longer throttling requests.
+func NotThrottled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeThrottled,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotThrottled,
+	}
+}
+
+// CircuitOpen returns a condition that indicates requests to the remote API fail fast without being sent, as it
+// kept failing.
+func CircuitOpen() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCircuitOpen,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonConsecutiveFailures,
+		Message:            "remote API kept failing, requests fail fast until it is probed successfully",
+	}
+}
+
+// CircuitClosed returns a condition that indicates requests to the remote API are sent again.
+func CircuitClosed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeCircuitOpen,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCircuitClosed,
+	}
+}
+
+// Failed returns a condition that indicates the request of a resource failed with the given terminal status code,
+// and will not succeed when retried.
+func Failed(statusCode int) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTerminalStatusCode,
+		Message:            fmt.Sprintf("request failed with terminal status code %d, it is not retried", statusCode),
+	}
+}
+
+// RetriesExhausted returns a condition that indicates the request of a resource failed the given number of times,
+// reaching its retries limit, and is no longer retried.
+func RetriesExhausted(failures int32) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeFailed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRetriesExhausted,
+		Message:            fmt.Sprintf("request failed %d times, reaching its retries limit, it is not retried", failures),
+	}
+}
+
+// NotFailed returns a condition that indicates the request of a resource no longer fails with a terminal status
+// code.
+func NotFailed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeFailed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotFailed,
+	}
+}
+
+// RequestFailing returns a condition that indicates the last request of a resource failed for the given reason.
+func RequestFailing(reason FailureReason) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRequestFailing,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             xpv1.ConditionReason(reason),
+		Message:            fmt.Sprintf("%s, see status.error for details", failureMessages[reason]),
+	}
+}
+
+// RequestSucceeded returns a condition that indicates the last request of a resource no longer fails.
+func RequestSucceeded() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRequestFailing,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRequestSucceeded,
+	}
+}
+
+// SetRequestFailing sets the RequestFailing condition of the given status for the given failure reason. An empty
+// reason marks the requests as succeeding again, if they were failing.
+func SetRequestFailing(status *xpv1.ResourceStatus, reason FailureReason) {
+	if reason != "" {
+		status.SetConditions(RequestFailing(reason))
+		return
+	}
+
+	if status.GetCondition(TypeRequestFailing).Status == corev1.ConditionTrue {
+		status.SetConditions(RequestSucceeded())
+	}
+}
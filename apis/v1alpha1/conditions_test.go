@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+func Test_SetRequestFailing(t *testing.T) {
+	type args struct {
+		conditions []xpv1.Condition
+		reasons    []FailureReason
+	}
+	type want struct {
+		condition xpv1.Condition
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NeverFailed": {
+			args: args{
+				reasons: []FailureReason{""},
+			},
+			want: want{
+				condition: xpv1.Condition{Type: TypeRequestFailing, Status: corev1.ConditionUnknown},
+			},
+		},
+		"Failing": {
+			args: args{
+				reasons: []FailureReason{FailureReasonTLS},
+			},
+			want: want{
+				condition: xpv1.Condition{
+					Type:    TypeRequestFailing,
+					Status:  corev1.ConditionTrue,
+					Reason:  "TLS",
+					Message: "TLS handshake with the remote API failed, see status.error for details",
+				},
+			},
+		},
+		"FailingForAnotherReason": {
+			args: args{
+				conditions: []xpv1.Condition{RequestFailing(FailureReasonAuth)},
+				reasons:    []FailureReason{FailureReasonTimeout},
+			},
+			want: want{
+				condition: xpv1.Condition{
+					Type:    TypeRequestFailing,
+					Status:  corev1.ConditionTrue,
+					Reason:  "Timeout",
+					Message: "request did not complete in time, see status.error for details",
+				},
+			},
+		},
+		"Recovered": {
+			args: args{
+				conditions: []xpv1.Condition{RequestFailing(FailureReasonServerError)},
+				reasons:    []FailureReason{""},
+			},
+			want: want{
+				condition: xpv1.Condition{Type: TypeRequestFailing, Status: corev1.ConditionFalse, Reason: ReasonRequestSucceeded},
+			},
+		},
+		"FailedAgain": {
+			args: args{
+				conditions: []xpv1.Condition{RequestSucceeded()},
+				reasons:    []FailureReason{FailureReasonConnection},
+			},
+			want: want{
+				condition: xpv1.Condition{
+					Type:    TypeRequestFailing,
+					Status:  corev1.ConditionTrue,
+					Reason:  "Connection",
+					Message: "remote API could not be reached, see status.error for details",
+				},
+			},
+		},
+		"FailedThenRecovered": {
+			args: args{
+				conditions: []xpv1.Condition{RequestSucceeded()},
+				reasons:    []FailureReason{FailureReasonClientError, ""},
+			},
+			want: want{
+				condition: xpv1.Condition{Type: TypeRequestFailing, Status: corev1.ConditionFalse, Reason: ReasonRequestSucceeded},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			status := &xpv1.ResourceStatus{}
+			status.SetConditions(tc.args.conditions...)
+			for _, reason := range tc.args.reasons {
+				SetRequestFailing(status, reason)
+			}
+
+			got := status.GetCondition(TypeRequestFailing)
+			if diff := cmp.Diff(tc.want.condition, got, cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("SetRequestFailing(...): -want condition, +got condition: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_failureMessages(t *testing.T) {
+	reasons := []FailureReason{
+		FailureReasonAuth,
+		FailureReasonTimeout,
+		FailureReasonClientError,
+		FailureReasonServerError,
+		FailureReasonRenderError,
+		FailureReasonUnexpectedResponse,
+		FailureReasonConnection,
+		FailureReasonTLS,
+		FailureReasonCircuitOpen,
+	}
+
+	for _, reason := range reasons {
+		if failureMessages[reason] == "" {
+			t.Errorf("failureMessages[%s]: want a message describing the failure reason", reason)
+		}
+	}
+	if diff := cmp.Diff(len(reasons), len(failureMessages)); diff != "" {
+		t.Errorf("failureMessages: -want messages, +got messages: %s", diff)
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// A Drift lists the fields of the observed state of a resource that differ from its desired state, as JSON
+// pointers, e.g. /settings/theme. Each list holds at most 10 fields.
+type Drift struct {
+	// Added are the observed fields that are not desired, when they are not allowed by the compare strategy.
+	Added []string `json:"added,omitempty"`
+
+	// Changed are the fields whose observed value differs from the desired one.
+	Changed []string `json:"changed,omitempty"`
+
+	// Removed are the desired fields that are not observed.
+	Removed []string `json:"removed,omitempty"`
+
+	// Truncated is true when some of the lists hold more fields than recorded.
+	Truncated bool `json:"truncated,omitempty"`
+}
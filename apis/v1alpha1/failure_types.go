@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// A FailureReason categorizes why an HTTP request failed.
+// +kubebuilder:validation:Enum=Auth;Timeout;ClientError;ServerError;RenderError;UnexpectedResponse;Connection;TLS;CircuitOpen
+type FailureReason string
+
+// Failure reasons.
+const (
+	// FailureReasonAuth is a 401 or 403 response.
+	FailureReasonAuth FailureReason = "Auth"
+	// FailureReasonTimeout is a request that did not complete in time.
+	FailureReasonTimeout FailureReason = "Timeout"
+	// FailureReasonClientError is any other 4xx response.
+	FailureReasonClientError FailureReason = "ClientError"
+	// FailureReasonServerError is a 5xx response.
+	FailureReasonServerError FailureReason = "ServerError"
+	// FailureReasonRenderError is a request that could not be generated from its mapping.
+	FailureReasonRenderError FailureReason = "RenderError"
+	// FailureReasonUnexpectedResponse is a response that does not match what was expected.
+	FailureReasonUnexpectedResponse FailureReason = "UnexpectedResponse"
+	// FailureReasonConnection is a request that failed without a response, e.g. because the host is unreachable.
+	FailureReasonConnection FailureReason = "Connection"
+	// FailureReasonTLS is a request that failed to establish a TLS connection, e.g. because of an untrusted or
+	// expired certificate.
+	FailureReasonTLS FailureReason = "TLS"
+	// FailureReasonCircuitOpen is a request that was not sent, as its host kept failing.
+	FailureReasonCircuitOpen FailureReason = "CircuitOpen"
+)
+
+// FailureCounts counts the failures of a resource since its last success, by reason.
+type FailureCounts struct {
+	Auth               int32 `json:"auth,omitempty"`
+	Timeout            int32 `json:"timeout,omitempty"`
+	ClientError        int32 `json:"clientError,omitempty"`
+	ServerError        int32 `json:"serverError,omitempty"`
+	RenderError        int32 `json:"renderError,omitempty"`
+	UnexpectedResponse int32 `json:"unexpectedResponse,omitempty"`
+	Connection         int32 `json:"connection,omitempty"`
+	TLS                int32 `json:"tls,omitempty"`
+	CircuitOpen        int32 `json:"circuitOpen,omitempty"`
+}
+
+// Record increments the counter of the supplied reason.
+func (f *FailureCounts) Record(reason FailureReason) {
+	switch reason {
+	case FailureReasonAuth:
+		f.Auth++
+	case FailureReasonTimeout:
+		f.Timeout++
+	case FailureReasonClientError:
+		f.ClientError++
+	case FailureReasonServerError:
+		f.ServerError++
+	case FailureReasonRenderError:
+		f.RenderError++
+	case FailureReasonUnexpectedResponse:
+		f.UnexpectedResponse++
+	case FailureReasonConnection:
+		f.Connection++
+	case FailureReasonTLS:
+		f.TLS++
+	case FailureReasonCircuitOpen:
+		f.CircuitOpen++
+	}
+}
@@ -29,6 +29,63 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// StatusCodes is the default status code policy of the resources using this ProviderConfig.
+	// It is used by resources that do not set their own.
+	StatusCodes *StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the requests of the resources using this ProviderConfig.
+	// +optional
+	Auth *Auth `json:"auth,omitempty"`
+
+	// TLS is the default TLS configuration of the resources using this ProviderConfig.
+	// The tls settings of resources and their mappings are merged over it.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// MaxResponseBodyBytes is the default number of bytes of response bodies read and stored by the Requests
+	// using this ProviderConfig, the rest of larger bodies is dropped. Requests may override it.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxResponseBodyBytes int64 `json:"maxResponseBodyBytes,omitempty"`
+
+	// Vault configures the Vault server that {{ vault:<path>#<key> }} placeholders in the headers and bodies
+	// of requests are resolved from.
+	// +optional
+	Vault *VaultConfig `json:"vault,omitempty"`
+
+	// RateLimit limits the rate of the requests sent by all the resources using this ProviderConfig, so that
+	// they do not exhaust the quota of the remote API. Requests wait for their turn when it is reached.
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// MaxConcurrentRequestsPerHost is the number of requests the resources using this ProviderConfig may have in
+	// flight to a host at once, the others wait for their turn. It overrides the limit of the provider.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentRequestsPerHost int32 `json:"maxConcurrentRequestsPerHost,omitempty"`
+
+	// Timeouts bound the phases of the requests of the resources using this ProviderConfig, to tune the client
+	// to the remote API.
+	// +optional
+	Timeouts *HTTPTimeouts `json:"timeouts,omitempty"`
+
+	// CorrelationHeader is the header, e.g. X-Request-ID, set on the requests of the resources using this
+	// ProviderConfig to the UID of the resource and the ID of the reconcile sending them, as <uid>/<reconcile ID>,
+	// so that they can be found in the logs of the remote API. Requests setting it explicitly keep their value.
+	// The value is recorded in the correlationID of the status of the resources.
+	// +optional
+	CorrelationHeader string `json:"correlationHeader,omitempty"`
+
+	// UserAgent is the User-Agent header of the requests of the resources using this ProviderConfig. It defaults
+	// to provider-http/<version> (<kind>). Requests setting it explicitly keep their value.
+	// +optional
+	UserAgent string `json:"userAgent,omitempty"`
+
+	// PollInterval is how often the resources using this ProviderConfig are observed, unless they set their own
+	// pollInterval. It defaults to the --poll interval of the provider.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
@@ -0,0 +1,38 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// A StatusCodeRange matches HTTP status codes. It is either a single code (e.g. "404"),
+// a class of codes (e.g. "5xx") or an inclusive range (e.g. "500-504").
+// +kubebuilder:validation:Pattern=`^([1-5][0-9]{2}|[1-5]xx|[1-5][0-9]{2}-[1-5][0-9]{2})$`
+type StatusCodeRange string
+
+// StatusCodePolicy declares how the status codes of responses are interpreted.
+// Codes are matched against success, terminal and retryable in that order. Codes
+// that match none of them fall back to the defaults: 2xx are successful, 4xx and
+// 5xx are retryable failures.
+type StatusCodePolicy struct {
+	// Success lists the status codes of successful responses.
+	Success []StatusCodeRange `json:"success,omitempty"`
+
+	// Retryable lists the status codes of failed responses that are retried.
+	Retryable []StatusCodeRange `json:"retryable,omitempty"`
+
+	// Terminal lists the status codes of failed responses that will not succeed
+	// when retried, e.g. "400" or "422".
+	Terminal []StatusCodeRange `json:"terminal,omitempty"`
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// HTTPTimeouts bound the phases of the requests of the resources using a ProviderConfig. The phases whose timeout
+// is not set are bounded by the overall timeout only.
+type HTTPTimeouts struct {
+	// Dial bounds how long connecting to the server may take.
+	// +optional
+	Dial *metav1.Duration `json:"dial,omitempty"`
+
+	// TLSHandshake bounds how long the TLS handshake with the server may take.
+	// +optional
+	TLSHandshake *metav1.Duration `json:"tlsHandshake,omitempty"`
+
+	// ResponseHeader bounds how long the server may take to answer with the headers of its response, once the
+	// request is sent.
+	// +optional
+	ResponseHeader *metav1.Duration `json:"responseHeader,omitempty"`
+
+	// Overall bounds how long requests may take, including reading their response body. It is used by the
+	// resources that do not set their own waitTimeout.
+	// +optional
+	Overall *metav1.Duration `json:"overall,omitempty"`
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Timings break down the duration of the last request of a resource, to tell a slow remote API from a slow
+// network or provider. The phases that did not happen, e.g. the TLS handshake of a plain HTTP request, are not set.
+type Timings struct {
+	// DNS is how long the lookup of the host took.
+	// +optional
+	DNS *metav1.Duration `json:"dns,omitempty"`
+
+	// Connect is how long connecting to the server took.
+	// +optional
+	Connect *metav1.Duration `json:"connect,omitempty"`
+
+	// TLS is how long the TLS handshake with the server took.
+	// +optional
+	TLS *metav1.Duration `json:"tls,omitempty"`
+
+	// FirstByte is how long the server took to answer once the request was sent, until the first byte of its
+	// response.
+	// +optional
+	FirstByte *metav1.Duration `json:"firstByte,omitempty"`
+
+	// Total is how long the whole request took, from sending it to reading its response body.
+	Total metav1.Duration `json:"total"`
+}
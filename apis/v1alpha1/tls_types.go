@@ -0,0 +1,44 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TLSConfig configures how the TLS certificate of the server is verified, and the certificate presented to it.
+type TLSConfig struct {
+	// InsecureSkipVerify, when set, overrides whether TLS certificate checks are skipped.
+	InsecureSkipVerify *bool `json:"insecureSkipVerify,omitempty"`
+
+	// CABundle is a PEM encoded bundle of CA certificates used to verify the
+	// certificate of the server instead of the system roots.
+	CABundle string `json:"caBundle,omitempty"`
+
+	// CABundleSecretRef references the key of a Secret holding a PEM encoded bundle of CA certificates.
+	// Its certificates are trusted together with those of caBundle and caBundleConfigMapRef.
+	CABundleSecretRef *xpv1.SecretKeySelector `json:"caBundleSecretRef,omitempty"`
+
+	// CABundleConfigMapRef references the key of a ConfigMap holding a PEM encoded bundle of CA certificates,
+	// e.g. one distributed by a trust manager. Its certificates are trusted together with those of caBundle and
+	// caBundleSecretRef.
+	CABundleConfigMapRef *ConfigMapKeySelector `json:"caBundleConfigMapRef,omitempty"`
+
+	// ClientCertSecretRef references a Secret holding the client certificate and private key presented to
+	// servers requiring mutual TLS, PEM encoded under the tls.crt and tls.key keys of kubernetes.io/tls Secrets.
+	ClientCertSecretRef *xpv1.SecretReference `json:"clientCertSecretRef,omitempty"`
+}
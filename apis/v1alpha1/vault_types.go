@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// VaultConfig configures the HashiCorp Vault server that {{ vault:<path>#<key> }} placeholders in request headers
+// and bodies are resolved from.
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200.
+	Address string `json:"address"`
+
+	// Namespace is the Vault Enterprise namespace the secrets are read from.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// CABundle is a PEM encoded CA bundle verifying the certificate of the Vault server, instead of the
+	// system roots.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+
+	// Auth authenticates the provider to Vault.
+	Auth VaultAuth `json:"auth"`
+}
+
+// VaultAuth configures the authentication to Vault. Exactly one of its fields should be set.
+type VaultAuth struct {
+	// TokenSecretRef references the key of a Secret holding a Vault token.
+	// +optional
+	TokenSecretRef *xpv1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+
+	// Kubernetes logs in with the Kubernetes auth method, using the service account token of the provider.
+	// +optional
+	Kubernetes *VaultKubernetesAuth `json:"kubernetes,omitempty"`
+}
+
+// VaultKubernetesAuth configures the Kubernetes auth method of Vault.
+type VaultKubernetesAuth struct {
+	// Role the provider logs in as.
+	Role string `json:"role"`
+
+	// MountPath of the Kubernetes auth method.
+	// +kubebuilder:default="kubernetes"
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+
+	// ServiceAccountTokenPath is the path of the service account token file of the provider.
+	// +kubebuilder:default="/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// +optional
+	ServiceAccountTokenPath string `json:"serviceAccountTokenPath,omitempty"`
+}
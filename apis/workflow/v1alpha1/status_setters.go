@@ -0,0 +1,81 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func (w *Workflow) SetResponse(statusCode int, body string, headers map[string][]string) {
+	w.Status.Response.StatusCode = statusCode
+	w.Status.Response.Body = body
+	w.Status.Response.Headers = headers
+}
+
+func (w *Workflow) SetOutput(name, value string) {
+	if w.Status.Outputs == nil {
+		w.Status.Outputs = map[string]string{}
+	}
+	w.Status.Outputs[name] = value
+}
+
+func (w *Workflow) SetError(reason apisv1alpha1.FailureReason, err error) {
+	w.Status.LastFailureReason = reason
+	apisv1alpha1.SetRequestFailing(&w.Status.ResourceStatus, reason)
+	w.Status.Error = ""
+	if err != nil {
+		w.Status.Error = err.Error()
+	}
+}
+
+func (w *Workflow) GetLastFailureReason() apisv1alpha1.FailureReason {
+	return w.Status.LastFailureReason
+}
+
+func (w *Workflow) SetCorrelationID(id string) {
+	w.Status.CorrelationID = id
+}
+
+func (w *Workflow) SetTimings(timings *apisv1alpha1.Timings) {
+	w.Status.Timings = timings
+}
+
+func (w *Workflow) SetLastObservedTime(t metav1.Time) {
+	w.Status.LastObservedTime = &t
+}
+
+func (w *Workflow) GetLastOperationTime() *metav1.Time {
+	return w.Status.LastOperationTime
+}
+
+func (w *Workflow) SetLastOperationTime(t metav1.Time) {
+	w.Status.LastOperationTime = &t
+}
+
+func (w *Workflow) GetNextPollTime() *metav1.Time {
+	return w.Status.NextPollTime
+}
+
+func (w *Workflow) SetNextPollTime(t *metav1.Time) {
+	w.Status.NextPollTime = t
+}
+
+func (w *Workflow) GetPollInterval() *metav1.Duration {
+	return w.Spec.ForProvider.PollInterval
+}
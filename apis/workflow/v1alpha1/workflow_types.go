@@ -0,0 +1,216 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// Workflow actions.
+const (
+	ActionCreate = "Create"
+	ActionUpdate = "Update"
+	ActionDelete = "Delete"
+)
+
+// WorkflowParameters are the configurable fields of a Workflow.
+type WorkflowParameters struct {
+	// Parameters is a JSON object available to the jq expressions of the steps as .parameters.
+	Parameters string `json:"parameters,omitempty"`
+
+	// Headers are sent with the requests of every step, in addition to the headers of the step.
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Create are the steps creating the resource, run in order.
+	// +kubebuilder:validation:MinItems=1
+	Create []Step `json:"create"`
+
+	// Observe is the step reading the resource once it was created. The resource does not exist when it
+	// responds with a 404 or 410. When unset, the resource exists once its create steps completed.
+	// +optional
+	Observe *Step `json:"observe,omitempty"`
+
+	// ExpectedResponse is a jq filter evaluated against the response of the observe step, available as
+	// .response next to .parameters and .outputs. The expression should return a boolean; the update steps
+	// run when it returns false. Example: '.response.body.name == .parameters.name'
+	// +optional
+	ExpectedResponse string `json:"expectedResponse,omitempty"`
+
+	// Update are the steps updating the resource when it is not up to date, run in order.
+	// +optional
+	Update []Step `json:"update,omitempty"`
+
+	// Delete are the steps deleting the resource, run in order. When unset, the resource is not deleted
+	// remotely.
+	// +optional
+	Delete []Step `json:"delete,omitempty"`
+
+	WaitTimeout *metav1.Duration `json:"waitTimeout,omitempty"`
+
+	// InsecureSkipTLSVerify, when set to true, skips TLS certificate checks for the HTTP requests
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+
+	// TLS configures how the TLS certificate of the server is verified. It is merged over the tls of the ProviderConfig.
+	// Its insecureSkipVerify takes precedence over insecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig `json:"tls,omitempty"`
+
+	// StatusCodes declares which response status codes are successful, retryable or terminal.
+	// It replaces the statusCodes of the ProviderConfig.
+	StatusCodes *apisv1alpha1.StatusCodePolicy `json:"statusCodes,omitempty"`
+
+	// Auth authenticates the requests of the Workflow. It replaces the auth of the ProviderConfig.
+	Auth *apisv1alpha1.Auth `json:"auth,omitempty"`
+
+	// PollInterval overrides how often the Workflow is observed, e.g. 30m.
+	// +optional
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// A Step is an HTTP request of a Workflow. Its url, body and headers are jq expressions evaluated against
+// .parameters, the .outputs recorded so far, and the responses of the previous steps of the same run as
+// .steps.<name>.statusCode, .steps.<name>.headers and .steps.<name>.body.
+type Step struct {
+	// Name identifies the step. It must be unique among the steps of an action.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Enum=POST;GET;PUT;DELETE
+	Method string `json:"method"`
+
+	URL string `json:"url"`
+
+	// +optional
+	Body string `json:"body,omitempty"`
+
+	// +optional
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	// Outputs are jq expressions evaluated once the step succeeded, with its response available as
+	// .response. Their results are recorded in status.outputs, e.g. an ID assigned by the server, so that
+	// later steps and actions can use them as .outputs.<name>.
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// A WorkflowSpec defines the desired state of a Workflow.
+type WorkflowSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	ForProvider WorkflowParameters `json:"forProvider"`
+}
+
+type Response struct {
+	StatusCode int                 `json:"statusCode,omitempty"`
+	Body       string              `json:"body,omitempty"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+}
+
+// StepReference references a step of an action of a Workflow.
+type StepReference struct {
+	// Action is the action of the step: Create, Update or Delete.
+	Action string `json:"action"`
+
+	// Index is the position of the step in the steps of the action, starting at 0.
+	Index int `json:"index"`
+
+	// Name is the name of the step.
+	Name string `json:"name"`
+}
+
+// A WorkflowStatus represents the observed state of a Workflow.
+type WorkflowStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// Created is true once the create steps completed.
+	Created bool `json:"created,omitempty"`
+
+	// Outputs are the outputs recorded by the steps.
+	Outputs map[string]string `json:"outputs,omitempty"`
+
+	// FailedStep is the step that failed, from which the action resumes when it runs again. The previous
+	// steps of the action are not run again, so their responses are not available in .steps.
+	FailedStep *StepReference `json:"failedStep,omitempty"`
+
+	// Response is the response of the latest step.
+	Response Response `json:"response,omitempty"`
+
+	Error string `json:"error,omitempty"`
+
+	// LastFailureReason is the reason of the most recent failure.
+	LastFailureReason apisv1alpha1.FailureReason `json:"lastFailureReason,omitempty"`
+
+	// CorrelationID is the value of the correlation header set on the last requests, when the ProviderConfig
+	// sets a correlationHeader.
+	CorrelationID string `json:"correlationID,omitempty"`
+
+	// Timings break down the duration of the last request.
+	Timings *apisv1alpha1.Timings `json:"timings,omitempty"`
+
+	// LastObservedTime is when the resource was last observed successfully, i.e. when its drift from the desired
+	// state was last checked.
+	LastObservedTime *metav1.Time `json:"lastObservedTime,omitempty"`
+
+	// LastOperationTime is when the resource was last created, updated or deleted successfully.
+	LastOperationTime *metav1.Time `json:"lastOperationTime,omitempty"`
+
+	// NextPollTime is when the resource is reconciled next, unless it changes before.
+	NextPollTime *metav1.Time `json:"nextPollTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Workflow manages a resource whose creation, update and deletion take several HTTP requests, e.g. creating
+// an object and then attaching another one to it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,http}
+type Workflow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkflowSpec   `json:"spec"`
+	Status WorkflowStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkflowList contains a list of Workflow
+type WorkflowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Workflow `json:"items"`
+}
+
+// Workflow type metadata.
+var (
+	WorkflowKind             = reflect.TypeOf(Workflow{}).Name()
+	WorkflowGroupKind        = schema.GroupKind{Group: Group, Kind: WorkflowKind}.String()
+	WorkflowKindAPIVersion   = WorkflowKind + "." + SchemeGroupVersion.String()
+	WorkflowGroupVersionKind = SchemeGroupVersion.WithKind(WorkflowKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Workflow{}, &WorkflowList{})
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"time"
@@ -25,6 +26,7 @@ import (
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
@@ -32,7 +34,17 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/arielsepton/provider-http/apis"
+	"github.com/arielsepton/provider-http/internal/admission"
+	"github.com/arielsepton/provider-http/internal/clients/auth"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/http/fixture"
+	"github.com/arielsepton/provider-http/internal/clients/ratelimit"
 	template "github.com/arielsepton/provider-http/internal/controller"
+	"github.com/arielsepton/provider-http/internal/controller/config"
+	"github.com/arielsepton/provider-http/internal/controller/options"
+	"github.com/arielsepton/provider-http/internal/features"
+	"github.com/arielsepton/provider-http/internal/tracing"
+	"github.com/arielsepton/provider-http/internal/webhook"
 )
 
 func main() {
@@ -41,11 +53,27 @@ func main() {
 		debug            = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		leaderElection   = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
 		timeout          = app.Flag("timeout", "Controls how long http requests may take before they are failed.").Default("10m").Duration()
+		reconcileTimeout = app.Flag("reconcile-timeout", "How long a reconcile, including its http requests, may take before it is failed. Defaults to --timeout.").Duration()
 		syncInterval     = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		pollInterval     = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
+		pollJitter       = app.Flag("poll-jitter", "Maximum per-resource delay added to the poll interval, so that resources are not all checked at the same time.").Default("10s").Duration()
 		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
-
-		// namespace = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
+		maxConcurrent    = app.Flag("max-concurrent-reconciles", "Number of resources of a kind reconciled at once, e.g. Request=20, instead of --max-reconcile-rate. May be repeated for several kinds.").PlaceHolder("KIND=N").StringMap()
+		maxPollStretch   = app.Flag("max-poll-stretch", "Maximum factor by which poll intervals are stretched while remote APIs keep failing or work queues are deep. 1 disables load shedding.").Default("1").Float64()
+		queueDepth       = app.Flag("queue-depth-threshold", "Work queue depth from which poll intervals are stretched, when load shedding is enabled.").Default("1000").Int()
+		circuitFailures  = app.Flag("circuit-breaker-failures", "Number of consecutive connection errors or 5xx responses of a host after which requests to it fail fast. 0 disables circuit breaking.").Default("0").Int()
+		circuitProbe     = app.Flag("circuit-breaker-probe-interval", "How often a request is sent to probe a host whose requests fail fast.").Default("30s").Duration()
+		maxPerHost       = app.Flag("max-concurrent-requests-per-host", "Number of requests that may be in flight to a host at once, the others wait for their turn. 0 does not limit them. ProviderConfigs may override it.").Default("0").Int()
+		mockFixtures     = app.Flag("mock-fixtures", "Path to a fixtures file. When set, requests are answered with the responses it defines instead of being sent.").String()
+		webhookAddress   = app.Flag("webhook-address", "Address on which webhook calls triggering the reconcile of Requests are served, e.g. :9443. Webhooks are disabled when it is empty.").Envar("WEBHOOK_ADDRESS").String()
+		webhookSecret    = app.Flag("webhook-secret", "Shared secret webhook calls must hold in their X-Webhook-Secret header.").Envar("WEBHOOK_SECRET").String()
+		tracingEndpoint  = app.Flag("tracing-endpoint", "Host and port of the OTLP gRPC collector the spans of reconciles and http requests are exported to, e.g. otel-collector:4317. Spans are not exported when it is empty.").Envar("TRACING_ENDPOINT").String()
+		tracingInsecure  = app.Flag("tracing-insecure", "Export spans to the tracing endpoint without TLS.").Default("false").Bool()
+		tracingRatio     = app.Flag("tracing-sample-ratio", "Ratio of the traces started by the provider that are exported.").Default("1").Float64()
+		webhookCertDir   = app.Flag("webhook-tls-cert-dir", "Directory holding the tls.crt and tls.key of the admission webhook server validating Requests. Admission webhooks are disabled when it is empty.").Envar("WEBHOOK_TLS_CERT_DIR").String()
+
+		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
+		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for publishing connection details to external secret stores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -76,10 +104,19 @@ func main() {
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
 		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
 		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+
+		CertDir: *webhookCertDir,
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Http APIs to scheme")
 
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{Endpoint: *tracingEndpoint, Insecure: *tracingInsecure, SampleRatio: *tracingRatio})
+	kingpin.FatalIfError(err, "Cannot setup tracing")
+	kingpin.FatalIfError(mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return shutdownTracing(context.Background())
+	})), "Cannot add tracing to controller manager")
+
 	o := controller.Options{
 		Logger:                  log,
 		MaxConcurrentReconciles: *maxReconcileRate,
@@ -88,6 +125,58 @@ func main() {
 		Features:                &feature.Flags{},
 	}
 
-	kingpin.FatalIfError(template.Setup(mgr, o, *timeout), "Cannot setup Template controllers")
+	if *enableExternalSecretStores {
+		o.Features.Enable(features.EnableAlphaExternalSecretStores)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaExternalSecretStores)
+
+		kingpin.FatalIfError(config.EnsureDefaultStoreConfig(context.Background(), mgr.GetClient(), *namespace), "Cannot create default store config")
+	}
+
+	if *reconcileTimeout == 0 {
+		*reconcileTimeout = *timeout
+	}
+
+	maxConcurrentReconciles, err := options.ParseMaxConcurrentReconciles(*maxConcurrent)
+	kingpin.FatalIfError(err, "Cannot parse the number of concurrent reconciles")
+
+	opts := options.Options{
+		Timeout:    *reconcileTimeout,
+		PollJitter: *pollJitter,
+
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+
+		MaxPollStretch:      *maxPollStretch,
+		QueueDepthThreshold: *queueDepth,
+
+		Tokens:       auth.NewTokenCache(),
+		RateLimiters: ratelimit.NewLimiters(),
+
+		CircuitBreakers: httpClient.NewCircuitBreakers(*circuitFailures, *circuitProbe),
+
+		MaxConcurrentRequestsPerHost: *maxPerHost,
+		HostSemaphores:               httpClient.NewHostSemaphores(),
+	}
+
+	if *mockFixtures != "" {
+		fixtures, err := fixture.Load(*mockFixtures)
+		kingpin.FatalIfError(err, "Cannot load mock fixtures")
+		opts.NewHttpClient, err = fixture.NewClientFn(fixtures)
+		kingpin.FatalIfError(err, "Cannot create mock http client")
+		log.Info("Requests are answered with mock fixtures instead of being sent", "fixtures", *mockFixtures)
+	}
+
+	if *webhookAddress != "" {
+		if *webhookSecret == "" {
+			kingpin.Fatalf("A webhook secret is required to serve webhooks")
+		}
+		opts.Webhook = webhook.New(*webhookAddress, *webhookSecret, mgr.GetClient(), log.WithValues("component", "webhook"))
+		kingpin.FatalIfError(mgr.Add(opts.Webhook), "Cannot add webhook receiver to controller manager")
+	}
+
+	if *webhookCertDir != "" {
+		kingpin.FatalIfError(admission.SetupRequest(mgr), "Cannot setup Request admission webhook")
+	}
+
+	kingpin.FatalIfError(template.Setup(mgr, o, opts), "Cannot setup Template controllers")
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
@@ -0,0 +1,106 @@
+// Package admission validates resources when they are admitted to the API server.
+package admission
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/cel"
+	"github.com/arielsepton/provider-http/internal/jsonschema"
+)
+
+const (
+	errNotRequest = "managed resource is not a Request custom resource"
+)
+
+// SetupRequest registers the webhook validating Requests with the given manager.
+func SetupRequest(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Request{}).
+		WithValidator(&requestValidator{}).
+		Complete()
+}
+
+// A requestValidator checks that the CEL expressions of the mappings of Requests compile. Header values are not
+// checked, as like with jq they are sent as they are when they are not valid expressions.
+type requestValidator struct{}
+
+func (v *requestValidator) ValidateCreate(_ context.Context, obj runtime.Object) error {
+	return validateRequest(obj)
+}
+
+func (v *requestValidator) ValidateUpdate(_ context.Context, _, newObj runtime.Object) error {
+	return validateRequest(newObj)
+}
+
+func (v *requestValidator) ValidateDelete(_ context.Context, _ runtime.Object) error {
+	return nil
+}
+
+// validateRequest checks that the url and body of the mappings evaluated as CEL expressions, and the readiness
+// condition and the pattern of the expected response check of the Request compile, and that the response schemas
+// of the mappings parse.
+func validateRequest(obj runtime.Object) error {
+	cr, ok := obj.(*v1alpha1.Request)
+	if !ok {
+		return errors.New(errNotRequest)
+	}
+
+	var errs field.ErrorList
+	for i, mapping := range cr.Spec.ForProvider.Mappings {
+		path := field.NewPath("spec", "forProvider", "mappings").Index(i)
+		if mapping.ResponseSchema != "" {
+			if _, err := jsonschema.Parse(mapping.ResponseSchema); err != nil {
+				errs = append(errs, field.Invalid(path.Child("responseSchema"), mapping.ResponseSchema, err.Error()))
+			}
+		}
+
+		if mapping.TemplateEngine != v1alpha1.TemplateEngineCEL {
+			continue
+		}
+
+		if mapping.URL != "" {
+			errs = append(errs, validateExpression(path.Child("url"), mapping.URL)...)
+		}
+		if mapping.Body != "" {
+			errs = append(errs, validateExpression(path.Child("body"), mapping.Body)...)
+		}
+	}
+
+	if readiness := cr.Spec.ForProvider.Readiness; readiness != nil {
+		if _, err := cel.CompileCondition(readiness.CEL); err != nil {
+			path := field.NewPath("spec", "forProvider", "readiness", "cel")
+			errs = append(errs, field.Invalid(path, readiness.CEL, err.Error()))
+		}
+	}
+
+	if check := cr.Spec.ForProvider.ExpectedResponseCheck; check != nil && check.Type == v1alpha1.ExpectedResponseCheckTypeRegex {
+		if _, err := regexp.Compile(check.Pattern); err != nil {
+			path := field.NewPath("spec", "forProvider", "expectedResponseCheck", "pattern")
+			errs = append(errs, field.Invalid(path, check.Pattern, err.Error()))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return kerrors.NewInvalid(schema.GroupKind{Group: v1alpha1.Group, Kind: v1alpha1.RequestKind}, cr.GetName(), errs)
+}
+
+// validateExpression checks that the given CEL expression compiles.
+func validateExpression(path *field.Path, expression string) field.ErrorList {
+	if _, err := cel.Compile(expression); err != nil {
+		return field.ErrorList{field.Invalid(path, expression, err.Error())}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package admission
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+func Test_ValidateCreate(t *testing.T) {
+	request := func(mappings ...v1alpha1.Mapping) *v1alpha1.Request {
+		cr := &v1alpha1.Request{}
+		cr.SetName("user")
+		cr.Spec.ForProvider.Mappings = mappings
+		return cr
+	}
+
+	type want struct {
+		invalid bool
+	}
+	cases := map[string]struct {
+		cr   *v1alpha1.Request
+		want want
+	}{
+		"JQMappingNotChecked": {
+			cr: request(v1alpha1.Mapping{Method: "POST", URL: ".payload.baseUrl +", Body: "{"}),
+		},
+		"ValidCEL": {
+			cr: request(v1alpha1.Mapping{
+				Method:         "POST",
+				TemplateEngine: v1alpha1.TemplateEngineCEL,
+				URL:            `payload.baseUrl`,
+				Body:           `{"username": payload.body.username}`,
+				Headers:        map[string][]string{"Accept": {"application/json"}},
+			}),
+		},
+		"InvalidURL": {
+			cr: request(v1alpha1.Mapping{Method: "POST", TemplateEngine: v1alpha1.TemplateEngineCEL, URL: `payload.baseUrl +`}),
+			want: want{
+				invalid: true,
+			},
+		},
+		"UndeclaredVariableInBody": {
+			cr: request(v1alpha1.Mapping{
+				Method:         "POST",
+				TemplateEngine: v1alpha1.TemplateEngineCEL,
+				URL:            `payload.baseUrl`,
+				Body:           `{"username": spec.username}`,
+			}),
+			want: want{
+				invalid: true,
+			},
+		},
+		"InvalidResponseSchema": {
+			cr: request(v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl", ResponseSchema: `{"type": "object",`}),
+			want: want{
+				invalid: true,
+			},
+		},
+		"InvalidResponseCheckPattern": {
+			cr: func() *v1alpha1.Request {
+				cr := request(v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl"})
+				cr.Spec.ForProvider.ExpectedResponseCheck = &v1alpha1.ExpectedResponseCheck{Type: v1alpha1.ExpectedResponseCheckTypeRegex, Pattern: `enabled=(true`}
+				return cr
+			}(),
+			want: want{
+				invalid: true,
+			},
+		},
+		"InvalidReadiness": {
+			cr: func() *v1alpha1.Request {
+				cr := request(v1alpha1.Mapping{Method: "GET", URL: ".payload.baseUrl"})
+				cr.Spec.ForProvider.Readiness = &v1alpha1.Readiness{CEL: `body.state ==`}
+				return cr
+			}(),
+			want: want{
+				invalid: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := (&requestValidator{}).ValidateCreate(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.invalid, kerrors.IsInvalid(err)); diff != "" {
+				t.Errorf("ValidateCreate(...): -want invalid, +got invalid: %s", diff)
+			}
+			if !tc.want.invalid && err != nil {
+				t.Errorf("ValidateCreate(...): unexpected error: %s", err)
+			}
+		})
+	}
+}
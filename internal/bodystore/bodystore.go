@@ -0,0 +1,120 @@
+// Package bodystore stores values too large for the status of resources in ConfigMaps or Secrets, and references
+// them by their SHA-256 digest.
+package bodystore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	// Prefix marks references to stored values.
+	Prefix = "stored:v1:"
+
+	// KindConfigMap stores values in a ConfigMap.
+	KindConfigMap = "ConfigMap"
+	// KindSecret stores values in a Secret.
+	KindSecret = "Secret"
+
+	errMalformedReference = "stored value reference is malformed"
+	errDigestMismatch     = "stored value does not match its digest"
+	errStore              = "cannot store value"
+	errLoad               = "cannot load stored value"
+	errRemove             = "cannot remove stored value"
+)
+
+// Location is the ConfigMap or Secret values are stored in.
+type Location struct {
+	// Kind is KindConfigMap or KindSecret, values are stored in a ConfigMap when it is empty.
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// IsStored checks whether the given value is a reference returned by Store.
+func IsStored(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Store stores the given value under its digest in the object at the given location, creating it when it does not
+// exist, and returns a reference to it.
+func Store(ctx context.Context, kube client.Client, location Location, value string) (string, error) {
+	digest := sha256.Sum256([]byte(value))
+	key := hex.EncodeToString(digest[:])
+
+	var err error
+	if location.Kind == KindSecret {
+		err = kubehandler.SetSecretValue(ctx, kube, location.Namespace, location.Name, key, []byte(value))
+	} else {
+		location.Kind = KindConfigMap
+		err = kubehandler.SetConfigMapValue(ctx, kube, location.Namespace, location.Name, key, []byte(value))
+	}
+	if err != nil {
+		return "", errors.Wrap(err, errStore)
+	}
+
+	return Prefix + strings.Join([]string{location.Kind, location.Namespace, location.Name, key}, "/"), nil
+}
+
+// Load returns the value the given reference refers to, once its digest is checked.
+func Load(ctx context.Context, kube client.Client, reference string) (string, error) {
+	location, key, err := parse(reference)
+	if err != nil {
+		return "", err
+	}
+
+	var value []byte
+	if location.Kind == KindSecret {
+		value, err = kubehandler.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: location.Name, Namespace: location.Namespace},
+			Key:             key,
+		})
+	} else {
+		value, err = kubehandler.GetConfigMapValue(ctx, kube, location.Namespace, location.Name, key)
+	}
+	if err != nil {
+		return "", errors.Wrap(err, errLoad)
+	}
+
+	digest := sha256.Sum256(value)
+	if hex.EncodeToString(digest[:]) != key {
+		return "", errors.New(errDigestMismatch)
+	}
+
+	return string(value), nil
+}
+
+// Remove removes the value the given reference refers to. The object it is stored in is deleted once it holds no
+// value.
+func Remove(ctx context.Context, kube client.Client, reference string) error {
+	location, key, err := parse(reference)
+	if err != nil {
+		return err
+	}
+
+	if location.Kind == KindSecret {
+		err = kubehandler.RemoveSecretValue(ctx, kube, location.Namespace, location.Name, key)
+	} else {
+		err = kubehandler.RemoveConfigMapValue(ctx, kube, location.Namespace, location.Name, key)
+	}
+
+	return errors.Wrap(err, errRemove)
+}
+
+// parse returns the location and the key of the value the given reference refers to.
+func parse(reference string) (Location, string, error) {
+	parts := strings.Split(strings.TrimPrefix(reference, Prefix), "/")
+	if !IsStored(reference) || len(parts) != 4 || (parts[0] != KindConfigMap && parts[0] != KindSecret) {
+		return Location{}, "", errors.New(errMalformedReference)
+	}
+
+	return Location{Kind: parts[0], Namespace: parts[1], Name: parts[2]}, parts[3], nil
+}
@@ -0,0 +1,138 @@
+package bodystore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// objectStore returns a client storing the ConfigMaps and Secrets it is given in memory, by name.
+func objectStore() client.Client {
+	objects := map[string]client.Object{}
+	save := func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+		objects[obj.GetName()] = obj.DeepCopyObject().(client.Object)
+		return nil
+	}
+
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			stored, ok := objects[key.Name]
+			if !ok {
+				return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+			}
+			switch o := obj.(type) {
+			case *corev1.ConfigMap:
+				stored.(*corev1.ConfigMap).DeepCopyInto(o)
+			case *corev1.Secret:
+				stored.(*corev1.Secret).DeepCopyInto(o)
+			}
+			return nil
+		},
+		MockCreate: save,
+		MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+			return save(ctx, obj)
+		},
+		MockDelete: func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+			delete(objects, obj.GetName())
+			return nil
+		},
+	}
+}
+
+func Test_StoreLoadRemove(t *testing.T) {
+	body := `{"items":[{"id":"123"},{"id":"456"}]}`
+
+	cases := map[string]struct {
+		location  Location
+		reference string
+	}{
+		"ConfigMap": {
+			location:  Location{Namespace: "default", Name: "responses"},
+			reference: "stored:v1:ConfigMap/default/responses/fdba637509574404f706c7337b0f0434ac88537b1dbb2363806fefa92aa39d2b",
+		},
+		"Secret": {
+			location:  Location{Kind: KindSecret, Namespace: "default", Name: "responses"},
+			reference: "stored:v1:Secret/default/responses/fdba637509574404f706c7337b0f0434ac88537b1dbb2363806fefa92aa39d2b",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			kube := objectStore()
+
+			reference, err := Store(context.Background(), kube, tc.location, body)
+			if err != nil {
+				t.Fatalf("Store(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.reference, reference); diff != "" {
+				t.Errorf("Store(...): -want reference, +got reference: %s", diff)
+			}
+			if !IsStored(reference) {
+				t.Errorf("IsStored(%q): want true", reference)
+			}
+
+			got, err := Load(context.Background(), kube, reference)
+			if err != nil {
+				t.Fatalf("Load(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(body, got); diff != "" {
+				t.Errorf("Load(...): -want value, +got value: %s", diff)
+			}
+
+			if err := Remove(context.Background(), kube, reference); err != nil {
+				t.Fatalf("Remove(...): unexpected error: %s", err)
+			}
+			if _, err := Load(context.Background(), kube, reference); err == nil {
+				t.Errorf("Load(...): want error once the value is removed")
+			}
+		})
+	}
+}
+
+func Test_Load(t *testing.T) {
+	type want struct {
+		value string
+		err   error
+	}
+	cases := map[string]struct {
+		reference string
+		want      want
+	}{
+		"NotStored": {
+			reference: `{"id":"123"}`,
+			want:      want{err: errors.New(errMalformedReference)},
+		},
+		"UnknownKind": {
+			reference: "stored:v1:Pod/default/responses/0000",
+			want:      want{err: errors.New(errMalformedReference)},
+		},
+		"DigestMismatch": {
+			reference: "stored:v1:ConfigMap/default/responses/0000",
+			want:      want{err: errors.New(errDigestMismatch)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.ConfigMap).Data = map[string]string{"0000": `{"id":"123"}`}
+				return nil
+			})}
+
+			got, err := Load(context.Background(), kube, tc.reference)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Load(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Errorf("Load(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
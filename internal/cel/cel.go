@@ -0,0 +1,158 @@
+// Package cel evaluates the CEL expressions of Request mappings.
+package cel
+
+import (
+	"reflect"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	errNewEnv   = "cannot create CEL environment"
+	errCompile  = "cannot compile CEL expression %q"
+	errEvaluate = "cannot evaluate CEL expression %q"
+	errConvert  = "cannot convert result of CEL expression %q"
+	errNotBool  = "CEL condition %q should return a bool, got: %s"
+)
+
+// Variables available to CEL expressions.
+const (
+	// VariablePayload is the payload of the request.
+	VariablePayload = "payload"
+	// VariableResponse is the last response of the request.
+	VariableResponse = "response"
+	// VariableGenerated are the generated values of the request.
+	VariableGenerated = "generated"
+	// VariableReferences are the values read from the responses of referenced requests.
+	VariableReferences = "references"
+	// VariableEnvironment is the data of the environment sources of the request.
+	VariableEnvironment = "environment"
+	// VariableCreateResponse is the response of the last successful CREATE request.
+	VariableCreateResponse = "createResponse"
+	// VariableExternalName is the external name of the request.
+	VariableExternalName = "externalName"
+)
+
+// newEnv returns the environment of CEL expressions: the payload, response, generated, references, environment,
+// createResponse and externalName variables, and the string and encoding extensions.
+func newEnv() (*cel.Env, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(VariablePayload, cel.DynType),
+		cel.Variable(VariableResponse, cel.DynType),
+		cel.Variable(VariableGenerated, cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable(VariableReferences, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(VariableEnvironment, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(VariableCreateResponse, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(VariableExternalName, cel.StringType),
+		ext.Strings(),
+		ext.Encoders(),
+	)
+	return env, errors.Wrap(err, errNewEnv)
+}
+
+// Variables available to CEL conditions over a response.
+const (
+	// VariableStatusCode is the status code of the response.
+	VariableStatusCode = "statusCode"
+	// VariableHeaders are the headers of the response.
+	VariableHeaders = "headers"
+	// VariableBody is the body of the response, decoded when it is JSON.
+	VariableBody = "body"
+)
+
+// newResponseEnv returns the environment of CEL conditions over a response: the statusCode, headers and body
+// variables, and the string and encoding extensions.
+func newResponseEnv() (*cel.Env, error) {
+	env, err := cel.NewEnv(
+		cel.Variable(VariableStatusCode, cel.IntType),
+		cel.Variable(VariableHeaders, cel.MapType(cel.StringType, cel.ListType(cel.StringType))),
+		cel.Variable(VariableBody, cel.DynType),
+		ext.Strings(),
+		ext.Encoders(),
+	)
+	return env, errors.Wrap(err, errNewEnv)
+}
+
+// Compile checks the given CEL expression, returning the program evaluating it.
+func Compile(expression string) (cel.Program, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return compile(env, expression)
+}
+
+// CompileCondition checks the given CEL condition over a response, returning the program evaluating it.
+func CompileCondition(expression string) (cel.Program, error) {
+	env, err := newResponseEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return compile(env, expression)
+}
+
+// compile checks the given CEL expression in the given environment, returning the program evaluating it.
+func compile(env *cel.Env, expression string) (cel.Program, error) {
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, errors.Wrapf(issues.Err(), errCompile, expression)
+	}
+
+	program, err := env.Program(ast)
+	return program, errors.Wrapf(err, errCompile, expression)
+}
+
+// Evaluate evaluates the given CEL expression with the given variables. The result is returned as a JSON
+// compatible value: a map, a slice, a string, a float64, a bool or nil.
+func Evaluate(expression string, variables map[string]interface{}) (interface{}, error) {
+	program, err := Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _, err := program.Eval(variables)
+	if err != nil {
+		return nil, errors.Wrapf(err, errEvaluate, expression)
+	}
+
+	value, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, errors.Wrapf(err, errConvert, expression)
+	}
+
+	return value.(*structpb.Value).AsInterface(), nil
+}
+
+// EvaluateCondition evaluates the given CEL condition over a response with the given status code, headers and
+// body, which should be JSON compatible.
+func EvaluateCondition(expression string, statusCode int, headers map[string][]string, body interface{}) (bool, error) {
+	program, err := CompileCondition(expression)
+	if err != nil {
+		return false, err
+	}
+
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{
+		VariableStatusCode: statusCode,
+		VariableHeaders:    headers,
+		VariableBody:       body,
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, errEvaluate, expression)
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.Errorf(errNotBool, expression, out.Type().TypeName())
+	}
+
+	return result, nil
+}
@@ -0,0 +1,135 @@
+package cel
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Evaluate(t *testing.T) {
+	variables := map[string]interface{}{
+		VariablePayload: map[string]interface{}{
+			"baseUrl": "https://api.example.com/users",
+			"body":    map[string]interface{}{"username": "john_doe", "tags": []interface{}{"a", "b"}},
+		},
+		VariableResponse: map[string]interface{}{
+			"statusCode": float64(200),
+			"body":       map[string]interface{}{"id": "123"},
+		},
+	}
+
+	type want struct {
+		result interface{}
+		err    bool
+	}
+	cases := map[string]struct {
+		expression string
+		want       want
+	}{
+		"String": {
+			expression: `payload.baseUrl + "/" + response.body.id`,
+			want: want{
+				result: "https://api.example.com/users/123",
+			},
+		},
+		"Object": {
+			expression: `{"name": payload.body.username.upperAscii(), "tags": payload.body.tags, "admin": false}`,
+			want: want{
+				result: map[string]interface{}{"name": "JOHN_DOE", "tags": []interface{}{"a", "b"}, "admin": false},
+			},
+		},
+		"Conditional": {
+			expression: `has(payload.body.nickname) ? payload.body.nickname : null`,
+			want: want{
+				result: nil,
+			},
+		},
+		"CompileError": {
+			expression: `payload.baseUrl +`,
+			want: want{
+				err: true,
+			},
+		},
+		"UndeclaredVariable": {
+			expression: `spec.forProvider`,
+			want: want{
+				err: true,
+			},
+		},
+		"EvaluationError": {
+			expression: `response.body.missing`,
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := Evaluate(tc.expression, variables)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("Evaluate(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Evaluate(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_EvaluateCondition(t *testing.T) {
+	headers := map[string][]string{"Content-Type": {"application/json"}}
+	body := map[string]interface{}{"state": "ACTIVE", "replicas": float64(3)}
+
+	type want struct {
+		result bool
+		err    bool
+	}
+	cases := map[string]struct {
+		expression string
+		want       want
+	}{
+		"BodyField": {
+			expression: `body.state == "ACTIVE"`,
+			want: want{
+				result: true,
+			},
+		},
+		"StatusCodeAndHeaders": {
+			expression: `statusCode == 200 && headers["Content-Type"][0].startsWith("application/json")`,
+			want: want{
+				result: true,
+			},
+		},
+		"NotMet": {
+			expression: `body.replicas > 5.0`,
+			want: want{
+				result: false,
+			},
+		},
+		"NotBool": {
+			expression: `body.state`,
+			want: want{
+				err: true,
+			},
+		},
+		"CompileError": {
+			expression: `body.state ==`,
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := EvaluateCondition(tc.expression, 200, headers, body)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("EvaluateCondition(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("EvaluateCondition(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
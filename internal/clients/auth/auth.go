@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package auth authenticates the requests sent to remote APIs.
+package auth
+
+import (
+	"context"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/http/ntlm"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	defaultUsernameKey = "username"
+	defaultPasswordKey = "password"
+	defaultAPIKeyName  = "X-API-Key"
+
+	errMultipleMethods = "only one authentication method can be set"
+	errGetUsername     = "cannot get username"
+	errGetPassword     = "cannot get password"
+	errGetClientID     = "cannot get OAuth2 client ID"
+	errGetClientSecret = "cannot get OAuth2 client secret"
+	errGetAPIKey       = "cannot get API key"
+)
+
+// Effective returns the auth of a resource, falling back to the ProviderConfig default. It also returns the auth
+// of a mapping, falling back to the auth of its resource.
+func Effective(resourceAuth, providerConfigAuth *apisv1alpha1.Auth) *apisv1alpha1.Auth {
+	if resourceAuth != nil {
+		return resourceAuth
+	}
+
+	return providerConfigAuth
+}
+
+// ClientOptions returns the HTTP client options authenticating requests as
+// configured by the given Auth.
+func ClientOptions(ctx context.Context, kube client.Client, tokens *TokenCache, auth *apisv1alpha1.Auth) ([]httpClient.ClientOption, error) {
+	if auth == nil {
+		return nil, nil
+	}
+
+	if methods(auth) > 1 {
+		return nil, errors.New(errMultipleMethods)
+	}
+
+	switch {
+	case auth.Disabled:
+		return nil, nil
+	case auth.Basic != nil:
+		username, password, err := basicCredentials(ctx, kube, auth.Basic)
+		if err != nil {
+			return nil, err
+		}
+		return []httpClient.ClientOption{httpClient.WithBasicAuth(username, password)}, nil
+	case auth.OAuth2 != nil:
+		config, err := clientCredentials(ctx, kube, auth.OAuth2)
+		if err != nil {
+			return nil, err
+		}
+		return []httpClient.ClientOption{httpClient.WithTokenSource(tokens.TokenSource(config))}, nil
+	case auth.Login != nil:
+		login, err := newLoginRequest(ctx, kube, auth.Login)
+		if err != nil {
+			return nil, err
+		}
+		header := auth.Login.Header
+		if header == "" {
+			header = defaultLoginHeader
+		}
+		return []httpClient.ClientOption{httpClient.WithHeaderTokenSource(header, auth.Login.Prefix, tokens.source(login.key(), login.token))}, nil
+	case auth.APIKey != nil:
+		return apiKeyOptions(ctx, kube, auth.APIKey)
+	case auth.NTLM != nil:
+		credentials, err := ntlmCredentials(ctx, kube, auth.NTLM)
+		if err != nil {
+			return nil, err
+		}
+		scheme := auth.NTLM.Scheme
+		if scheme == "" {
+			scheme = ntlm.SchemeNTLM
+		}
+		return []httpClient.ClientOption{httpClient.WithNTLM(scheme, credentials)}, nil
+	case auth.JWT != nil:
+		signer, err := newJWTSigner(ctx, kube, auth.JWT)
+		if err != nil {
+			return nil, err
+		}
+		return []httpClient.ClientOption{httpClient.WithTokenSource(tokens.source(signer.key(), signer.token))}, nil
+	case auth.GCPIDToken != nil:
+		key, fetch, err := gcpIDTokenSource(ctx, kube, auth.GCPIDToken)
+		if err != nil {
+			return nil, err
+		}
+		return []httpClient.ClientOption{httpClient.WithTokenSource(tokens.source(key, fetch))}, nil
+	case auth.AzureAD != nil:
+		key, fetch, err := azureTokenSource(ctx, kube, auth.AzureAD)
+		if err != nil {
+			return nil, err
+		}
+		return []httpClient.ClientOption{httpClient.WithTokenSource(tokens.source(key, fetch))}, nil
+	}
+
+	return nil, nil
+}
+
+// apiKeyOptions returns the HTTP client options sending the API key of the given API key auth.
+func apiKeyOptions(ctx context.Context, kube client.Client, spec *apisv1alpha1.APIKeyAuth) ([]httpClient.ClientOption, error) {
+	key, err := kubehandler.GetSecretValue(ctx, kube, spec.SecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetAPIKey)
+	}
+
+	name := spec.Name
+	if name == "" {
+		name = defaultAPIKeyName
+	}
+
+	if spec.In == apisv1alpha1.APIKeyInQuery {
+		return []httpClient.ClientOption{httpClient.WithQueryParameter(name, string(key))}, nil
+	}
+
+	return []httpClient.ClientOption{httpClient.WithHeaderTokenSource(name, spec.Prefix, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: string(key)}))}, nil
+}
+
+// methods returns the number of authentication methods set in the given Auth.
+func methods(auth *apisv1alpha1.Auth) int {
+	configured := []bool{
+		auth.Basic != nil, auth.OAuth2 != nil, auth.Login != nil, auth.APIKey != nil, auth.NTLM != nil, auth.JWT != nil,
+		auth.GCPIDToken != nil, auth.AzureAD != nil, auth.Disabled,
+	}
+
+	n := 0
+	for _, set := range configured {
+		if set {
+			n++
+		}
+	}
+
+	return n
+}
+
+// basicCredentials returns the username and password read from the Secret of the given basic auth.
+func basicCredentials(ctx context.Context, kube client.Client, spec *apisv1alpha1.BasicAuth) (string, string, error) {
+	return usernamePassword(ctx, kube, spec.SecretRef, spec.UsernameKey, spec.PasswordKey)
+}
+
+// ntlmCredentials returns the NTLM credentials read from the Secret of the given NTLM auth.
+func ntlmCredentials(ctx context.Context, kube client.Client, spec *apisv1alpha1.NTLMAuth) (ntlm.Credentials, error) {
+	username, password, err := usernamePassword(ctx, kube, spec.SecretRef, spec.UsernameKey, spec.PasswordKey)
+	if err != nil {
+		return ntlm.Credentials{}, err
+	}
+
+	domain := spec.Domain
+	if qualifiedDomain, user, ok := strings.Cut(username, `\`); ok && domain == "" {
+		domain, username = qualifiedDomain, user
+	}
+
+	return ntlm.Credentials{Domain: domain, Username: username, Password: password}, nil
+}
+
+// usernamePassword returns the username and password read from the given keys of a Secret, which default to
+// username and password.
+func usernamePassword(ctx context.Context, kube client.Client, ref xpv1.SecretReference, usernameKey, passwordKey string) (string, string, error) {
+	if usernameKey == "" {
+		usernameKey = defaultUsernameKey
+	}
+	if passwordKey == "" {
+		passwordKey = defaultPasswordKey
+	}
+
+	username, err := kubehandler.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: ref, Key: usernameKey})
+	if err != nil {
+		return "", "", errors.Wrap(err, errGetUsername)
+	}
+
+	password, err := kubehandler.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: ref, Key: passwordKey})
+	if err != nil {
+		return "", "", errors.Wrap(err, errGetPassword)
+	}
+
+	return string(username), string(password), nil
+}
+
+// clientCredentials returns the client credentials flow configuration, with
+// the client ID and secret read from their Secrets.
+func clientCredentials(ctx context.Context, kube client.Client, spec *apisv1alpha1.OAuth2ClientCredentials) (clientcredentials.Config, error) {
+	clientID, err := kubehandler.GetSecretValue(ctx, kube, spec.ClientIDSecretRef)
+	if err != nil {
+		return clientcredentials.Config{}, errors.Wrap(err, errGetClientID)
+	}
+
+	clientSecret, err := kubehandler.GetSecretValue(ctx, kube, spec.ClientSecretSecretRef)
+	if err != nil {
+		return clientcredentials.Config{}, errors.Wrap(err, errGetClientSecret)
+	}
+
+	return clientcredentials.Config{
+		ClientID:     string(clientID),
+		ClientSecret: string(clientSecret),
+		TokenURL:     spec.TokenURL,
+		Scopes:       spec.Scopes,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func Test_basicCredentials(t *testing.T) {
+	kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{
+			"username": []byte("john_doe"),
+			"password": []byte("hunter2"),
+			"user":     []byte("jane_doe"),
+		}
+		return nil
+	})}
+	secretRef := xpv1.SecretReference{Name: "api-credentials", Namespace: "crossplane-system"}
+
+	type want struct {
+		username string
+		password string
+		err      bool
+	}
+	cases := map[string]struct {
+		spec *apisv1alpha1.BasicAuth
+		want want
+	}{
+		"DefaultKeys": {
+			spec: &apisv1alpha1.BasicAuth{SecretRef: secretRef},
+			want: want{
+				username: "john_doe",
+				password: "hunter2",
+			},
+		},
+		"CustomKeys": {
+			spec: &apisv1alpha1.BasicAuth{SecretRef: secretRef, UsernameKey: "user"},
+			want: want{
+				username: "jane_doe",
+				password: "hunter2",
+			},
+		},
+		"MissingKey": {
+			spec: &apisv1alpha1.BasicAuth{SecretRef: secretRef, PasswordKey: "pass"},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			username, password, err := basicCredentials(context.Background(), kube, tc.spec)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("basicCredentials(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.username, username); diff != "" {
+				t.Errorf("basicCredentials(...): -want username, +got username: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.password, password); diff != "" {
+				t.Errorf("basicCredentials(...): -want password, +got password: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ClientOptions(t *testing.T) {
+	cases := map[string]struct {
+		auth    *apisv1alpha1.Auth
+		wantLen int
+		wantErr bool
+	}{
+		"NoAuth": {},
+		"MultipleMethods": {
+			auth: &apisv1alpha1.Auth{
+				Basic:  &apisv1alpha1.BasicAuth{},
+				OAuth2: &apisv1alpha1.OAuth2ClientCredentials{},
+			},
+			wantErr: true,
+		},
+		"Basic": {
+			auth: &apisv1alpha1.Auth{
+				Basic: &apisv1alpha1.BasicAuth{},
+			},
+			wantLen: 1,
+		},
+		"OAuth2": {
+			auth: &apisv1alpha1.Auth{
+				OAuth2: &apisv1alpha1.OAuth2ClientCredentials{
+					TokenURL:              "https://auth.example.com/token",
+					ClientIDSecretRef:     xpv1.SecretKeySelector{Key: "username"},
+					ClientSecretSecretRef: xpv1.SecretKeySelector{Key: "password"},
+				},
+			},
+			wantLen: 1,
+		},
+		"APIKey": {
+			auth: &apisv1alpha1.Auth{
+				APIKey: &apisv1alpha1.APIKeyAuth{SecretRef: xpv1.SecretKeySelector{Key: "password"}, In: apisv1alpha1.APIKeyInQuery},
+			},
+			wantLen: 1,
+		},
+		"Disabled": {
+			auth:    &apisv1alpha1.Auth{Disabled: true},
+			wantLen: 0,
+		},
+		"DisabledWithMethod": {
+			auth: &apisv1alpha1.Auth{
+				Disabled: true,
+				Basic:    &apisv1alpha1.BasicAuth{},
+			},
+			wantErr: true,
+		},
+		"MissingAPIKey": {
+			auth: &apisv1alpha1.Auth{
+				APIKey: &apisv1alpha1.APIKeyAuth{SecretRef: xpv1.SecretKeySelector{Key: "api-key"}},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.Secret).Data = map[string][]byte{"username": []byte("u"), "password": []byte("p")}
+				return nil
+			})}
+			got, err := ClientOptions(context.Background(), kube, NewTokenCache(), tc.auth)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("ClientOptions(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.wantLen, len(got)); diff != "" {
+				t.Errorf("ClientOptions(...): -want options, +got options: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // the x5t header is defined as the SHA-1 thumbprint of the certificate.
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	defaultAzureAuthorityHost = "https://login.microsoftonline.com"
+
+	clientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+
+	errAzureMultipleCredentials = "only one of clientSecretSecretRef and clientCertificateSecretRef can be set"
+	errAzureMissingApplication  = "tenantID and clientID are required with a client secret or certificate"
+	errGetAzureClientSecret     = "cannot get Azure AD client secret"
+	errGetAzureCertificate      = "cannot get Azure AD client certificate"
+	errGetAzureCertificateKey   = "cannot get Azure AD client certificate key"
+	errInvalidAzureCertificate  = "Azure AD client certificate is not a PEM encoded certificate"
+	errAzureCertificateKey      = "Azure AD client certificate key must be an RSA private key"
+	errGetManagedIdentityToken  = "cannot get managed identity access token"
+	errManagedIdentityStatus    = "managed identity endpoint responded with status code %d"
+	errManagedIdentityEmpty     = "managed identity endpoint responded with no access token"
+)
+
+// azureIMDSEndpoint is the token endpoint of the Azure instance metadata service.
+var azureIMDSEndpoint = "http://169.254.169.254/metadata/identity/oauth2/token"
+
+// azureTokenSource returns the key identifying the access tokens of the given Azure AD auth in the token cache,
+// and the function fetching them.
+func azureTokenSource(ctx context.Context, kube client.Client, spec *apisv1alpha1.AzureAD) (string, func() (*oauth2.Token, error), error) {
+	if spec.ClientSecretSecretRef != nil && spec.ClientCertificateSecretRef != nil {
+		return "", nil, errors.New(errAzureMultipleCredentials)
+	}
+
+	if spec.ClientSecretSecretRef == nil && spec.ClientCertificateSecretRef == nil {
+		return cacheKey("azure-managed-identity", azureIMDSEndpoint, spec.ClientID, spec.Scope), func() (*oauth2.Token, error) {
+			return managedIdentityToken(spec.ClientID, spec.Scope)
+		}, nil
+	}
+
+	if spec.TenantID == "" || spec.ClientID == "" {
+		return "", nil, errors.New(errAzureMissingApplication)
+	}
+
+	authorityHost := spec.AuthorityHost
+	if authorityHost == "" {
+		authorityHost = defaultAzureAuthorityHost
+	}
+	tokenURL := strings.TrimSuffix(authorityHost, "/") + "/" + url.PathEscape(spec.TenantID) + "/oauth2/v2.0/token"
+
+	if ref := spec.ClientSecretSecretRef; ref != nil {
+		secret, err := kubehandler.GetSecretValue(ctx, kube, *ref)
+		if err != nil {
+			return "", nil, errors.Wrap(err, errGetAzureClientSecret)
+		}
+
+		config := clientcredentials.Config{ClientID: spec.ClientID, ClientSecret: string(secret), TokenURL: tokenURL, Scopes: []string{spec.Scope}}
+		return cacheKey(config.TokenURL, config.ClientID, config.ClientSecret, spec.Scope), func() (*oauth2.Token, error) {
+			ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: tokenTimeout})
+			return config.Token(ctx)
+		}, nil
+	}
+
+	signer, err := newAzureCertificateSigner(ctx, kube, *spec.ClientCertificateSecretRef, spec.ClientID, spec.Scope, tokenURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signer.key(), signer.token, nil
+}
+
+// newAzureCertificateSigner returns a signer authenticating the given application with client assertions signed
+// by the certificate key read from the given kubernetes.io/tls Secret.
+func newAzureCertificateSigner(ctx context.Context, kube client.Client, ref xpv1.SecretReference, clientID, scope, tokenURL string) (*jwtSigner, error) {
+	certPEM, err := kubehandler.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: ref, Key: corev1.TLSCertKey})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetAzureCertificate)
+	}
+
+	keyPEM, err := kubehandler.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: ref, Key: corev1.TLSPrivateKeyKey})
+	if err != nil {
+		return nil, errors.Wrap(err, errGetAzureCertificateKey)
+	}
+
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New(errInvalidAzureCertificate)
+	}
+	thumbprint := sha1.Sum(block.Bytes) //nolint:gosec // see the import.
+
+	signingKey, alg, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	if alg != "RS256" {
+		return nil, errors.New(errAzureCertificateKey)
+	}
+
+	spec := &apisv1alpha1.JWTBearer{Issuer: clientID, Subject: clientID, Audience: tokenURL, TokenURL: tokenURL, Scopes: []string{scope}}
+	return &jwtSigner{
+		spec:       spec,
+		keyPEM:     keyPEM,
+		signingKey: signingKey,
+		alg:        alg,
+		ttl:        defaultJWTTTL,
+		now:        time.Now,
+		header:     map[string]string{"x5t": base64.RawURLEncoding.EncodeToString(thumbprint[:])},
+		form: func(assertion string) url.Values {
+			return url.Values{
+				"grant_type":            {"client_credentials"},
+				"client_id":             {clientID},
+				"scope":                 {scope},
+				"client_assertion_type": {clientAssertionType},
+				"client_assertion":      {assertion},
+			}
+		},
+	}, nil
+}
+
+// managedIdentityToken requests an access token for the given scope from the instance metadata service, which
+// serves the tokens of the managed identity of the node, or of the user-assigned one with the given client ID.
+func managedIdentityToken(clientID, scope string) (*oauth2.Token, error) {
+	query := url.Values{"api-version": {"2018-02-01"}, "resource": {strings.TrimSuffix(scope, "/.default")}}
+	if clientID != "" {
+		query.Set("client_id", clientID)
+	}
+
+	request, err := http.NewRequest(http.MethodGet, azureIMDSEndpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetManagedIdentityToken)
+	}
+	request.Header.Set("Metadata", "true")
+
+	response, err := (&http.Client{Timeout: tokenTimeout}).Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetManagedIdentityToken)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetManagedIdentityToken)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf(errManagedIdentityStatus, response.StatusCode)
+	}
+
+	// The metadata service encodes the numbers as strings.
+	var result struct {
+		AccessToken string      `json:"access_token"`
+		TokenType   string      `json:"token_type"`
+		ExpiresOn   json.Number `json:"expires_on"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, errors.Wrap(err, errGetManagedIdentityToken)
+	}
+	if result.AccessToken == "" {
+		return nil, errors.New(errManagedIdentityEmpty)
+	}
+
+	token := &oauth2.Token{AccessToken: result.AccessToken, TokenType: result.TokenType}
+	if expiresOn, err := result.ExpiresOn.Int64(); err == nil && expiresOn > 0 {
+		token.Expiry = time.Unix(expiresOn, 0)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func Test_azureTokenSource(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "app"}, NotAfter: time.Now().Add(time.Hour)}
+	certDER, _ := x509.CreateCertificate(rand.Reader, template, template, &rsaKey.PublicKey, rsaKey)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenant/oauth2/v2.0/token" || r.FormValue("scope") != "https://management.azure.com/.default" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if assertion := r.FormValue("client_assertion"); assertion != "" {
+			claims := verifyJWT(t, assertion, &rsaKey.PublicKey)
+			header, _ := base64.RawURLEncoding.DecodeString(strings.Split(assertion, ".")[0])
+			if r.FormValue("client_assertion_type") != clientAssertionType || claims["sub"] != "client" || !strings.Contains(string(header), `"x5t"`) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		} else {
+			if id, secret, ok := r.BasicAuth(); !ok || id != "client" || secret != "s3cr3t" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"aad-token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{
+			"client-secret":         []byte("s3cr3t"),
+			corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}),
+			corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+		}
+		return nil
+	})}
+	secretRef := xpv1.SecretReference{Name: "azure-credentials", Namespace: "crossplane-system"}
+	application := apisv1alpha1.AzureAD{
+		Scope:         "https://management.azure.com/.default",
+		TenantID:      "tenant",
+		ClientID:      "client",
+		AuthorityHost: server.URL,
+	}
+
+	cases := map[string]struct {
+		spec    func(spec *apisv1alpha1.AzureAD)
+		want    string
+		wantErr bool
+	}{
+		"ClientSecret": {
+			spec: func(spec *apisv1alpha1.AzureAD) {
+				spec.ClientSecretSecretRef = &xpv1.SecretKeySelector{SecretReference: secretRef, Key: "client-secret"}
+			},
+			want: "aad-token",
+		},
+		"ClientCertificate": {
+			spec: func(spec *apisv1alpha1.AzureAD) {
+				spec.ClientCertificateSecretRef = &secretRef
+			},
+			want: "aad-token",
+		},
+		"MultipleCredentials": {
+			spec: func(spec *apisv1alpha1.AzureAD) {
+				spec.ClientSecretSecretRef = &xpv1.SecretKeySelector{SecretReference: secretRef, Key: "client-secret"}
+				spec.ClientCertificateSecretRef = &secretRef
+			},
+			wantErr: true,
+		},
+		"MissingTenant": {
+			spec: func(spec *apisv1alpha1.AzureAD) {
+				spec.TenantID = ""
+				spec.ClientCertificateSecretRef = &secretRef
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			spec := application
+			tc.spec(&spec)
+
+			_, fetch, err := azureTokenSource(context.Background(), kube, &spec)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("azureTokenSource(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := fetch()
+			if err != nil {
+				t.Fatalf("fetch(): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.AccessToken); diff != "" {
+				t.Errorf("fetch(): -want access token, +got access token: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_managedIdentityToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Query().Get("resource") != "https://management.azure.com" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ := json.Marshal(map[string]string{
+			"access_token": "msi-token-" + r.URL.Query().Get("client_id"),
+			"token_type":   "Bearer",
+			"expires_on":   "1700003600",
+		})
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	endpoint := azureIMDSEndpoint
+	azureIMDSEndpoint = server.URL
+	defer func() { azureIMDSEndpoint = endpoint }()
+
+	cases := map[string]struct {
+		clientID string
+		scope    string
+		want     string
+		wantErr  bool
+	}{
+		"SystemAssigned": {
+			scope: "https://management.azure.com/.default",
+			want:  "msi-token-",
+		},
+		"UserAssigned": {
+			clientID: "identity",
+			scope:    "https://management.azure.com/.default",
+			want:     "msi-token-identity",
+		},
+		"StatusCode": {
+			scope:   "https://vault.azure.net/.default",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := managedIdentityToken(tc.clientID, tc.scope)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("managedIdentityToken(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.AccessToken); diff != "" {
+				t.Errorf("managedIdentityToken(...): -want access token, +got access token: %s", diff)
+			}
+			if diff := cmp.Diff(time.Unix(1700003600, 0), got.Expiry); diff != "" {
+				t.Errorf("managedIdentityToken(...): -want expiry, +got expiry: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	defaultGCPTokenURL     = "https://oauth2.googleapis.com/token"
+	defaultGCPMetadataHost = "metadata.google.internal"
+
+	// gcpMetadataHostEnv overrides the metadata server host, as with the Google client libraries.
+	gcpMetadataHostEnv = "GCE_METADATA_HOST"
+
+	errGetServiceAccountKey     = "cannot get GCP service account key"
+	errInvalidServiceAccountKey = "GCP service account key is not a valid JSON key"
+	errGetMetadataIDToken       = "cannot get ID token from the GCP metadata server"
+	errMetadataStatusCode       = "GCP metadata server responded with status code %d"
+	errMetadataEmptyToken       = "GCP metadata server responded with an empty ID token"
+)
+
+// serviceAccountKey holds the fields of a GCP service account JSON key used to request ID tokens.
+type serviceAccountKey struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// gcpIDTokenSource returns the key identifying the ID tokens of the given GCP ID token auth in the token cache,
+// and the function fetching them.
+func gcpIDTokenSource(ctx context.Context, kube client.Client, spec *apisv1alpha1.GCPIDToken) (string, func() (*oauth2.Token, error), error) {
+	if spec.ServiceAccountKeySecretRef == nil {
+		host := os.Getenv(gcpMetadataHostEnv)
+		if host == "" {
+			host = defaultGCPMetadataHost
+		}
+		return cacheKey("gcp-metadata", host, spec.Audience), func() (*oauth2.Token, error) {
+			return metadataIDToken(host, spec.Audience)
+		}, nil
+	}
+
+	signer, err := newServiceAccountSigner(ctx, kube, *spec.ServiceAccountKeySecretRef, spec.Audience)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return signer.key(), signer.token, nil
+}
+
+// newServiceAccountSigner returns a signer exchanging JWTs signed by the service account key read from the given
+// Secret for ID tokens with the given audience.
+func newServiceAccountSigner(ctx context.Context, kube client.Client, ref xpv1.SecretKeySelector, audience string) (*jwtSigner, error) {
+	data, err := kubehandler.GetSecretValue(ctx, kube, ref)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetServiceAccountKey)
+	}
+
+	var key serviceAccountKey
+	if err := json.Unmarshal(data, &key); err != nil || key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, errors.New(errInvalidServiceAccountKey)
+	}
+
+	signingKey, alg, err := parsePrivateKey([]byte(key.PrivateKey))
+	if err != nil {
+		return nil, err
+	}
+
+	tokenURL := key.TokenURI
+	if tokenURL == "" {
+		tokenURL = defaultGCPTokenURL
+	}
+
+	spec := &apisv1alpha1.JWTBearer{
+		KeyID:    key.PrivateKeyID,
+		Issuer:   key.ClientEmail,
+		Subject:  key.ClientEmail,
+		Audience: tokenURL,
+		Claims:   map[string]string{"target_audience": audience},
+		TokenURL: tokenURL,
+	}
+
+	return &jwtSigner{spec: spec, keyPEM: []byte(key.PrivateKey), signingKey: signingKey, alg: alg, ttl: defaultJWTTTL, now: time.Now, idToken: true}, nil
+}
+
+// metadataIDToken requests an ID token with the given audience from the metadata server, which serves the
+// tokens of the attached service account, e.g. with GKE workload identity.
+func metadataIDToken(host, audience string) (*oauth2.Token, error) {
+	query := url.Values{"audience": {audience}, "format": {"full"}}
+	endpoint := "http://" + host + "/computeMetadata/v1/instance/service-accounts/default/identity?" + query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetMetadataIDToken)
+	}
+	request.Header.Set("Metadata-Flavor", "Google")
+
+	response, err := (&http.Client{Timeout: tokenTimeout}).Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetMetadataIDToken)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetMetadataIDToken)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf(errMetadataStatusCode, response.StatusCode)
+	}
+
+	idToken := strings.TrimSpace(string(body))
+	if idToken == "" {
+		return nil, errors.New(errMetadataEmptyToken)
+	}
+
+	return &oauth2.Token{AccessToken: idToken, TokenType: "Bearer", Expiry: jwtExpiry(idToken)}, nil
+}
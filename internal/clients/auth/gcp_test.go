@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// unsignedIDToken returns a JWT with the given exp claim, as the signature is not verified.
+func unsignedIDToken(exp time.Time) string {
+	payload, _ := json.Marshal(map[string]interface{}{"aud": "https://service.run.app", "exp": exp.Unix()})
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+}
+
+func Test_gcpIDTokenSourceServiceAccountKey(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	exp := time.Unix(1700003600, 0)
+	idToken := unsignedIDToken(exp)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != jwtBearerGrantType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		claims := verifyJWT(t, r.FormValue("assertion"), &rsaKey.PublicKey)
+		if claims["target_audience"] != "https://service.run.app" || claims["iss"] != "sa@project.iam.gserviceaccount.com" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id_token":"` + idToken + `"}`))
+	}))
+	defer server.Close()
+
+	serviceAccountKey, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "sa@project.iam.gserviceaccount.com",
+		"private_key":  string(keyPEM),
+		"token_uri":    server.URL,
+	})
+	kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"key.json": serviceAccountKey, "invalid": []byte("{}")}
+		return nil
+	})}
+	secretRef := xpv1.SecretReference{Name: "gcp-credentials", Namespace: "crossplane-system"}
+
+	cases := map[string]struct {
+		key     string
+		want    string
+		wantErr bool
+	}{
+		"ValidKey": {
+			key:  "key.json",
+			want: idToken,
+		},
+		"InvalidKey": {
+			key:     "invalid",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			spec := &apisv1alpha1.GCPIDToken{
+				Audience:                   "https://service.run.app",
+				ServiceAccountKeySecretRef: &xpv1.SecretKeySelector{SecretReference: secretRef, Key: tc.key},
+			}
+			_, fetch, err := gcpIDTokenSource(context.Background(), kube, spec)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("gcpIDTokenSource(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+
+			got, err := fetch()
+			if err != nil {
+				t.Fatalf("fetch(): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got.AccessToken); diff != "" {
+				t.Errorf("fetch(): -want ID token, +got ID token: %s", diff)
+			}
+			if diff := cmp.Diff(exp, got.Expiry); diff != "" {
+				t.Errorf("fetch(): -want expiry, +got expiry: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_metadataIDToken(t *testing.T) {
+	exp := time.Unix(1700003600, 0)
+	idToken := unsignedIDToken(exp)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Query().Get("audience") != "https://service.run.app" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(idToken))
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	cases := map[string]struct {
+		audience string
+		want     string
+		wantErr  bool
+	}{
+		"Success": {
+			audience: "https://service.run.app",
+			want:     idToken,
+		},
+		"StatusCode": {
+			audience: "https://other.run.app",
+			wantErr:  true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := metadataIDToken(host, tc.audience)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Fatalf("metadataIDToken(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.AccessToken); diff != "" {
+				t.Errorf("metadataIDToken(...): -want ID token, +got ID token: %s", diff)
+			}
+			if diff := cmp.Diff(exp, got.Expiry); diff != "" {
+				t.Errorf("metadataIDToken(...): -want expiry, +got expiry: %s", diff)
+			}
+		})
+	}
+}
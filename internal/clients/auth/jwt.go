@@ -0,0 +1,322 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	defaultJWTTTL = 5 * time.Minute
+
+	// jwtClockSkew backdates the iat claim, so that servers with a clock running behind accept the JWT.
+	jwtClockSkew = time.Minute
+
+	jwtBearerGrantType = "urn:ietf:params:oauth:grant-type:jwt-bearer"
+
+	errGetPrivateKey      = "cannot get JWT private key"
+	errInvalidPrivateKey  = "JWT private key is not a PEM encoded RSA or ECDSA private key"
+	errUnsupportedCurve   = "JWT private key uses the unsupported curve %s"
+	errSignJWT            = "cannot sign JWT"
+	errExchangeJWT        = "cannot exchange JWT for an access token"
+	errExchangeStatusCode = "JWT exchange failed with status code %d"
+	errExchangeEmptyToken = "JWT exchange response holds no token"
+)
+
+// jwtSigner signs the JWTs of a JWT bearer auth.
+type jwtSigner struct {
+	spec       *apisv1alpha1.JWTBearer
+	keyPEM     []byte
+	signingKey crypto.Signer
+	alg        string
+	ttl        time.Duration
+	now        func() time.Time
+
+	// header holds additional JOSE header parameters of the JWT.
+	header map[string]string
+
+	// form returns the form exchanging the given JWT, the JWT bearer grant when nil.
+	form func(assertion string) url.Values
+
+	// idToken exchanges the JWT for the ID token of the response instead of its access token.
+	idToken bool
+}
+
+// newJWTSigner returns the signer of the given JWT bearer auth, with the private key read from its Secret.
+func newJWTSigner(ctx context.Context, kube client.Client, spec *apisv1alpha1.JWTBearer) (*jwtSigner, error) {
+	keyPEM, err := kubehandler.GetSecretValue(ctx, kube, spec.PrivateKeySecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetPrivateKey)
+	}
+
+	key, alg, err := parsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := defaultJWTTTL
+	if spec.TTL != nil {
+		ttl = spec.TTL.Duration
+	}
+
+	return &jwtSigner{spec: spec, keyPEM: keyPEM, signingKey: key, alg: alg, ttl: ttl, now: time.Now}, nil
+}
+
+// parsePrivateKey parses a PEM encoded PKCS #1, PKCS #8 or SEC 1 private key, and returns the JWS algorithm
+// it signs with.
+func parsePrivateKey(keyPEM []byte) (crypto.Signer, string, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, "", errors.New(errInvalidPrivateKey)
+	}
+
+	var key interface{}
+	var err error
+	if key, err = x509.ParsePKCS8PrivateKey(block.Bytes); err != nil {
+		if key, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+			if key, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+				return nil, "", errors.New(errInvalidPrivateKey)
+			}
+		}
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, "RS256", nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return k, "ES256", nil
+		case elliptic.P384():
+			return k, "ES384", nil
+		}
+		return nil, "", errors.Errorf(errUnsupportedCurve, k.Curve.Params().Name)
+	}
+
+	return nil, "", errors.New(errInvalidPrivateKey)
+}
+
+// key identifies the signer in the token cache.
+func (s *jwtSigner) key() string {
+	claims, _ := json.Marshal(s.spec.Claims)
+	return cacheKey(string(s.keyPEM), s.spec.KeyID, s.spec.Issuer, s.spec.Subject, s.spec.Audience, string(claims),
+		s.ttl.String(), s.spec.TokenURL, strings.Join(s.spec.Scopes, " "))
+}
+
+// sign returns a new signed JWT and its expiry.
+func (s *jwtSigner) sign() (string, time.Time, error) {
+	now := s.now()
+	expiry := now.Add(s.ttl)
+
+	header := map[string]interface{}{"alg": s.alg, "typ": "JWT"}
+	if s.spec.KeyID != "" {
+		header["kid"] = s.spec.KeyID
+	}
+	for name, value := range s.header {
+		header[name] = value
+	}
+
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", time.Time{}, errors.Wrap(err, errSignJWT)
+	}
+
+	claims := map[string]interface{}{
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": expiry.Unix(),
+		"jti": hex.EncodeToString(jti),
+	}
+	for name, value := range map[string]string{"iss": s.spec.Issuer, "sub": s.spec.Subject, "aud": s.spec.Audience} {
+		if value != "" {
+			claims[name] = value
+		}
+	}
+	for name, value := range s.spec.Claims {
+		claims[name] = value
+	}
+
+	signingInput, err := encodeSegments(header, claims)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errSignJWT)
+	}
+
+	signature, err := s.signature(signingInput)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errSignJWT)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), expiry, nil
+}
+
+// signature signs the given input with the algorithm of the key.
+func (s *jwtSigner) signature(signingInput string) ([]byte, error) {
+	switch s.alg {
+	case "ES256":
+		digest := sha256.Sum256([]byte(signingInput))
+		return ecdsaSignature(s.signingKey.(*ecdsa.PrivateKey), digest[:], 32)
+	case "ES384":
+		digest := sha512.Sum384([]byte(signingInput))
+		return ecdsaSignature(s.signingKey.(*ecdsa.PrivateKey), digest[:], 48)
+	default:
+		digest := sha256.Sum256([]byte(signingInput))
+		return rsa.SignPKCS1v15(rand.Reader, s.signingKey.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	}
+}
+
+// ecdsaSignature returns the JWS encoding of an ECDSA signature, the fixed size concatenation of r and s.
+func ecdsaSignature(key *ecdsa.PrivateKey, digest []byte, size int) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	s.FillBytes(signature[size:])
+	return signature, nil
+}
+
+// encodeSegments returns the base64url encoded JSON segments joined with dots.
+func encodeSegments(segments ...interface{}) (string, error) {
+	encoded := make([]string, len(segments))
+	for i, segment := range segments {
+		b, err := json.Marshal(segment)
+		if err != nil {
+			return "", err
+		}
+		encoded[i] = base64.RawURLEncoding.EncodeToString(b)
+	}
+
+	return strings.Join(encoded, "."), nil
+}
+
+// token returns a bearer token, either the signed JWT itself or the access token it is exchanged for.
+func (s *jwtSigner) token() (*oauth2.Token, error) {
+	assertion, expiry, err := s.sign()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.spec.TokenURL == "" {
+		return &oauth2.Token{AccessToken: assertion, TokenType: "Bearer", Expiry: expiry}, nil
+	}
+
+	return s.exchange(assertion)
+}
+
+// exchange exchanges the given JWT for an access token, with the JWT bearer grant unless another form is set.
+func (s *jwtSigner) exchange(assertion string) (*oauth2.Token, error) {
+	var form url.Values
+	if s.form != nil {
+		form = s.form(assertion)
+	} else {
+		form = url.Values{"grant_type": {jwtBearerGrantType}, "assertion": {assertion}}
+		if len(s.spec.Scopes) > 0 {
+			form.Set("scope", strings.Join(s.spec.Scopes, " "))
+		}
+	}
+
+	response, err := (&http.Client{Timeout: tokenTimeout}).PostForm(s.spec.TokenURL, form)
+	if err != nil {
+		return nil, errors.Wrap(err, errExchangeJWT)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errExchangeJWT)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, errors.Errorf(errExchangeStatusCode, response.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, errors.Wrap(err, errExchangeJWT)
+	}
+
+	if s.idToken {
+		if result.IDToken == "" {
+			return nil, errors.New(errExchangeEmptyToken)
+		}
+		return &oauth2.Token{AccessToken: result.IDToken, TokenType: "Bearer", Expiry: jwtExpiry(result.IDToken)}, nil
+	}
+
+	if result.AccessToken == "" {
+		return nil, errors.New(errExchangeEmptyToken)
+	}
+
+	token := &oauth2.Token{AccessToken: result.AccessToken, TokenType: result.TokenType}
+	if result.ExpiresIn > 0 {
+		token.Expiry = s.now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// jwtExpiry returns the time of the exp claim of the given JWT, or the zero time when it cannot be read.
+// The signature is not verified, the JWT was received from the issuer.
+func jwtExpiry(jwt string) time.Time {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(claims.Exp, 0)
+}
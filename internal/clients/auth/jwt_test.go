@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// verifyJWT checks the signature of the given JWT and returns its claims.
+func verifyJWT(t *testing.T, jwt string, public crypto.PublicKey) map[string]interface{} {
+	t.Helper()
+
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT has %d segments", len(parts))
+	}
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+
+	switch k := public.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature); err != nil {
+			t.Fatalf("invalid RS256 signature: %s", err)
+		}
+	case *ecdsa.PublicKey:
+		r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(k, digest[:], r, s) {
+			t.Fatalf("invalid ES256 signature")
+		}
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	claims := map[string]interface{}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("cannot decode claims: %s", err)
+	}
+	return claims
+}
+
+func Test_jwtSignerSign(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecDER, _ := x509.MarshalECPrivateKey(ecKey)
+	now := time.Unix(1700000000, 0)
+
+	cases := map[string]struct {
+		keyPEM []byte
+		public crypto.PublicKey
+		alg    string
+	}{
+		"RSA": {
+			keyPEM: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+			public: &rsaKey.PublicKey,
+			alg:    "RS256",
+		},
+		"ECDSA": {
+			keyPEM: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER}),
+			public: &ecKey.PublicKey,
+			alg:    "ES256",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			key, alg, err := parsePrivateKey(tc.keyPEM)
+			if err != nil {
+				t.Fatalf("parsePrivateKey(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.alg, alg); diff != "" {
+				t.Errorf("parsePrivateKey(...): -want algorithm, +got algorithm: %s", diff)
+			}
+
+			signer := &jwtSigner{
+				spec:       &apisv1alpha1.JWTBearer{Issuer: "12345", Audience: "https://login.example.com", Claims: map[string]string{"scope": "repo"}},
+				signingKey: key,
+				alg:        alg,
+				ttl:        10 * time.Minute,
+				now:        func() time.Time { return now },
+			}
+			jwt, expiry, err := signer.sign()
+			if err != nil {
+				t.Fatalf("sign(): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(now.Add(10*time.Minute), expiry); diff != "" {
+				t.Errorf("sign(): -want expiry, +got expiry: %s", diff)
+			}
+
+			claims := verifyJWT(t, jwt, tc.public)
+			delete(claims, "jti")
+			want := map[string]interface{}{
+				"iss":   "12345",
+				"aud":   "https://login.example.com",
+				"scope": "repo",
+				"iat":   float64(now.Add(-jwtClockSkew).Unix()),
+				"exp":   float64(now.Add(10 * time.Minute).Unix()),
+			}
+			if diff := cmp.Diff(want, claims); diff != "" {
+				t.Errorf("sign(): -want claims, +got claims: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_jwtSignerExchange(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != jwtBearerGrantType || r.FormValue("assertion") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"exchanged","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	now := time.Unix(1700000000, 0)
+	signer := &jwtSigner{
+		spec:       &apisv1alpha1.JWTBearer{Issuer: "client", TokenURL: server.URL},
+		signingKey: rsaKey,
+		alg:        "RS256",
+		ttl:        time.Minute,
+		now:        func() time.Time { return now },
+	}
+
+	got, err := signer.token()
+	if err != nil {
+		t.Fatalf("token(): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff("exchanged", got.AccessToken); diff != "" {
+		t.Errorf("token(): -want access token, +got access token: %s", diff)
+	}
+	if diff := cmp.Diff(now.Add(time.Hour), got.Expiry); diff != "" {
+		t.Errorf("token(): -want expiry, +got expiry: %s", diff)
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/controller/request/requestprocessing"
+	"github.com/arielsepton/provider-http/internal/jq"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	defaultLoginMethod = http.MethodPost
+	defaultLoginHeader = "Authorization"
+	defaultTokenTTL    = time.Hour
+
+	errGetLoginCredentials = "cannot get login credentials"
+	errRenderLoginBody     = "cannot render login request body"
+	errLogin               = "cannot send login request"
+	errLoginStatusCode     = "login request failed with status code %d"
+	errExtractToken        = "cannot extract token from login response"
+	errEmptyToken          = "login response holds an empty token"
+)
+
+// loginRequest is a rendered login request.
+type loginRequest struct {
+	method    string
+	url       string
+	headers   map[string][]string
+	body      string
+	tokenPath string
+	ttl       time.Duration
+}
+
+// key identifies the login request in the token cache.
+func (l loginRequest) key() string {
+	headers, _ := json.Marshal(l.headers)
+	return cacheKey(l.method, l.url, string(headers), l.body, l.tokenPath, l.ttl.String())
+}
+
+// newLoginRequest renders the login request of the given login flow, with the credentials read from its Secret.
+func newLoginRequest(ctx context.Context, kube client.Client, spec *apisv1alpha1.LoginFlow) (loginRequest, error) {
+	credentials := map[string]interface{}{}
+	if spec.CredentialsSecretRef != nil {
+		data, err := kubehandler.GetSecretData(ctx, kube, *spec.CredentialsSecretRef)
+		if err != nil {
+			return loginRequest{}, errors.Wrap(err, errGetLoginCredentials)
+		}
+		for key, value := range data {
+			credentials[key] = string(value)
+		}
+	}
+
+	var body string
+	if spec.Body != "" {
+		var err error
+		body, err = requestprocessing.ApplyJQOnStr(requestprocessing.ConvertStringToJQQuery(spec.Body), map[string]interface{}{"credentials": credentials})
+		if err != nil {
+			return loginRequest{}, errors.Wrap(err, errRenderLoginBody)
+		}
+	}
+
+	method := spec.Method
+	if method == "" {
+		method = defaultLoginMethod
+	}
+
+	ttl := defaultTokenTTL
+	if spec.TokenTTL != nil {
+		ttl = spec.TokenTTL.Duration
+	}
+
+	return loginRequest{
+		method:    method,
+		url:       spec.URL,
+		headers:   spec.Headers,
+		body:      body,
+		tokenPath: spec.TokenPath,
+		ttl:       ttl,
+	}, nil
+}
+
+// token sends the login request and extracts the token from its response. The token expires after the TTL
+// of the login flow.
+func (l loginRequest) token() (*oauth2.Token, error) {
+	request, err := http.NewRequest(l.method, l.url, strings.NewReader(l.body))
+	if err != nil {
+		return nil, errors.Wrap(err, errLogin)
+	}
+	for key, values := range l.headers {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
+	response, err := (&http.Client{Timeout: tokenTimeout}).Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, errLogin)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, errLogin)
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, errors.Errorf(errLoginStatusCode, response.StatusCode)
+	}
+
+	token, err := jq.ParseString(l.tokenPath, loginResponseObject(response, body))
+	if err != nil {
+		return nil, errors.Wrap(err, errExtractToken)
+	}
+	if token == "" {
+		return nil, errors.New(errEmptyToken)
+	}
+
+	return &oauth2.Token{AccessToken: token, Expiry: time.Now().Add(l.ttl)}, nil
+}
+
+// loginResponseObject returns the jq object of a login response, its body is parsed when it is JSON.
+func loginResponseObject(response *http.Response, body []byte) map[string]interface{} {
+	headers := map[string]interface{}{}
+	for key, values := range response.Header {
+		list := make([]interface{}, len(values))
+		for i, value := range values {
+			list[i] = value
+		}
+		headers[key] = list
+	}
+
+	var parsedBody interface{} = string(body)
+	var jsonBody interface{}
+	if err := json.Unmarshal(body, &jsonBody); err == nil {
+		parsedBody = jsonBody
+	}
+
+	return map[string]interface{}{
+		"response": map[string]interface{}{
+			"statusCode": response.StatusCode,
+			"headers":    headers,
+			"body":       parsedBody,
+		},
+	}
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func Test_loginToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch string(body) {
+		case `{"password":"hunter2","username":"john_doe"}`:
+			w.Header().Set("X-Session", "header-session")
+			_, _ = w.Write([]byte(`{"session":{"token":"body-session"}}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"username": []byte("john_doe"), "password": []byte("hunter2")}
+		return nil
+	})}
+	credentials := &xpv1.SecretReference{Name: "legacy-api", Namespace: "crossplane-system"}
+
+	type want struct {
+		token string
+		err   bool
+	}
+	cases := map[string]struct {
+		spec *apisv1alpha1.LoginFlow
+		want want
+	}{
+		"TokenFromBody": {
+			spec: &apisv1alpha1.LoginFlow{
+				URL:                  server.URL,
+				Body:                 "{ username: .credentials.username, password: .credentials.password }",
+				CredentialsSecretRef: credentials,
+				TokenPath:            ".response.body.session.token",
+			},
+			want: want{
+				token: "body-session",
+			},
+		},
+		"TokenFromHeader": {
+			spec: &apisv1alpha1.LoginFlow{
+				URL:                  server.URL,
+				Body:                 "{ username: .credentials.username, password: .credentials.password }",
+				CredentialsSecretRef: credentials,
+				TokenPath:            `.response.headers["X-Session"][0]`,
+			},
+			want: want{
+				token: "header-session",
+			},
+		},
+		"LoginFailed": {
+			spec: &apisv1alpha1.LoginFlow{
+				URL:       server.URL,
+				TokenPath: ".response.body.session.token",
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			login, err := newLoginRequest(context.Background(), kube, tc.spec)
+			if err != nil {
+				t.Fatalf("newLoginRequest(...): unexpected error: %s", err)
+			}
+			got, err := login.token()
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("token(): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.token, got.AccessToken); diff != "" {
+				t.Errorf("token(): -want token, +got token: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_newLoginRequestDefaults(t *testing.T) {
+	got, err := newLoginRequest(context.Background(), nil, &apisv1alpha1.LoginFlow{URL: "https://api.example.com/login"})
+	if err != nil {
+		t.Fatalf("newLoginRequest(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(http.MethodPost, got.method); diff != "" {
+		t.Errorf("newLoginRequest(...): -want method, +got method: %s", diff)
+	}
+	if diff := cmp.Diff(defaultTokenTTL, got.ttl); diff != "" {
+		t.Errorf("newLoginRequest(...): -want ttl, +got ttl: %s", diff)
+	}
+
+	got, err = newLoginRequest(context.Background(), nil, &apisv1alpha1.LoginFlow{URL: "https://api.example.com/login", TokenTTL: &metav1.Duration{Duration: 10 * time.Minute}})
+	if err != nil {
+		t.Fatalf("newLoginRequest(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff(10*time.Minute, got.ttl); diff != "" {
+		t.Errorf("newLoginRequest(...): -want ttl, +got ttl: %s", diff)
+	}
+}
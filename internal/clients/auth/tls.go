@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	errGetCABundleSecret    = "cannot get CA bundle from secret"
+	errGetCABundleConfigMap = "cannot get CA bundle from configmap"
+	errGetClientCert        = "cannot get client certificate"
+	errGetClientKey         = "cannot get client certificate key"
+)
+
+// EffectiveTLS returns the TLS settings of a resource: the given settings, each one overriding the ones before it,
+// e.g. those of a mapping over those of its resource, over those of its ProviderConfig. Certificates are verified
+// unless the settings skip it.
+func EffectiveTLS(overrides ...*apisv1alpha1.TLSConfig) apisv1alpha1.TLSConfig {
+	skipVerify := false
+	result := apisv1alpha1.TLSConfig{InsecureSkipVerify: &skipVerify}
+
+	for _, override := range overrides {
+		if override == nil {
+			continue
+		}
+		if override.InsecureSkipVerify != nil {
+			result.InsecureSkipVerify = override.InsecureSkipVerify
+		}
+		if override.CABundle != "" {
+			result.CABundle = override.CABundle
+		}
+		if override.CABundleSecretRef != nil {
+			result.CABundleSecretRef = override.CABundleSecretRef
+		}
+		if override.CABundleConfigMapRef != nil {
+			result.CABundleConfigMapRef = override.CABundleConfigMapRef
+		}
+		if override.ClientCertSecretRef != nil {
+			result.ClientCertSecretRef = override.ClientCertSecretRef
+		}
+	}
+
+	return result
+}
+
+// SkipTLSVerify returns the TLS settings of a resource that skips TLS certificate checks with a boolean, such as
+// insecureSkipTLSVerify, or nil when it does not skip them, so that it overrides none.
+func SkipTLSVerify(skip bool) *apisv1alpha1.TLSConfig {
+	if !skip {
+		return nil
+	}
+
+	return &apisv1alpha1.TLSConfig{InsecureSkipVerify: &skip}
+}
+
+// TLSClientOptions returns the HTTP client options verifying servers and presenting a client certificate as
+// configured by the given TLS settings.
+func TLSClientOptions(ctx context.Context, kube client.Client, tlsConfig apisv1alpha1.TLSConfig) ([]httpClient.ClientOption, error) {
+	caBundle, err := caBundle(ctx, kube, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []httpClient.ClientOption
+	if caBundle != "" {
+		opts = append(opts, httpClient.WithCABundle(caBundle))
+	}
+
+	if ref := tlsConfig.ClientCertSecretRef; ref != nil {
+		cert, err := kubehandler.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: *ref, Key: corev1.TLSCertKey})
+		if err != nil {
+			return nil, errors.Wrap(err, errGetClientCert)
+		}
+
+		key, err := kubehandler.GetSecretValue(ctx, kube, xpv1.SecretKeySelector{SecretReference: *ref, Key: corev1.TLSPrivateKeyKey})
+		if err != nil {
+			return nil, errors.Wrap(err, errGetClientKey)
+		}
+
+		opts = append(opts, httpClient.WithClientCertificate(cert, key))
+	}
+
+	return opts, nil
+}
+
+// caBundle returns the PEM encoded CA certificates of the inline bundle and of the referenced Secret and ConfigMap,
+// so that they end up in a single pool.
+func caBundle(ctx context.Context, kube client.Client, tlsConfig apisv1alpha1.TLSConfig) (string, error) {
+	var bundles []string
+	if tlsConfig.CABundle != "" {
+		bundles = append(bundles, tlsConfig.CABundle)
+	}
+
+	if ref := tlsConfig.CABundleSecretRef; ref != nil {
+		bundle, err := kubehandler.GetSecretValue(ctx, kube, *ref)
+		if err != nil {
+			return "", errors.Wrap(err, errGetCABundleSecret)
+		}
+		bundles = append(bundles, string(bundle))
+	}
+
+	if ref := tlsConfig.CABundleConfigMapRef; ref != nil {
+		bundle, err := kubehandler.GetConfigMapValue(ctx, kube, ref.Namespace, ref.Name, ref.Key)
+		if err != nil {
+			return "", errors.Wrap(err, errGetCABundleConfigMap)
+		}
+		bundles = append(bundles, string(bundle))
+	}
+
+	return strings.Join(bundles, "\n"), nil
+}
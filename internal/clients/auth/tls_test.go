@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func Test_caBundle(t *testing.T) {
+	kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		switch o := obj.(type) {
+		case *corev1.Secret:
+			o.Data = map[string][]byte{"ca.crt": []byte("secret-ca")}
+		case *corev1.ConfigMap:
+			o.Data = map[string]string{"ca.crt": "configmap-ca"}
+		}
+		return nil
+	})}
+	secretRef := &xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{Name: "private-ca", Namespace: "crossplane-system"},
+		Key:             "ca.crt",
+	}
+	configMapRef := &apisv1alpha1.ConfigMapKeySelector{Name: "trust-bundle", Namespace: "crossplane-system", Key: "ca.crt"}
+
+	type want struct {
+		bundle string
+		err    bool
+	}
+	cases := map[string]struct {
+		tlsConfig apisv1alpha1.TLSConfig
+		want      want
+	}{
+		"NoBundle": {
+			want: want{},
+		},
+		"Inline": {
+			tlsConfig: apisv1alpha1.TLSConfig{CABundle: "inline-ca"},
+			want: want{
+				bundle: "inline-ca",
+			},
+		},
+		"AllSources": {
+			tlsConfig: apisv1alpha1.TLSConfig{CABundle: "inline-ca", CABundleSecretRef: secretRef, CABundleConfigMapRef: configMapRef},
+			want: want{
+				bundle: "inline-ca\nsecret-ca\nconfigmap-ca",
+			},
+		},
+		"MissingKey": {
+			tlsConfig: apisv1alpha1.TLSConfig{CABundleConfigMapRef: &apisv1alpha1.ConfigMapKeySelector{Name: "trust-bundle", Namespace: "crossplane-system", Key: "missing"}},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := caBundle(context.Background(), kube, tc.tlsConfig)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("caBundle(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.bundle, got); diff != "" {
+				t.Errorf("caBundle(...): -want bundle, +got bundle: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_EffectiveTLS(t *testing.T) {
+	skipVerify := true
+	verify := false
+	secretRef := &xpv1.SecretReference{Name: "client-cert", Namespace: "crossplane-system"}
+
+	cases := map[string]struct {
+		overrides []*apisv1alpha1.TLSConfig
+		want      apisv1alpha1.TLSConfig
+	}{
+		"NoOverrides": {
+			want: apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify},
+		},
+		"ProviderConfigSkipsVerify": {
+			overrides: []*apisv1alpha1.TLSConfig{{InsecureSkipVerify: &skipVerify}, SkipTLSVerify(false), nil},
+			want:      apisv1alpha1.TLSConfig{InsecureSkipVerify: &skipVerify},
+		},
+		"ResourceVerifies": {
+			overrides: []*apisv1alpha1.TLSConfig{{InsecureSkipVerify: &skipVerify}, SkipTLSVerify(false), {InsecureSkipVerify: &verify}},
+			want:      apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify},
+		},
+		"ResourceSkipsVerify": {
+			overrides: []*apisv1alpha1.TLSConfig{nil, SkipTLSVerify(true), nil},
+			want:      apisv1alpha1.TLSConfig{InsecureSkipVerify: &skipVerify},
+		},
+		"FieldsMerged": {
+			overrides: []*apisv1alpha1.TLSConfig{{CABundle: "provider-ca", ClientCertSecretRef: secretRef}, {CABundle: "resource-ca"}},
+			want:      apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify, CABundle: "resource-ca", ClientCertSecretRef: secretRef},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := EffectiveTLS(tc.overrides...)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("EffectiveTLS(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// refreshMargin is how long before their expiry tokens are refreshed.
+	refreshMargin = time.Minute
+
+	// tokenTimeout bounds the requests sent to token endpoints.
+	tokenTimeout = 30 * time.Second
+)
+
+// A TokenCache shares OAuth2 tokens between the resources using the same
+// client credentials, so that a token is only requested once for all of them.
+type TokenCache struct {
+	mu      sync.Mutex
+	sources map[string]oauth2.TokenSource
+}
+
+// NewTokenCache returns an empty TokenCache.
+func NewTokenCache() *TokenCache {
+	return &TokenCache{sources: map[string]oauth2.TokenSource{}}
+}
+
+// TokenSource returns the cached token source of the given client credentials.
+// Its tokens are reused until shortly before they expire.
+func (c *TokenCache) TokenSource(config clientcredentials.Config) oauth2.TokenSource {
+	key := cacheKey(config.TokenURL, config.ClientID, config.ClientSecret, strings.Join(config.Scopes, " "))
+
+	return c.source(key, func() (*oauth2.Token, error) {
+		ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: tokenTimeout})
+		return config.Token(ctx)
+	})
+}
+
+// source returns the token source cached under the given key, creating it with the given fetch function
+// when there is none.
+func (c *TokenCache) source(key string, fetch func() (*oauth2.Token, error)) oauth2.TokenSource {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ts, ok := c.sources[key]; ok {
+		return ts
+	}
+
+	ts := oauth2.ReuseTokenSource(nil, earlyExpiry{fetch: fetch})
+	c.sources[key] = ts
+	return ts
+}
+
+// cacheKey identifies the token source of the given configuration. Credentials are part of it, so that
+// rotated credentials get a new token source.
+func cacheKey(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// earlyExpiry is a token source whose tokens expire before the actual
+// expiry, so that they are refreshed while requests in flight can still use
+// the old ones.
+type earlyExpiry struct {
+	fetch func() (*oauth2.Token, error)
+	now   func() time.Time
+}
+
+func (e earlyExpiry) Token() (*oauth2.Token, error) {
+	token, err := e.fetch()
+	if err != nil || token.Expiry.IsZero() {
+		return token, err
+	}
+
+	now := time.Now
+	if e.now != nil {
+		now = e.now
+	}
+
+	// Short-lived tokens would otherwise be refreshed on every request.
+	margin := refreshMargin
+	if lifetime := token.Expiry.Sub(now()); lifetime/2 < margin {
+		margin = lifetime / 2
+	}
+
+	early := *token
+	early.Expiry = token.Expiry.Add(-margin)
+	return &early, nil
+}
@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+func Test_TokenCache(t *testing.T) {
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&issued, 1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"Bearer","expires_in":3600}`, n)
+	}))
+	defer server.Close()
+
+	cache := NewTokenCache()
+	config := clientcredentials.Config{ClientID: "id", ClientSecret: "secret", TokenURL: server.URL}
+
+	for i := 0; i < 3; i++ {
+		token, err := cache.TokenSource(config).Token()
+		if err != nil {
+			t.Fatalf("Token(): unexpected error: %s", err)
+		}
+		if diff := cmp.Diff("token-1", token.AccessToken); diff != "" {
+			t.Errorf("Token(): -want cached token, +got token: %s", diff)
+		}
+	}
+
+	rotated := config
+	rotated.ClientSecret = "rotated"
+	token, err := cache.TokenSource(rotated).Token()
+	if err != nil {
+		t.Fatalf("Token(): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff("token-2", token.AccessToken); diff != "" {
+		t.Errorf("Token(): -want new token for rotated secret, +got token: %s", diff)
+	}
+}
+
+func Test_earlyExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type want struct {
+		expiry time.Time
+	}
+	cases := map[string]struct {
+		expiry time.Time
+		want   want
+	}{
+		"NoExpiry": {
+			want: want{},
+		},
+		"LongLived": {
+			expiry: now.Add(time.Hour),
+			want: want{
+				expiry: now.Add(time.Hour - refreshMargin),
+			},
+		},
+		"ShortLived": {
+			expiry: now.Add(time.Minute),
+			want: want{
+				expiry: now.Add(30 * time.Second),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := earlyExpiry{
+				fetch: func() (*oauth2.Token, error) { return &oauth2.Token{AccessToken: "abc", Expiry: tc.expiry}, nil },
+				now:   func() time.Time { return now },
+			}
+			got, err := e.Token()
+			if err != nil {
+				t.Fatalf("Token(): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.expiry, got.Expiry); diff != "" {
+				t.Errorf("Token(): -want expiry, +got expiry: %s", diff)
+			}
+		})
+	}
+}
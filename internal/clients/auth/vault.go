@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/vault"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	defaultVaultMountPath          = "kubernetes"
+	defaultServiceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+	errVaultAuth               = "exactly one of tokenSecretRef and kubernetes must be set in the Vault auth"
+	errGetVaultToken           = "cannot get Vault token"
+	errReadServiceAccountToken = "cannot read service account token"
+	errInvalidVaultCABundle    = "Vault CA bundle does not contain any valid PEM encoded certificate"
+)
+
+// VaultClientOptions returns the HTTP client options resolving the Vault secret placeholders of requests from the
+// given Vault server.
+func VaultClientOptions(ctx context.Context, kube client.Client, tokens *TokenCache, config *apisv1alpha1.VaultConfig) ([]httpClient.ClientOption, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	h := &http.Client{Timeout: tokenTimeout}
+	if config.CABundle != "" {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM([]byte(config.CABundle)) {
+			return nil, errors.New(errInvalidVaultCABundle)
+		}
+		h.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}}
+	}
+
+	ts, err := vaultTokenSource(ctx, kube, tokens, h, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return []httpClient.ClientOption{httpClient.WithSecretResolver(vault.NewClient(config.Address, config.Namespace, h, ts).Read)}, nil
+}
+
+// vaultTokenSource returns the source of the Vault tokens of the given Vault auth.
+func vaultTokenSource(ctx context.Context, kube client.Client, tokens *TokenCache, h *http.Client, config *apisv1alpha1.VaultConfig) (oauth2.TokenSource, error) {
+	spec := config.Auth
+	if (spec.TokenSecretRef == nil) == (spec.Kubernetes == nil) {
+		return nil, errors.New(errVaultAuth)
+	}
+
+	if spec.TokenSecretRef != nil {
+		token, err := kubehandler.GetSecretValue(ctx, kube, *spec.TokenSecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetVaultToken)
+		}
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: strings.TrimSpace(string(token))}), nil
+	}
+
+	mountPath := spec.Kubernetes.MountPath
+	if mountPath == "" {
+		mountPath = defaultVaultMountPath
+	}
+	tokenPath := spec.Kubernetes.ServiceAccountTokenPath
+	if tokenPath == "" {
+		tokenPath = defaultServiceAccountTokenPath
+	}
+
+	// The service account token is read on every login, as projected tokens are rotated.
+	key := cacheKey("vault-kubernetes", config.Address, config.Namespace, config.CABundle, mountPath, spec.Kubernetes.Role, tokenPath)
+	return tokens.source(key, func() (*oauth2.Token, error) {
+		jwt, err := os.ReadFile(tokenPath) //nolint:gosec // the path is configured by the ProviderConfig.
+		if err != nil {
+			return nil, errors.Wrap(err, errReadServiceAccountToken)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), tokenTimeout)
+		defer cancel()
+		return vault.KubernetesLogin(ctx, h, config.Address, config.Namespace, mountPath, spec.Kubernetes.Role, strings.TrimSpace(string(jwt)))
+	}), nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package graphql encodes GraphQL requests and decodes their responses.
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errEncodeRequest  = "cannot encode GraphQL request"
+	errDecodeResponse = "cannot decode GraphQL response"
+	errResponse       = "GraphQL response holds errors: %s"
+)
+
+// A Request is the body of a GraphQL request sent over HTTP.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Encode returns the JSON body of the request.
+func (r Request) Encode() (string, error) {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return "", errors.Wrap(err, errEncodeRequest)
+	}
+
+	return string(body), nil
+}
+
+// An Error is an error of a GraphQL response.
+type Error struct {
+	Message string `json:"message"`
+}
+
+// A Response is the body of a GraphQL response.
+type Response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []Error                `json:"errors"`
+}
+
+// Decode decodes the given response body. A response holding errors is returned with an error listing their
+// messages, even when it holds data as well, as GraphQL servers report them with a 200 status code.
+func Decode(body string) (Response, error) {
+	var res Response
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		return Response{}, errors.Wrap(err, errDecodeResponse)
+	}
+
+	if len(res.Errors) == 0 {
+		return res, nil
+	}
+
+	messages := make([]string, 0, len(res.Errors))
+	for _, e := range res.Errors {
+		messages = append(messages, e.Message)
+	}
+
+	return res, errors.Errorf(errResponse, strings.Join(messages, "; "))
+}
@@ -0,0 +1,84 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_RequestEncode(t *testing.T) {
+	cases := map[string]struct {
+		request Request
+		want    string
+	}{
+		"QueryOnly": {
+			request: Request{Query: "{ viewer { login } }"},
+			want:    `{"query":"{ viewer { login } }"}`,
+		},
+		"WithVariables": {
+			request: Request{
+				Query:         "mutation CreateUser($name: String!) { createUser(name: $name) { id } }",
+				OperationName: "CreateUser",
+				Variables:     map[string]interface{}{"name": "jane"},
+			},
+			want: `{"query":"mutation CreateUser($name: String!) { createUser(name: $name) { id } }","operationName":"CreateUser","variables":{"name":"jane"}}`,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.request.Encode()
+			if err != nil {
+				t.Fatalf("Encode(): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Encode(): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Decode(t *testing.T) {
+	type want struct {
+		res Response
+		err error
+	}
+	cases := map[string]struct {
+		body string
+		want want
+	}{
+		"Data": {
+			body: `{"data": {"user": {"id": "1"}}}`,
+			want: want{res: Response{Data: map[string]interface{}{"user": map[string]interface{}{"id": "1"}}}},
+		},
+		"Errors": {
+			body: `{"data": null, "errors": [{"message": "name is taken"}, {"message": "not allowed"}]}`,
+			want: want{
+				res: Response{Errors: []Error{{Message: "name is taken"}, {Message: "not allowed"}}},
+				err: errors.Errorf(errResponse, "name is taken; not allowed"),
+			},
+		},
+		"EmptyErrors": {
+			body: `{"data": {"ok": true}, "errors": []}`,
+			want: want{res: Response{Data: map[string]interface{}{"ok": true}, Errors: []Error{}}},
+		},
+		"NotJSON": {
+			body: "<html></html>",
+			want: want{err: errors.Wrap(errors.New("invalid character '<' looking for beginning of value"), errDecodeResponse)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := Decode(tc.body)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Decode(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.res, got); diff != "" {
+				t.Errorf("Decode(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
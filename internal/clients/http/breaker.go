@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const errCircuitOpen = "circuit open for host %s after %d consecutive failures, next probe at %s"
+
+// A CircuitOpenError is returned instead of sending a request to a host whose circuit is open.
+type CircuitOpenError struct {
+	Host     string
+	Failures int
+	Until    time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf(errCircuitOpen, e.Host, e.Failures, e.Until.UTC().Format(time.RFC3339))
+}
+
+// CircuitBreakers stop sending requests to the hosts that keep failing, so that flapping remote APIs are not
+// flooded by the reconciles of all the resources calling them. They are shared by the clients of all resources.
+type CircuitBreakers struct {
+	threshold     int
+	probeInterval time.Duration
+	now           func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+// A circuit tracks the consecutive failures of a host.
+type circuit struct {
+	failures int
+
+	// openUntil is when the next request is let through to probe the host, once the circuit is open.
+	openUntil time.Time
+}
+
+// NewCircuitBreakers returns circuit breakers opening the circuit of a host after the given number of consecutive
+// failures, connection errors or 5xx responses. While a circuit is open, requests to its host fail fast, and one of
+// them is let through every probe interval to probe whether the host recovered. Circuits are never opened when the
+// threshold is not positive, nil is returned then.
+func NewCircuitBreakers(threshold int, probeInterval time.Duration) *CircuitBreakers {
+	if threshold <= 0 {
+		return nil
+	}
+
+	return &CircuitBreakers{
+		threshold:     threshold,
+		probeInterval: probeInterval,
+		now:           time.Now,
+		circuits:      map[string]*circuit{},
+	}
+}
+
+// allow returns a CircuitOpenError when the circuit of the given host is open and no probe is due.
+func (b *CircuitBreakers) allow(host string) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c := b.circuits[host]
+	if c == nil || c.failures < b.threshold {
+		return nil
+	}
+
+	now := b.now()
+	if now.Before(c.openUntil) {
+		return &CircuitOpenError{Host: host, Failures: c.failures, Until: c.openUntil}
+	}
+
+	// The request probes the host, the others keep failing fast until the next probe.
+	c.openUntil = now.Add(b.probeInterval)
+	return nil
+}
+
+// record records the outcome of a request sent to the given host. A success closes its circuit, while a failure
+// opens it once the threshold is reached. Canceled requests are not recorded.
+func (b *CircuitBreakers) record(host string, response *http.Response, err error) {
+	if b == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil && response.StatusCode < http.StatusInternalServerError {
+		delete(b.circuits, host)
+		return
+	}
+
+	c := b.circuits[host]
+	if c == nil {
+		c = &circuit{}
+		b.circuits[host] = c
+	}
+
+	c.failures++
+	if c.failures >= b.threshold {
+		c.openUntil = b.now().Add(b.probeInterval)
+	}
+}
+
+// WithCircuitBreakers makes the client fail fast instead of sending requests to the hosts whose circuit is open in
+// the given circuit breakers, and record the outcome of the requests it sends in them.
+func WithCircuitBreakers(breakers *CircuitBreakers) ClientOption {
+	return func(c *client) {
+		c.breakers = breakers
+	}
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_SendRequestCircuitBreakers(t *testing.T) {
+	type step struct {
+		// after is how long after the previous request the request is sent.
+		after      time.Duration
+		statusCode int
+		// sent is whether the request reaches the server, it fails fast otherwise.
+		sent bool
+	}
+	cases := map[string]struct {
+		threshold int
+		steps     []step
+	}{
+		"Disabled": {
+			threshold: 0,
+			steps: []step{
+				{statusCode: http.StatusInternalServerError, sent: true},
+				{statusCode: http.StatusInternalServerError, sent: true},
+				{statusCode: http.StatusInternalServerError, sent: true},
+			},
+		},
+		"OpenedAfterConsecutiveFailures": {
+			threshold: 2,
+			steps: []step{
+				{statusCode: http.StatusBadGateway, sent: true},
+				{statusCode: http.StatusBadGateway, sent: true},
+				{statusCode: http.StatusBadGateway, sent: false},
+				{after: 10 * time.Second, statusCode: http.StatusBadGateway, sent: false},
+			},
+		},
+		"SuccessResetsFailures": {
+			threshold: 2,
+			steps: []step{
+				{statusCode: http.StatusServiceUnavailable, sent: true},
+				{statusCode: http.StatusOK, sent: true},
+				{statusCode: http.StatusServiceUnavailable, sent: true},
+				{statusCode: http.StatusOK, sent: true},
+			},
+		},
+		"ClientErrorsAreNotFailures": {
+			threshold: 1,
+			steps: []step{
+				{statusCode: http.StatusNotFound, sent: true},
+				{statusCode: http.StatusNotFound, sent: true},
+			},
+		},
+		"ClosedByProbe": {
+			threshold: 1,
+			steps: []step{
+				{statusCode: http.StatusInternalServerError, sent: true},
+				{statusCode: http.StatusOK, sent: false},
+				{after: time.Minute, statusCode: http.StatusOK, sent: true},
+				{statusCode: http.StatusOK, sent: true},
+			},
+		},
+		"ReopenedByFailedProbe": {
+			threshold: 1,
+			steps: []step{
+				{statusCode: http.StatusInternalServerError, sent: true},
+				{after: time.Minute, statusCode: http.StatusInternalServerError, sent: true},
+				{statusCode: http.StatusOK, sent: false},
+				{after: time.Minute, statusCode: http.StatusOK, sent: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var statusCode, received int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received++
+				w.WriteHeader(statusCode)
+			}))
+			defer server.Close()
+
+			now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			breakers := NewCircuitBreakers(tc.threshold, 30*time.Second)
+			if breakers != nil {
+				breakers.now = func() time.Time { return now }
+			}
+
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithCircuitBreakers(breakers))
+			for i, s := range tc.steps {
+				now = now.Add(s.after)
+				statusCode = s.statusCode
+				before := received
+
+				_, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+				var open *CircuitOpenError
+				if diff := cmp.Diff(!s.sent, errors.As(err, &open)); diff != "" {
+					t.Errorf("step %d: SendRequest(...): -want failed fast, +got failed fast: %s", i, diff)
+				}
+				if diff := cmp.Diff(s.sent, received > before); diff != "" {
+					t.Errorf("step %d: SendRequest(...): -want sent, +got sent: %s", i, diff)
+				}
+			}
+		})
+	}
+}
@@ -1,16 +1,57 @@
 package http
 
 import (
+	"bufio"
 	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/andybalholm/brotli"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+
+	"github.com/arielsepton/provider-http/internal/clients/http/ntlm"
+	"github.com/arielsepton/provider-http/internal/redact"
+	"github.com/arielsepton/provider-http/internal/tracing"
+)
+
+const (
+	maxRedirects          = 10
+	hostHeader            = "Host"
+	authorizationHeader   = "Authorization"
+	contentEncodingHeader = "Content-Encoding"
+	acceptEncodingHeader  = "Accept-Encoding"
+
+	// acceptedEncodings are the compressed response encodings the client decodes.
+	acceptedEncodings = "gzip, deflate, br"
+
+	// EncodingGzip is the Content-Encoding of gzip compressed request bodies.
+	EncodingGzip = "gzip"
+
+	msgTruncatedResponse = "response body truncated to %d bytes"
+
+	errTooManyRedirects    = "stopped after %d redirects"
+	errInvalidCABundle     = "CA bundle does not contain any valid PEM encoded certificate"
+	errCompressBody        = "cannot compress request body"
+	errDecodeResponse      = "cannot decode %s response body"
+	errGetToken            = "cannot get authentication token"
+	errClientCert          = "cannot load client certificate"
+	errWaitRateLimit       = "cannot wait for the rate limit"
+	errWaitHostConcurrency = "cannot wait for the requests in flight to the host"
 )
 
 // Client is the interface to interact with Http
@@ -18,9 +59,226 @@ type Client interface {
 	SendRequest(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp HttpDetails, err error)
 }
 
+// StreamingClient is a Client that can stream request bodies.
+type StreamingClient interface {
+	Client
+	SendRequestStream(ctx context.Context, method string, url string, body io.Reader, headers map[string][]string, skipTLSVerify bool) (resp HttpDetails, err error)
+}
+
 type client struct {
 	log     logging.Logger
 	timeout time.Duration
+
+	// capturedRedirects holds the redirect status codes that are returned to the caller instead of being followed.
+	capturedRedirects map[int]bool
+
+	// rootCAs verifies the certificates of servers instead of the system roots when set.
+	rootCAs *x509.CertPool
+
+	// certificates are presented to servers requiring mutual TLS.
+	certificates []tls.Certificate
+
+	// basicAuth holds the credentials of requests without an Authorization header when set.
+	basicAuth *basicAuth
+
+	// tokens provides the tokens of requests without the token header when set.
+	tokens oauth2.TokenSource
+
+	// tokenHeader and tokenPrefix send tokens in a custom header, instead of as bearer tokens in the
+	// Authorization header.
+	tokenHeader string
+	tokenPrefix string
+
+	// ntlmCredentials authenticate requests with an NTLM handshake sent with ntlmScheme when set.
+	ntlmCredentials *ntlm.Credentials
+	ntlmScheme      string
+
+	// queryParameters are added to the query string of requests that do not set them explicitly.
+	queryParameters url.Values
+
+	// headers are added to requests that do not set them explicitly.
+	headers http.Header
+
+	// dialTimeout, tlsHandshakeTimeout and responseHeaderTimeout bound the phases of requests when positive.
+	dialTimeout           time.Duration
+	tlsHandshakeTimeout   time.Duration
+	responseHeaderTimeout time.Duration
+
+	// stream bounds how much of response bodies is read when set.
+	stream *ResponseStream
+
+	// maxResponseBytes is the number of bytes of response bodies read when positive.
+	maxResponseBytes int64
+
+	// secrets resolves the Vault secret placeholders of request headers and bodies when set.
+	secrets SecretResolver
+
+	// redactor masks the sensitive values of the logged requests, the default sensitive headers when unset.
+	redactor *redact.Redactor
+
+	// breakers fail requests to the hosts that keep failing fast when set.
+	breakers *CircuitBreakers
+
+	// limiter limits the rate at which requests are sent when set, they wait for their turn.
+	limiter *rate.Limiter
+
+	// hosts limit the requests of hostScope in flight per host to maxPerHost when set and maxPerHost is
+	// positive.
+	hosts      *HostSemaphores
+	hostScope  string
+	maxPerHost int
+
+	// err records an invalid option, it is returned by NewClient.
+	err error
+}
+
+// ClientOption configures a Client.
+type ClientOption func(*client)
+
+// WithCapturedRedirects makes the client return redirect responses with one of the given status codes
+// instead of following them.
+func WithCapturedRedirects(statusCodes ...int) ClientOption {
+	return func(c *client) {
+		c.capturedRedirects = make(map[int]bool, len(statusCodes))
+		for _, statusCode := range statusCodes {
+			c.capturedRedirects[statusCode] = true
+		}
+	}
+}
+
+// WithMaxResponseBytes makes the client read at most the given number of bytes of response bodies, dropping the
+// rest of larger bodies. Bodies are not limited when it is not positive.
+func WithMaxResponseBytes(maxBytes int64) ClientOption {
+	return func(c *client) {
+		c.maxResponseBytes = maxBytes
+	}
+}
+
+// WithRedactor makes the client mask the sensitive values the given redactor selects in the requests it logs.
+func WithRedactor(redactor *redact.Redactor) ClientOption {
+	return func(c *client) {
+		c.redactor = redactor
+	}
+}
+
+// WithRateLimiter makes the client wait for the given limiter before sending requests. The limiter may be shared
+// by several clients, to limit their requests together.
+func WithRateLimiter(limiter *rate.Limiter) ClientOption {
+	return func(c *client) {
+		c.limiter = limiter
+	}
+}
+
+// WithTransportTimeouts bounds how long connecting to servers, the TLS handshake with them, and waiting for the
+// headers of their responses may take. The phases whose timeout is not positive are bounded by the timeout of the
+// client only.
+func WithTransportTimeouts(dial, tlsHandshake, responseHeader time.Duration) ClientOption {
+	return func(c *client) {
+		c.dialTimeout = dial
+		c.tlsHandshakeTimeout = tlsHandshake
+		c.responseHeaderTimeout = responseHeader
+	}
+}
+
+// WithCABundle makes the client verify the certificates of servers using the given PEM encoded CA certificates
+// instead of the system roots. An empty bundle keeps the system roots.
+func WithCABundle(caBundle string) ClientOption {
+	return func(c *client) {
+		if caBundle == "" {
+			return
+		}
+
+		c.rootCAs = x509.NewCertPool()
+		if !c.rootCAs.AppendCertsFromPEM([]byte(caBundle)) {
+			c.err = errors.New(errInvalidCABundle)
+		}
+	}
+}
+
+// WithClientCertificate makes the client present the given PEM encoded certificate and private key to servers
+// requiring mutual TLS.
+func WithClientCertificate(certPEM, keyPEM []byte) ClientOption {
+	return func(c *client) {
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			c.err = errors.Wrap(err, errClientCert)
+			return
+		}
+
+		c.certificates = []tls.Certificate{cert}
+	}
+}
+
+type basicAuth struct {
+	username string
+	password string
+}
+
+// WithBasicAuth authenticates requests with the given username and password, unless they set an
+// Authorization header explicitly. The credentials are not recorded in the returned request details.
+func WithBasicAuth(username, password string) ClientOption {
+	return func(c *client) {
+		c.basicAuth = &basicAuth{username: username, password: password}
+	}
+}
+
+// WithTokenSource authenticates requests with bearer tokens from the given source,
+// unless they set an Authorization header explicitly. Tokens are not recorded in
+// the returned request details.
+func WithTokenSource(tokens oauth2.TokenSource) ClientOption {
+	return func(c *client) {
+		c.tokens = tokens
+	}
+}
+
+// WithHeaderTokenSource sends tokens from the given source in the given header, prefixed with the given prefix,
+// unless requests set the header explicitly. Tokens are not recorded in the returned request details.
+func WithHeaderTokenSource(header, prefix string, tokens oauth2.TokenSource) ClientOption {
+	return func(c *client) {
+		c.tokens = tokens
+		c.tokenHeader = header
+		c.tokenPrefix = prefix
+	}
+}
+
+// WithNTLM authenticates requests with an NTLM handshake sent with the given scheme, either ntlm.SchemeNTLM
+// or ntlm.SchemeNegotiate, unless they set an Authorization header explicitly. Streamed request bodies are
+// buffered, as they are sent with both legs of the handshake.
+func WithNTLM(scheme string, credentials ntlm.Credentials) ClientOption {
+	return func(c *client) {
+		c.ntlmCredentials = &credentials
+		c.ntlmScheme = scheme
+	}
+}
+
+// WithQueryParameter adds the given query parameter to requests that do not set it explicitly. It is not recorded
+// in the returned request details.
+func WithQueryParameter(name, value string) ClientOption {
+	return func(c *client) {
+		if c.queryParameters == nil {
+			c.queryParameters = url.Values{}
+		}
+		c.queryParameters.Set(name, value)
+	}
+}
+
+// WithHeader adds the given header to requests that do not set it explicitly. It is not recorded in the returned
+// request details.
+func WithHeader(name, value string) ClientOption {
+	return func(c *client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		c.headers.Set(name, value)
+	}
+}
+
+// WithSecretResolver resolves the {{ vault:<path>#<key> }} placeholders of request headers and bodies with the
+// given resolver. The resolved values are not recorded in the returned request details.
+func WithSecretResolver(resolver SecretResolver) ClientOption {
+	return func(c *client) {
+		c.secrets = resolver
+	}
 }
 
 type HttpResponse struct {
@@ -39,12 +297,12 @@ type HttpRequest struct {
 type HttpDetails struct {
 	HttpResponse HttpResponse
 	HttpRequest  HttpRequest
+
+	// Timings break down the duration of the request, once it was sent.
+	Timings *Timings
 }
 
 func (hc *client) SendRequest(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (details HttpDetails, err error) {
-	requestBody := []byte(body)
-	request, err := http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(requestBody))
-
 	requestDetails := HttpRequest{
 		URL:     url,
 		Body:    body,
@@ -52,39 +310,159 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 		Method:  method,
 	}
 
+	resolvedBody, err := hc.resolvePlaceholders(ctx, body)
+	if err != nil {
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, err
+	}
+
+	requestBody, err := encodeBody(resolvedBody, headers)
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
 		}, err
 	}
 
+	return hc.send(ctx, requestDetails, bytes.NewBuffer(requestBody), skipTLSVerify)
+}
+
+// SendRequestStream sends a request whose body is streamed from the given reader with chunked transfer encoding,
+// instead of being held in memory. The body is not recorded in the returned request details.
+func (hc *client) SendRequestStream(ctx context.Context, method string, url string, body io.Reader, headers map[string][]string, skipTLSVerify bool) (details HttpDetails, err error) {
+	requestDetails := HttpRequest{
+		URL:     url,
+		Headers: headers,
+		Method:  method,
+	}
+
+	return hc.send(ctx, requestDetails, encodeStream(body, headers), skipTLSVerify)
+}
+
+// send sends the described request with the given body.
+func (hc *client) send(ctx context.Context, requestDetails HttpRequest, body io.Reader, skipTLSVerify bool) (details HttpDetails, err error) {
+	method, url, headers := requestDetails.Method, requestDetails.URL, requestDetails.Headers
+
+	request, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, err
+	}
+
+	ctx, span := tracing.StartRequest(ctx, method, request.URL.Host)
+	defer func() {
+		tracing.EndRequest(span, details.HttpResponse.StatusCode, err)
+	}()
+	request = request.WithContext(ctx)
+
 	for key, values := range headers {
+		// net/http ignores a Host header, the request's Host field has to be set instead.
+		if http.CanonicalHeaderKey(key) == hostHeader {
+			if len(values) > 0 {
+				request.Host = values[0]
+			}
+			continue
+		}
+
 		for _, value := range values {
+			value, err := hc.resolvePlaceholders(ctx, value)
+			if err != nil {
+				return HttpDetails{
+					HttpRequest: requestDetails,
+				}, err
+			}
 			request.Header.Add(key, value)
 		}
 	}
 
+	for key, values := range hc.headers {
+		if request.Header.Get(key) == "" {
+			request.Header[key] = values
+		}
+	}
+
+	if request.Header.Get(acceptEncodingHeader) == "" {
+		request.Header.Set(acceptEncodingHeader, acceptedEncodings)
+	}
+
+	tracing.Inject(ctx, request.Header)
+
+	if err := hc.authorize(request); err != nil {
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, err
+	}
+
+	// #nosec G402
+	tlsConfig := &tls.Config{InsecureSkipVerify: skipTLSVerify, RootCAs: hc.rootCAs, Certificates: hc.certificates}
+	if request.Host != request.URL.Host {
+		// Verify the certificate against the overridden host rather than the connect address.
+		tlsConfig.ServerName = hostname(request.Host)
+	}
+
+	// Responses are decoded by readBody, whatever the accepted encodings.
+	var transport http.RoundTripper = &http.Transport{
+		DialContext:           (&net.Dialer{Timeout: hc.dialTimeout}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   hc.tlsHandshakeTimeout,
+		ResponseHeaderTimeout: hc.responseHeaderTimeout,
+		DisableCompression:    true,
+	}
+	if hc.ntlmCredentials != nil {
+		transport = &ntlm.Transport{Base: transport, Scheme: hc.ntlmScheme, Credentials: *hc.ntlmCredentials}
+	}
+
 	client := &http.Client{
-		Transport: &http.Transport{
-			// #nosec G402
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: skipTLSVerify},
-		},
-		Timeout: hc.timeout,
+		Transport:     transport,
+		Timeout:       hc.timeout,
+		CheckRedirect: hc.checkRedirect,
+	}
+
+	if err := hc.breakers.allow(request.URL.Host); err != nil {
+		return HttpDetails{
+			HttpRequest: requestDetails,
+		}, err
+	}
+
+	if hc.limiter != nil {
+		if err := hc.limiter.Wait(ctx); err != nil {
+			return HttpDetails{
+				HttpRequest: requestDetails,
+			}, errors.Wrap(err, errWaitRateLimit)
+		}
+	}
+
+	if hc.hosts != nil && hc.maxPerHost > 0 {
+		release, err := hc.hosts.acquire(ctx, hc.hostScope, request.URL.Host, hc.maxPerHost)
+		if err != nil {
+			return HttpDetails{
+				HttpRequest: requestDetails,
+			}, errors.Wrap(err, errWaitHostConcurrency)
+		}
+		// The slot is held until the response body is read.
+		defer release()
 	}
 
-	response, err := client.Do(request)
+	ctx, timer := newTimer(ctx)
+	response, err := client.Do(request.WithContext(ctx))
+	hc.breakers.record(request.URL.Host, response, err)
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
+			Timings:     timer.timings(),
 		}, err
 	}
+	defer func() { _ = response.Body.Close() }()
 
-	responsebody, err := io.ReadAll(response.Body)
+	responsebody, err := hc.readBody(response)
 	if err != nil {
 		return HttpDetails{
 			HttpRequest: requestDetails,
+			Timings:     timer.timings(),
 		}, err
 	}
+	timings := timer.timings()
 
 	beautifiedResponse := HttpResponse{
 		Body:       string(responsebody),
@@ -92,27 +470,226 @@ func (hc *client) SendRequest(ctx context.Context, method string, url string, bo
 		StatusCode: response.StatusCode,
 	}
 
-	err = response.Body.Close()
-	if err != nil {
-		return HttpDetails{
-			HttpRequest: requestDetails,
-		}, err
-	}
-
-	hc.log.Info(fmt.Sprint("http request sent: ", toJSON(requestDetails)))
+	hc.log.Info(fmt.Sprint("http request sent: ", toJSON(hc.redacted(requestDetails))))
 
 	return HttpDetails{
 		HttpResponse: beautifiedResponse,
 		HttpRequest:  requestDetails,
+		Timings:      timings,
 	}, nil
 }
 
+// authorize sets the authentication header and query parameters of the given request from the configured
+// credentials, unless they were set explicitly.
+func (hc *client) authorize(request *http.Request) error {
+	// The parameters are appended, so that the order of the existing ones is kept.
+	query, missing := request.URL.Query(), url.Values{}
+	for name, values := range hc.queryParameters {
+		if !query.Has(name) {
+			missing[name] = values
+		}
+	}
+	if len(missing) > 0 {
+		if request.URL.RawQuery != "" {
+			request.URL.RawQuery += "&"
+		}
+		request.URL.RawQuery += missing.Encode()
+	}
+
+	switch {
+	case hc.basicAuth != nil:
+		if request.Header.Get(authorizationHeader) == "" {
+			request.SetBasicAuth(hc.basicAuth.username, hc.basicAuth.password)
+		}
+	case hc.tokens != nil:
+		header := hc.tokenHeader
+		if header == "" {
+			header = authorizationHeader
+		}
+		if request.Header.Get(header) != "" {
+			return nil
+		}
+
+		token, err := hc.tokens.Token()
+		if err != nil {
+			return errors.Wrap(err, errGetToken)
+		}
+
+		if hc.tokenHeader == "" {
+			token.SetAuthHeader(request)
+		} else {
+			request.Header.Set(hc.tokenHeader, hc.tokenPrefix+token.AccessToken)
+		}
+	}
+
+	return nil
+}
+
+// encodeBody returns the body to send, compressed when the Content-Encoding header asks for gzip.
+func encodeBody(body string, headers map[string][]string) ([]byte, error) {
+	if body == "" || !strings.EqualFold(http.Header(headers).Get(contentEncodingHeader), EncodingGzip) {
+		return []byte(body), nil
+	}
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write([]byte(body)); err != nil {
+		return nil, errors.Wrap(err, errCompressBody)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, errors.Wrap(err, errCompressBody)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// readBody reads the body of the given response, decoded according to its Content-Encoding header, within the
+// bounds of the response stream when set. The header is removed once the body is decoded.
+func (hc *client) readBody(response *http.Response) ([]byte, error) {
+	buffered := bufio.NewReader(response.Body)
+	if _, err := buffered.Peek(1); err == io.EOF {
+		return nil, nil
+	}
+
+	body, decoded, err := decodeReader(buffered, response.Header)
+	if err != nil {
+		return nil, errors.Wrapf(err, errDecodeResponse, response.Header.Get(contentEncodingHeader))
+	}
+	if decoded {
+		response.Header.Del(contentEncodingHeader)
+		response.Header.Del("Content-Length")
+	}
+
+	if hc.stream != nil {
+		return hc.stream.read(body, response.Header, hc.maxResponseBytes)
+	}
+	if hc.maxResponseBytes <= 0 {
+		return io.ReadAll(body)
+	}
+
+	// One more byte is read to tell whether the body is truncated.
+	read, err := io.ReadAll(io.LimitReader(body, hc.maxResponseBytes+1))
+	if err == nil && int64(len(read)) > hc.maxResponseBytes {
+		hc.log.Info(fmt.Sprintf(msgTruncatedResponse, hc.maxResponseBytes))
+		read = read[:hc.maxResponseBytes]
+	}
+	return read, err
+}
+
+// decodeReader returns a reader decoding the given body according to the given Content-Encoding header, and
+// whether it decodes it. Bodies with an unknown encoding are not decoded.
+func decodeReader(body io.Reader, headers http.Header) (io.Reader, bool, error) {
+	decoded, isDecoded := body, false
+
+	// Encodings are listed in the order they were applied.
+	encodings := strings.Split(strings.Join(headers.Values(contentEncodingHeader), ","), ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var err error
+		switch strings.ToLower(strings.TrimSpace(encodings[i])) {
+		case "", "identity":
+			continue
+		case EncodingGzip, "x-gzip":
+			decoded, err = gzip.NewReader(decoded)
+		case "deflate":
+			decoded, err = deflateReader(decoded)
+		case "br":
+			decoded = brotli.NewReader(decoded)
+		default:
+			return body, false, nil
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		isDecoded = true
+	}
+
+	return decoded, isDecoded, nil
+}
+
+// deflateReader returns a reader decoding the given deflate body. deflate is meant to be zlib wrapped, but some
+// servers send raw deflate data.
+func deflateReader(body io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(body)
+	header, err := buffered.Peek(2)
+	if err != nil {
+		return nil, err
+	}
+
+	// A zlib header declares the deflate method and is a multiple of 31.
+	if header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+		return zlib.NewReader(buffered)
+	}
+
+	return flate.NewReader(buffered), nil
+}
+
+// encodeStream returns the body to stream, compressed on the fly when the Content-Encoding header asks for gzip.
+// The returned reader hides the type of the given one, so that its length is unknown and chunked transfer
+// encoding is used.
+func encodeStream(body io.Reader, headers map[string][]string) io.Reader {
+	if !strings.EqualFold(http.Header(headers).Get(contentEncodingHeader), EncodingGzip) {
+		return io.MultiReader(body)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		gw := gzip.NewWriter(pw)
+		_, err := io.Copy(gw, body)
+		if err == nil {
+			err = gw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return pr
+}
+
+// hostname returns the host without its port.
+func hostname(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+
+	return host
+}
+
+// checkRedirect stops at redirects that should be captured, and otherwise behaves like the default policy
+// of net/http, which follows up to 10 redirects.
+func (hc *client) checkRedirect(req *http.Request, via []*http.Request) error {
+	if req.Response != nil && hc.capturedRedirects[req.Response.StatusCode] {
+		return http.ErrUseLastResponse
+	}
+
+	if len(via) >= maxRedirects {
+		return errors.Errorf(errTooManyRedirects, maxRedirects)
+	}
+
+	return nil
+}
+
 // NewClient returns a new Http Client
-func NewClient(log logging.Logger, timeout time.Duration) (Client, error) {
-	return &client{
+func NewClient(log logging.Logger, timeout time.Duration, opts ...ClientOption) (Client, error) {
+	c := &client{
 		log:     log,
 		timeout: timeout,
-	}, nil
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return c, nil
+}
+
+// redacted returns a copy of the given request with its sensitive headers and body fields masked.
+func (hc *client) redacted(request HttpRequest) HttpRequest {
+	request.Headers = hc.redactor.Headers(request.Headers)
+	request.Body = hc.redactor.Body(request.Body)
+	return request
 }
 
 func toJSON(request HttpRequest) string {
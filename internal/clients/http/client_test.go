@@ -0,0 +1,1114 @@
+package http
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+func Test_SendRequestRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users" {
+			w.Header().Set("Location", "/users/123")
+			w.WriteHeader(http.StatusSeeOther)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"123"}`))
+	}))
+	defer server.Close()
+
+	type args struct {
+		opts []ClientOption
+	}
+	type want struct {
+		statusCode int
+		location   string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FollowedByDefault": {
+			args: args{},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		"Captured": {
+			args: args{
+				opts: []ClientOption{WithCapturedRedirects(http.StatusSeeOther)},
+			},
+			want: want{
+				statusCode: http.StatusSeeOther,
+				location:   "/users/123",
+			},
+		},
+		"OtherStatusCodeCaptured": {
+			args: args{
+				opts: []ClientOption{WithCapturedRedirects(http.StatusFound)},
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, tc.args.opts...)
+			got, err := c.SendRequest(context.Background(), http.MethodPost, server.URL+"/users", "", nil, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, got.HttpResponse.StatusCode); diff != "" {
+				t.Errorf("SendRequest(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.location, http.Header(got.HttpResponse.Headers).Get("Location")); diff != "" {
+				t.Errorf("SendRequest(...): -want location, +got location: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestHostOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	}))
+	defer server.Close()
+
+	type args struct {
+		headers map[string][]string
+	}
+	type want struct {
+		host string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultHost": {
+			args: args{},
+			want: want{
+				host: server.Listener.Addr().String(),
+			},
+		},
+		"HostHeader": {
+			args: args{
+				headers: map[string][]string{"Host": {"api.example.com"}},
+			},
+			want: want{
+				host: "api.example.com",
+			},
+		},
+		"LowercaseHostHeader": {
+			args: args{
+				headers: map[string][]string{"host": {"api.example.com:8443"}},
+			},
+			want: want{
+				host: "api.example.com:8443",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second)
+			got, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", tc.args.headers, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.host, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want host, +got host: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	type args struct {
+		opts []ClientOption
+	}
+	type want struct {
+		newClientErr bool
+		sendErr      bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SystemRoots": {
+			args: args{},
+			want: want{
+				sendErr: true,
+			},
+		},
+		"ServerCA": {
+			args: args{
+				opts: []ClientOption{WithCABundle(serverCA)},
+			},
+			want: want{},
+		},
+		"InvalidCABundle": {
+			args: args{
+				opts: []ClientOption{WithCABundle("not a certificate")},
+			},
+			want: want{
+				newClientErr: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, tc.args.opts...)
+			if diff := cmp.Diff(tc.want.newClientErr, err != nil); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			_, err = c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+			if diff := cmp.Diff(tc.want.sendErr, err != nil); diff != "" {
+				t.Errorf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestGzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = gr
+		}
+		_, _ = io.Copy(w, body)
+	}))
+	defer server.Close()
+
+	type args struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+	}{
+		"Uncompressed": {
+			args: args{},
+		},
+		"Gzip": {
+			args: args{
+				headers: map[string][]string{"Content-Encoding": {"gzip"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			body := `{"username":"john_doe"}`
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second)
+			got, err := c.SendRequest(context.Background(), http.MethodPost, server.URL, body, tc.args.headers, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(body, got.HttpRequest.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want request body, +got request body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestCompressedResponse(t *testing.T) {
+	body := `{"username":"john_doe"}`
+	compress := func(encoding string, data []byte) []byte {
+		var buf bytes.Buffer
+		var w io.WriteCloser
+		switch encoding {
+		case "gzip":
+			w = gzip.NewWriter(&buf)
+		case "deflate":
+			w = zlib.NewWriter(&buf)
+		case "raw-deflate":
+			w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+		case "br":
+			w = brotli.NewWriter(&buf)
+		default:
+			return data
+		}
+		_, _ = w.Write(data)
+		_ = w.Close()
+		return buf.Bytes()
+	}
+
+	var acceptEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		encodings := r.URL.Query()["encoding"]
+		data := []byte(body)
+		for _, encoding := range encodings {
+			data = compress(encoding, data)
+			if encoding == "raw-deflate" {
+				encoding = "deflate"
+			}
+			w.Header().Add("Content-Encoding", encoding)
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	type args struct {
+		query   string
+		headers map[string][]string
+	}
+	type want struct {
+		body            string
+		contentEncoding string
+		acceptEncoding  string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Uncompressed": {
+			args: args{},
+			want: want{body: body, acceptEncoding: "gzip, deflate, br"},
+		},
+		"Gzip": {
+			args: args{query: "?encoding=gzip"},
+			want: want{body: body, acceptEncoding: "gzip, deflate, br"},
+		},
+		"Deflate": {
+			args: args{query: "?encoding=deflate"},
+			want: want{body: body, acceptEncoding: "gzip, deflate, br"},
+		},
+		"RawDeflate": {
+			args: args{query: "?encoding=raw-deflate"},
+			want: want{body: body, acceptEncoding: "gzip, deflate, br"},
+		},
+		"Brotli": {
+			args: args{query: "?encoding=br"},
+			want: want{body: body, acceptEncoding: "gzip, deflate, br"},
+		},
+		"Several": {
+			args: args{query: "?encoding=gzip&encoding=br"},
+			want: want{body: body, acceptEncoding: "gzip, deflate, br"},
+		},
+		"ExplicitAcceptEncoding": {
+			args: args{query: "?encoding=gzip", headers: map[string][]string{"Accept-Encoding": {"gzip"}}},
+			want: want{body: body, acceptEncoding: "gzip"},
+		},
+		"UnknownEncoding": {
+			args: args{query: "?encoding=compress"},
+			want: want{body: body, contentEncoding: "compress", acceptEncoding: "gzip, deflate, br"},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second)
+			got, err := c.SendRequest(context.Background(), http.MethodGet, server.URL+tc.args.query, "", tc.args.headers, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.contentEncoding, http.Header(got.HttpResponse.Headers).Get("Content-Encoding")); diff != "" {
+				t.Errorf("SendRequest(...): -want Content-Encoding, +got Content-Encoding: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.acceptEncoding, acceptEncoding); diff != "" {
+				t.Errorf("SendRequest(...): -want Accept-Encoding, +got Accept-Encoding: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestResponseStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.URL.Query().Get("type"))
+		_, _ = io.WriteString(w, r.URL.Query().Get("body"))
+	}))
+	defer server.Close()
+
+	errBoom := errors.New("boom")
+	records := "{\"id\":1,\"status\":\"running\"}\n\n{\"id\":2,\"status\":\"done\"}\n{\"id\":3,\"status\":\"done\"}\n"
+	type args struct {
+		contentType string
+		body        string
+		stream      ResponseStream
+	}
+	type want struct {
+		body string
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NDJSON": {
+			args: args{contentType: "application/x-ndjson", body: records},
+			want: want{body: `[{"id":1,"status":"running"},{"id":2,"status":"done"},{"id":3,"status":"done"}]`},
+		},
+		"MaxRecords": {
+			args: args{contentType: "application/x-ndjson", body: records, stream: ResponseStream{MaxRecords: 1}},
+			want: want{body: `[{"id":1,"status":"running"}]`},
+		},
+		"Until": {
+			args: args{
+				contentType: "application/jsonl; charset=utf-8",
+				body:        records,
+				stream: ResponseStream{Until: func(record []byte) (bool, error) {
+					return strings.Contains(string(record), `"done"`), nil
+				}},
+			},
+			want: want{body: `[{"id":1,"status":"running"},{"id":2,"status":"done"}]`},
+		},
+		"UntilError": {
+			args: args{
+				contentType: "application/x-ndjson",
+				body:        records,
+				stream: ResponseStream{Until: func(record []byte) (bool, error) {
+					return false, errBoom
+				}},
+			},
+			want: want{err: errors.Wrap(errBoom, errStreamUntil)},
+		},
+		"RecordCutByMaxBytes": {
+			args: args{contentType: "application/x-ndjson", body: records, stream: ResponseStream{MaxBytes: 40}},
+			want: want{body: `[{"id":1,"status":"running"}]`},
+		},
+		"InvalidRecord": {
+			args: args{contentType: "application/x-ndjson", body: "{\"id\":1}\nnot json\n"},
+			want: want{err: errors.Errorf(errInvalidRecord, 2)},
+		},
+		"PlainStreamCutByMaxBytes": {
+			args: args{contentType: "text/plain", body: "line 1\nline 2\n", stream: ResponseStream{MaxBytes: 6}},
+			want: want{body: "line 1"},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			query := url.Values{"type": {tc.args.contentType}, "body": {tc.args.body}}
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithResponseStream(tc.args.stream))
+			got, err := c.SendRequest(context.Background(), http.MethodGet, server.URL+"?"+query.Encode(), "", nil, false)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestMaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.URL.Query().Get("type"))
+		_, _ = io.WriteString(w, r.URL.Query().Get("body"))
+	}))
+	defer server.Close()
+
+	type args struct {
+		contentType string
+		body        string
+		opts        []ClientOption
+	}
+	type want struct {
+		body string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoLimit": {
+			args: args{contentType: "application/json", body: `{"username":"john_doe"}`},
+			want: want{body: `{"username":"john_doe"}`},
+		},
+		"WithinLimit": {
+			args: args{contentType: "application/json", body: `{"username":"john_doe"}`, opts: []ClientOption{WithMaxResponseBytes(23)}},
+			want: want{body: `{"username":"john_doe"}`},
+		},
+		"Truncated": {
+			args: args{contentType: "application/json", body: `{"username":"john_doe"}`, opts: []ClientOption{WithMaxResponseBytes(12)}},
+			want: want{body: `{"username":`},
+		},
+		"StreamDefaultsToLimit": {
+			args: args{
+				contentType: "application/x-ndjson",
+				body:        "{\"id\":1}\n{\"id\":2}\n",
+				opts:        []ClientOption{WithMaxResponseBytes(12), WithResponseStream(ResponseStream{})},
+			},
+			want: want{body: `[{"id":1}]`},
+		},
+		"StreamCappedByLimit": {
+			args: args{
+				contentType: "application/x-ndjson",
+				body:        "{\"id\":1}\n{\"id\":2}\n",
+				opts:        []ClientOption{WithMaxResponseBytes(12), WithResponseStream(ResponseStream{MaxBytes: 1024})},
+			},
+			want: want{body: `[{"id":1}]`},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			query := url.Values{"type": {tc.args.contentType}, "body": {tc.args.body}}
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, tc.args.opts...)
+			got, err := c.SendRequest(context.Background(), http.MethodGet, server.URL+"?"+query.Encode(), "", nil, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestClosesUnreadableBody(t *testing.T) {
+	released := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		_, _ = io.WriteString(w, "not a gzip stream")
+		w.(http.Flusher).Flush()
+		// The body is left unfinished, so that the connection is only released by closing it.
+		<-r.Context().Done()
+		close(released)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(logging.NewNopLogger(), 5*time.Second)
+	if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false); err == nil {
+		t.Fatal("SendRequest(...): expected an error decoding the body")
+	}
+
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Error("SendRequest(...): the response body was not closed after failing to read it")
+	}
+}
+
+func Test_SendRequestStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TransferEncoding) == 0 || r.TransferEncoding[0] != "chunked" {
+			w.WriteHeader(http.StatusLengthRequired)
+			return
+		}
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = gr
+		}
+		_, _ = io.Copy(w, body)
+	}))
+	defer server.Close()
+
+	type args struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+	}{
+		"Uncompressed": {
+			args: args{},
+		},
+		"Gzip": {
+			args: args{
+				headers: map[string][]string{"Content-Encoding": {"gzip"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			body := `{"username":"john_doe"}`
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second)
+			got, err := c.(StreamingClient).SendRequestStream(context.Background(), http.MethodPost, server.URL, strings.NewReader(body), tc.args.headers, false)
+			if err != nil {
+				t.Fatalf("SendRequestStream(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(http.StatusOK, got.HttpResponse.StatusCode); diff != "" {
+				t.Errorf("SendRequestStream(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequestStream(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff("", got.HttpRequest.Body); diff != "" {
+				t.Errorf("SendRequestStream(...): -want request body, +got request body: %s", diff)
+			}
+		})
+	}
+}
+
+type tokenSourceFn func() (*oauth2.Token, error)
+
+func (fn tokenSourceFn) Token() (*oauth2.Token, error) { return fn() }
+
+func Test_SendRequestAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer server.Close()
+
+	token := tokenSourceFn(func() (*oauth2.Token, error) {
+		return &oauth2.Token{AccessToken: "abc", TokenType: "Bearer"}, nil
+	})
+
+	type args struct {
+		opts    []ClientOption
+		headers map[string][]string
+	}
+	type want struct {
+		authorization string
+		err           bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoCredentials": {
+			args: args{},
+			want: want{},
+		},
+		"BasicAuth": {
+			args: args{
+				opts: []ClientOption{WithBasicAuth("user", "pass")},
+			},
+			want: want{
+				authorization: "Basic dXNlcjpwYXNz",
+			},
+		},
+		"BearerToken": {
+			args: args{
+				opts: []ClientOption{WithTokenSource(token)},
+			},
+			want: want{
+				authorization: "Bearer abc",
+			},
+		},
+		"HeaderToken": {
+			args: args{
+				opts: []ClientOption{WithHeaderTokenSource("Authorization", "Session ", token)},
+			},
+			want: want{
+				authorization: "Session abc",
+			},
+		},
+		"ExplicitAuthorizationHeader": {
+			args: args{
+				opts:    []ClientOption{WithTokenSource(token)},
+				headers: map[string][]string{"authorization": {"Basic dXNlcjpwYXNz"}},
+			},
+			want: want{
+				authorization: "Basic dXNlcjpwYXNz",
+			},
+		},
+		"TokenError": {
+			args: args{
+				opts: []ClientOption{WithTokenSource(tokenSourceFn(func() (*oauth2.Token, error) {
+					return nil, errors.New("boom")
+				}))},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, tc.args.opts...)
+			got, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", tc.args.headers, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.authorization, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want authorization, +got authorization: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.headers, got.HttpRequest.Headers); diff != "" {
+				t.Errorf("SendRequest(...): -want recorded headers, +got recorded headers: %s", diff)
+			}
+		})
+	}
+}
+
+// newClientCertificate returns a self-signed PEM encoded client certificate and its private key.
+func newClientCertificate(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("cannot generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "provider-http"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("cannot create certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("cannot marshal key: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func Test_SendRequestClientCertificate(t *testing.T) {
+	certPEM, keyPEM := newClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	type args struct {
+		opts []ClientOption
+	}
+	type want struct {
+		newClientErr bool
+		sendErr      bool
+		body         string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoClientCertificate": {
+			args: args{
+				opts: []ClientOption{WithCABundle(serverCA)},
+			},
+			want: want{
+				sendErr: true,
+			},
+		},
+		"ClientCertificate": {
+			args: args{
+				opts: []ClientOption{WithCABundle(serverCA), WithClientCertificate(certPEM, keyPEM)},
+			},
+			want: want{
+				body: "provider-http",
+			},
+		},
+		"InvalidClientCertificate": {
+			args: args{
+				opts: []ClientOption{WithClientCertificate(certPEM, []byte("not a key"))},
+			},
+			want: want{
+				newClientErr: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, err := NewClient(logging.NewNopLogger(), 5*time.Second, tc.args.opts...)
+			if diff := cmp.Diff(tc.want.newClientErr, err != nil); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			got, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+			if diff := cmp.Diff(tc.want.sendErr, err != nil); diff != "" {
+				t.Errorf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestQueryParameter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.RawQuery))
+	}))
+	defer server.Close()
+
+	type args struct {
+		url string
+	}
+	type want struct {
+		query string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoQuery": {
+			args: args{
+				url: server.URL,
+			},
+			want: want{
+				query: "api_key=secret",
+			},
+		},
+		"ExistingQueryKept": {
+			args: args{
+				url: server.URL + "?z=1&a=2",
+			},
+			want: want{
+				query: "z=1&a=2&api_key=secret",
+			},
+		},
+		"ExplicitParameter": {
+			args: args{
+				url: server.URL + "?api_key=other",
+			},
+			want: want{
+				query: "api_key=other",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithQueryParameter("api_key", "secret"))
+			got, err := c.SendRequest(context.Background(), http.MethodGet, tc.args.url, "", nil, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.query, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want query, +got query: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.url, got.HttpRequest.URL); diff != "" {
+				t.Errorf("SendRequest(...): -want recorded url, +got recorded url: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestHeader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("X-Api-Key")))
+	}))
+	defer server.Close()
+
+	type args struct {
+		headers map[string][]string
+	}
+	type want struct {
+		header string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoHeader": {
+			args: args{},
+			want: want{
+				header: "secret",
+			},
+		},
+		"ExplicitHeader": {
+			args: args{
+				headers: map[string][]string{"X-Api-Key": {"other"}},
+			},
+			want: want{
+				header: "other",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithHeader("X-Api-Key", "secret"))
+			got, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", tc.args.headers, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.header, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want header, +got header: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.headers, got.HttpRequest.Headers); diff != "" {
+				t.Errorf("SendRequest(...): -want recorded headers, +got recorded headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestSecretPlaceholders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(r.Header.Get("Authorization") + " " + string(body)))
+	}))
+	defer server.Close()
+
+	secrets := func(_ context.Context, path, key string) (string, error) {
+		if path == "secret/data/api" && key == "token" {
+			return "s3cr3t", nil
+		}
+		return "", errors.New("not found")
+	}
+
+	type args struct {
+		body    string
+		headers map[string][]string
+	}
+	type want struct {
+		body string
+		err  bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Resolved": {
+			args: args{
+				body:    `{"password": "{{ vault:secret/data/api#token }}"}`,
+				headers: map[string][]string{"Authorization": {"Bearer {{vault:secret/data/api#token}}"}},
+			},
+			want: want{
+				body: `Bearer s3cr3t {"password": "s3cr3t"}`,
+			},
+		},
+		"NoPlaceholder": {
+			args: args{
+				body: `{"password": "{{ secret/data/api#token }}"}`,
+			},
+			want: want{
+				body: ` {"password": "{{ secret/data/api#token }}"}`,
+			},
+		},
+		"UnknownSecret": {
+			args: args{
+				body: `{"password": "{{ vault:secret/data/other#token }}"}`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithSecretResolver(secrets))
+			got, err := c.SendRequest(context.Background(), http.MethodPost, server.URL, tc.args.body, tc.args.headers, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, got.HttpResponse.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.body, got.HttpRequest.Body); diff != "" {
+				t.Errorf("SendRequest(...): -want recorded body, +got recorded body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.args.headers, got.HttpRequest.Headers); diff != "" {
+				t.Errorf("SendRequest(...): -want recorded headers, +got recorded headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SendRequestRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	// The limiter allows a single request, as the next one is not allowed before the deadline.
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithRateLimiter(limiter))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if _, err := c.SendRequest(ctx, http.MethodGet, server.URL, "", nil, false); err != nil {
+		t.Fatalf("SendRequest(...): unexpected error: %s", err)
+	}
+	if _, err := c.SendRequest(ctx, http.MethodGet, server.URL, "", nil, false); err == nil {
+		t.Errorf("SendRequest(...): want rate limit error, got none")
+	}
+}
+
+func Test_SendRequestHostConcurrency(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	semaphores := NewHostSemaphores()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each resource has its own client, sharing the semaphores.
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithHostConcurrency(semaphores, "pc", 2))
+			if _, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false); err != nil {
+				t.Errorf("SendRequest(...): unexpected error: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if maxInFlight > 2 {
+		t.Errorf("SendRequest(...): want at most 2 requests in flight, got %d", maxInFlight)
+	}
+
+	// A request waiting for its turn fails once its context is done.
+	release, err := semaphores.acquire(context.Background(), "other", server.Listener.Addr().String(), 1)
+	if err != nil {
+		t.Fatalf("acquire(...): unexpected error: %s", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, WithHostConcurrency(semaphores, "other", 1))
+	if _, err := c.SendRequest(ctx, http.MethodGet, server.URL, "", nil, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("SendRequest(...): want context deadline exceeded, got %v", err)
+	}
+}
+
+func Test_SendRequestTransportTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	cases := map[string]struct {
+		opts    []ClientOption
+		wantErr bool
+	}{
+		"NotBounded": {},
+		"ResponseHeaderTimeout": {
+			opts:    []ClientOption{WithTransportTimeouts(0, 0, 50*time.Millisecond)},
+			wantErr: true,
+		},
+		"LongerResponseHeaderTimeout": {
+			opts: []ClientOption{WithTransportTimeouts(time.Second, time.Second, time.Second)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second, tc.opts...)
+			_, err := c.SendRequest(context.Background(), http.MethodGet, server.URL, "", nil, false)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("SendRequest(...): -want error, +got error: %s (%v)", diff, err)
+			}
+		})
+	}
+}
+
+func Test_SendRequestTimings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	type want struct {
+		sent bool
+		err  bool
+	}
+	cases := map[string]struct {
+		url  string
+		want want
+	}{
+		"Sent": {
+			url:  server.URL,
+			want: want{sent: true},
+		},
+		"InvalidURL": {
+			url:  "://invalid",
+			want: want{sent: false, err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(logging.NewNopLogger(), 5*time.Second)
+			got, err := c.SendRequest(context.Background(), http.MethodGet, tc.url, "", nil, false)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, got.Timings != nil); diff != "" {
+				t.Fatalf("SendRequest(...): -want timings, +got timings: %s", diff)
+			}
+			if !tc.want.sent {
+				return
+			}
+			if got.Timings.FirstByte < 10*time.Millisecond || got.Timings.Total < got.Timings.FirstByte {
+				t.Errorf("SendRequest(...): unexpected timings %+v", *got.Timings)
+			}
+			if got.Timings.Connect <= 0 || got.Timings.TLS != 0 {
+				t.Errorf("SendRequest(...): unexpected connection timings %+v", *got.Timings)
+			}
+		})
+	}
+}
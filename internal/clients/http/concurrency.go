@@ -0,0 +1,52 @@
+package http
+
+import (
+	"context"
+	"sync"
+)
+
+// HostSemaphores limit the number of requests in flight per host, so that the resources reconciled at the same
+// time do not all call the same endpoint at once. They are shared by the clients of all resources.
+type HostSemaphores struct {
+	mu         sync.Mutex
+	semaphores map[string]chan struct{}
+}
+
+// NewHostSemaphores returns empty HostSemaphores.
+func NewHostSemaphores() *HostSemaphores {
+	return &HostSemaphores{semaphores: map[string]chan struct{}{}}
+}
+
+// acquire waits until fewer than limit requests of the given scope are in flight to the given host, or the context
+// is done. It returns the function releasing the acquired slot. The semaphore is replaced when the limit changes,
+// the requests in flight then release their slot in the previous one.
+func (s *HostSemaphores) acquire(ctx context.Context, scope, host string, limit int) (func(), error) {
+	key := scope + "/" + host
+
+	s.mu.Lock()
+	semaphore, ok := s.semaphores[key]
+	if !ok || cap(semaphore) != limit {
+		semaphore = make(chan struct{}, limit)
+		s.semaphores[key] = semaphore
+	}
+	s.mu.Unlock()
+
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// WithHostConcurrency makes the client wait until fewer than the given number of requests of the given scope are
+// in flight to a host before sending a request to it, using the given semaphores. Clients of the same scope share
+// the limit, e.g. the clients of all the resources using a ProviderConfig. Requests are not limited when the limit
+// is not positive.
+func WithHostConcurrency(semaphores *HostSemaphores, scope string, limit int) ClientOption {
+	return func(c *client) {
+		c.hosts = semaphores
+		c.hostScope = scope
+		c.maxPerHost = limit
+	}
+}
@@ -0,0 +1,61 @@
+// Package fake implements a fake http client for the tests of the controllers.
+package fake
+
+import (
+	"context"
+	"net/http"
+
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+// MockSendRequestFn is the function a MockClient sends its requests with.
+type MockSendRequestFn func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error)
+
+// MockClient is an http client sending its requests with its MockSendRequest.
+type MockClient struct {
+	MockSendRequest MockSendRequestFn
+}
+
+// SendRequest calls the MockSendRequest of the client.
+func (c *MockClient) SendRequest(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+	return c.MockSendRequest(ctx, method, url, body, headers, skipTLSVerify)
+}
+
+// Respond returns a MockSendRequestFn responding to every request with the given status code and body.
+func Respond(statusCode int, body string) MockSendRequestFn {
+	return func(_ context.Context, _ string, _ string, _ string, _ map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+		return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: statusCode, Body: body}}, nil
+	}
+}
+
+// Fail returns a MockSendRequestFn failing to send every request with the given error.
+func Fail(err error) MockSendRequestFn {
+	return func(_ context.Context, _ string, _ string, _ string, _ map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+		return httpClient.HttpDetails{}, err
+	}
+}
+
+// Server returns a MockSendRequestFn responding with the given responses, found by "<method> <url> <body>" or
+// otherwise by "<method> <url>", and with a 500 to unknown requests. When sent is not nil, the requests it receives
+// are recorded in it as "<method> <url> <body>".
+func Server(responses map[string]httpClient.HttpResponse, sent *[]string) MockSendRequestFn {
+	return func(_ context.Context, method string, url string, body string, _ map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+		if sent != nil {
+			*sent = append(*sent, method+" "+url+" "+body)
+		}
+		res, ok := responses[method+" "+url+" "+body]
+		if !ok {
+			res, ok = responses[method+" "+url]
+		}
+		if !ok {
+			res = httpClient.HttpResponse{StatusCode: http.StatusInternalServerError}
+		}
+		return httpClient.HttpDetails{HttpResponse: res}, nil
+	}
+}
+
+// Route returns a MockSendRequestFn responding with the given status code and body to the requests with the given
+// method and URL, and with a 500 to any other request.
+func Route(method, url string, statusCode int, body string) MockSendRequestFn {
+	return Server(map[string]httpClient.HttpResponse{method + " " + url: {StatusCode: statusCode, Body: body}}, nil)
+}
@@ -0,0 +1,131 @@
+// Package fixture implements an http client answering requests with responses
+// defined declaratively in a fixture file, instead of sending them. It lets
+// the provider run end to end in clusters without access to the remote API.
+package fixture
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+const (
+	errReadFixtures  = "cannot read fixtures file %s"
+	errParseFixtures = "cannot parse fixtures file %s"
+	errInvalidURL    = "fixture %d has an invalid url pattern"
+	errNoFixture     = "no fixture matches %s %s"
+)
+
+// Fixtures is the content of a fixtures file.
+type Fixtures struct {
+	Fixtures []Fixture `json:"fixtures"`
+}
+
+// Fixture is the response returned to the requests it matches.
+type Fixture struct {
+	// Method of the matched requests, any method matches when empty.
+	Method string `json:"method,omitempty"`
+
+	// URL is a regular expression matching the whole URL of the matched requests.
+	URL string `json:"url"`
+
+	// Response is returned to the matched requests.
+	Response Response `json:"response"`
+}
+
+// Response is a response returned by a fixture.
+type Response struct {
+	// StatusCode defaults to 200.
+	StatusCode int                 `json:"statusCode,omitempty"`
+	Headers    map[string][]string `json:"headers,omitempty"`
+	Body       string              `json:"body,omitempty"`
+}
+
+type fixture struct {
+	Fixture
+	url *regexp.Regexp
+}
+
+type client struct {
+	log      logging.Logger
+	fixtures []fixture
+}
+
+// Load reads the fixtures of the given YAML or JSON file.
+func Load(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- the path is supplied by the operator.
+	if err != nil {
+		return Fixtures{}, errors.Wrapf(err, errReadFixtures, path)
+	}
+
+	f := Fixtures{}
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return Fixtures{}, errors.Wrapf(err, errParseFixtures, path)
+	}
+
+	return f, nil
+}
+
+// NewClientFn returns a function creating clients answering with the given fixtures. It can be used in place of
+// httpClient.NewClient, the timeout and options are ignored.
+func NewClientFn(f Fixtures) (func(log logging.Logger, timeout time.Duration, opts ...httpClient.ClientOption) (httpClient.Client, error), error) {
+	fixtures := make([]fixture, 0, len(f.Fixtures))
+	for i, fx := range f.Fixtures {
+		url, err := regexp.Compile("^(?:" + fx.URL + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, errInvalidURL, i)
+		}
+		fixtures = append(fixtures, fixture{Fixture: fx, url: url})
+	}
+
+	return func(log logging.Logger, _ time.Duration, _ ...httpClient.ClientOption) (httpClient.Client, error) {
+		return &client{log: log, fixtures: fixtures}, nil
+	}, nil
+}
+
+// SendRequest answers with the response of the first fixture matching the request, or with a 404 response if
+// none matches.
+func (c *client) SendRequest(_ context.Context, method string, url string, body string, headers map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+	details := httpClient.HttpDetails{
+		HttpRequest: httpClient.HttpRequest{
+			Method:  method,
+			URL:     url,
+			Body:    body,
+			Headers: headers,
+		},
+	}
+
+	for _, fx := range c.fixtures {
+		if (fx.Method != "" && fx.Method != method) || !fx.url.MatchString(url) {
+			continue
+		}
+
+		statusCode := fx.Response.StatusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+
+		details.HttpResponse = httpClient.HttpResponse{
+			StatusCode: statusCode,
+			Headers:    fx.Response.Headers,
+			Body:       fx.Response.Body,
+		}
+		return details, nil
+	}
+
+	c.log.Info(fmt.Sprintf(errNoFixture, method, url))
+	details.HttpResponse = httpClient.HttpResponse{
+		StatusCode: http.StatusNotFound,
+		Body:       fmt.Sprintf(errNoFixture, method, url),
+	}
+	return details, nil
+}
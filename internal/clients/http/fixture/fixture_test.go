@@ -0,0 +1,119 @@
+package fixture
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+const testFixtures = `
+fixtures:
+- method: POST
+  url: https://api.example.com/users
+  response:
+    statusCode: 201
+    body: '{"id":"123"}'
+- method: GET
+  url: https://api.example.com/users/[0-9]+
+  response:
+    headers:
+      Content-Type:
+      - application/json
+    body: '{"id":"123","username":"john_doe"}'
+- url: https://api.example.com/users/.*
+  response:
+    statusCode: 204
+`
+
+func Test_SendRequest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.yaml")
+	if err := os.WriteFile(path, []byte(testFixtures), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fixtures, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load(...): unexpected error: %s", err)
+	}
+	newClient, err := NewClientFn(fixtures)
+	if err != nil {
+		t.Fatalf("NewClientFn(...): unexpected error: %s", err)
+	}
+	c, _ := newClient(logging.NewNopLogger(), time.Minute)
+
+	type args struct {
+		method string
+		url    string
+	}
+	type want struct {
+		response httpClient.HttpResponse
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Create": {
+			args: args{
+				method: http.MethodPost,
+				url:    "https://api.example.com/users",
+			},
+			want: want{
+				response: httpClient.HttpResponse{StatusCode: 201, Body: `{"id":"123"}`},
+			},
+		},
+		"DefaultStatusCode": {
+			args: args{
+				method: http.MethodGet,
+				url:    "https://api.example.com/users/123",
+			},
+			want: want{
+				response: httpClient.HttpResponse{
+					StatusCode: 200,
+					Headers:    map[string][]string{"Content-Type": {"application/json"}},
+					Body:       `{"id":"123","username":"john_doe"}`,
+				},
+			},
+		},
+		"AnyMethod": {
+			args: args{
+				method: http.MethodDelete,
+				url:    "https://api.example.com/users/123",
+			},
+			want: want{
+				response: httpClient.HttpResponse{StatusCode: 204},
+			},
+		},
+		"WholeURLMatched": {
+			args: args{
+				method: http.MethodPost,
+				url:    "https://api.example.com/users?dryRun=true",
+			},
+			want: want{
+				response: httpClient.HttpResponse{
+					StatusCode: 404,
+					Body:       "no fixture matches POST https://api.example.com/users?dryRun=true",
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := c.SendRequest(context.Background(), tc.args.method, tc.args.url, "", nil, false)
+			if err != nil {
+				t.Fatalf("SendRequest(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.response, got.HttpResponse); diff != "" {
+				t.Errorf("SendRequest(...): -want response, +got response: %s", diff)
+			}
+		})
+	}
+}
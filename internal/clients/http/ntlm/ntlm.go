@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ntlm implements the client side of NTLMv2 HTTP authentication, sent
+// with the NTLM or the Negotiate scheme.
+package ntlm
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5" // #nosec G501 -- NTLMv2 is defined with HMAC-MD5.
+	"crypto/rand"
+	"encoding/binary"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	flagUnicode                 = 0x00000001
+	flagRequestTarget           = 0x00000004
+	flagNTLM                    = 0x00000200
+	flagAlwaysSign              = 0x00008000
+	flagExtendedSessionSecurity = 0x00080000
+	flagTargetInfo              = 0x00800000
+	flag128                     = 0x20000000
+	flag56                      = 0x80000000
+
+	negotiateFlags = flagUnicode | flagRequestTarget | flagNTLM | flagAlwaysSign | flagExtendedSessionSecurity |
+		flagTargetInfo | flag128 | flag56
+
+	messageNegotiate    = 1
+	messageChallenge    = 2
+	messageAuthenticate = 3
+
+	avIDEOL       = 0
+	avIDTimestamp = 7
+
+	// windowsEpochOffset is the number of 100ns intervals between 1601-01-01 and 1970-01-01.
+	windowsEpochOffset = 116444736000000000
+
+	errInvalidChallenge = "invalid NTLM challenge message"
+)
+
+var signature = []byte("NTLMSSP\x00")
+
+// Credentials authenticate with NTLM.
+type Credentials struct {
+	Domain   string
+	Username string
+	Password string
+}
+
+// negotiateMessage returns the NEGOTIATE_MESSAGE opening the handshake.
+func negotiateMessage() []byte {
+	msg := append([]byte{}, signature...)
+	msg = binary.LittleEndian.AppendUint32(msg, messageNegotiate)
+	msg = binary.LittleEndian.AppendUint32(msg, negotiateFlags)
+	// Empty domain and workstation fields.
+	return append(msg, make([]byte, 16)...)
+}
+
+// challenge is a parsed CHALLENGE_MESSAGE.
+type challenge struct {
+	flags           uint32
+	serverChallenge []byte
+	targetInfo      []byte
+}
+
+// parseChallenge parses the CHALLENGE_MESSAGE sent by the server.
+func parseChallenge(msg []byte) (challenge, error) {
+	if len(msg) < 48 || !bytes.Equal(msg[:8], signature) || binary.LittleEndian.Uint32(msg[8:]) != messageChallenge {
+		return challenge{}, errors.New(errInvalidChallenge)
+	}
+
+	targetInfo, ok := field(msg, 40)
+	if !ok {
+		return challenge{}, errors.New(errInvalidChallenge)
+	}
+
+	return challenge{
+		flags:           binary.LittleEndian.Uint32(msg[20:]),
+		serverChallenge: msg[24:32],
+		targetInfo:      targetInfo,
+	}, nil
+}
+
+// field returns the payload referenced by the field descriptor at the given offset of a message.
+func field(msg []byte, offset int) ([]byte, bool) {
+	length := int(binary.LittleEndian.Uint16(msg[offset:]))
+	start := int(binary.LittleEndian.Uint32(msg[offset+4:]))
+	if start+length > len(msg) {
+		return nil, false
+	}
+
+	return msg[start : start+length], true
+}
+
+// authenticateMessage returns the AUTHENTICATE_MESSAGE answering the given challenge with NTLMv2 responses.
+func authenticateMessage(creds Credentials, c challenge, now time.Time) ([]byte, error) {
+	clientChallenge := make([]byte, 8)
+	if _, err := rand.Read(clientChallenge); err != nil {
+		return nil, err
+	}
+
+	timestamp, ok := avTimestamp(c.targetInfo)
+	if !ok {
+		timestamp = binary.LittleEndian.AppendUint64(nil, uint64(now.UnixNano()/100+windowsEpochOffset))
+	}
+
+	ntResponse, lmResponse := responses(ntowfv2(creds), c.serverChallenge, clientChallenge, timestamp, c.targetInfo)
+
+	payloads := [][]byte{
+		lmResponse,
+		ntResponse,
+		utf16le(creds.Domain),
+		utf16le(creds.Username),
+		nil, // workstation
+		nil, // encrypted random session key
+	}
+
+	const headerLength = 64
+	msg := append([]byte{}, signature...)
+	msg = binary.LittleEndian.AppendUint32(msg, messageAuthenticate)
+	offset := headerLength
+	for _, payload := range payloads {
+		msg = binary.LittleEndian.AppendUint16(msg, uint16(len(payload)))
+		msg = binary.LittleEndian.AppendUint16(msg, uint16(len(payload)))
+		msg = binary.LittleEndian.AppendUint32(msg, uint32(offset))
+		offset += len(payload)
+	}
+	msg = binary.LittleEndian.AppendUint32(msg, c.flags&negotiateFlags)
+	for _, payload := range payloads {
+		msg = append(msg, payload...)
+	}
+
+	return msg, nil
+}
+
+// responses returns the NTLMv2 and LMv2 responses to the given server challenge.
+func responses(key, serverChallenge, clientChallenge, timestamp, targetInfo []byte) ([]byte, []byte) {
+	temp := []byte{1, 1, 0, 0, 0, 0, 0, 0}
+	temp = append(temp, timestamp...)
+	temp = append(temp, clientChallenge...)
+	temp = append(temp, 0, 0, 0, 0)
+	temp = append(temp, targetInfo...)
+	temp = append(temp, 0, 0, 0, 0)
+
+	ntResponse := append(hmacMD5(key, serverChallenge, temp), temp...)
+	lmResponse := append(hmacMD5(key, serverChallenge, clientChallenge), clientChallenge...)
+	return ntResponse, lmResponse
+}
+
+// avTimestamp returns the MsvAvTimestamp pair of the given target info, which the client has to use
+// instead of its own clock.
+func avTimestamp(targetInfo []byte) ([]byte, bool) {
+	for len(targetInfo) >= 4 {
+		id := binary.LittleEndian.Uint16(targetInfo)
+		length := int(binary.LittleEndian.Uint16(targetInfo[2:]))
+		if id == avIDEOL || len(targetInfo) < 4+length {
+			break
+		}
+		if id == avIDTimestamp && length == 8 {
+			return targetInfo[4:12], true
+		}
+		targetInfo = targetInfo[4+length:]
+	}
+
+	return nil, false
+}
+
+// ntowfv2 returns the NTLMv2 hash of the given credentials.
+func ntowfv2(creds Credentials) []byte {
+	return hmacMD5(md4(utf16le(creds.Password)), utf16le(strings.ToUpper(creds.Username)+creds.Domain))
+}
+
+func hmacMD5(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(md5.New, key)
+	for _, d := range data {
+		mac.Write(d)
+	}
+
+	return mac.Sum(nil)
+}
+
+func utf16le(s string) []byte {
+	encoded := utf16.Encode([]rune(s))
+	b := make([]byte, 0, 2*len(encoded))
+	for _, u := range encoded {
+		b = binary.LittleEndian.AppendUint16(b, u)
+	}
+
+	return b
+}
@@ -0,0 +1,64 @@
+package ntlm
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("cannot decode %s: %s", s, err)
+	}
+	return b
+}
+
+func Test_md4(t *testing.T) {
+	cases := map[string]struct {
+		data string
+		want string
+	}{
+		"Empty": {
+			data: "",
+			want: "31d6cfe0d16ae931b73c59d7e0c089c0",
+		},
+		"Short": {
+			data: "abc",
+			want: "a448017aaf21d8525fc10ae87aa6729d",
+		},
+		"MultipleBlocks": {
+			data: "12345678901234567890123456789012345678901234567890123456789012345678901234567890",
+			want: "e33b4ddc9c38f2199c3e7b164fcc0536",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, hex.EncodeToString(md4([]byte(tc.data)))); diff != "" {
+				t.Errorf("md4(...): -want digest, +got digest: %s", diff)
+			}
+		})
+	}
+}
+
+// Test_responses checks the NTLMv2 test vectors of section 4.2.4 of MS-NLMP.
+func Test_responses(t *testing.T) {
+	key := ntowfv2(Credentials{Domain: "Domain", Username: "User", Password: "Password"})
+	if diff := cmp.Diff("0c868a403bfd7a93a3001ef22ef02e3f", hex.EncodeToString(key)); diff != "" {
+		t.Errorf("ntowfv2(...): -want key, +got key: %s", diff)
+	}
+
+	targetInfo := mustDecodeHex(t, "02000c0044006f006d00610069006e0001000c00530065007200760065007200"+"00000000")
+	ntResponse, lmResponse := responses(key, mustDecodeHex(t, "0123456789abcdef"), mustDecodeHex(t, "aaaaaaaaaaaaaaaa"), make([]byte, 8), targetInfo)
+
+	if diff := cmp.Diff("68cd0ab851e51c96aabc927bebef6a1c", hex.EncodeToString(ntResponse[:16])); diff != "" {
+		t.Errorf("responses(...): -want NTProofStr, +got NTProofStr: %s", diff)
+	}
+	if diff := cmp.Diff("86c35097ac9cec102554764a57cccc19aaaaaaaaaaaaaaaa", hex.EncodeToString(lmResponse)); diff != "" {
+		t.Errorf("responses(...): -want LMv2 response, +got LMv2 response: %s", diff)
+	}
+}
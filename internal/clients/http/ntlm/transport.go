@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ntlm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// SchemeNTLM sends the handshake with the NTLM authentication scheme.
+	SchemeNTLM = "NTLM"
+
+	// SchemeNegotiate sends the handshake with the Negotiate (SPNEGO) authentication scheme, which servers
+	// accept as NTLM when Kerberos is not used.
+	SchemeNegotiate = "Negotiate"
+
+	authorizationHeader  = "Authorization"
+	authenticateHeader   = "WWW-Authenticate"
+	maxDrainedBodyLength = 1 << 16
+)
+
+// A Transport authenticates requests with an NTLM handshake. Both legs of the handshake are sent on the same
+// connection, as the server authenticates connections rather than requests.
+type Transport struct {
+	Base        http.RoundTripper
+	Scheme      string
+	Credentials Credentials
+}
+
+// RoundTrip sends the request with an NTLM handshake, unless it sets an Authorization header explicitly.
+// The body of the request is buffered, so that it can be sent with both legs of the handshake.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get(authorizationHeader) != "" {
+		return t.Base.RoundTrip(req)
+	}
+
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.Base.RoundTrip(withAuthorization(req, t.Scheme, negotiateMessage(), body))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challengeMessage, ok := t.challenge(resp)
+	if !ok {
+		return resp, nil
+	}
+
+	c, err := parseChallenge(challengeMessage)
+	if err != nil {
+		return resp, nil
+	}
+
+	authenticate, err := authenticateMessage(t.Credentials, c, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// The connection is only reused for the second leg when the first response was read entirely.
+	_, _ = io.CopyN(io.Discard, resp.Body, maxDrainedBodyLength)
+	_ = resp.Body.Close()
+
+	return t.Base.RoundTrip(withAuthorization(req, t.Scheme, authenticate, body))
+}
+
+// challenge returns the challenge message of a response to a negotiate message.
+func (t *Transport) challenge(resp *http.Response) ([]byte, bool) {
+	prefix := t.Scheme + " "
+	for _, value := range resp.Header.Values(authenticateHeader) {
+		if len(value) <= len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+			continue
+		}
+
+		msg, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[len(prefix):]))
+		if err == nil {
+			return msg, true
+		}
+	}
+
+	return nil, false
+}
+
+// readBody returns the body of the given request.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	defer func() { _ = req.Body.Close() }()
+	return io.ReadAll(req.Body)
+}
+
+// withAuthorization returns a copy of the given request with the given message in its Authorization header.
+func withAuthorization(req *http.Request, scheme string, msg, body []byte) *http.Request {
+	r := req.Clone(req.Context())
+	r.Header.Set(authorizationHeader, scheme+" "+base64.StdEncoding.EncodeToString(msg))
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	r.TransferEncoding = nil
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	if body == nil {
+		r.Body = http.NoBody
+	}
+
+	return r
+}
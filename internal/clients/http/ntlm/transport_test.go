@@ -0,0 +1,130 @@
+package ntlm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// newServer returns a server that requires an NTLM handshake with the given credentials on a single
+// connection, and echoes the body of authenticated requests.
+func newServer(t *testing.T, scheme string, creds Credentials) *httptest.Server {
+	t.Helper()
+
+	serverChallenge := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	challenged := map[string]bool{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), scheme+" ")
+		msg, err := base64.StdEncoding.DecodeString(token)
+		if err != nil || len(msg) < 12 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch binary.LittleEndian.Uint32(msg[8:]) {
+		case messageNegotiate:
+			challenged[r.RemoteAddr] = true
+			challengeMsg := append([]byte{}, signature...)
+			challengeMsg = binary.LittleEndian.AppendUint32(challengeMsg, messageChallenge)
+			challengeMsg = append(challengeMsg, make([]byte, 8)...) // target name
+			challengeMsg = binary.LittleEndian.AppendUint32(challengeMsg, negotiateFlags)
+			challengeMsg = append(challengeMsg, serverChallenge...)
+			challengeMsg = append(challengeMsg, make([]byte, 8)...) // reserved
+			challengeMsg = append(challengeMsg, 4, 0, 4, 0, 48, 0, 0, 0)
+			challengeMsg = append(challengeMsg, 0, 0, 0, 0) // MsvAvEOL
+			w.Header().Set("WWW-Authenticate", scheme+" "+base64.StdEncoding.EncodeToString(challengeMsg))
+			w.WriteHeader(http.StatusUnauthorized)
+		case messageAuthenticate:
+			ntResponse, _ := field(msg, 20)
+			user, _ := field(msg, 36)
+			temp := ntResponse[16:]
+			expected := hmacMD5(ntowfv2(creds), serverChallenge, temp)
+			if !challenged[r.RemoteAddr] || !bytes.Equal(user, utf16le(creds.Username)) || !bytes.Equal(ntResponse[:16], expected) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write(body)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+}
+
+func Test_Transport(t *testing.T) {
+	creds := Credentials{Domain: "CORP", Username: "john_doe", Password: "hunter2"}
+
+	type args struct {
+		scheme string
+		creds  Credentials
+		body   string
+	}
+	type want struct {
+		statusCode int
+		body       string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NTLM": {
+			args: args{
+				scheme: SchemeNTLM,
+				creds:  creds,
+				body:   `{"name":"john_doe"}`,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+				body:       `{"name":"john_doe"}`,
+			},
+		},
+		"Negotiate": {
+			args: args{
+				scheme: SchemeNegotiate,
+				creds:  creds,
+			},
+			want: want{
+				statusCode: http.StatusOK,
+			},
+		},
+		"WrongPassword": {
+			args: args{
+				scheme: SchemeNTLM,
+				creds:  Credentials{Domain: "CORP", Username: "john_doe", Password: "wrong"},
+			},
+			want: want{
+				statusCode: http.StatusUnauthorized,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			server := newServer(t, tc.args.scheme, creds)
+			defer server.Close()
+
+			client := &http.Client{Transport: &Transport{Base: http.DefaultTransport, Scheme: tc.args.scheme, Credentials: tc.args.creds}}
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader(tc.args.body))
+			if err != nil {
+				t.Fatalf("Post(...): unexpected error: %s", err)
+			}
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(resp.Body)
+			if diff := cmp.Diff(tc.want.statusCode, resp.StatusCode); diff != "" {
+				t.Errorf("Post(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, string(body)); diff != "" {
+				t.Errorf("Post(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
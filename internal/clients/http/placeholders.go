@@ -0,0 +1,47 @@
+package http
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+const errResolvePlaceholder = "cannot resolve placeholder of vault secret %s key %s"
+
+// placeholderPattern matches the {{ vault:<path>#<key> }} placeholders of Vault secrets.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*vault:([^#{}\s]+)#([^{}\s]+)\s*\}\}`)
+
+// A SecretResolver returns the value of the given key of the Vault secret at the given path.
+type SecretResolver func(ctx context.Context, path, key string) (string, error)
+
+// ContainsPlaceholder checks whether the given string holds a Vault secret placeholder.
+func ContainsPlaceholder(s string) bool {
+	return placeholderPattern.MatchString(s)
+}
+
+// resolvePlaceholders replaces the Vault secret placeholders of the given string with the values of the
+// secrets. Strings without placeholders are returned as is.
+func (hc *client) resolvePlaceholders(ctx context.Context, s string) (string, error) {
+	if hc.secrets == nil || !ContainsPlaceholder(s) {
+		return s, nil
+	}
+
+	var err error
+	resolved := placeholderPattern.ReplaceAllStringFunc(s, func(placeholder string) string {
+		if err != nil {
+			return placeholder
+		}
+
+		match := placeholderPattern.FindStringSubmatch(placeholder)
+		value, resolveErr := hc.secrets(ctx, match[1], match[2])
+		if resolveErr != nil {
+			err = errors.Wrapf(resolveErr, errResolvePlaceholder, match[1], match[2])
+			return placeholder
+		}
+
+		return value
+	})
+
+	return resolved, err
+}
@@ -0,0 +1,122 @@
+package http
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMaxResponseBytes is the number of bytes of streamed response bodies after which reading stops by
+	// default.
+	DefaultMaxResponseBytes = 1 << 20
+
+	errInvalidRecord = "record %d of the response stream is not JSON"
+	errStreamUntil   = "cannot evaluate the end of the response stream"
+)
+
+// ndjsonContentTypes are the media types of newline delimited JSON streams.
+var ndjsonContentTypes = map[string]bool{
+	"application/x-ndjson":      true,
+	"application/ndjson":        true,
+	"application/jsonl":         true,
+	"application/jsonlines":     true,
+	"application/x-jsonlines":   true,
+	"application/stream+json":   true,
+	"application/json-stream":   true,
+	"application/x-json-stream": true,
+}
+
+// ResponseStream bounds how much of response bodies is read, for endpoints that return NDJSON or chunked streams.
+type ResponseStream struct {
+	// MaxBytes is the number of bytes after which reading stops, at most the response limit of the client. The
+	// limit of the client, or DefaultMaxResponseBytes without limit, is used when it is not positive.
+	MaxBytes int64
+
+	// MaxRecords is the number of NDJSON records after which reading stops, when positive.
+	MaxRecords int
+
+	// Until reports whether reading stops after the given NDJSON record, when set.
+	Until func(record []byte) (bool, error)
+}
+
+// WithResponseStream makes the client read response bodies within the bounds of the given stream, instead of until
+// the server closes them. NDJSON records are collected into a JSON array, which is returned as the response body.
+func WithResponseStream(stream ResponseStream) ClientOption {
+	return func(c *client) {
+		c.stream = &stream
+	}
+}
+
+// isNDJSON reports whether the given Content-Type is the one of a newline delimited JSON stream.
+func isNDJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && ndjsonContentTypes[mediaType]
+}
+
+// read reads the given response body within the bounds of the stream and the given limit, when positive. NDJSON
+// records are collected into a JSON array, and a record cut by the byte limit is dropped.
+func (s *ResponseStream) read(body io.Reader, headers http.Header, limit int64) ([]byte, error) {
+	maxBytes := s.MaxBytes
+	if limit > 0 && (maxBytes <= 0 || maxBytes > limit) {
+		maxBytes = limit
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxResponseBytes
+	}
+	limited := &io.LimitedReader{R: body, N: maxBytes}
+
+	if !isNDJSON(headers.Get("Content-Type")) {
+		return io.ReadAll(limited)
+	}
+
+	records := []json.RawMessage{}
+	r := bufio.NewReader(limited)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		end := err == io.EOF
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if !json.Valid(line) {
+				if end && limited.N == 0 {
+					break
+				}
+				return nil, errors.Errorf(errInvalidRecord, len(records)+1)
+			}
+			records = append(records, line)
+
+			done, err := s.done(line, len(records))
+			if err != nil {
+				return nil, err
+			}
+			end = end || done
+		}
+
+		if end {
+			break
+		}
+	}
+
+	return json.Marshal(records)
+}
+
+// done reports whether reading stops after the given record, the count-th of the stream.
+func (s *ResponseStream) done(record []byte, count int) (bool, error) {
+	if s.MaxRecords > 0 && count >= s.MaxRecords {
+		return true, nil
+	}
+	if s.Until == nil {
+		return false, nil
+	}
+
+	done, err := s.Until(record)
+	return done, errors.Wrap(err, errStreamUntil)
+}
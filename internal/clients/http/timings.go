@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Timings break down the duration of a request. The phases that did not happen, e.g. the TLS handshake of a plain
+// HTTP request, are zero.
+type Timings struct {
+	// DNS is the duration of the lookup of the host.
+	DNS time.Duration
+
+	// Connect is the duration of the connection to the server.
+	Connect time.Duration
+
+	// TLS is the duration of the TLS handshake.
+	TLS time.Duration
+
+	// FirstByte is the duration between the request being written and the first byte of the response, i.e. how
+	// long the server took to answer.
+	FirstByte time.Duration
+
+	// Total is the duration of the whole request, including reading its response body.
+	Total time.Duration
+}
+
+// A timer records the timings of a request through the hooks of its context.
+type timer struct {
+	mu    sync.Mutex
+	start time.Time
+
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	wroteRequest, firstByte   time.Time
+}
+
+// newTimer returns a timer started now, and the given context with its hooks.
+func newTimer(ctx context.Context) (context.Context, *timer) {
+	t := &timer{start: time.Now()}
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { t.mark(&t.dnsDone) },
+		ConnectStart:         func(string, string) { t.mark(&t.connectStart) },
+		ConnectDone:          func(string, string, error) { t.mark(&t.connectDone) },
+		TLSHandshakeStart:    func() { t.mark(&t.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { t.mark(&t.tlsDone) },
+		WroteRequest:         func(httptrace.WroteRequestInfo) { t.mark(&t.wroteRequest) },
+		GotFirstResponseByte: func() { t.mark(&t.firstByte) },
+	}), t
+}
+
+// mark records the current time in the given field, unless it is already set: only the first connection attempt
+// and the first request of redirects are timed.
+func (t *timer) mark(at *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if at.IsZero() {
+		*at = time.Now()
+	}
+}
+
+// timings returns the timings recorded so far, with the total duration until now.
+func (t *timer) timings() *Timings {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return &Timings{
+		DNS:       between(t.dnsStart, t.dnsDone),
+		Connect:   between(t.connectStart, t.connectDone),
+		TLS:       between(t.tlsStart, t.tlsDone),
+		FirstByte: between(t.wroteRequest, t.firstByte),
+		Total:     time.Since(t.start),
+	}
+}
+
+// between returns the duration between the given times, zero when either did not happen.
+func between(start, end time.Time) time.Duration {
+	if start.IsZero() || end.IsZero() {
+		return 0
+	}
+	return end.Sub(start)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit shares the rate and concurrency limits of the ProviderConfigs between the resources using them.
+package ratelimit
+
+import (
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+// Limiters holds a token bucket and host semaphores per ProviderConfig, shared by the clients of all the resources
+// using it, so that their requests are limited together.
+type Limiters struct {
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+
+	hosts *httpClient.HostSemaphores
+}
+
+// NewLimiters returns empty Limiters.
+func NewLimiters() *Limiters {
+	return &Limiters{limiters: map[string]*rate.Limiter{}, hosts: httpClient.NewHostSemaphores()}
+}
+
+// ClientOptions returns the client options limiting the requests of the given ProviderConfig to its rate limit
+// and to its number of concurrent requests per host. None are returned when the ProviderConfig has neither, or
+// when l is nil.
+func (l *Limiters) ClientOptions(pc *apisv1alpha1.ProviderConfig) []httpClient.ClientOption {
+	if l == nil {
+		return nil
+	}
+
+	var opts []httpClient.ClientOption
+	if limiter := l.limiter(pc); limiter != nil {
+		opts = append(opts, httpClient.WithRateLimiter(limiter))
+	}
+	if maxPerHost := pc.Spec.MaxConcurrentRequestsPerHost; maxPerHost > 0 {
+		opts = append(opts, httpClient.WithHostConcurrency(l.hosts, pc.Name, int(maxPerHost)))
+	}
+
+	return opts
+}
+
+// limiter returns the token bucket of the given ProviderConfig, nil when it has no rate limit. Changes of the rate
+// limit apply to the shared token bucket, without resetting it.
+func (l *Limiters) limiter(pc *apisv1alpha1.ProviderConfig) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	config := pc.Spec.RateLimit
+	if config == nil {
+		delete(l.limiters, pc.Name)
+		return nil
+	}
+
+	limit, burst := rate.Limit(config.RequestsPerSecond), int(config.Burst)
+	if burst <= 0 {
+		burst = int(config.RequestsPerSecond)
+	}
+
+	limiter, ok := l.limiters[pc.Name]
+	if !ok {
+		limiter = rate.NewLimiter(limit, burst)
+		l.limiters[pc.Name] = limiter
+	}
+	if limiter.Limit() != limit {
+		limiter.SetLimit(limit)
+	}
+	if limiter.Burst() != burst {
+		limiter.SetBurst(burst)
+	}
+
+	return limiter
+}
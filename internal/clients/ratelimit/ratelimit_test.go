@@ -0,0 +1,131 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func providerConfig(name string, rateLimit *apisv1alpha1.RateLimit) *apisv1alpha1.ProviderConfig {
+	return &apisv1alpha1.ProviderConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       apisv1alpha1.ProviderConfigSpec{RateLimit: rateLimit},
+	}
+}
+
+func Test_ClientOptions(t *testing.T) {
+	type want struct {
+		options int
+		limit   rate.Limit
+		burst   int
+	}
+	cases := map[string]struct {
+		limiters *Limiters
+		pc       *apisv1alpha1.ProviderConfig
+		want     want
+	}{
+		"NilLimiters": {
+			pc: providerConfig("pc", &apisv1alpha1.RateLimit{RequestsPerSecond: 5}),
+		},
+		"NoRateLimit": {
+			limiters: NewLimiters(),
+			pc:       providerConfig("pc", nil),
+		},
+		"DefaultBurst": {
+			limiters: NewLimiters(),
+			pc:       providerConfig("pc", &apisv1alpha1.RateLimit{RequestsPerSecond: 5}),
+			want:     want{options: 1, limit: 5, burst: 5},
+		},
+		"Burst": {
+			limiters: NewLimiters(),
+			pc:       providerConfig("pc", &apisv1alpha1.RateLimit{RequestsPerSecond: 5, Burst: 20}),
+			want:     want{options: 1, limit: 5, burst: 20},
+		},
+		"MaxConcurrentRequestsPerHost": {
+			limiters: NewLimiters(),
+			pc: &apisv1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "pc"},
+				Spec:       apisv1alpha1.ProviderConfigSpec{MaxConcurrentRequestsPerHost: 4},
+			},
+			want: want{options: 1},
+		},
+		"RateLimitAndMaxConcurrentRequestsPerHost": {
+			limiters: NewLimiters(),
+			pc: &apisv1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "pc"},
+				Spec: apisv1alpha1.ProviderConfigSpec{
+					RateLimit:                    &apisv1alpha1.RateLimit{RequestsPerSecond: 5},
+					MaxConcurrentRequestsPerHost: 4,
+				},
+			},
+			want: want{options: 2, limit: 5, burst: 5},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := tc.limiters.ClientOptions(tc.pc)
+			if diff := cmp.Diff(tc.want.options, len(got)); diff != "" {
+				t.Fatalf("ClientOptions(...): -want options, +got options: %s", diff)
+			}
+			if tc.pc.Spec.RateLimit == nil || tc.limiters == nil {
+				return
+			}
+
+			limiter := tc.limiters.limiters[tc.pc.Name]
+			if diff := cmp.Diff(tc.want.limit, limiter.Limit()); diff != "" {
+				t.Errorf("ClientOptions(...): -want limit, +got limit: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.burst, limiter.Burst()); diff != "" {
+				t.Errorf("ClientOptions(...): -want burst, +got burst: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ClientOptionsShared(t *testing.T) {
+	l := NewLimiters()
+	l.ClientOptions(providerConfig("pc", &apisv1alpha1.RateLimit{RequestsPerSecond: 5}))
+	shared := l.limiters["pc"]
+
+	l.ClientOptions(providerConfig("other", &apisv1alpha1.RateLimit{RequestsPerSecond: 5}))
+	if l.limiters["other"] == shared {
+		t.Errorf("ClientOptions(...): ProviderConfigs share a limiter")
+	}
+
+	l.ClientOptions(providerConfig("pc", &apisv1alpha1.RateLimit{RequestsPerSecond: 10, Burst: 1}))
+	if l.limiters["pc"] != shared {
+		t.Fatalf("ClientOptions(...): limiter of the ProviderConfig replaced")
+	}
+	if diff := cmp.Diff(rate.Limit(10), shared.Limit()); diff != "" {
+		t.Errorf("ClientOptions(...): -want updated limit, +got limit: %s", diff)
+	}
+	if diff := cmp.Diff(1, shared.Burst()); diff != "" {
+		t.Errorf("ClientOptions(...): -want updated burst, +got burst: %s", diff)
+	}
+
+	l.ClientOptions(providerConfig("pc", nil))
+	if _, ok := l.limiters["pc"]; ok {
+		t.Errorf("ClientOptions(...): limiter of the ProviderConfig kept once its rate limit is removed")
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vault reads secrets from HashiCorp Vault.
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+const (
+	tokenHeader     = "X-Vault-Token"
+	namespaceHeader = "X-Vault-Namespace"
+
+	errGetToken    = "cannot get Vault token"
+	errRead        = "cannot read Vault secret %s"
+	errStatusCode  = "Vault responded with status code %d"
+	errMissingKey  = "Vault secret %s has no key %s"
+	errLogin       = "cannot log in to Vault"
+	errEmptyToken  = "Vault login response holds no client token"
+	errEncodeValue = "cannot encode value of key %s of Vault secret %s"
+)
+
+// A Client reads secrets from Vault with the tokens of a token source.
+type Client struct {
+	address   string
+	namespace string
+	http      *http.Client
+	tokens    oauth2.TokenSource
+}
+
+// NewClient returns a Client reading secrets from the Vault server at the given address, in the given
+// namespace when it is not empty.
+func NewClient(address, namespace string, httpClient *http.Client, tokens oauth2.TokenSource) *Client {
+	return &Client{address: strings.TrimSuffix(address, "/"), namespace: namespace, http: httpClient, tokens: tokens}
+}
+
+// Read returns the value of the given key of the secret at the given path, e.g. secret/data/api for a
+// secret of a KV version 2 engine mounted at secret. Values that are not strings are returned as JSON.
+func (c *Client) Read(ctx context.Context, path, key string) (string, error) {
+	token, err := c.tokens.Token()
+	if err != nil {
+		return "", errors.Wrap(err, errGetToken)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.address+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", errors.Wrapf(err, errRead, path)
+	}
+	request.Header.Set(tokenHeader, token.AccessToken)
+
+	var result struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := send(c.http, c.namespace, request, &result); err != nil {
+		return "", errors.Wrapf(err, errRead, path)
+	}
+
+	data := result.Data
+	// KV version 2 engines nest the secret data next to its metadata.
+	if nested, ok := data["data"].(map[string]interface{}); ok && data["metadata"] != nil {
+		data = nested
+	}
+
+	value, ok := data[key]
+	if !ok {
+		return "", errors.Errorf(errMissingKey, path, key)
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", errors.Wrapf(err, errEncodeValue, key, path)
+	}
+
+	return string(encoded), nil
+}
+
+// KubernetesLogin logs in with the Kubernetes auth method mounted at the given path, exchanging the given service
+// account token for a Vault token of the given role.
+func KubernetesLogin(ctx context.Context, httpClient *http.Client, address, namespace, mountPath, role, jwt string) (*oauth2.Token, error) {
+	body, err := json.Marshal(map[string]string{"role": role, "jwt": jwt})
+	if err != nil {
+		return nil, errors.Wrap(err, errLogin)
+	}
+
+	url := strings.TrimSuffix(address, "/") + "/v1/auth/" + strings.Trim(mountPath, "/") + "/login"
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, errLogin)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := send(httpClient, namespace, request, &result); err != nil {
+		return nil, errors.Wrap(err, errLogin)
+	}
+
+	if result.Auth.ClientToken == "" {
+		return nil, errors.New(errEmptyToken)
+	}
+
+	token := &oauth2.Token{AccessToken: result.Auth.ClientToken}
+	if result.Auth.LeaseDuration > 0 {
+		token.Expiry = time.Now().Add(time.Duration(result.Auth.LeaseDuration) * time.Second)
+	}
+
+	return token, nil
+}
+
+// send sends the given request in the given namespace and decodes the JSON response into the given value.
+func send(httpClient *http.Client, namespace string, request *http.Request, v interface{}) error {
+	if namespace != "" {
+		request.Header.Set(namespaceHeader, namespace)
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return errors.Errorf(errStatusCode, response.StatusCode)
+	}
+
+	return json.Unmarshal(body, v)
+}
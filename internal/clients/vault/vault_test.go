@@ -0,0 +1,104 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/oauth2"
+)
+
+func Test_ClientRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(tokenHeader) != "s.token" || r.Header.Get(namespaceHeader) != "team" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/kv/api":
+			_, _ = w.Write([]byte(`{"data":{"token":"v1-token"}}`))
+		case "/v1/secret/data/api":
+			_, _ = w.Write([]byte(`{"data":{"data":{"token":"v2-token","ports":[80,443]},"metadata":{"version":3}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL+"/", "team", server.Client(), oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "s.token"}))
+
+	type want struct {
+		value string
+		err   bool
+	}
+	cases := map[string]struct {
+		path string
+		key  string
+		want want
+	}{
+		"KVVersion1": {
+			path: "kv/api",
+			key:  "token",
+			want: want{value: "v1-token"},
+		},
+		"KVVersion2": {
+			path: "secret/data/api",
+			key:  "token",
+			want: want{value: "v2-token"},
+		},
+		"NonStringValue": {
+			path: "secret/data/api",
+			key:  "ports",
+			want: want{value: "[80,443]"},
+		},
+		"MissingKey": {
+			path: "secret/data/api",
+			key:  "password",
+			want: want{err: true},
+		},
+		"MissingSecret": {
+			path: "secret/data/other",
+			key:  "token",
+			want: want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := c.Read(context.Background(), tc.path, tc.key)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("Read(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Errorf("Read(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_KubernetesLogin(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if r.URL.Path != "/v1/auth/k8s/login" || json.NewDecoder(r.Body).Decode(&body) != nil || body["role"] != "provider-http" || body["jwt"] != "sa-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"auth":{"client_token":"s.token","lease_duration":3600}}`))
+	}))
+	defer server.Close()
+
+	got, err := KubernetesLogin(context.Background(), server.Client(), server.URL, "", "/k8s/", "provider-http", "sa-token")
+	if err != nil {
+		t.Fatalf("KubernetesLogin(...): unexpected error: %s", err)
+	}
+	if diff := cmp.Diff("s.token", got.AccessToken); diff != "" {
+		t.Errorf("KubernetesLogin(...): -want token, +got token: %s", diff)
+	}
+	if got.Expiry.IsZero() {
+		t.Errorf("KubernetesLogin(...): expected the token to expire with its lease")
+	}
+}
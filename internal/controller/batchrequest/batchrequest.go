@@ -0,0 +1,441 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/arielsepton/provider-http/apis/batchrequest/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/options"
+	"github.com/arielsepton/provider-http/internal/controller/requeue"
+	"github.com/arielsepton/provider-http/internal/controller/timestamps"
+	"github.com/arielsepton/provider-http/internal/jq"
+	json_util "github.com/arielsepton/provider-http/internal/json"
+	"github.com/arielsepton/provider-http/internal/tracing"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	defaultIDPath   = ".id"
+	defaultListPath = "."
+
+	errNotBatchRequest = "managed resource is not a BatchRequest custom resource"
+	errInvalidBaseURL  = "base URL is not a valid URL"
+	errInvalidItem     = "item %d is not a JSON object"
+	errItemKey         = "cannot extract key of item %d with %s"
+	errDuplicateKey    = "items %d and %d have the same key %s"
+	errList            = "cannot list items"
+	errListBody        = "cannot extract items from list response with %s"
+	errRemoteItem      = "cannot extract key and ID of remote item %d"
+	errNoValue         = "%s returned no value"
+	errCreateItem      = "cannot create item %s"
+	errUpdateItem      = "cannot update item %s"
+	errDeleteItem      = "cannot delete item %s"
+)
+
+// Setup adds a controller that reconciles BatchRequest managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
+	name := managed.ControllerName(v1alpha1.BatchRequestGroupKind)
+	cps := options.ConnectionPublishers(mgr.GetClient(), mgr.GetScheme(), o)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.BatchRequestGroupVersionKind),
+		managed.WithExternalConnecter(timestamps.NewConnecter(&connector{
+			Clients: opts.Clients(mgr.GetClient()),
+			logger:  o.Logger,
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(opts.Timeout),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	schedules := []requeue.ScheduleFn{
+		requeue.PollJitter(o.PollInterval, opts.PollJitter),
+		requeue.PollInterval(o.PollInterval, opts.PollJitter, requeue.ResourcePollInterval(mgr.GetClient())),
+		opts.LoadShedder(name).Schedule,
+	}
+	requeuer := requeue.NewReconciler(r, mgr.GetClient(), newBatchRequest, schedules...)
+	traced := tracing.NewReconciler(name, requeuer)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(opts.ForControllerRuntime(o, v1alpha1.BatchRequestKind)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.BatchRequest{}).
+		Complete(ratelimiter.NewReconciler(name, traced, o.GlobalRateLimiter))
+}
+
+func newBatchRequest() resource.Managed {
+	return &v1alpha1.BatchRequest{}
+}
+
+type connector struct {
+	options.Clients
+	logger logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.BatchRequest)
+	if !ok {
+		return nil, errors.New(errNotBatchRequest)
+	}
+
+	l := c.logger.WithValues("batchRequest", cr.Name)
+
+	h, err := c.NewClient(ctx, l, cr, options.ClientParameters{
+		Kind:                  v1alpha1.BatchRequestKind,
+		Auth:                  cr.Spec.ForProvider.Auth,
+		WaitTimeout:           cr.Spec.ForProvider.WaitTimeout,
+		InsecureSkipTLSVerify: cr.Spec.ForProvider.InsecureSkipTLSVerify,
+		TLS:                   cr.Spec.ForProvider.TLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		logger:        l,
+		http:          h.Client,
+		statusCodes:   utils.StatusCodePolicy(cr.Spec.ForProvider.StatusCodes, h.ProviderConfig.Spec.StatusCodes),
+		skipTLSVerify: h.SkipTLSVerify,
+		correlationID: h.CorrelationID,
+	}, nil
+}
+
+type external struct {
+	logger      logging.Logger
+	http        httpClient.Client
+	statusCodes *apisv1alpha1.StatusCodePolicy
+
+	// skipTLSVerify skips TLS certificate checks, when set by the resource or its ProviderConfig.
+	skipTLSVerify bool
+
+	// correlationID is the value of the correlation header set on the requests, recorded in the status.
+	correlationID string
+}
+
+// item is a desired item of a BatchRequest.
+type item struct {
+	key    string
+	body   string
+	fields map[string]interface{}
+}
+
+// plan is what it takes to bring the remote collection to the desired items.
+type plan struct {
+	// managed are the remote items that are managed by the BatchRequest.
+	managed []v1alpha1.ItemStatus
+
+	create []item
+	update map[string]item
+	delete []v1alpha1.ItemStatus
+}
+
+func (p plan) upToDate() bool {
+	return len(p.create) == 0 && len(p.update) == 0 && len(p.delete) == 0
+}
+
+// collectionURL returns the URL of the collection of the items.
+func collectionURL(params v1alpha1.BatchRequestParameters) string {
+	return strings.TrimSuffix(params.BaseURL, "/") + "/" + strings.Trim(params.Path, "/")
+}
+
+// itemURL returns the URL of the item with the given ID.
+func itemURL(params v1alpha1.BatchRequestParameters, id string) string {
+	return collectionURL(params) + "/" + url.PathEscape(id)
+}
+
+// extract returns the result of the given jq filter for the given item as a string.
+func extract(filter string, fields map[string]interface{}) (string, error) {
+	value, err := jq.ParseString(filter+" | tostring", fields)
+	if err != nil {
+		return "", err
+	}
+	if value == "" || value == "null" {
+		return "", errors.Errorf(errNoValue, filter)
+	}
+
+	return value, nil
+}
+
+// desiredItems returns the desired items of the BatchRequest, which must have distinct keys.
+func desiredItems(params v1alpha1.BatchRequestParameters) ([]item, error) {
+	items := make([]item, 0, len(params.Items))
+	indexes := map[string]int{}
+	for i, body := range params.Items {
+		if !json_util.IsJSONString(body) {
+			return nil, errors.Errorf(errInvalidItem, i)
+		}
+
+		fields := json_util.JsonStringToMap(body)
+		key, err := extract(params.KeyPath, fields)
+		if err != nil {
+			return nil, errors.Wrapf(err, errItemKey, i, params.KeyPath)
+		}
+
+		if j, ok := indexes[key]; ok {
+			return nil, errors.Errorf(errDuplicateKey, j, i, key)
+		}
+		indexes[key] = i
+
+		items = append(items, item{key: key, body: body, fields: fields})
+	}
+
+	return items, nil
+}
+
+// isUpToDate checks whether the remote representation of an item holds the fields of its desired one. Fields
+// holding Vault secret placeholders are ignored, as their values are not known.
+func isUpToDate(desired, remote map[string]interface{}) bool {
+	fields := make(map[string]interface{}, len(desired))
+	for key, value := range desired {
+		if !httpClient.ContainsPlaceholder(fmt.Sprint(value)) {
+			fields[key] = value
+		}
+	}
+
+	return json_util.Contains(remote, fields)
+}
+
+// send sends a request for the items and records the reason it failed in the status of the BatchRequest.
+// Responses with one of the given tolerated status codes are not failures.
+func (c *external) send(ctx context.Context, cr *v1alpha1.BatchRequest, method, url, body string, tolerated ...int) (httpClient.HttpResponse, error) {
+	details, err := c.http.SendRequest(ctx, method, url, body, cr.Spec.ForProvider.Headers, c.skipTLSVerify)
+	cr.SetTimings(utils.Timings(details.Timings))
+	cr.SetCorrelationID(c.correlationID)
+	res := details.HttpResponse
+	if err != nil {
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, err), err)
+		return res, err
+	}
+
+	for _, code := range tolerated {
+		if res.StatusCode == code {
+			return res, nil
+		}
+	}
+
+	if utils.IsFailure(c.statusCodes, res.StatusCode) {
+		err := errors.Errorf(utils.ErrStatusCode, method, strconv.Itoa(res.StatusCode))
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, nil), err)
+		return res, err
+	}
+
+	return res, nil
+}
+
+// list returns the remote items of the collection, by key.
+func (c *external) list(ctx context.Context, cr *v1alpha1.BatchRequest) (map[string]v1alpha1.ItemStatus, map[string]map[string]interface{}, error) {
+	res, err := c.send(ctx, cr, http.MethodGet, collectionURL(cr.Spec.ForProvider), "")
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errList)
+	}
+
+	listPath := cr.Spec.ForProvider.ListPath
+	if listPath == "" {
+		listPath = defaultListPath
+	}
+	idPath := cr.Spec.ForProvider.IDPath
+	if idPath == "" {
+		idPath = defaultIDPath
+	}
+
+	var body interface{}
+	if err := json.Unmarshal([]byte(res.Body), &body); err != nil {
+		return nil, nil, errors.Wrapf(err, errListBody, listPath)
+	}
+
+	remote, err := jq.ParseArray(listPath, body)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, errListBody, listPath)
+	}
+
+	items := make(map[string]v1alpha1.ItemStatus, len(remote))
+	fields := make(map[string]map[string]interface{}, len(remote))
+	for i, r := range remote {
+		f, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, nil, errors.Errorf(errRemoteItem, i)
+		}
+
+		key, err := extract(cr.Spec.ForProvider.KeyPath, f)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errRemoteItem, i)
+		}
+		id, err := extract(idPath, f)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, errRemoteItem, i)
+		}
+
+		items[key] = v1alpha1.ItemStatus{Key: key, ID: id}
+		fields[key] = f
+	}
+
+	return items, fields, nil
+}
+
+// plan diffs the remote items of the collection against the desired items. Remote items that were managed
+// and are no longer desired are deleted.
+func (c *external) plan(ctx context.Context, cr *v1alpha1.BatchRequest) (plan, error) {
+	desired, err := desiredItems(cr.Spec.ForProvider)
+	if err != nil {
+		return plan{}, err
+	}
+
+	remote, fields, err := c.list(ctx, cr)
+	if err != nil {
+		return plan{}, err
+	}
+
+	p := plan{update: map[string]item{}}
+	isDesired := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		isDesired[d.key] = true
+
+		r, ok := remote[d.key]
+		if !ok {
+			p.create = append(p.create, d)
+			continue
+		}
+
+		p.managed = append(p.managed, r)
+		if !isUpToDate(d.fields, fields[d.key]) {
+			p.update[r.ID] = d
+		}
+	}
+
+	for _, previous := range cr.Status.Items {
+		if r, ok := remote[previous.Key]; ok && !isDesired[previous.Key] {
+			p.managed = append(p.managed, r)
+			p.delete = append(p.delete, r)
+		}
+	}
+
+	return p, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BatchRequest)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBatchRequest)
+	}
+
+	p, err := c.plan(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.Items = p.managed
+	cr.SetError("", nil)
+
+	// A BatchRequest without items exists as long as it is not deleted, so that it is not created over and over.
+	exists := len(p.managed) > 0 || (len(cr.Spec.ForProvider.Items) == 0 && !meta.WasDeleted(cr))
+	if exists {
+		cr.Status.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   exists,
+		ResourceUpToDate: p.upToDate(),
+	}, nil
+}
+
+// sync creates the missing items, updates the drifted ones and deletes the removed ones.
+func (c *external) sync(ctx context.Context, cr *v1alpha1.BatchRequest) error {
+	if !utils.IsUrlValid(cr.Spec.ForProvider.BaseURL) {
+		return errors.New(errInvalidBaseURL)
+	}
+
+	p, err := c.plan(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range p.create {
+		if _, err := c.send(ctx, cr, http.MethodPost, collectionURL(cr.Spec.ForProvider), d.body); err != nil {
+			return errors.Wrapf(err, errCreateItem, d.key)
+		}
+	}
+
+	for id, d := range p.update {
+		if _, err := c.send(ctx, cr, http.MethodPut, itemURL(cr.Spec.ForProvider, id), d.body); err != nil {
+			return errors.Wrapf(err, errUpdateItem, d.key)
+		}
+	}
+
+	for _, r := range p.delete {
+		if _, err := c.send(ctx, cr, http.MethodDelete, itemURL(cr.Spec.ForProvider, r.ID), "", http.StatusNotFound, http.StatusGone); err != nil {
+			return errors.Wrapf(err, errDeleteItem, r.Key)
+		}
+	}
+
+	cr.SetError("", nil)
+	return nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.BatchRequest)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBatchRequest)
+	}
+
+	return managed.ExternalCreation{}, c.sync(ctx, cr)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BatchRequest)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBatchRequest)
+	}
+
+	return managed.ExternalUpdate{}, c.sync(ctx, cr)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.BatchRequest)
+	if !ok {
+		return errors.New(errNotBatchRequest)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	for _, r := range cr.Status.Items {
+		if _, err := c.send(ctx, cr, http.MethodDelete, itemURL(cr.Spec.ForProvider, r.ID), "", http.StatusNotFound, http.StatusGone); err != nil {
+			return errors.Wrapf(err, errDeleteItem, r.Key)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batchrequest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arielsepton/provider-http/apis/batchrequest/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/http/fake"
+)
+
+const testCollectionURL = "https://api.example.com/v1/users"
+
+// collection returns a fake.MockSendRequestFn listing the given remote items, and recording the other requests it
+// receives as "<method> <url> <body>".
+func collection(list string, sent *[]string) fake.MockSendRequestFn {
+	return func(_ context.Context, method string, url string, body string, _ map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+		if method == http.MethodGet && url == testCollectionURL {
+			return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK, Body: list}}, nil
+		}
+		*sent = append(*sent, method+" "+url+" "+body)
+		return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: http.StatusOK}}, nil
+	}
+}
+
+func batchRequest(items []string, managed ...v1alpha1.ItemStatus) *v1alpha1.BatchRequest {
+	return &v1alpha1.BatchRequest{
+		ObjectMeta: v1.ObjectMeta{Name: "users"},
+		Spec: v1alpha1.BatchRequestSpec{
+			ForProvider: v1alpha1.BatchRequestParameters{
+				BaseURL:  "https://api.example.com/v1",
+				Path:     "users",
+				Items:    items,
+				KeyPath:  ".name",
+				IDPath:   ".id",
+				ListPath: ".data",
+			},
+		},
+		Status: v1alpha1.BatchRequestStatus{Items: managed},
+	}
+}
+
+func Test_Observe(t *testing.T) {
+	type want struct {
+		obs   managed.ExternalObservation
+		items []v1alpha1.ItemStatus
+		err   error
+	}
+	cases := map[string]struct {
+		cr   *v1alpha1.BatchRequest
+		list string
+		want want
+	}{
+		"NoneCreated": {
+			cr:   batchRequest([]string{`{"name": "jane"}`}),
+			list: `{"data": []}`,
+			want: want{obs: managed.ExternalObservation{ResourceExists: false, ResourceUpToDate: false}},
+		},
+		"UpToDate": {
+			cr:   batchRequest([]string{`{"name": "jane", "role": "admin"}`}),
+			list: `{"data": [{"id": 1, "name": "jane", "role": "admin"}, {"id": 2, "name": "john"}]}`,
+			want: want{
+				obs:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				items: []v1alpha1.ItemStatus{{Key: "jane", ID: "1"}},
+			},
+		},
+		"Drifted": {
+			cr:   batchRequest([]string{`{"name": "jane", "role": "admin"}`}),
+			list: `{"data": [{"id": 1, "name": "jane", "role": "viewer"}]}`,
+			want: want{
+				obs:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				items: []v1alpha1.ItemStatus{{Key: "jane", ID: "1"}},
+			},
+		},
+		"Removed": {
+			cr:   batchRequest([]string{`{"name": "jane"}`}, v1alpha1.ItemStatus{Key: "jane", ID: "1"}, v1alpha1.ItemStatus{Key: "john", ID: "2"}),
+			list: `{"data": [{"id": 1, "name": "jane"}, {"id": 2, "name": "john"}]}`,
+			want: want{
+				obs:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				items: []v1alpha1.ItemStatus{{Key: "jane", ID: "1"}, {Key: "john", ID: "2"}},
+			},
+		},
+		"NoItems": {
+			cr:   batchRequest(nil),
+			list: `{"data": [{"id": 2, "name": "john"}]}`,
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"DuplicateKey": {
+			cr:   batchRequest([]string{`{"name": "jane"}`, `{"name": "jane"}`}),
+			list: `{"data": []}`,
+			want: want{err: errors.Errorf(errDuplicateKey, 0, 1, "jane")},
+		},
+		"InvalidList": {
+			cr:   batchRequest([]string{`{"name": "jane"}`}),
+			list: `{"items": []}`,
+			want: want{err: errors.Wrapf(errors.Errorf("failed to parse array: %s", "<nil>"), errListBody, ".data")},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var sent []string
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: collection(tc.list, &sent)}}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.items, tc.cr.Status.Items); diff != "" {
+				t.Errorf("Observe(...): -want managed items, +got managed items: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	cr := batchRequest([]string{`{"name": "jane", "role": "admin"}`, `{"name": "joe"}`},
+		v1alpha1.ItemStatus{Key: "jane", ID: "1"}, v1alpha1.ItemStatus{Key: "john", ID: "2"})
+	list := `{"data": [{"id": 1, "name": "jane", "role": "viewer"}, {"id": 2, "name": "john"}, {"id": 3, "name": "jim"}]}`
+
+	var sent []string
+	e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: collection(list, &sent)}}
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update(...): unexpected error: %s", err)
+	}
+
+	want := []string{
+		`POST https://api.example.com/v1/users {"name": "joe"}`,
+		`PUT https://api.example.com/v1/users/1 {"name": "jane", "role": "admin"}`,
+		`DELETE https://api.example.com/v1/users/2 `,
+	}
+	if diff := cmp.Diff(want, sent); diff != "" {
+		t.Errorf("Update(...): -want requests, +got requests: %s", diff)
+	}
+}
+
+func Test_Create(t *testing.T) {
+	list := `{"data": [{"id": 1, "name": "jane"}]}`
+
+	type want struct {
+		sent []string
+		err  error
+	}
+	cases := map[string]struct {
+		baseURL   string
+		responses map[string]httpClient.HttpResponse
+		want      want
+	}{
+		"Created": {
+			responses: map[string]httpClient.HttpResponse{
+				"GET " + testCollectionURL:  {StatusCode: http.StatusOK, Body: list},
+				"POST " + testCollectionURL: {StatusCode: http.StatusCreated, Body: `{"id": 2}`},
+			},
+			want: want{sent: []string{
+				`GET https://api.example.com/v1/users `,
+				`POST https://api.example.com/v1/users {"name": "joe"}`,
+			}},
+		},
+		"CreateFailed": {
+			responses: map[string]httpClient.HttpResponse{
+				"GET " + testCollectionURL:  {StatusCode: http.StatusOK, Body: list},
+				"POST " + testCollectionURL: {StatusCode: http.StatusConflict},
+			},
+			want: want{
+				sent: []string{
+					`GET https://api.example.com/v1/users `,
+					`POST https://api.example.com/v1/users {"name": "joe"}`,
+				},
+				err: errors.Wrapf(errors.New("HTTP POST request failed with status code: 409"), errCreateItem, "joe"),
+			},
+		},
+		"InvalidBaseURL": {
+			baseURL: "api.example.com",
+			want:    want{err: errors.New(errInvalidBaseURL)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := batchRequest([]string{`{"name": "jane"}`, `{"name": "joe"}`})
+			if tc.baseURL != "" {
+				cr.Spec.ForProvider.BaseURL = tc.baseURL
+			}
+
+			var sent []string
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: fake.Server(tc.responses, &sent)}}
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Create(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Errorf("Create(...): -want requests, +got requests: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	cases := map[string]struct {
+		responses map[string]httpClient.HttpResponse
+		want      error
+	}{
+		"Deleted": {
+			responses: map[string]httpClient.HttpResponse{
+				"DELETE https://api.example.com/v1/users/1": {StatusCode: http.StatusNoContent},
+				"DELETE https://api.example.com/v1/users/2": {StatusCode: http.StatusNoContent},
+			},
+		},
+		"AlreadyDeleted": {
+			responses: map[string]httpClient.HttpResponse{
+				"DELETE https://api.example.com/v1/users/1": {StatusCode: http.StatusNotFound},
+				"DELETE https://api.example.com/v1/users/2": {StatusCode: http.StatusGone},
+			},
+		},
+		"DeleteFailed": {
+			responses: map[string]httpClient.HttpResponse{
+				"DELETE https://api.example.com/v1/users/1": {StatusCode: http.StatusNoContent},
+				"DELETE https://api.example.com/v1/users/2": {StatusCode: http.StatusForbidden},
+			},
+			want: errors.Wrapf(errors.New("HTTP DELETE request failed with status code: 403"), errDeleteItem, "john"),
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := batchRequest([]string{`{"name": "jane"}`}, v1alpha1.ItemStatus{Key: "jane", ID: "1"}, v1alpha1.ItemStatus{Key: "john", ID: "2"})
+
+			var sent []string
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: fake.Server(tc.responses, &sent)}}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Delete(...): -want error, +got error: %s", diff)
+			}
+			want := []string{`DELETE https://api.example.com/v1/users/1 `, `DELETE https://api.example.com/v1/users/2 `}
+			if diff := cmp.Diff(want, sent); diff != "" {
+				t.Errorf("Delete(...): -want requests, +got requests: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// DefaultStoreConfigName is the name of the StoreConfig that resources
+// publish their connection details to by default.
+const DefaultStoreConfigName = "default"
+
+// EnsureDefaultStoreConfig creates the default StoreConfig, which stores
+// connection secrets in the supplied namespace, unless it already exists.
+func EnsureDefaultStoreConfig(ctx context.Context, kube client.Client, namespace string) error {
+	return resource.Ignore(kerrors.IsAlreadyExists, kube.Create(ctx, &v1alpha1.StoreConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: DefaultStoreConfigName,
+		},
+		Spec: v1alpha1.StoreConfigSpec{
+			// NOTE: Default secret store config has no type, which defaults to Kubernetes.
+			SecretStoreConfig: xpv1.SecretStoreConfig{
+				DefaultScope: namespace,
+			},
+		},
+	}))
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+var errBoom = errors.New("boom")
+
+func Test_EnsureDefaultStoreConfig(t *testing.T) {
+	type want struct {
+		created *v1alpha1.StoreConfig
+		err     error
+	}
+	cases := map[string]struct {
+		createErr error
+		want      want
+	}{
+		"Created": {
+			want: want{
+				created: &v1alpha1.StoreConfig{},
+			},
+		},
+		"AlreadyExists": {
+			createErr: kerrors.NewAlreadyExists(schema.GroupResource{Group: v1alpha1.Group, Resource: "storeconfigs"}, DefaultStoreConfigName),
+			want: want{
+				created: &v1alpha1.StoreConfig{},
+			},
+		},
+		"CreateFailed": {
+			createErr: errBoom,
+			want: want{
+				created: &v1alpha1.StoreConfig{},
+				err:     errBoom,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var created *v1alpha1.StoreConfig
+			kube := &test.MockClient{MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+				created = obj.(*v1alpha1.StoreConfig)
+				return tc.createErr
+			}}
+
+			err := EnsureDefaultStoreConfig(context.Background(), kube, "crossplane-system")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("EnsureDefaultStoreConfig(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(DefaultStoreConfigName, created.GetName()); diff != "" {
+				t.Errorf("EnsureDefaultStoreConfig(...): -want name, +got name: %s", diff)
+			}
+			if diff := cmp.Diff("crossplane-system", created.Spec.DefaultScope); diff != "" {
+				t.Errorf("EnsureDefaultStoreConfig(...): -want default scope, +got default scope: %s", diff)
+			}
+		})
+	}
+}
@@ -24,15 +24,19 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/arielsepton/provider-http/internal/jq"
 	json_util "github.com/arielsepton/provider-http/internal/json"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -40,121 +44,353 @@ import (
 	"github.com/arielsepton/provider-http/apis/desposiblerequest/v1alpha1"
 	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/options"
+	"github.com/arielsepton/provider-http/internal/controller/requeue"
+	"github.com/arielsepton/provider-http/internal/controller/timestamps"
+	"github.com/arielsepton/provider-http/internal/cron"
+	"github.com/arielsepton/provider-http/internal/tracing"
 	"github.com/arielsepton/provider-http/internal/utils"
 )
 
 const (
-	errNotDesposibleRequest              = "managed resource is not a DesposibleRequest custom resource"
-	errTrackPCUsage                      = "cannot track ProviderConfig usage"
-	errNewHttpClient                     = "cannot create new Http client"
-	errProviderNotRetrieved              = "provider could not be retrieved"
+	errNotDesposibleRequest              = "managed resource is not a DisposableRequest or a DesposibleRequest custom resource"
+	errParseSchedule                     = "cannot parse schedule"
 	errFailedToSendHttpDesposibleRequest = "failed to send http request"
 	errFailedUpdateStatusConditions      = "failed updating status conditions"
 	ErrExpectedFormat                    = "JQ filter should return a boolean, but returned error: %s"
+	errThrottled                         = "remote API is throttling requests until %s"
+	errRetriesExhausted                  = "request failed %d times, reaching its rollback retries limit, set the %s annotation to send it again"
+	errRemoveResumeAnnotation            = "cannot remove resume annotation"
+
+	reasonRetriesExhausted event.Reason = "RetriesExhausted"
+	reasonResumed          event.Reason = "Resumed"
 )
 
-// Setup adds a controller that reconciles DesposibleRequest managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
-	name := managed.ControllerName(v1alpha1.DesposibleRequestGroupKind)
-	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+// kind describes a kind of one-shot requests reconciled by a controller.
+type kind struct {
+	name    string
+	gvk     schema.GroupVersionKind
+	object  client.Object
+	newFunc func() resource.Managed
+}
+
+// Setup adds the controllers that reconcile DisposableRequest and DesposibleRequest managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
+	kinds := []kind{
+		{
+			name:    v1alpha1.DisposableRequestKind,
+			gvk:     v1alpha1.DisposableRequestGroupVersionKind,
+			object:  &v1alpha1.DisposableRequest{},
+			newFunc: func() resource.Managed { return &v1alpha1.DisposableRequest{} },
+		},
+		{
+			name:    v1alpha1.DesposibleRequestKind,
+			gvk:     v1alpha1.DesposibleRequestGroupVersionKind,
+			object:  &v1alpha1.DesposibleRequest{},
+			newFunc: func() resource.Managed { return &v1alpha1.DesposibleRequest{} },
+		},
+	}
+
+	for _, k := range kinds {
+		if err := setup(mgr, o, opts, k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setup adds a controller that reconciles managed resources of the given kind.
+func setup(mgr ctrl.Manager, o controller.Options, opts options.Options, k kind) error {
+	name := managed.ControllerName(k.gvk.GroupKind().String())
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+	cps := options.ConnectionPublishers(mgr.GetClient(), mgr.GetScheme(), o)
 
 	r := managed.NewReconciler(mgr,
-		resource.ManagedKind(v1alpha1.DesposibleRequestGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			logger:          o.Logger,
-			kube:            mgr.GetClient(),
-			usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newHttpClientFn: httpClient.NewClient,
-		}),
+		resource.ManagedKind(k.gvk),
+		managed.WithExternalConnecter(timestamps.NewConnecter(&connector{
+			Clients:  opts.Clients(mgr.GetClient()),
+			kind:     k.name,
+			logger:   o.Logger,
+			recorder: recorder,
+		})),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithTimeout(timeout),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithTimeout(opts.Timeout),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
+	schedules := []requeue.ScheduleFn{
+		requeue.PollJitter(o.PollInterval, opts.PollJitter),
+		requeue.PollInterval(o.PollInterval, opts.PollJitter, requeue.ResourcePollInterval(mgr.GetClient())),
+		opts.LoadShedder(name).Schedule,
+		requeueScheduled,
+		requeueRetry,
+		requeueThrottled,
+	}
+	requeuer := requeue.NewReconciler(r, mgr.GetClient(), k.newFunc, schedules...)
+	traced := tracing.NewReconciler(name, requeuer)
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(opts.ForControllerRuntime(o, k.name)).
 		WithEventFilter(resource.DesiredStateChanged()).
-		For(&v1alpha1.DesposibleRequest{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(k.object).
+		Complete(ratelimiter.NewReconciler(name, traced, o.GlobalRateLimiter))
 }
 
 type connector struct {
-	logger          logging.Logger
-	kube            client.Client
-	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration) (httpClient.Client, error)
+	options.Clients
+
+	// kind of the requests, sent in the User-Agent.
+	kind string
+
+	logger   logging.Logger
+	recorder event.Recorder
 }
 
 func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
-	cr, ok := mg.(*v1alpha1.DesposibleRequest)
+	cr, ok := mg.(v1alpha1.OneShotRequest)
 	if !ok {
 		return nil, errors.New(errNotDesposibleRequest)
 	}
 
-	l := c.logger.WithValues("desposibleRequest", cr.Name)
+	l := c.logger.WithValues("kind", c.kind, "name", cr.GetName())
 
-	if err := c.usage.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, errTrackPCUsage)
-	}
-
-	pc := &apisv1alpha1.ProviderConfig{}
-	n := types.NamespacedName{Name: cr.GetProviderConfigReference().Name}
-	if err := c.kube.Get(ctx, n, pc); err != nil {
-		return nil, errors.Wrap(err, errProviderNotRetrieved)
+	params := cr.GetParameters()
+	h, err := c.NewClient(ctx, l, cr, options.ClientParameters{
+		Kind:                  c.kind,
+		Auth:                  params.Auth,
+		WaitTimeout:           params.WaitTimeout,
+		InsecureSkipTLSVerify: params.InsecureSkipTLSVerify,
+		TLS:                   params.TLS,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout))
-	if err != nil {
-		return nil, errors.Wrap(err, errNewHttpClient)
+	var schedule *cron.Schedule
+	if params.Schedule != "" {
+		if schedule, err = cron.Parse(params.Schedule); err != nil {
+			return nil, errors.Wrap(err, errParseSchedule)
+		}
 	}
 
 	return &external{
-		localKube: c.kube,
-		logger:    l,
-		http:      h,
+		localKube:     c.Kube,
+		logger:        l,
+		recorder:      c.recorder,
+		http:          h.Client,
+		statusCodes:   utils.StatusCodePolicy(params.StatusCodes, h.ProviderConfig.Spec.StatusCodes),
+		skipTLSVerify: h.SkipTLSVerify,
+		schedule:      schedule,
+		correlationID: h.CorrelationID,
 	}, nil
 }
 
 type external struct {
-	localKube client.Client
-	logger    logging.Logger
-	http      httpClient.Client
+	localKube   client.Client
+	logger      logging.Logger
+	recorder    event.Recorder
+	http        httpClient.Client
+	statusCodes *apisv1alpha1.StatusCodePolicy
+
+	// skipTLSVerify skips TLS certificate checks, when set by the resource or its ProviderConfig.
+	skipTLSVerify bool
+
+	// schedule sends the request again at its activation times when set.
+	schedule *cron.Schedule
+
+	// correlationID is the value of the correlation header set on the requests, recorded in the status.
+	correlationID string
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
-	cr, ok := mg.(*v1alpha1.DesposibleRequest)
+	cr, ok := mg.(v1alpha1.OneShotRequest)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotDesposibleRequest)
 	}
 
-	if !cr.Status.Synced {
+	if until := throttledUntil(cr); until != nil {
+		return managed.ExternalObservation{}, errors.Errorf(errThrottled, until.UTC().Format(time.RFC3339))
+	}
+
+	if _, ok := cr.GetAnnotations()[v1alpha1.AnnotationKeyResume]; ok {
+		return managed.ExternalObservation{ResourceExists: false}, c.resume(ctx, cr)
+	}
+
+	if !cr.GetRequestStatus().Synced {
 		return managed.ExternalObservation{
 			ResourceExists: false,
 		}, nil
 	}
 
 	// Get the latest version of the resource before updating
-	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
+	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, cr); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get the latest version of the resource")
 	}
 
-	cr.Status.SetConditions(xpv1.Available())
+	due, err := c.scheduleNextRun(cr, time.Now())
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errParseSchedule)
+	}
+
+	exhausted := retriesExhausted(cr) && cr.GetCondition(apisv1alpha1.TypeFailed).Reason != apisv1alpha1.ReasonRetriesExhausted
+	if exhausted {
+		cr.SetConditions(apisv1alpha1.RetriesExhausted(cr.GetRequestStatus().Failed))
+	}
+
+	cr.SetConditions(xpv1.Available())
 	if err := c.localKube.Status().Update(ctx, cr); err != nil {
-		return managed.ExternalObservation{}, errors.New(errFailedUpdateStatusConditions)
+		return managed.ExternalObservation{}, errors.Wrap(err, errFailedUpdateStatusConditions)
+	}
+
+	if exhausted {
+		c.recorder.Event(cr, event.Warning(reasonRetriesExhausted, errors.Errorf(errRetriesExhausted, cr.GetRequestStatus().Failed, v1alpha1.AnnotationKeyResume)))
 	}
 
 	return managed.ExternalObservation{
 		ResourceExists:    true,
-		ResourceUpToDate:  !(utils.ShouldRetry(cr.Spec.ForProvider.RollbackRetriesLimit, cr.Status.Failed) && !utils.RetriesLimitReached(cr.Status.Failed, cr.Spec.ForProvider.RollbackRetriesLimit)),
+		ResourceUpToDate:  !c.shouldRetry(cr) && !due,
 		ConnectionDetails: nil,
 	}, nil
 }
 
-func (c *external) deployAction(ctx context.Context, cr *v1alpha1.DesposibleRequest) error {
-	details, err := c.http.SendRequest(ctx, cr.Spec.ForProvider.Method,
-		cr.Spec.ForProvider.URL, cr.Spec.ForProvider.Body, cr.Spec.ForProvider.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
+// resume removes the resume annotation of a one-shot request and clears its failures, so that its request is sent
+// again as if it was just created.
+func (c *external) resume(ctx context.Context, cr v1alpha1.OneShotRequest) error {
+	meta.RemoveAnnotations(cr, v1alpha1.AnnotationKeyResume)
+	if err := c.localKube.Update(ctx, cr); err != nil {
+		return errors.Wrap(err, errRemoveResumeAnnotation)
+	}
+
+	cr.SetSynced(false)
+	cr.SetTerminal(0, false)
+	if err := c.localKube.Status().Update(ctx, cr); err != nil {
+		return errors.Wrap(err, errFailedUpdateStatusConditions)
+	}
+
+	c.recorder.Event(cr, event.Normal(reasonResumed, "Failures cleared by the "+v1alpha1.AnnotationKeyResume+" annotation, sending the request again"))
+	return nil
+}
+
+// scheduleNextRun records when the request is sent next according to its schedule, counting from its last run,
+// and returns whether that time has come.
+func (c *external) scheduleNextRun(cr v1alpha1.OneShotRequest, now time.Time) (bool, error) {
+	status := cr.GetRequestStatus()
+	if c.schedule == nil {
+		status.NextRunTime = nil
+		return false, nil
+	}
+
+	last := cr.GetCreationTimestamp().Time
+	if status.LastRunTime != nil {
+		last = status.LastRunTime.Time
+	}
+
+	next, err := c.schedule.Next(last)
+	if err != nil {
+		return false, err
+	}
+
+	nextRun := metav1.NewTime(next)
+	status.NextRunTime = &nextRun
+	return !now.Before(next), nil
+}
+
+// requeueScheduled requeues a scheduled one-shot request when its next run is due, if that is before its next poll.
+func requeueScheduled(mg resource.Managed, result reconcile.Result) reconcile.Result {
+	cr, ok := mg.(v1alpha1.OneShotRequest)
+	if !ok || cr.GetRequestStatus().NextRunTime == nil {
+		return result
+	}
+
+	until := time.Until(cr.GetRequestStatus().NextRunTime.Time)
+	if until <= 0 || (result.RequeueAfter > 0 && result.RequeueAfter <= until) {
+		return result
+	}
+
+	return reconcile.Result{RequeueAfter: until}
+}
+
+// throttledUntil returns the time until which the remote API asked not to be called, or nil if it is not
+// throttling requests.
+func throttledUntil(cr v1alpha1.OneShotRequest) *metav1.Time {
+	if until := cr.GetRequestStatus().ThrottledUntil; until != nil && time.Now().Before(until.Time) {
+		return until
+	}
+
+	return nil
+}
+
+// requeueThrottled requeues a throttled one-shot request exactly when the remote API allows it to be called
+// again, rather than on its poll interval or schedule.
+func requeueThrottled(mg resource.Managed, result reconcile.Result) reconcile.Result {
+	cr, ok := mg.(v1alpha1.OneShotRequest)
+	if !ok {
+		return result
+	}
+
+	if until := throttledUntil(cr); until != nil {
+		return reconcile.Result{RequeueAfter: time.Until(until.Time)}
+	}
+
+	return result
+}
+
+// retriesExhausted returns whether the request failed as many times as its rollback retries limit allows, and is
+// no longer sent again.
+func retriesExhausted(cr v1alpha1.OneShotRequest) bool {
+	limit, failed := cr.GetParameters().RollbackRetriesLimit, cr.GetRequestStatus().Failed
+	return utils.ShouldRetry(limit, failed) && utils.RetriesLimitReached(failed, limit)
+}
+
+// retryPending returns whether the failed request is waiting for its next retry, according to its retry backoff.
+func retryPending(cr v1alpha1.OneShotRequest) bool {
+	next := cr.GetRequestStatus().NextRetryTime
+	return next != nil && time.Now().Before(next.Time)
+}
+
+// requeueRetry requeues a failed one-shot request when its next retry is due, if that is before its next poll.
+func requeueRetry(mg resource.Managed, result reconcile.Result) reconcile.Result {
+	cr, ok := mg.(v1alpha1.OneShotRequest)
+	if !ok || !retryPending(cr) {
+		return result
+	}
+
+	until := time.Until(cr.GetRequestStatus().NextRetryTime.Time)
+	if result.RequeueAfter > 0 && result.RequeueAfter <= until {
+		return result
+	}
+
+	return reconcile.Result{RequeueAfter: until}
+}
+
+// shouldRetry checks whether the request should be sent again, which is not the case for terminal responses nor
+// before its next retry is due.
+func (c *external) shouldRetry(cr v1alpha1.OneShotRequest) bool {
+	if utils.IsTerminal(c.statusCodes, cr.GetRequestStatus().Response.StatusCode) || retryPending(cr) {
+		return false
+	}
+
+	return utils.ShouldRetry(cr.GetParameters().RollbackRetriesLimit, cr.GetRequestStatus().Failed) && !retriesExhausted(cr)
+}
+
+func (c *external) deployAction(ctx context.Context, cr v1alpha1.OneShotRequest) error {
+	if until := throttledUntil(cr); until != nil {
+		return errors.Errorf(errThrottled, until.UTC().Format(time.RFC3339))
+	}
+
+	start := time.Now()
+	var next *time.Time
+	if c.schedule != nil {
+		if t, err := c.schedule.Next(start); err == nil {
+			next = &t
+		}
+	}
+
+	details, err := c.http.SendRequest(ctx, cr.GetParameters().Method,
+		cr.GetParameters().URL, cr.GetParameters().Body, cr.GetParameters().Headers, c.skipTLSVerify)
 
 	res := details.HttpResponse
 	resource := &utils.RequestResource{
@@ -163,27 +399,30 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha1.DesposibleRequ
 		HttpResponse:   details.HttpResponse,
 		LocalClient:    c.localKube,
 		HttpRequest:    details.HttpRequest,
+		Timings:        details.Timings,
 	}
+	setRun := resource.SetRun(start, next)
+	setCorrelationID := resource.SetCorrelationID(c.correlationID)
 
 	// Get the latest version of the resource before updating
-	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
+	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.GetName(), Namespace: cr.GetNamespace()}, cr); err != nil {
 		return errors.Wrap(err, "failed to get the latest version of the resource")
 	}
 
 	if err != nil {
 		setErr := resource.SetError(err)
-		if settingError := utils.SetRequestResourceStatus(*resource, setErr, resource.SetRequestDetails()); settingError != nil {
+		if settingError := utils.SetRequestResourceStatus(*resource, setErr, resource.SetRequestDetails(), setRun, setCorrelationID, resource.SetTimings(), resource.SetNextRetry(cr.GetParameters().RetryBackoff)); settingError != nil {
 			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 		}
 		return err
 	}
 
-	if utils.IsHTTPError(res.StatusCode) {
-		if settingError := utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetHeaders(), resource.SetBody(), resource.SetRequestDetails(), resource.SetError(nil)); settingError != nil {
+	if utils.IsFailure(c.statusCodes, res.StatusCode) {
+		if settingError := utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetHeaders(), resource.SetBody(), resource.SetRequestDetails(), resource.SetThrottledUntil(), resource.SetTerminal(c.statusCodes), resource.SetError(nil), setRun, setCorrelationID, resource.SetTimings(), resource.SetNextRetry(cr.GetParameters().RetryBackoff)); settingError != nil {
 			return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 		}
 
-		return errors.Errorf(utils.ErrStatusCode, cr.Spec.ForProvider.Method, strconv.Itoa(res.StatusCode))
+		return errors.Errorf(utils.ErrStatusCode, cr.GetParameters().Method, strconv.Itoa(res.StatusCode))
 	}
 
 	isExpectedResponse, err := c.isResponseAsExpected(cr, res)
@@ -192,21 +431,21 @@ func (c *external) deployAction(ctx context.Context, cr *v1alpha1.DesposibleRequ
 	}
 
 	if !isExpectedResponse {
-		limit := utils.GetRollbackRetriesLimit(cr.Spec.ForProvider.RollbackRetriesLimit)
+		limit := utils.GetRollbackRetriesLimit(cr.GetParameters().RollbackRetriesLimit)
 		return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetHeaders(), resource.SetBody(),
-			resource.SetError(errors.New("Response does not match the expected format, retries limit "+fmt.Sprint(limit))), resource.SetRequestDetails())
+			resource.SetError(errors.New("Response does not match the expected format, retries limit "+fmt.Sprint(limit))), resource.SetRequestDetails(), resource.SetThrottledUntil(), setRun, setCorrelationID, resource.SetTimings(), resource.SetNextRetry(cr.GetParameters().RetryBackoff))
 	}
 
-	return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetHeaders(), resource.SetBody(), resource.SetSynced(), resource.SetRequestDetails())
+	return utils.SetRequestResourceStatus(*resource, resource.SetStatusCode(), resource.SetHeaders(), resource.SetBody(), resource.SetSynced(), resource.SetRequestDetails(), resource.SetThrottledUntil(), resource.SetTerminal(c.statusCodes), setRun, setCorrelationID, resource.SetTimings())
 }
 
-func (c *external) isResponseAsExpected(cr *v1alpha1.DesposibleRequest, res httpClient.HttpResponse) (bool, error) {
+func (c *external) isResponseAsExpected(cr v1alpha1.OneShotRequest, res httpClient.HttpResponse) (bool, error) {
 	// If no expected response is defined, consider it as expected.
-	if cr.Spec.ForProvider.ExpectedResponse == "" {
+	if cr.GetParameters().ExpectedResponse == "" {
 		return true, nil
 	}
 
-	if cr.Status.Response.StatusCode == 0 {
+	if cr.GetRequestStatus().Response.StatusCode == 0 {
 		return false, nil
 	}
 
@@ -217,7 +456,7 @@ func (c *external) isResponseAsExpected(cr *v1alpha1.DesposibleRequest, res http
 
 	json_util.ConvertJSONStringsToMaps(&responseMap)
 
-	isExpected, err := jq.ParseBool(cr.Spec.ForProvider.ExpectedResponse, responseMap)
+	isExpected, err := jq.ParseBool(cr.GetParameters().ExpectedResponse, responseMap)
 	if err != nil {
 		return false, errors.Errorf(ErrExpectedFormat, err.Error())
 	}
@@ -226,12 +465,12 @@ func (c *external) isResponseAsExpected(cr *v1alpha1.DesposibleRequest, res http
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
-	cr, ok := mg.(*v1alpha1.DesposibleRequest)
+	cr, ok := mg.(v1alpha1.OneShotRequest)
 	if !ok {
 		return managed.ExternalCreation{}, errors.New(errNotDesposibleRequest)
 	}
 
-	if err := utils.IsRequestValid(cr.Spec.ForProvider.Method, cr.Spec.ForProvider.URL); err != nil {
+	if err := utils.IsRequestValid(cr.GetParameters().Method, cr.GetParameters().URL); err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
@@ -239,12 +478,12 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	cr, ok := mg.(*v1alpha1.DesposibleRequest)
+	cr, ok := mg.(v1alpha1.OneShotRequest)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotDesposibleRequest)
 	}
 
-	if err := utils.IsRequestValid(cr.Spec.ForProvider.Method, cr.Spec.ForProvider.URL); err != nil {
+	if err := utils.IsRequestValid(cr.GetParameters().Method, cr.GetParameters().URL); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
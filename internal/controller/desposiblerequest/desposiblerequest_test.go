@@ -25,12 +25,14 @@ import (
 	"github.com/arielsepton/provider-http/apis/desposiblerequest/v1alpha1"
 
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/cron"
 	"github.com/arielsepton/provider-http/internal/utils"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -104,6 +106,15 @@ func httpDesposibleRequest(rm ...httpDesposibleRequestModifier) *v1alpha1.Despos
 	return r
 }
 
+func httpDisposableRequest() *v1alpha1.DisposableRequest {
+	r := httpDesposibleRequest()
+	return &v1alpha1.DisposableRequest{
+		ObjectMeta: r.ObjectMeta,
+		Spec:       r.Spec,
+		Status:     r.Status,
+	}
+}
+
 type MockSendRequestFn func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error)
 
 type MockHttpClient struct {
@@ -177,6 +188,24 @@ func Test_httpExternal_Create(t *testing.T) {
 				err: nil,
 			},
 		},
+		"DisposableRequestSuccess": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockCreate:       test.NewMockCreateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpDisposableRequest(),
+			},
+			want: want{
+				err: nil,
+			},
+		},
 	}
 	for name, tc := range cases {
 		tc := tc // Create local copies of loop variables
@@ -270,8 +299,9 @@ func Test_httpExternal_Update(t *testing.T) {
 }
 
 func Test_deployAction(t *testing.T) {
+	throttledUntilTime := v1.NewTime(time.Now().Add(time.Minute).Truncate(time.Second))
 	type args struct {
-		cr        *v1alpha1.DesposibleRequest
+		cr        v1alpha1.OneShotRequest
 		http      httpClient.Client
 		localKube client.Client
 	}
@@ -288,6 +318,16 @@ func Test_deployAction(t *testing.T) {
 		want want
 		shouldCheckStatus
 	}{
+		"Throttled": {
+			args: args{
+				cr: &v1alpha1.DesposibleRequest{
+					Status: v1alpha1.DesposibleRequestStatus{ThrottledUntil: &throttledUntilTime},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errThrottled, throttledUntilTime.UTC().Format(time.RFC3339)),
+			},
+		},
 		"SuccessUpdateStatusRequestFailure": {
 			args: args{
 				http: &MockHttpClient{
@@ -391,6 +431,33 @@ func Test_deployAction(t *testing.T) {
 				condition: true,
 			},
 		},
+		"DisposableRequestSuccessUpdateStatusSuccessfulRequest": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 201,
+								Body:       testBody,
+								Headers:    testHeaders,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				cr: httpDisposableRequest(),
+			},
+			want: want{
+				err:        nil,
+				statusCode: 201,
+			},
+			shouldCheckStatus: shouldCheckStatus{
+				condition: true,
+			},
+		},
 	}
 	for name, tc := range cases {
 		tc := tc // Create local copies of loop variables
@@ -408,24 +475,274 @@ func Test_deployAction(t *testing.T) {
 			}
 
 			if gotErr != nil {
-				if diff := cmp.Diff(tc.args.cr.Status.Failed, tc.want.failuresIndex); diff != "" {
+				if diff := cmp.Diff(tc.args.cr.GetRequestStatus().Failed, tc.want.failuresIndex); diff != "" {
 					t.Fatalf("deployAction(...): -want Status.Failed, +got Status.Failed: %s", diff)
 				}
 			}
 
 			if tc.shouldCheckStatus.condition {
-				if diff := cmp.Diff(tc.args.cr.Spec.ForProvider.Body, tc.args.cr.Status.Response.Body); diff != "" {
+				if diff := cmp.Diff(tc.args.cr.GetParameters().Body, tc.args.cr.GetRequestStatus().Response.Body); diff != "" {
 					t.Fatalf("deployAction(...): -want Status.Response.Body, +got Status.Response.Body: %s", diff)
 				}
 
-				if diff := cmp.Diff(tc.want.statusCode, tc.args.cr.Status.Response.StatusCode); diff != "" {
+				if diff := cmp.Diff(tc.want.statusCode, tc.args.cr.GetRequestStatus().Response.StatusCode); diff != "" {
 					t.Fatalf("deployAction(...): -want Status.Response.StatusCode, +got Status.Response.StatusCode: %s", diff)
 				}
 
-				if diff := cmp.Diff(tc.args.cr.Spec.ForProvider.Headers, tc.args.cr.Status.Response.Headers); diff != "" {
+				if diff := cmp.Diff(tc.args.cr.GetParameters().Headers, tc.args.cr.GetRequestStatus().Response.Headers); diff != "" {
 					t.Fatalf("deployAction(...): -want Status.Response.Headers, +got Status.Response.Headers: %s", diff)
 				}
 			}
 		})
 	}
 }
+
+func Test_scheduleNextRun(t *testing.T) {
+	daily, _ := cron.Parse("0 3 * * *")
+	created := v1.NewTime(time.Date(2024, time.January, 10, 12, 0, 0, 0, time.UTC))
+	lastRun := v1.NewTime(time.Date(2024, time.January, 11, 3, 0, 5, 0, time.UTC))
+
+	type args struct {
+		schedule *cron.Schedule
+		lastRun  *v1.Time
+		now      time.Time
+	}
+	type want struct {
+		due     bool
+		nextRun *v1.Time
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotScheduled": {
+			args: args{
+				now: time.Date(2024, time.January, 11, 4, 0, 0, 0, time.UTC),
+			},
+			want: want{
+				due: false,
+			},
+		},
+		"NotDue": {
+			args: args{
+				schedule: daily,
+				lastRun:  &lastRun,
+				now:      time.Date(2024, time.January, 11, 4, 0, 0, 0, time.UTC),
+			},
+			want: want{
+				due:     false,
+				nextRun: &v1.Time{Time: time.Date(2024, time.January, 12, 3, 0, 0, 0, time.UTC)},
+			},
+		},
+		"Due": {
+			args: args{
+				schedule: daily,
+				lastRun:  &lastRun,
+				now:      time.Date(2024, time.January, 12, 3, 0, 30, 0, time.UTC),
+			},
+			want: want{
+				due:     true,
+				nextRun: &v1.Time{Time: time.Date(2024, time.January, 12, 3, 0, 0, 0, time.UTC)},
+			},
+		},
+		"NeverRunCountsFromCreation": {
+			args: args{
+				schedule: daily,
+				now:      time.Date(2024, time.January, 11, 3, 0, 0, 0, time.UTC),
+			},
+			want: want{
+				due:     true,
+				nextRun: &v1.Time{Time: time.Date(2024, time.January, 11, 3, 0, 0, 0, time.UTC)},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DesposibleRequest{}
+			cr.CreationTimestamp = created
+			cr.Status.LastRunTime = tc.args.lastRun
+			e := &external{schedule: tc.args.schedule}
+
+			got, err := e.scheduleNextRun(cr, tc.args.now)
+			if err != nil {
+				t.Fatalf("scheduleNextRun(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.due, got); diff != "" {
+				t.Errorf("scheduleNextRun(...): -want due, +got due: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.nextRun, cr.Status.NextRunTime); diff != "" {
+				t.Errorf("scheduleNextRun(...): -want next run, +got next run: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_requeueScheduled(t *testing.T) {
+	soon := v1.NewTime(time.Now().Add(time.Minute))
+	later := v1.NewTime(time.Now().Add(time.Hour))
+	past := v1.NewTime(time.Now().Add(-time.Minute))
+
+	cases := map[string]struct {
+		nextRun *v1.Time
+		result  reconcile.Result
+		want    reconcile.Result
+	}{
+		"NotScheduled": {
+			result: reconcile.Result{RequeueAfter: 10 * time.Minute},
+			want:   reconcile.Result{RequeueAfter: 10 * time.Minute},
+		},
+		"RunBeforePoll": {
+			nextRun: &soon,
+			result:  reconcile.Result{RequeueAfter: 10 * time.Minute},
+			want:    reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"PollBeforeRun": {
+			nextRun: &later,
+			result:  reconcile.Result{RequeueAfter: 10 * time.Minute},
+			want:    reconcile.Result{RequeueAfter: 10 * time.Minute},
+		},
+		"RunPassed": {
+			nextRun: &past,
+			result:  reconcile.Result{Requeue: true},
+			want:    reconcile.Result{Requeue: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DesposibleRequest{}
+			cr.Status.NextRunTime = tc.nextRun
+			got := requeueScheduled(cr, tc.result)
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Duration) bool {
+				return a-b < time.Second && b-a < time.Second
+			})); diff != "" {
+				t.Errorf("requeueScheduled(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_requeueThrottled(t *testing.T) {
+	until := v1.NewTime(time.Now().Add(time.Minute))
+	past := v1.NewTime(time.Now().Add(-time.Minute))
+
+	cases := map[string]struct {
+		throttledUntil *v1.Time
+		result         reconcile.Result
+		want           reconcile.Result
+	}{
+		"NotThrottled": {
+			result: reconcile.Result{RequeueAfter: 10 * time.Minute},
+			want:   reconcile.Result{RequeueAfter: 10 * time.Minute},
+		},
+		"Throttled": {
+			throttledUntil: &until,
+			result:         reconcile.Result{RequeueAfter: 10 * time.Minute},
+			want:           reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"ThrottlingEnded": {
+			throttledUntil: &past,
+			result:         reconcile.Result{Requeue: true},
+			want:           reconcile.Result{Requeue: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DesposibleRequest{}
+			cr.Status.ThrottledUntil = tc.throttledUntil
+			got := requeueThrottled(cr, tc.result)
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Duration) bool {
+				return a-b < time.Second && b-a < time.Second
+			})); diff != "" {
+				t.Errorf("requeueThrottled(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_retriesExhausted(t *testing.T) {
+	limit := int32(2)
+
+	cases := map[string]struct {
+		rollbackRetriesLimit *int32
+		failed               int32
+		want                 bool
+	}{
+		"NoLimit": {
+			failed: 5,
+			want:   false,
+		},
+		"NotFailed": {
+			rollbackRetriesLimit: &limit,
+			want:                 false,
+		},
+		"RetriesLeft": {
+			rollbackRetriesLimit: &limit,
+			failed:               1,
+			want:                 false,
+		},
+		"Exhausted": {
+			rollbackRetriesLimit: &limit,
+			failed:               2,
+			want:                 true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DesposibleRequest{}
+			cr.Spec.ForProvider.RollbackRetriesLimit = tc.rollbackRetriesLimit
+			cr.Status.Failed = tc.failed
+			if diff := cmp.Diff(tc.want, retriesExhausted(cr)); diff != "" {
+				t.Errorf("retriesExhausted(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_requeueRetry(t *testing.T) {
+	soon := v1.NewTime(time.Now().Add(time.Minute))
+	later := v1.NewTime(time.Now().Add(time.Hour))
+	past := v1.NewTime(time.Now().Add(-time.Minute))
+
+	cases := map[string]struct {
+		nextRetry *v1.Time
+		result    reconcile.Result
+		want      reconcile.Result
+	}{
+		"NotRetried": {
+			result: reconcile.Result{RequeueAfter: 10 * time.Minute},
+			want:   reconcile.Result{RequeueAfter: 10 * time.Minute},
+		},
+		"RetryBeforePoll": {
+			nextRetry: &soon,
+			result:    reconcile.Result{RequeueAfter: 10 * time.Minute},
+			want:      reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"PollBeforeRetry": {
+			nextRetry: &later,
+			result:    reconcile.Result{RequeueAfter: 10 * time.Minute},
+			want:      reconcile.Result{RequeueAfter: 10 * time.Minute},
+		},
+		"RetryDue": {
+			nextRetry: &past,
+			result:    reconcile.Result{Requeue: true},
+			want:      reconcile.Result{Requeue: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DesposibleRequest{}
+			cr.Status.NextRetryTime = tc.nextRetry
+			got := requeueRetry(cr, tc.result)
+			if diff := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b time.Duration) bool {
+				return a-b < time.Second && b-a < time.Second
+			})); diff != "" {
+				t.Errorf("requeueRetry(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filedownload
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/filedownload/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/options"
+	"github.com/arielsepton/provider-http/internal/controller/requeue"
+	"github.com/arielsepton/provider-http/internal/controller/timestamps"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+	"github.com/arielsepton/provider-http/internal/tracing"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	errNotFileDownload = "managed resource is not a FileDownload custom resource"
+	errDownload        = "cannot download file"
+	errReadTarget      = "cannot read target"
+	errWriteTarget     = "cannot write file to target"
+	errRemoveTarget    = "cannot remove file from target"
+)
+
+// Setup adds a controller that reconciles FileDownload managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
+	name := managed.ControllerName(v1alpha1.FileDownloadGroupKind)
+	cps := options.ConnectionPublishers(mgr.GetClient(), mgr.GetScheme(), o)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.FileDownloadGroupVersionKind),
+		managed.WithExternalConnecter(timestamps.NewConnecter(&connector{
+			Clients: opts.Clients(mgr.GetClient()),
+			logger:  o.Logger,
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(opts.Timeout),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	schedules := []requeue.ScheduleFn{
+		requeue.PollJitter(o.PollInterval, opts.PollJitter),
+		requeue.PollInterval(o.PollInterval, opts.PollJitter, requeue.ResourcePollInterval(mgr.GetClient())),
+		opts.LoadShedder(name).Schedule,
+	}
+	requeuer := requeue.NewReconciler(r, mgr.GetClient(), newFileDownload, schedules...)
+	traced := tracing.NewReconciler(name, requeuer)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(opts.ForControllerRuntime(o, v1alpha1.FileDownloadKind)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.FileDownload{}).
+		Complete(ratelimiter.NewReconciler(name, traced, o.GlobalRateLimiter))
+}
+
+func newFileDownload() resource.Managed {
+	return &v1alpha1.FileDownload{}
+}
+
+type connector struct {
+	options.Clients
+	logger logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.FileDownload)
+	if !ok {
+		return nil, errors.New(errNotFileDownload)
+	}
+
+	l := c.logger.WithValues("fileDownload", cr.Name)
+
+	h, err := c.NewClient(ctx, l, cr, options.ClientParameters{
+		Kind:                  v1alpha1.FileDownloadKind,
+		Auth:                  cr.Spec.ForProvider.Auth,
+		WaitTimeout:           cr.Spec.ForProvider.WaitTimeout,
+		InsecureSkipTLSVerify: cr.Spec.ForProvider.InsecureSkipTLSVerify,
+		TLS:                   cr.Spec.ForProvider.TLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		localKube:     c.Kube,
+		logger:        l,
+		http:          h.Client,
+		statusCodes:   utils.StatusCodePolicy(cr.Spec.ForProvider.StatusCodes, h.ProviderConfig.Spec.StatusCodes),
+		skipTLSVerify: h.SkipTLSVerify,
+		correlationID: h.CorrelationID,
+	}, nil
+}
+
+type external struct {
+	localKube   client.Client
+	logger      logging.Logger
+	http        httpClient.Client
+	statusCodes *apisv1alpha1.StatusCodePolicy
+
+	// skipTLSVerify skips TLS certificate checks, when set by the resource or its ProviderConfig.
+	skipTLSVerify bool
+
+	// correlationID is the value of the correlation header set on the requests, recorded in the status.
+	correlationID string
+
+	// file is the content downloaded by Observe, written to the target by Create and Update.
+	file []byte
+}
+
+// checksum returns the hexadecimal SHA-256 checksum of the given content.
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// download downloads the file and records the reason it failed in the status of the FileDownload.
+func (c *external) download(ctx context.Context, cr *v1alpha1.FileDownload) ([]byte, error) {
+	details, err := c.http.SendRequest(ctx, http.MethodGet, cr.Spec.ForProvider.URL, "", cr.Spec.ForProvider.Headers, c.skipTLSVerify)
+	cr.SetTimings(utils.Timings(details.Timings))
+	cr.SetCorrelationID(c.correlationID)
+	res := details.HttpResponse
+	if err != nil {
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, err), err)
+		return nil, err
+	}
+
+	if !utils.IsSuccess(c.statusCodes, res.StatusCode) {
+		err := errors.Errorf(utils.ErrStatusCode, http.MethodGet, strconv.Itoa(res.StatusCode))
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, nil), err)
+		return nil, err
+	}
+
+	cr.SetError("", nil)
+	return []byte(res.Body), nil
+}
+
+// readTarget returns the content of the target key, and whether it exists.
+func (c *external) readTarget(ctx context.Context, target v1alpha1.Target) ([]byte, bool, error) {
+	n := types.NamespacedName{Name: target.Name, Namespace: target.Namespace}
+
+	if target.Kind == v1alpha1.TargetKindConfigMap {
+		configMap := &corev1.ConfigMap{}
+		if err := c.localKube.Get(ctx, n, configMap); err != nil {
+			return nil, false, client.IgnoreNotFound(err)
+		}
+		if value, ok := configMap.Data[target.Key]; ok {
+			return []byte(value), true, nil
+		}
+		value, ok := configMap.BinaryData[target.Key]
+		return value, ok, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.localKube.Get(ctx, n, secret); err != nil {
+		return nil, false, client.IgnoreNotFound(err)
+	}
+	value, ok := secret.Data[target.Key]
+	return value, ok, nil
+}
+
+// writeTarget writes the downloaded file to the target key.
+func (c *external) writeTarget(ctx context.Context, cr *v1alpha1.FileDownload) error {
+	t := cr.Spec.ForProvider.Target
+	if t.Kind == v1alpha1.TargetKindConfigMap {
+		return kubehandler.SetConfigMapValue(ctx, c.localKube, t.Namespace, t.Name, t.Key, c.file)
+	}
+	return kubehandler.SetSecretValue(ctx, c.localKube, t.Namespace, t.Name, t.Key, c.file)
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.FileDownload)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotFileDownload)
+	}
+
+	current, exists, err := c.readTarget(ctx, cr.Spec.ForProvider.Target)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errReadTarget)
+	}
+
+	// The file is not downloaded again while the FileDownload is deleted, so that an unreachable URL does not
+	// block the removal of the target key.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: exists, ResourceUpToDate: true}, nil
+	}
+
+	c.file, err = c.download(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errDownload)
+	}
+
+	cr.Status.Checksum = checksum(c.file)
+	cr.Status.Size = len(c.file)
+	if exists {
+		cr.Status.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   exists,
+		ResourceUpToDate: exists && checksum(current) == cr.Status.Checksum,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.FileDownload)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotFileDownload)
+	}
+
+	return managed.ExternalCreation{}, errors.Wrap(c.writeTarget(ctx, cr), errWriteTarget)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.FileDownload)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotFileDownload)
+	}
+
+	return managed.ExternalUpdate{}, errors.Wrap(c.writeTarget(ctx, cr), errWriteTarget)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.FileDownload)
+	if !ok {
+		return errors.New(errNotFileDownload)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	t := cr.Spec.ForProvider.Target
+	if t.Kind == v1alpha1.TargetKindConfigMap {
+		return errors.Wrap(kubehandler.RemoveConfigMapValue(ctx, c.localKube, t.Namespace, t.Name, t.Key), errRemoveTarget)
+	}
+	return errors.Wrap(kubehandler.RemoveSecretValue(ctx, c.localKube, t.Namespace, t.Name, t.Key), errRemoveTarget)
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filedownload
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/filedownload/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/clients/http/fake"
+)
+
+var (
+	errBoom     = errors.New("boom")
+	errNotFound = kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "ca")
+)
+
+const testURL = "https://pki.example.com/ca.crt"
+
+// withSecret returns a MockGetFn finding a Secret with the given data, or no Secret when it is nil.
+func withSecret(data map[string][]byte) test.MockGetFn {
+	if data == nil {
+		return test.NewMockGetFn(errNotFound)
+	}
+	return test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.(*corev1.Secret).Data = data
+		return nil
+	})
+}
+
+func fileDownload() *v1alpha1.FileDownload {
+	return &v1alpha1.FileDownload{
+		ObjectMeta: v1.ObjectMeta{Name: "ca"},
+		Spec: v1alpha1.FileDownloadSpec{
+			ForProvider: v1alpha1.FileDownloadParameters{
+				URL: testURL,
+				Target: v1alpha1.Target{
+					Kind:      v1alpha1.TargetKindSecret,
+					Name:      "ca",
+					Namespace: "crossplane-system",
+					Key:       "ca.crt",
+				},
+			},
+		},
+	}
+}
+
+func Test_Observe(t *testing.T) {
+	type want struct {
+		obs      managed.ExternalObservation
+		checksum string
+		err      error
+	}
+	cases := map[string]struct {
+		get  test.MockGetFn
+		send fake.MockSendRequestFn
+		want want
+	}{
+		"TargetMissing": {
+			get:  withSecret(nil),
+			send: fake.Respond(http.StatusOK, "cert"),
+			want: want{
+				obs:      managed.ExternalObservation{ResourceExists: false},
+				checksum: checksum([]byte("cert")),
+			},
+		},
+		"UpToDate": {
+			get:  withSecret(map[string][]byte{"ca.crt": []byte("cert")}),
+			send: fake.Respond(http.StatusOK, "cert"),
+			want: want{
+				obs:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				checksum: checksum([]byte("cert")),
+			},
+		},
+		"Changed": {
+			get:  withSecret(map[string][]byte{"ca.crt": []byte("old")}),
+			send: fake.Respond(http.StatusOK, "cert"),
+			want: want{
+				obs:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				checksum: checksum([]byte("cert")),
+			},
+		},
+		"DownloadFailed": {
+			get:  withSecret(nil),
+			send: fake.Respond(http.StatusNotFound, ""),
+			want: want{
+				err: errors.Wrap(errors.New("HTTP GET request failed with status code: 404"), errDownload),
+			},
+		},
+		"ReadTargetFailed": {
+			get: test.NewMockGetFn(errBoom),
+			want: want{
+				err: errors.Wrap(errBoom, errReadTarget),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := fileDownload()
+			e := &external{
+				localKube: &test.MockClient{MockGet: tc.get},
+				logger:    logging.NewNopLogger(),
+				http:      &fake.MockClient{MockSendRequest: tc.send},
+			}
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.checksum, cr.Status.Checksum); diff != "" {
+				t.Errorf("Observe(...): -want checksum, +got checksum: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	cases := map[string]struct {
+		kind string
+		want client.Object
+	}{
+		"Secret": {
+			kind: v1alpha1.TargetKindSecret,
+			want: &corev1.Secret{Data: map[string][]byte{"ca.crt": []byte("cert")}},
+		},
+		"ConfigMap": {
+			kind: v1alpha1.TargetKindConfigMap,
+			want: &corev1.ConfigMap{Data: map[string]string{"ca.crt": "cert"}},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := fileDownload()
+			cr.Spec.ForProvider.Target.Kind = tc.kind
+
+			var got client.Object
+			e := &external{
+				localKube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						got = obj
+						return nil
+					},
+				},
+				logger: logging.NewNopLogger(),
+				file:   []byte("cert"),
+			}
+			if _, err := e.Update(context.Background(), cr); err != nil {
+				t.Fatalf("Update(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Update(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphqlrequest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/arielsepton/provider-http/apis/graphqlrequest/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/clients/graphql"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/options"
+	"github.com/arielsepton/provider-http/internal/controller/requeue"
+	"github.com/arielsepton/provider-http/internal/controller/timestamps"
+	"github.com/arielsepton/provider-http/internal/jq"
+	"github.com/arielsepton/provider-http/internal/tracing"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	// idVariable is the variable passing the ID of the resource to the observe, update and delete operations.
+	idVariable = "id"
+
+	errNotGraphQLRequest = "managed resource is not a GraphQLRequest custom resource"
+	errInvalidURL        = "url is not a valid URL"
+	errParseVariables    = "variables must be a JSON object"
+	errObserve           = "cannot run observe query"
+	errCreate            = "cannot run create mutation"
+	errUpdate            = "cannot run update mutation"
+	errDelete            = "cannot run delete mutation"
+	errExtractID         = "cannot extract resource ID from create response with %s"
+	errExpectedFormat    = "JQ filter should return a boolean, but returned error: %s"
+)
+
+// Setup adds a controller that reconciles GraphQLRequest managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
+	name := managed.ControllerName(v1alpha1.GraphQLRequestGroupKind)
+	cps := options.ConnectionPublishers(mgr.GetClient(), mgr.GetScheme(), o)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.GraphQLRequestGroupVersionKind),
+		managed.WithExternalConnecter(timestamps.NewConnecter(&connector{
+			Clients: opts.Clients(mgr.GetClient()),
+			logger:  o.Logger,
+		})),
+		// The external name is recorded once the resource is created, as it is the ID assigned by the server.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(opts.Timeout),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	schedules := []requeue.ScheduleFn{
+		requeue.PollJitter(o.PollInterval, opts.PollJitter),
+		requeue.PollInterval(o.PollInterval, opts.PollJitter, requeue.ResourcePollInterval(mgr.GetClient())),
+		opts.LoadShedder(name).Schedule,
+	}
+	requeuer := requeue.NewReconciler(r, mgr.GetClient(), newGraphQLRequest, schedules...)
+	traced := tracing.NewReconciler(name, requeuer)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(opts.ForControllerRuntime(o, v1alpha1.GraphQLRequestKind)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.GraphQLRequest{}).
+		Complete(ratelimiter.NewReconciler(name, traced, o.GlobalRateLimiter))
+}
+
+func newGraphQLRequest() resource.Managed {
+	return &v1alpha1.GraphQLRequest{}
+}
+
+type connector struct {
+	options.Clients
+	logger logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.GraphQLRequest)
+	if !ok {
+		return nil, errors.New(errNotGraphQLRequest)
+	}
+
+	l := c.logger.WithValues("graphQLRequest", cr.Name)
+
+	h, err := c.NewClient(ctx, l, cr, options.ClientParameters{
+		Kind:                  v1alpha1.GraphQLRequestKind,
+		Auth:                  cr.Spec.ForProvider.Auth,
+		WaitTimeout:           cr.Spec.ForProvider.WaitTimeout,
+		InsecureSkipTLSVerify: cr.Spec.ForProvider.InsecureSkipTLSVerify,
+		TLS:                   cr.Spec.ForProvider.TLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		logger:        l,
+		http:          h.Client,
+		statusCodes:   utils.StatusCodePolicy(cr.Spec.ForProvider.StatusCodes, h.ProviderConfig.Spec.StatusCodes),
+		skipTLSVerify: h.SkipTLSVerify,
+		correlationID: h.CorrelationID,
+	}, nil
+}
+
+type external struct {
+	logger      logging.Logger
+	http        httpClient.Client
+	statusCodes *apisv1alpha1.StatusCodePolicy
+
+	// skipTLSVerify skips TLS certificate checks, when set by the resource or its ProviderConfig.
+	skipTLSVerify bool
+
+	// correlationID is the value of the correlation header set on the requests, recorded in the status.
+	correlationID string
+}
+
+// variables returns the variables of the operations of the resource, with its ID as the id variable once it
+// was extracted from the create response.
+func variables(cr *v1alpha1.GraphQLRequest) (map[string]interface{}, error) {
+	vars := map[string]interface{}{}
+	if cr.Spec.ForProvider.Variables != "" {
+		if err := json.Unmarshal([]byte(cr.Spec.ForProvider.Variables), &vars); err != nil {
+			return nil, errors.Wrap(err, errParseVariables)
+		}
+	}
+
+	if id := meta.GetExternalName(cr); id != "" && cr.Spec.ForProvider.IDPath != "" {
+		vars[idVariable] = id
+	}
+
+	return vars, nil
+}
+
+// send posts the given operation and records its response, or the reason it failed, in the status of the
+// resource. Responses holding GraphQL errors are failures, whatever their status code.
+func (c *external) send(ctx context.Context, cr *v1alpha1.GraphQLRequest, op v1alpha1.Operation) (graphql.Response, map[string]interface{}, error) {
+	vars, err := variables(cr)
+	if err != nil {
+		return graphql.Response{}, nil, err
+	}
+
+	body, err := graphql.Request{Query: op.Query, OperationName: op.OperationName, Variables: vars}.Encode()
+	if err != nil {
+		return graphql.Response{}, nil, err
+	}
+
+	details, err := c.http.SendRequest(ctx, http.MethodPost, cr.Spec.ForProvider.URL, body, cr.Spec.ForProvider.Headers, c.skipTLSVerify)
+	cr.SetTimings(utils.Timings(details.Timings))
+	cr.SetCorrelationID(c.correlationID)
+	res := details.HttpResponse
+	if err != nil {
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, err), err)
+		return graphql.Response{}, nil, err
+	}
+
+	cr.SetResponse(res.StatusCode, res.Body, res.Headers)
+	if utils.IsFailure(c.statusCodes, res.StatusCode) {
+		err := errors.Errorf(utils.ErrStatusCode, http.MethodPost, strconv.Itoa(res.StatusCode))
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, nil), err)
+		return graphql.Response{}, nil, err
+	}
+
+	response, err := graphql.Decode(res.Body)
+	if err != nil {
+		cr.SetError(apisv1alpha1.FailureReasonUnexpectedResponse, err)
+		return graphql.Response{}, nil, err
+	}
+
+	cr.SetError("", nil)
+	return response, vars, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.GraphQLRequest)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotGraphQLRequest)
+	}
+
+	// Without a delete mutation, deleted resources are left as they are remotely.
+	if meta.GetExternalName(cr) == "" || (meta.WasDeleted(cr) && cr.Spec.ForProvider.Delete == nil) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	if cr.Spec.ForProvider.Observe == nil {
+		cr.Status.SetConditions(xpv1.Available())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	res, vars, err := c.send(ctx, cr, *cr.Spec.ForProvider.Observe)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserve)
+	}
+
+	if allNull(res.Data) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	upToDate, err := isExpectedResponse(cr.Spec.ForProvider.ExpectedResponse, res, vars)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+// allNull checks whether none of the fields of the given response data hold a value, which is how GraphQL APIs
+// answer queries for objects that do not exist.
+func allNull(data map[string]interface{}) bool {
+	for _, value := range data {
+		if value != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isExpectedResponse evaluates the given jq filter against the data of the given response and the variables it
+// was queried with. Every response is expected when the filter is empty.
+func isExpectedResponse(expectedResponse string, res graphql.Response, vars map[string]interface{}) (bool, error) {
+	if expectedResponse == "" {
+		return true, nil
+	}
+
+	expected, err := jq.ParseBool(expectedResponse, map[string]interface{}{"data": res.Data, "variables": vars})
+	if err != nil {
+		return false, errors.Errorf(errExpectedFormat, err.Error())
+	}
+
+	return expected, nil
+}
+
+// extractID returns the ID of the resource from the data of the create response. Numeric IDs are returned as
+// strings.
+func extractID(idPath string, res graphql.Response) (string, error) {
+	id, err := jq.ParseString(idPath+" | tostring", map[string]interface{}{"data": res.Data})
+	if err != nil || id == "" || id == "null" {
+		return "", errors.Errorf(errExtractID, idPath)
+	}
+
+	return id, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.GraphQLRequest)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotGraphQLRequest)
+	}
+
+	if !utils.IsUrlValid(cr.Spec.ForProvider.URL) {
+		return managed.ExternalCreation{}, errors.New(errInvalidURL)
+	}
+
+	res, _, err := c.send(ctx, cr, cr.Spec.ForProvider.Create)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	// Without an ID, the name of the resource records that it was created.
+	id := cr.Name
+	if cr.Spec.ForProvider.IDPath != "" {
+		if id, err = extractID(cr.Spec.ForProvider.IDPath, res); err != nil {
+			return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+		}
+	}
+	meta.SetExternalName(cr, id)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.GraphQLRequest)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotGraphQLRequest)
+	}
+
+	if cr.Spec.ForProvider.Update == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	_, _, err := c.send(ctx, cr, *cr.Spec.ForProvider.Update)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.GraphQLRequest)
+	if !ok {
+		return errors.New(errNotGraphQLRequest)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	if cr.Spec.ForProvider.Delete == nil {
+		return nil
+	}
+
+	_, _, err := c.send(ctx, cr, *cr.Spec.ForProvider.Delete)
+	return errors.Wrap(err, errDelete)
+}
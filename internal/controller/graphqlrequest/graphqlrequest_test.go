@@ -0,0 +1,282 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graphqlrequest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arielsepton/provider-http/apis/graphqlrequest/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/http/fake"
+)
+
+const (
+	testURL       = "https://api.example.com/graphql"
+	testVariables = `{"name": "jane"}`
+)
+
+// respond returns a MockSendRequestFn responding with the given status code and body to the query with the given
+// request body, and with a 500 to any other request.
+func respond(body string, statusCode int, response string) fake.MockSendRequestFn {
+	return fake.Server(map[string]httpClient.HttpResponse{http.MethodPost + " " + testURL + " " + body: {StatusCode: statusCode, Body: response}}, nil)
+}
+
+func graphQLRequest(id string) *v1alpha1.GraphQLRequest {
+	cr := &v1alpha1.GraphQLRequest{
+		ObjectMeta: v1.ObjectMeta{Name: "user"},
+		Spec: v1alpha1.GraphQLRequestSpec{
+			ForProvider: v1alpha1.GraphQLRequestParameters{
+				URL:              testURL,
+				Variables:        testVariables,
+				Create:           v1alpha1.Operation{Query: "mutation($name: String!) { createUser(name: $name) { id } }"},
+				Observe:          &v1alpha1.Operation{Query: "query($id: ID!) { user(id: $id) { name } }"},
+				IDPath:           ".data.createUser.id",
+				ExpectedResponse: ".data.user.name == .variables.name",
+			},
+		},
+	}
+	if id != "" {
+		meta.SetExternalName(cr, id)
+	}
+	return cr
+}
+
+func Test_Observe(t *testing.T) {
+	observeBody := `{"query":"query($id: ID!) { user(id: $id) { name } }","variables":{"id":"42","name":"jane"}}`
+
+	type want struct {
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		id   string
+		send fake.MockSendRequestFn
+		want want
+	}{
+		"NotCreated": {
+			want: want{obs: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			id:   "42",
+			send: respond(observeBody, http.StatusOK, `{"data": {"user": {"name": "jane"}}}`),
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"Outdated": {
+			id:   "42",
+			send: respond(observeBody, http.StatusOK, `{"data": {"user": {"name": "john"}}}`),
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"Deleted": {
+			id:   "42",
+			send: respond(observeBody, http.StatusOK, `{"data": {"user": null}}`),
+			want: want{obs: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ErrorsWithStatusOK": {
+			id:   "42",
+			send: respond(observeBody, http.StatusOK, `{"data": null, "errors": [{"message": "not authorized"}]}`),
+			want: want{err: errors.Wrap(errors.New("GraphQL response holds errors: not authorized"), errObserve)},
+		},
+		"StatusCode": {
+			id:   "42",
+			send: respond(observeBody, http.StatusBadGateway, ""),
+			want: want{err: errors.Wrap(errors.New("HTTP POST request failed with status code: 502"), errObserve)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: tc.send}}
+			got, err := e.Observe(context.Background(), graphQLRequest(tc.id))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Create(t *testing.T) {
+	createBody := `{"query":"mutation($name: String!) { createUser(name: $name) { id } }","variables":{"name":"jane"}}`
+
+	type want struct {
+		id  string
+		err error
+	}
+	cases := map[string]struct {
+		idPath string
+		send   fake.MockSendRequestFn
+		want   want
+	}{
+		"NumericID": {
+			idPath: ".data.createUser.id",
+			send:   respond(createBody, http.StatusOK, `{"data": {"createUser": {"id": 42}}}`),
+			want:   want{id: "42"},
+		},
+		"WithoutIDPath": {
+			send: respond(createBody, http.StatusOK, `{"data": {"createUser": {"id": 42}}}`),
+			want: want{id: "user"},
+		},
+		"MissingID": {
+			idPath: ".data.createUser.id",
+			send:   respond(createBody, http.StatusOK, `{"data": {"createUser": null}}`),
+			want:   want{err: errors.Wrap(errors.Errorf(errExtractID, ".data.createUser.id"), errCreate)},
+		},
+		"ErrorsWithStatusOK": {
+			idPath: ".data.createUser.id",
+			send:   respond(createBody, http.StatusOK, `{"errors": [{"message": "name is taken"}]}`),
+			want:   want{err: errors.Wrap(errors.New("GraphQL response holds errors: name is taken"), errCreate)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := graphQLRequest("")
+			cr.Spec.ForProvider.IDPath = tc.idPath
+
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: tc.send}}
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Create(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.id, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("Create(...): -want external name, +got external name: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Update(t *testing.T) {
+	update := &v1alpha1.Operation{Query: "mutation($id: ID!, $name: String!) { renameUser(id: $id, name: $name) { id } }"}
+	updateBody := `{"query":"mutation($id: ID!, $name: String!) { renameUser(id: $id, name: $name) { id } }","variables":{"id":"42","name":"jane"}}`
+
+	type want struct {
+		sent []string
+		err  error
+	}
+	cases := map[string]struct {
+		update    *v1alpha1.Operation
+		responses map[string]httpClient.HttpResponse
+		want      want
+	}{
+		"NoUpdate": {
+			want: want{},
+		},
+		"Updated": {
+			update: update,
+			responses: map[string]httpClient.HttpResponse{
+				http.MethodPost + " " + testURL + " " + updateBody: {StatusCode: http.StatusOK, Body: `{"data": {"renameUser": {"id": 42}}}`},
+			},
+			want: want{sent: []string{"POST " + testURL + " " + updateBody}},
+		},
+		"ErrorsWithStatusOK": {
+			update: update,
+			responses: map[string]httpClient.HttpResponse{
+				http.MethodPost + " " + testURL + " " + updateBody: {StatusCode: http.StatusOK, Body: `{"errors": [{"message": "not authorized"}]}`},
+			},
+			want: want{
+				sent: []string{"POST " + testURL + " " + updateBody},
+				err:  errors.Wrap(errors.New("GraphQL response holds errors: not authorized"), errUpdate),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := graphQLRequest("42")
+			cr.Spec.ForProvider.Update = tc.update
+
+			var sent []string
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: fake.Server(tc.responses, &sent)}}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Update(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Errorf("Update(...): -want requests, +got requests: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	del := &v1alpha1.Operation{Query: "mutation($id: ID!) { deleteUser(id: $id) }"}
+	deleteBody := `{"query":"mutation($id: ID!) { deleteUser(id: $id) }","variables":{"id":"42","name":"jane"}}`
+
+	type want struct {
+		sent []string
+		err  error
+	}
+	cases := map[string]struct {
+		delete    *v1alpha1.Operation
+		responses map[string]httpClient.HttpResponse
+		want      want
+	}{
+		"NoDelete": {
+			want: want{},
+		},
+		"Deleted": {
+			delete: del,
+			responses: map[string]httpClient.HttpResponse{
+				http.MethodPost + " " + testURL + " " + deleteBody: {StatusCode: http.StatusOK, Body: `{"data": {"deleteUser": true}}`},
+			},
+			want: want{sent: []string{"POST " + testURL + " " + deleteBody}},
+		},
+		"StatusCode": {
+			delete: del,
+			responses: map[string]httpClient.HttpResponse{
+				http.MethodPost + " " + testURL + " " + deleteBody: {StatusCode: http.StatusBadGateway},
+			},
+			want: want{
+				sent: []string{"POST " + testURL + " " + deleteBody},
+				err:  errors.Wrap(errors.New("HTTP POST request failed with status code: 502"), errDelete),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := graphQLRequest("42")
+			cr.Spec.ForProvider.Delete = tc.delete
+
+			var sent []string
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: fake.Server(tc.responses, &sent)}}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Delete(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Errorf("Delete(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(xpv1.Deleting(), cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want condition, +got condition: %s", diff)
+			}
+		})
+	}
+}
@@ -17,25 +17,36 @@ limitations under the License.
 package controller
 
 import (
-	"time"
-
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	"github.com/arielsepton/provider-http/internal/controller/batchrequest"
 	"github.com/arielsepton/provider-http/internal/controller/config"
 	desposiblerequest "github.com/arielsepton/provider-http/internal/controller/desposiblerequest"
+	"github.com/arielsepton/provider-http/internal/controller/filedownload"
+	"github.com/arielsepton/provider-http/internal/controller/graphqlrequest"
+	"github.com/arielsepton/provider-http/internal/controller/httpprobe"
+	"github.com/arielsepton/provider-http/internal/controller/options"
 	request "github.com/arielsepton/provider-http/internal/controller/request"
+	"github.com/arielsepton/provider-http/internal/controller/restresource"
+	"github.com/arielsepton/provider-http/internal/controller/workflow"
 )
 
 // Setup creates all http controllers with the supplied logger and adds them to
 // the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
-	for _, setup := range []func(ctrl.Manager, controller.Options, time.Duration) error{
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
+	for _, setup := range []func(ctrl.Manager, controller.Options, options.Options) error{
 		config.Setup,
+		batchrequest.Setup,
 		desposiblerequest.Setup,
+		filedownload.Setup,
+		graphqlrequest.Setup,
+		httpprobe.Setup,
 		request.Setup,
+		restresource.Setup,
+		workflow.Setup,
 	} {
-		if err := setup(mgr, o, timeout); err != nil {
+		if err := setup(mgr, o, opts); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpprobe
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/arielsepton/provider-http/apis/httpprobe/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/options"
+	"github.com/arielsepton/provider-http/internal/controller/requeue"
+	"github.com/arielsepton/provider-http/internal/controller/timestamps"
+	"github.com/arielsepton/provider-http/internal/jq"
+	json_util "github.com/arielsepton/provider-http/internal/json"
+	"github.com/arielsepton/provider-http/internal/tracing"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	errNotHttpProbe      = "managed resource is not a HttpProbe custom resource"
+	errEvaluateAssertion = "cannot evaluate assertion %s"
+	errLatency           = "latency %s exceeds %s"
+)
+
+// Setup adds a controller that reconciles HttpProbe managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
+	name := managed.ControllerName(v1alpha1.HttpProbeGroupKind)
+	cps := options.ConnectionPublishers(mgr.GetClient(), mgr.GetScheme(), o)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.HttpProbeGroupVersionKind),
+		managed.WithExternalConnecter(timestamps.NewConnecter(&connector{
+			Clients: opts.Clients(mgr.GetClient()),
+			logger:  o.Logger,
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(opts.Timeout),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	schedules := []requeue.ScheduleFn{
+		requeuePeriod(o.PollInterval),
+		requeue.PollJitter(o.PollInterval, opts.PollJitter),
+		requeue.PollInterval(o.PollInterval, opts.PollJitter, requeue.ResourcePollInterval(mgr.GetClient())),
+		opts.LoadShedder(name).Schedule,
+	}
+	requeuer := requeue.NewReconciler(r, mgr.GetClient(), newHttpProbe, schedules...)
+	traced := tracing.NewReconciler(name, requeuer)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(opts.ForControllerRuntime(o, v1alpha1.HttpProbeKind)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.HttpProbe{}).
+		Complete(ratelimiter.NewReconciler(name, traced, o.GlobalRateLimiter))
+}
+
+func newHttpProbe() resource.Managed {
+	return &v1alpha1.HttpProbe{}
+}
+
+// requeuePeriod returns a ScheduleFn probing a HttpProbe with a period after its period instead of the poll
+// interval.
+func requeuePeriod(pollInterval time.Duration) requeue.ScheduleFn {
+	return func(mg resource.Managed, result reconcile.Result) reconcile.Result {
+		cr, ok := mg.(*v1alpha1.HttpProbe)
+		if !ok || cr.Spec.ForProvider.Period == nil || cr.Spec.ForProvider.Period.Duration <= 0 || result.Requeue || result.RequeueAfter != pollInterval {
+			return result
+		}
+
+		return reconcile.Result{RequeueAfter: cr.Spec.ForProvider.Period.Duration}
+	}
+}
+
+type connector struct {
+	options.Clients
+	logger logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.HttpProbe)
+	if !ok {
+		return nil, errors.New(errNotHttpProbe)
+	}
+
+	l := c.logger.WithValues("httpProbe", cr.Name)
+
+	h, err := c.NewClient(ctx, l, cr, options.ClientParameters{
+		Kind:                  v1alpha1.HttpProbeKind,
+		Auth:                  cr.Spec.ForProvider.Auth,
+		WaitTimeout:           cr.Spec.ForProvider.WaitTimeout,
+		InsecureSkipTLSVerify: cr.Spec.ForProvider.InsecureSkipTLSVerify,
+		TLS:                   cr.Spec.ForProvider.TLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		logger:        l,
+		http:          h.Client,
+		statusCodes:   utils.StatusCodePolicy(cr.Spec.ForProvider.StatusCodes, h.ProviderConfig.Spec.StatusCodes),
+		skipTLSVerify: h.SkipTLSVerify,
+		correlationID: h.CorrelationID,
+		now:           time.Now,
+	}, nil
+}
+
+type external struct {
+	logger      logging.Logger
+	http        httpClient.Client
+	statusCodes *apisv1alpha1.StatusCodePolicy
+
+	// skipTLSVerify skips TLS certificate checks, when set by the resource or its ProviderConfig.
+	skipTLSVerify bool
+
+	// correlationID is the value of the correlation header set on the requests, recorded in the status.
+	correlationID string
+
+	// now returns the current time, it measures the latency of the probes.
+	now func() time.Time
+}
+
+// failedAssertions returns the messages of the given assertions the given response fails.
+func failedAssertions(assertions []v1alpha1.Assertion, response httpClient.HttpResponse) ([]string, error) {
+	if len(assertions) == 0 {
+		return nil, nil
+	}
+
+	responseMap, err := json_util.StructToMap(map[string]interface{}{
+		"response": map[string]interface{}{
+			"statusCode": response.StatusCode,
+			"headers":    response.Headers,
+			"body":       response.Body,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert response to map")
+	}
+
+	json_util.ConvertJSONStringsToMaps(&responseMap)
+
+	var failed []string
+	for _, assertion := range assertions {
+		ok, err := jq.ParseBool(assertion.Expression, responseMap)
+		if err != nil {
+			return nil, errors.Wrapf(err, errEvaluateAssertion, assertion.Expression)
+		}
+
+		if !ok {
+			failed = append(failed, assertion.Message)
+		}
+	}
+
+	return failed, nil
+}
+
+// probe sends a GET request to the endpoint and records its health in the status of the HttpProbe. It returns
+// why the endpoint is unhealthy, or nil when it is healthy.
+func (c *external) probe(ctx context.Context, cr *v1alpha1.HttpProbe) error {
+	start := c.now()
+	details, err := c.http.SendRequest(ctx, http.MethodGet, cr.Spec.ForProvider.URL, "", cr.Spec.ForProvider.Headers, c.skipTLSVerify)
+	latency := c.now().Sub(start)
+	cr.SetTimings(utils.Timings(details.Timings))
+	cr.SetCorrelationID(c.correlationID)
+	res := details.HttpResponse
+
+	probeTime := metav1.NewTime(start)
+	cr.Status.LastProbeTime = &probeTime
+	cr.Status.Latency = &metav1.Duration{Duration: latency}
+	cr.Status.StatusCode = res.StatusCode
+	cr.Status.FailedAssertions = nil
+
+	if err != nil {
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, err), err)
+		return err
+	}
+
+	if !utils.IsSuccess(c.statusCodes, res.StatusCode) {
+		err := errors.Errorf(utils.ErrStatusCode, http.MethodGet, strconv.Itoa(res.StatusCode))
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, nil), err)
+		return err
+	}
+
+	if max := cr.Spec.ForProvider.MaxLatency; max != nil && latency > max.Duration {
+		err := errors.Errorf(errLatency, latency, max.Duration)
+		cr.SetError(apisv1alpha1.FailureReasonTimeout, err)
+		return err
+	}
+
+	failed, err := failedAssertions(cr.Spec.ForProvider.Assertions, res)
+	if err != nil {
+		cr.SetError(apisv1alpha1.FailureReasonUnexpectedResponse, err)
+		return err
+	}
+	if len(failed) > 0 {
+		cr.Status.FailedAssertions = failed
+		err := errors.New(strings.Join(failed, "; "))
+		cr.SetError(apisv1alpha1.FailureReasonUnexpectedResponse, err)
+		return err
+	}
+
+	cr.SetError("", nil)
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.HttpProbe)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotHttpProbe)
+	}
+
+	// An unhealthy endpoint is what the probe observes rather than a failure to reconcile it, so it is
+	// reflected in the Ready condition while the HttpProbe stays synced.
+	if err := c.probe(ctx, cr); err != nil {
+		cr.Status.Healthy = false
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(err.Error()))
+	} else {
+		cr.Status.Healthy = true
+		cr.Status.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (c *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package httpprobe
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/arielsepton/provider-http/apis/httpprobe/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/http/fake"
+)
+
+var errBoom = errors.New("boom")
+
+const testURL = "https://api.example.com/healthz"
+
+// clock returns a now function whose consecutive calls are the given latency apart.
+func clock(latency time.Duration) func() time.Time {
+	t := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now := t
+		t = t.Add(latency)
+		return now
+	}
+}
+
+func httpProbe() *v1alpha1.HttpProbe {
+	return &v1alpha1.HttpProbe{
+		ObjectMeta: v1.ObjectMeta{Name: "api"},
+		Spec: v1alpha1.HttpProbeSpec{
+			ForProvider: v1alpha1.HttpProbeParameters{
+				URL:        testURL,
+				MaxLatency: &v1.Duration{Duration: time.Second},
+				Assertions: []v1alpha1.Assertion{
+					{Expression: `.response.body.status == "ok"`, Message: "the service is degraded"},
+				},
+			},
+		},
+	}
+}
+
+func Test_Observe(t *testing.T) {
+	type want struct {
+		healthy          bool
+		statusCode       int
+		failedAssertions []string
+		reason           apisv1alpha1.FailureReason
+		condition        xpv1.Condition
+	}
+	cases := map[string]struct {
+		send    fake.MockSendRequestFn
+		latency time.Duration
+		want    want
+	}{
+		"Healthy": {
+			send:    fake.Respond(http.StatusOK, `{"status": "ok"}`),
+			latency: 100 * time.Millisecond,
+			want: want{
+				healthy:    true,
+				statusCode: http.StatusOK,
+				condition:  xpv1.Available(),
+			},
+		},
+		"ServerError": {
+			send:    fake.Respond(http.StatusServiceUnavailable, ""),
+			latency: 100 * time.Millisecond,
+			want: want{
+				statusCode: http.StatusServiceUnavailable,
+				reason:     apisv1alpha1.FailureReasonServerError,
+				condition:  xpv1.Unavailable().WithMessage("HTTP GET request failed with status code: 503"),
+			},
+		},
+		"Slow": {
+			send:    fake.Respond(http.StatusOK, `{"status": "ok"}`),
+			latency: 2 * time.Second,
+			want: want{
+				statusCode: http.StatusOK,
+				reason:     apisv1alpha1.FailureReasonTimeout,
+				condition:  xpv1.Unavailable().WithMessage("latency 2s exceeds 1s"),
+			},
+		},
+		"AssertionFailed": {
+			send:    fake.Respond(http.StatusOK, `{"status": "degraded"}`),
+			latency: 100 * time.Millisecond,
+			want: want{
+				statusCode:       http.StatusOK,
+				failedAssertions: []string{"the service is degraded"},
+				reason:           apisv1alpha1.FailureReasonUnexpectedResponse,
+				condition:        xpv1.Unavailable().WithMessage("the service is degraded"),
+			},
+		},
+		"RequestFailed": {
+			send: func(_ context.Context, _ string, _ string, _ string, _ map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+				return httpClient.HttpDetails{}, errBoom
+			},
+			want: want{
+				reason:    apisv1alpha1.FailureReasonConnection,
+				condition: xpv1.Unavailable().WithMessage(errBoom.Error()),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := httpProbe()
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: tc.send}, now: clock(tc.latency)}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.healthy, cr.Status.Healthy); diff != "" {
+				t.Errorf("Observe(...): -want healthy, +got healthy: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.statusCode, cr.Status.StatusCode); diff != "" {
+				t.Errorf("Observe(...): -want status code, +got status code: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.failedAssertions, cr.Status.FailedAssertions); diff != "" {
+				t.Errorf("Observe(...): -want failed assertions, +got failed assertions: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.reason, cr.Status.LastFailureReason); diff != "" {
+				t.Errorf("Observe(...): -want failure reason, +got failure reason: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.Status.GetCondition(xpv1.TypeReady), test.EquateConditions(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Observe(...): -want condition, +got condition: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_requeuePeriod(t *testing.T) {
+	pollInterval := time.Minute
+	cases := map[string]struct {
+		period *v1.Duration
+		result reconcile.Result
+		want   reconcile.Result
+	}{
+		"NoPeriod": {
+			result: reconcile.Result{RequeueAfter: pollInterval},
+			want:   reconcile.Result{RequeueAfter: pollInterval},
+		},
+		"Period": {
+			period: &v1.Duration{Duration: 10 * time.Second},
+			result: reconcile.Result{RequeueAfter: pollInterval},
+			want:   reconcile.Result{RequeueAfter: 10 * time.Second},
+		},
+		"ShortWait": {
+			period: &v1.Duration{Duration: 10 * time.Second},
+			result: reconcile.Result{RequeueAfter: time.Second},
+			want:   reconcile.Result{RequeueAfter: time.Second},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := httpProbe()
+			cr.Spec.ForProvider.Period = tc.period
+			got := requeuePeriod(pollInterval)(cr, tc.result)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("requeuePeriod(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package harness simulates the polling of many resources against a remote
+// API suffering an outage, in virtual time, to measure how load shedding
+// reduces the requests sent to it and how fast polling recovers.
+package harness
+
+import (
+	"container/heap"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/controller/loadshed"
+)
+
+// Config describes a simulation.
+type Config struct {
+	// Resources is the number of polled resources.
+	Resources int
+
+	// PollInterval is the poll interval of every resource.
+	PollInterval time.Duration
+
+	// Duration is the simulated duration.
+	Duration time.Duration
+
+	// OutageStart and OutageEnd delimit the period during which every request
+	// to the remote API fails with a server error.
+	OutageStart time.Duration
+	OutageEnd   time.Duration
+
+	// MaxStretch is passed to the load shedder, 1 disables it.
+	MaxStretch float64
+}
+
+// Result holds the requests sent during a simulation.
+type Result struct {
+	// RequestsPerMinute is the number of requests sent in every simulated minute.
+	RequestsPerMinute []int
+}
+
+// Requests returns the number of requests sent between the given offsets.
+func (r Result) Requests(from, to time.Duration) int {
+	total := 0
+	for minute := int(from / time.Minute); minute < int(to/time.Minute) && minute < len(r.RequestsPerMinute); minute++ {
+		total += r.RequestsPerMinute[minute]
+	}
+	return total
+}
+
+type poll struct {
+	at time.Duration
+	cr *v1alpha1.Request
+}
+
+type schedule []poll
+
+func (s schedule) Len() int            { return len(s) }
+func (s schedule) Less(i, j int) bool  { return s[i].at < s[j].at }
+func (s schedule) Swap(i, j int)       { s[i], s[j] = s[j], s[i] }
+func (s *schedule) Push(x interface{}) { *s = append(*s, x.(poll)) }
+func (s *schedule) Pop() interface{} {
+	old := *s
+	p := old[len(old)-1]
+	*s = old[:len(old)-1]
+	return p
+}
+
+// Run runs the described simulation. The first polls of the resources are
+// spread evenly over the first poll interval.
+func Run(cfg Config) Result {
+	start := time.Unix(0, 0)
+	now := time.Duration(0)
+	shedder := loadshed.New(loadshed.Config{
+		MaxStretch: cfg.MaxStretch,
+		Now:        func() time.Time { return start.Add(now) },
+	})
+
+	s := make(schedule, 0, cfg.Resources)
+	for i := 0; i < cfg.Resources; i++ {
+		s = append(s, poll{at: cfg.PollInterval * time.Duration(i) / time.Duration(cfg.Resources), cr: &v1alpha1.Request{}})
+	}
+	heap.Init(&s)
+
+	result := Result{RequestsPerMinute: make([]int, int(cfg.Duration/time.Minute)+1)}
+	for s.Len() > 0 {
+		p := heap.Pop(&s).(poll)
+		if p.at >= cfg.Duration {
+			break
+		}
+		now = p.at
+
+		result.RequestsPerMinute[int(now/time.Minute)]++
+		p.cr.Status.LastFailureReason = ""
+		if now >= cfg.OutageStart && now < cfg.OutageEnd {
+			p.cr.Status.LastFailureReason = apisv1alpha1.FailureReasonServerError
+		}
+
+		next := shedder.Schedule(p.cr, reconcile.Result{RequeueAfter: cfg.PollInterval})
+		heap.Push(&s, poll{at: now + next.RequeueAfter, cr: p.cr})
+	}
+
+	return result
+}
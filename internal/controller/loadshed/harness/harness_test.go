@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package harness
+
+import (
+	"testing"
+	"time"
+)
+
+var outage10k = Config{
+	Resources:    10000,
+	PollInterval: time.Minute,
+	Duration:     40 * time.Minute,
+	OutageStart:  5 * time.Minute,
+	OutageEnd:    20 * time.Minute,
+}
+
+func Test_Run(t *testing.T) {
+	baseline := outage10k
+	baseline.MaxStretch = 1
+	shedding := outage10k
+	shedding.MaxStretch = 4
+
+	without, with := Run(baseline), Run(shedding)
+
+	// Once the failures are detected, the remote API gets a quarter of the requests.
+	outageWithout := without.Requests(10*time.Minute, 20*time.Minute)
+	outageWith := with.Requests(10*time.Minute, 20*time.Minute)
+	if outageWith*3 > outageWithout {
+		t.Errorf("Run(...): %d requests during the outage with load shedding, want less than a third of %d without", outageWith, outageWithout)
+	}
+
+	// Polling recovers once the remote API is healthy again.
+	recoveredWithout := without.Requests(30*time.Minute, 40*time.Minute)
+	recoveredWith := with.Requests(30*time.Minute, 40*time.Minute)
+	if recoveredWith*10 < recoveredWithout*9 {
+		t.Errorf("Run(...): %d requests after the outage with load shedding, want at least 90%% of %d without", recoveredWith, recoveredWithout)
+	}
+}
+
+func BenchmarkRun10k(b *testing.B) {
+	cfg := outage10k
+	cfg.MaxStretch = 4
+	for i := 0; i < b.N; i++ {
+		Run(cfg)
+	}
+}
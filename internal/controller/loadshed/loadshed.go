@@ -0,0 +1,244 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package loadshed stretches the poll intervals of the resources of a
+// controller while the remote APIs they call keep failing, or while the work
+// queue of the controller is deep, to give both a chance to recover.
+package loadshed
+
+import (
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+const (
+	numBuckets = 10
+
+	defaultWindow            = time.Minute
+	defaultMinSamples        = 20
+	defaultFailureRate       = 0.5
+	defaultQueueDepth        = 1000
+	defaultQueueDepthRefresh = 5 * time.Second
+)
+
+// FailureReasoner is a resource recording the reason of its most recent failure.
+type FailureReasoner interface {
+	GetLastFailureReason() apisv1alpha1.FailureReason
+}
+
+// Config configures a Shedder.
+type Config struct {
+	// MaxStretch is the maximum factor by which poll intervals are stretched.
+	// Values of 1 or less disable load shedding.
+	MaxStretch float64
+
+	// Window is the duration over which the failure rate is computed.
+	Window time.Duration
+
+	// MinSamples is the number of reconciles within the window from which the
+	// failure rate is considered.
+	MinSamples int
+
+	// FailureRate is the share of failing resources from which poll intervals
+	// are stretched. They are stretched by MaxStretch when all resources fail.
+	FailureRate float64
+
+	// QueueDepth returns the current depth of the work queue, it is ignored
+	// when nil.
+	QueueDepth func() int
+
+	// QueueDepthThreshold is the depth of the work queue from which poll
+	// intervals are stretched, proportionally to the depth.
+	QueueDepthThreshold int
+
+	// Now returns the current time, it defaults to time.Now.
+	Now func() time.Time
+}
+
+type bucket struct {
+	start  int64
+	total  int
+	failed int
+}
+
+// A Shedder tracks the health of the reconciles of a controller and stretches
+// their poll intervals under pressure. It is safe for concurrent use.
+type Shedder struct {
+	cfg       Config
+	bucketDur int64
+
+	mu      sync.Mutex
+	buckets [numBuckets]bucket
+
+	depth        int
+	depthUpdated time.Time
+}
+
+// New returns a Shedder with the given configuration, unset fields get
+// default values.
+func New(cfg Config) *Shedder {
+	if cfg.Window <= 0 {
+		cfg.Window = defaultWindow
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = defaultMinSamples
+	}
+	if cfg.FailureRate <= 0 || cfg.FailureRate >= 1 {
+		cfg.FailureRate = defaultFailureRate
+	}
+	if cfg.QueueDepthThreshold <= 0 {
+		cfg.QueueDepthThreshold = defaultQueueDepth
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return &Shedder{
+		cfg:       cfg,
+		bucketDur: int64(cfg.Window) / numBuckets,
+	}
+}
+
+// Enabled checks whether the Shedder may stretch poll intervals at all.
+func (s *Shedder) Enabled() bool {
+	return s.cfg.MaxStretch > 1
+}
+
+// Record records the outcome of a reconcile.
+func (s *Shedder) Record(failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start := s.cfg.Now().UnixNano() / s.bucketDur
+	b := &s.buckets[start%numBuckets]
+	if b.start != start {
+		*b = bucket{start: start}
+	}
+
+	b.total++
+	if failed {
+		b.failed++
+	}
+}
+
+// Stretch returns the factor by which poll intervals are currently stretched,
+// 1 when there is no pressure.
+func (s *Shedder) Stretch() float64 {
+	if !s.Enabled() {
+		return 1
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stretch := 1.0
+	if f := s.failureStretch(); f > stretch {
+		stretch = f
+	}
+	if f := s.queueStretch(); f > stretch {
+		stretch = f
+	}
+
+	if stretch > s.cfg.MaxStretch {
+		return s.cfg.MaxStretch
+	}
+	return stretch
+}
+
+// failureStretch grows linearly from 1 at the failure rate threshold to
+// MaxStretch when every reconcile fails.
+func (s *Shedder) failureStretch() float64 {
+	oldest := s.cfg.Now().UnixNano()/s.bucketDur - numBuckets + 1
+
+	total, failed := 0, 0
+	for _, b := range s.buckets {
+		if b.start >= oldest {
+			total += b.total
+			failed += b.failed
+		}
+	}
+
+	if total < s.cfg.MinSamples {
+		return 1
+	}
+
+	rate := float64(failed) / float64(total)
+	if rate <= s.cfg.FailureRate {
+		return 1
+	}
+
+	return 1 + (rate-s.cfg.FailureRate)/(1-s.cfg.FailureRate)*(s.cfg.MaxStretch-1)
+}
+
+// queueStretch is the ratio of the depth of the work queue to its threshold.
+// The depth is refreshed at most every few seconds, as reading it may be
+// expensive.
+func (s *Shedder) queueStretch() float64 {
+	if s.cfg.QueueDepth == nil {
+		return 1
+	}
+
+	if now := s.cfg.Now(); now.Sub(s.depthUpdated) >= defaultQueueDepthRefresh {
+		s.depth = s.cfg.QueueDepth()
+		s.depthUpdated = now
+	}
+
+	if s.depth <= s.cfg.QueueDepthThreshold {
+		return 1
+	}
+
+	return float64(s.depth) / float64(s.cfg.QueueDepthThreshold)
+}
+
+// Schedule records whether the given resource is failing because of its
+// remote API, and stretches the delay of its next poll under pressure. It can
+// be used as a requeue.ScheduleFn.
+func (s *Shedder) Schedule(mg resource.Managed, result reconcile.Result) reconcile.Result {
+	if !s.Enabled() {
+		return result
+	}
+
+	s.Record(remoteFailure(mg))
+
+	if result.RequeueAfter <= 0 {
+		return result
+	}
+
+	result.RequeueAfter = time.Duration(float64(result.RequeueAfter) * s.Stretch())
+	return result
+}
+
+// remoteFailure checks whether the resource is failing because its remote API
+// is unavailable, rather than because of its own configuration.
+func remoteFailure(mg resource.Managed) bool {
+	fr, ok := mg.(FailureReasoner)
+	if !ok {
+		return false
+	}
+
+	switch fr.GetLastFailureReason() {
+	case apisv1alpha1.FailureReasonServerError, apisv1alpha1.FailureReasonTimeout, apisv1alpha1.FailureReasonConnection,
+		apisv1alpha1.FailureReasonCircuitOpen:
+		return true
+	default:
+		return false
+	}
+}
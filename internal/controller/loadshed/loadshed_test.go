@@ -0,0 +1,234 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadshed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func Test_Stretch(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	type args struct {
+		cfg     Config
+		ok      int
+		failed  int
+		elapsed time.Duration
+	}
+	type want struct {
+		stretch float64
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Disabled": {
+			args: args{
+				cfg:    Config{MaxStretch: 1},
+				failed: 100,
+			},
+			want: want{
+				stretch: 1,
+			},
+		},
+		"NotEnoughSamples": {
+			args: args{
+				cfg:    Config{MaxStretch: 4},
+				failed: 10,
+			},
+			want: want{
+				stretch: 1,
+			},
+		},
+		"BelowFailureRate": {
+			args: args{
+				cfg:    Config{MaxStretch: 4},
+				ok:     50,
+				failed: 50,
+			},
+			want: want{
+				stretch: 1,
+			},
+		},
+		"AboveFailureRate": {
+			args: args{
+				cfg:    Config{MaxStretch: 4},
+				ok:     25,
+				failed: 75,
+			},
+			want: want{
+				stretch: 2.5,
+			},
+		},
+		"AllFailed": {
+			args: args{
+				cfg:    Config{MaxStretch: 4},
+				failed: 100,
+			},
+			want: want{
+				stretch: 4,
+			},
+		},
+		"FailuresOutsideWindow": {
+			args: args{
+				cfg:     Config{MaxStretch: 4},
+				failed:  100,
+				elapsed: 2 * time.Minute,
+			},
+			want: want{
+				stretch: 1,
+			},
+		},
+		"DeepQueue": {
+			args: args{
+				cfg: Config{MaxStretch: 4, QueueDepth: func() int { return 3000 }},
+			},
+			want: want{
+				stretch: 3,
+			},
+		},
+		"DeepQueueCapped": {
+			args: args{
+				cfg: Config{MaxStretch: 4, QueueDepth: func() int { return 10000 }},
+			},
+			want: want{
+				stretch: 4,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			current := now
+			tc.args.cfg.Now = func() time.Time { return current }
+			s := New(tc.args.cfg)
+			for i := 0; i < tc.args.ok; i++ {
+				s.Record(false)
+			}
+			for i := 0; i < tc.args.failed; i++ {
+				s.Record(true)
+			}
+			current = current.Add(tc.args.elapsed)
+
+			if diff := cmp.Diff(tc.want.stretch, s.Stretch()); diff != "" {
+				t.Errorf("Stretch(): -want stretch, +got stretch: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Schedule(t *testing.T) {
+	failing := &v1alpha1.Request{}
+	failing.Status.LastFailureReason = apisv1alpha1.FailureReasonServerError
+
+	type args struct {
+		mg     resource.Managed
+		result reconcile.Result
+	}
+	type want struct {
+		result reconcile.Result
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Stretched": {
+			args: args{
+				mg:     failing,
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: 4 * time.Minute},
+			},
+		},
+		"ImmediateRequeue": {
+			args: args{
+				mg:     failing,
+				result: reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			s := New(Config{MaxStretch: 4, MinSamples: 1})
+			got := s.Schedule(tc.args.mg, tc.args.result)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Schedule(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_remoteFailure(t *testing.T) {
+	cases := map[apisv1alpha1.FailureReason]bool{
+		"":                                    false,
+		apisv1alpha1.FailureReasonServerError: true,
+		apisv1alpha1.FailureReasonTimeout:     true,
+		apisv1alpha1.FailureReasonConnection:  true,
+		apisv1alpha1.FailureReasonCircuitOpen: true,
+		apisv1alpha1.FailureReasonClientError: false,
+		apisv1alpha1.FailureReasonAuth:        false,
+	}
+	for reason, want := range cases {
+		cr := &v1alpha1.Request{}
+		cr.Status.LastFailureReason = reason
+		if diff := cmp.Diff(want, remoteFailure(cr)); diff != "" {
+			t.Errorf("remoteFailure(%q): -want, +got: %s", reason, diff)
+		}
+	}
+}
+
+func Test_WorkqueueDepth(t *testing.T) {
+	depth := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: workqueueDepthMetric}, []string{"name"})
+	depth.WithLabelValues("managed/request").Set(42)
+	depth.WithLabelValues("managed/other").Set(7)
+
+	r := prometheus.NewRegistry()
+	r.MustRegister(depth)
+
+	if diff := cmp.Diff(42, WorkqueueDepth(r, "managed/request")()); diff != "" {
+		t.Errorf("WorkqueueDepth(...): -want depth, +got depth: %s", diff)
+	}
+	if diff := cmp.Diff(0, WorkqueueDepth(r, "managed/unknown")()); diff != "" {
+		t.Errorf("WorkqueueDepth(...): -want depth, +got depth: %s", diff)
+	}
+}
+
+func BenchmarkSchedule(b *testing.B) {
+	s := New(Config{MaxStretch: 4})
+	cr := &v1alpha1.Request{}
+	cr.Status.LastFailureReason = apisv1alpha1.FailureReasonServerError
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			s.Schedule(cr, reconcile.Result{RequeueAfter: time.Minute})
+		}
+	})
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadshed
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var workqueueDepthMetric = metrics.WorkQueueSubsystem + "_" + metrics.DepthKey
+
+// WorkqueueDepth returns a function reading the depth of the work queue of the
+// named controller from the metrics gathered by g, e.g. metrics.Registry.
+func WorkqueueDepth(g prometheus.Gatherer, name string) func() int {
+	return func() int {
+		families, err := g.Gather()
+		if err != nil {
+			return 0
+		}
+
+		for _, family := range families {
+			if family.GetName() != workqueueDepthMetric {
+				continue
+			}
+			for _, m := range family.GetMetric() {
+				for _, label := range m.GetLabel() {
+					if label.GetName() == "name" && label.GetValue() == name {
+						return int(m.GetGauge().GetValue())
+					}
+				}
+			}
+		}
+
+		return 0
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/clients/auth"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/ratelimit"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	errTrackPCUsage         = "cannot track ProviderConfig usage"
+	errProviderNotRetrieved = "provider could not be retrieved"
+	errAuthenticate         = "cannot configure request authentication"
+	errConfigureVault       = "cannot configure Vault secret resolution"
+	errNewHttpClient        = "cannot create new Http client"
+)
+
+// Clients create the http clients of the managed resources from their
+// ProviderConfig.
+type Clients struct {
+	// Kube reads the ProviderConfigs and the secrets they reference.
+	Kube client.Client
+
+	// Usage tracks the usage of the ProviderConfigs.
+	Usage resource.Tracker
+
+	// NewHttpClient creates the http clients.
+	NewHttpClient NewHttpClientFn
+
+	// Tokens caches the OAuth2 tokens of the ProviderConfigs.
+	Tokens *auth.TokenCache
+
+	// RateLimiters hold the rate limits of the ProviderConfigs.
+	RateLimiters *ratelimit.Limiters
+}
+
+// Clients returns the factory of the http clients of the managed resources,
+// reading their ProviderConfig with the given client.
+func (o Options) Clients(kube client.Client) Clients {
+	return Clients{
+		Kube:          kube,
+		Usage:         resource.NewProviderConfigUsageTracker(kube, &apisv1alpha1.ProviderConfigUsage{}),
+		NewHttpClient: o.HttpClientFn(),
+		Tokens:        o.TokenCache(),
+		RateLimiters:  o.RateLimiters,
+	}
+}
+
+// ClientParameters are the settings of a managed resource its http client is
+// created with.
+type ClientParameters struct {
+	// Kind of the resource, sent in the User-Agent.
+	Kind string
+
+	// Auth replaces the auth of the ProviderConfig when set.
+	Auth *apisv1alpha1.Auth
+
+	// WaitTimeout replaces the request timeout of the ProviderConfig when set.
+	WaitTimeout *metav1.Duration
+
+	// InsecureSkipTLSVerify skips TLS certificate checks.
+	InsecureSkipTLSVerify bool
+
+	// TLS overrides the TLS settings of the ProviderConfig, and takes
+	// precedence over InsecureSkipTLSVerify.
+	TLS *apisv1alpha1.TLSConfig
+}
+
+// Client is the http client of a managed resource, with the settings it
+// derived from its ProviderConfig.
+type Client struct {
+	httpClient.Client
+
+	// ProviderConfig the client was created from.
+	ProviderConfig *apisv1alpha1.ProviderConfig
+
+	// SkipTLSVerify skips TLS certificate checks, as set by the resource or
+	// otherwise by its ProviderConfig.
+	SkipTLSVerify bool
+
+	// CorrelationID is the value of the correlation header set on the
+	// requests, recorded in the status of the resource.
+	CorrelationID string
+}
+
+// ProviderConfig tracks the usage of the ProviderConfig of the given managed
+// resource, and returns it.
+func (c Clients) ProviderConfig(ctx context.Context, mg resource.Managed) (*apisv1alpha1.ProviderConfig, error) {
+	if err := c.Usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	n := types.NamespacedName{Name: mg.GetProviderConfigReference().Name}
+	if err := c.Kube.Get(ctx, n, pc); err != nil {
+		return nil, errors.Wrap(err, errProviderNotRetrieved)
+	}
+
+	return pc, nil
+}
+
+// ClientOptions returns the options the http clients of a managed resource of
+// the given kind take from its ProviderConfig: its Vault secrets, rate limit,
+// transport timeouts, correlation header and User-Agent. It also returns the
+// correlation ID of the reconcile, recorded in the status of the resource.
+func (c Clients) ClientOptions(ctx context.Context, pc *apisv1alpha1.ProviderConfig, mg resource.Managed, kind string) ([]httpClient.ClientOption, string, error) {
+	opts, err := auth.VaultClientOptions(ctx, c.Kube, c.Tokens, pc.Spec.Vault)
+	if err != nil {
+		return nil, "", errors.Wrap(err, errConfigureVault)
+	}
+
+	correlationID := utils.CorrelationID(ctx, pc.Spec.CorrelationHeader, mg)
+	opts = append(opts, c.RateLimiters.ClientOptions(pc)...)
+	opts = append(opts, utils.TransportTimeoutOptions(pc.Spec.Timeouts)...)
+	opts = append(opts, utils.CorrelationOptions(pc.Spec.CorrelationHeader, correlationID)...)
+	opts = append(opts, utils.UserAgentOptions(pc.Spec.UserAgent, kind)...)
+	return opts, correlationID, nil
+}
+
+// NewClient creates the http client of the given managed resource from its
+// ProviderConfig, authenticated with the auth of the resource or otherwise
+// of the ProviderConfig. The TLS settings of the resource are merged over
+// those of the ProviderConfig, like those of Requests.
+func (c Clients) NewClient(ctx context.Context, log logging.Logger, mg resource.Managed, params ClientParameters) (*Client, error) {
+	pc, err := c.ProviderConfig(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+
+	authOpts, err := auth.ClientOptions(ctx, c.Kube, c.Tokens, auth.Effective(params.Auth, pc.Spec.Auth))
+	if err != nil {
+		return nil, errors.Wrap(err, errAuthenticate)
+	}
+
+	sharedOpts, correlationID, err := c.ClientOptions(ctx, pc, mg, params.Kind)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := auth.EffectiveTLS(pc.Spec.TLS, auth.SkipTLSVerify(params.InsecureSkipTLSVerify), params.TLS)
+	tlsOpts, err := auth.TLSClientOptions(ctx, c.Kube, tlsConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHttpClient)
+	}
+
+	opts := append(append(tlsOpts, authOpts...), sharedOpts...)
+	h, err := c.NewHttpClient(log, utils.RequestTimeout(params.WaitTimeout, pc.Spec.Timeouts), opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHttpClient)
+	}
+
+	return &Client{
+		Client:         h,
+		ProviderConfig: pc,
+		SkipTLSVerify:  *tlsConfig.InsecureSkipVerify,
+		CorrelationID:  correlationID,
+	}, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/clients/auth"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+func Test_ClientsNewClient(t *testing.T) {
+	errBoom := errors.New("boom")
+	skipVerify := true
+	verify := false
+
+	type args struct {
+		usage  resource.Tracker
+		pc     apisv1alpha1.ProviderConfigSpec
+		getErr error
+		params ClientParameters
+	}
+	type want struct {
+		err           error
+		timeout       time.Duration
+		skipTLSVerify bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TrackFailed": {
+			args: args{
+				usage: resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return errBoom }),
+			},
+			want: want{err: errors.Wrap(errBoom, errTrackPCUsage)},
+		},
+		"ProviderConfigNotRetrieved": {
+			args: args{
+				getErr: errBoom,
+			},
+			want: want{err: errors.Wrap(errBoom, errProviderNotRetrieved)},
+		},
+		"ProviderConfigTimeout": {
+			args: args{
+				pc: apisv1alpha1.ProviderConfigSpec{Timeouts: &apisv1alpha1.HTTPTimeouts{Overall: &metav1.Duration{Duration: time.Minute}}},
+			},
+			want: want{timeout: time.Minute},
+		},
+		"WaitTimeout": {
+			args: args{
+				pc:     apisv1alpha1.ProviderConfigSpec{Timeouts: &apisv1alpha1.HTTPTimeouts{Overall: &metav1.Duration{Duration: time.Minute}}},
+				params: ClientParameters{WaitTimeout: &metav1.Duration{Duration: time.Second}},
+			},
+			want: want{timeout: time.Second},
+		},
+		"ProviderConfigSkipsTLSVerify": {
+			args: args{
+				pc: apisv1alpha1.ProviderConfigSpec{TLS: &apisv1alpha1.TLSConfig{InsecureSkipVerify: &skipVerify}},
+			},
+			want: want{timeout: utils.WaitTimeout(nil), skipTLSVerify: true},
+		},
+		"ResourceVerifiesTLS": {
+			args: args{
+				pc:     apisv1alpha1.ProviderConfigSpec{TLS: &apisv1alpha1.TLSConfig{InsecureSkipVerify: &skipVerify}},
+				params: ClientParameters{TLS: &apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify}},
+			},
+			want: want{timeout: utils.WaitTimeout(nil), skipTLSVerify: false},
+		},
+		"ResourceTLSOverridesInsecureSkipTLSVerify": {
+			args: args{
+				params: ClientParameters{InsecureSkipTLSVerify: true, TLS: &apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify}},
+			},
+			want: want{timeout: utils.WaitTimeout(nil), skipTLSVerify: false},
+		},
+		"ResourceSkipsTLSVerify": {
+			args: args{
+				params: ClientParameters{InsecureSkipTLSVerify: true},
+			},
+			want: want{timeout: utils.WaitTimeout(nil), skipTLSVerify: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			usage := tc.args.usage
+			if usage == nil {
+				usage = resource.TrackerFn(func(_ context.Context, _ resource.Managed) error { return nil })
+			}
+
+			var timeout time.Duration
+			c := Clients{
+				Kube: &test.MockClient{
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						if tc.args.getErr != nil {
+							return tc.args.getErr
+						}
+						obj.(*apisv1alpha1.ProviderConfig).Spec = tc.args.pc
+						return nil
+					},
+				},
+				Usage: usage,
+				NewHttpClient: func(log logging.Logger, t time.Duration, opts ...httpClient.ClientOption) (httpClient.Client, error) {
+					timeout = t
+					return httpClient.NewClient(log, t, opts...)
+				},
+				Tokens: auth.NewTokenCache(),
+			}
+			mg := &fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "default"}}}
+
+			got, err := c.NewClient(context.Background(), logging.NewNopLogger(), mg, tc.args.params)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("NewClient(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.timeout, timeout); diff != "" {
+				t.Errorf("NewClient(...): -want timeout, +got timeout: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.skipTLSVerify, got.SkipTLSVerify); diff != "" {
+				t.Errorf("NewClient(...): -want SkipTLSVerify, +got SkipTLSVerify: %s", diff)
+			}
+		})
+	}
+}
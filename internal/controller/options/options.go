@@ -0,0 +1,181 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the provider-wide settings shared by the http controllers.
+package options
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/clients/auth"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/ratelimit"
+	"github.com/arielsepton/provider-http/internal/controller/loadshed"
+	"github.com/arielsepton/provider-http/internal/features"
+	"github.com/arielsepton/provider-http/internal/webhook"
+)
+
+const errMaxConcurrentReconciles = "invalid number of concurrent reconciles %q of kind %s, it must be a positive integer"
+
+// NewHttpClientFn creates the http clients used to send requests.
+type NewHttpClientFn func(log logging.Logger, timeout time.Duration, opts ...httpClient.ClientOption) (httpClient.Client, error)
+
+// Options are the provider-wide settings of the http controllers, in addition
+// to the generic crossplane-runtime controller options.
+type Options struct {
+	// Timeout controls how long a reconcile, including its http requests, may
+	// take before it is failed.
+	Timeout time.Duration
+
+	// MaxConcurrentReconciles overrides the number of resources of a kind,
+	// e.g. Request, reconciled at once. The kinds it does not hold use the
+	// MaxConcurrentReconciles of the controller options.
+	MaxConcurrentReconciles map[string]int
+
+	// PollJitter is the maximum delay added to the poll interval of a resource.
+	// Each resource gets a deterministic share of it, so that resources with
+	// the same poll interval are not all checked at the same time.
+	PollJitter time.Duration
+
+	// MaxPollStretch is the maximum factor by which poll intervals are
+	// stretched while remote APIs keep failing or work queues are deep.
+	// Values of 1 or less disable load shedding.
+	MaxPollStretch float64
+
+	// QueueDepthThreshold is the work queue depth from which poll intervals
+	// are stretched.
+	QueueDepthThreshold int
+
+	// NewHttpClient replaces the http clients sending requests, e.g. with
+	// fixture-backed fakes. Real clients are used when it is nil.
+	NewHttpClient NewHttpClientFn
+
+	// Tokens caches the OAuth2 tokens of the ProviderConfigs, it is shared by
+	// the controllers so that each token is requested once.
+	Tokens *auth.TokenCache
+
+	// RateLimiters hold the rate limits of the ProviderConfigs, they are
+	// shared by the controllers so that the requests of all the resources
+	// using a ProviderConfig are limited together.
+	RateLimiters *ratelimit.Limiters
+
+	// MaxConcurrentRequestsPerHost is the number of requests that may be in
+	// flight to a host at once, the others wait for their turn. Requests are
+	// not limited when it is not positive, ProviderConfigs may override it.
+	MaxConcurrentRequestsPerHost int
+
+	// HostSemaphores hold the requests in flight per host, they are shared by
+	// the controllers.
+	HostSemaphores *httpClient.HostSemaphores
+
+	// CircuitBreakers fail the requests to the hosts that keep failing fast,
+	// they are shared by the controllers. Requests are always sent when it is
+	// nil.
+	CircuitBreakers *httpClient.CircuitBreakers
+
+	// Webhook receives the calls triggering the reconcile of Requests. Requests
+	// are only reconciled at their poll interval when it is nil.
+	Webhook *webhook.Receiver
+}
+
+// HttpClientFn returns the function creating the http clients used to send
+// requests, sharing the circuit breakers and the host semaphores when set.
+func (o Options) HttpClientFn() NewHttpClientFn {
+	if o.NewHttpClient != nil {
+		return o.NewHttpClient
+	}
+
+	// The provider-wide options come first, so that the options of the resources override them.
+	var shared []httpClient.ClientOption
+	if o.CircuitBreakers != nil {
+		shared = append(shared, httpClient.WithCircuitBreakers(o.CircuitBreakers))
+	}
+	if o.HostSemaphores != nil && o.MaxConcurrentRequestsPerHost > 0 {
+		shared = append(shared, httpClient.WithHostConcurrency(o.HostSemaphores, "", o.MaxConcurrentRequestsPerHost))
+	}
+	if len(shared) == 0 {
+		return httpClient.NewClient
+	}
+
+	return func(log logging.Logger, timeout time.Duration, opts ...httpClient.ClientOption) (httpClient.Client, error) {
+		return httpClient.NewClient(log, timeout, append(append([]httpClient.ClientOption{}, shared...), opts...)...)
+	}
+}
+
+// TokenCache returns the cache of OAuth2 tokens, or a new one when it is not shared.
+func (o Options) TokenCache() *auth.TokenCache {
+	if o.Tokens != nil {
+		return o.Tokens
+	}
+
+	return auth.NewTokenCache()
+}
+
+// LoadShedder returns the load shedder of the named controller.
+func (o Options) LoadShedder(controllerName string) *loadshed.Shedder {
+	return loadshed.New(loadshed.Config{
+		MaxStretch:          o.MaxPollStretch,
+		QueueDepth:          loadshed.WorkqueueDepth(metrics.Registry, controllerName),
+		QueueDepthThreshold: o.QueueDepthThreshold,
+	})
+}
+
+// ForControllerRuntime returns the controller-runtime options of the
+// controller of the given kind, with its number of concurrent reconciles.
+func (o Options) ForControllerRuntime(co controller.Options, kind string) crcontroller.Options {
+	cro := co.ForControllerRuntime()
+	if n, ok := o.MaxConcurrentReconciles[kind]; ok {
+		cro.MaxConcurrentReconciles = n
+	}
+	return cro
+}
+
+// ConnectionPublishers returns the publishers of the connection details of the
+// managed resources: their connection secrets, and the external secret stores
+// when EnableAlphaExternalSecretStores is enabled.
+func ConnectionPublishers(kube client.Client, scheme *runtime.Scheme, co controller.Options) []managed.ConnectionPublisher {
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(kube, scheme)}
+	if co.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(kube, apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+	return cps
+}
+
+// ParseMaxConcurrentReconciles parses the numbers of concurrent reconciles
+// of the given kinds.
+func ParseMaxConcurrentReconciles(values map[string]string) (map[string]int, error) {
+	parsed := make(map[string]int, len(values))
+	for kind, value := range values {
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return nil, errors.Errorf(errMaxConcurrentReconciles, value, kind)
+		}
+		parsed[kind] = n
+	}
+	return parsed, nil
+}
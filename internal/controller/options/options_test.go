@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package options
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/features"
+)
+
+func Test_ParseMaxConcurrentReconciles(t *testing.T) {
+	type want struct {
+		parsed map[string]int
+		err    error
+	}
+	cases := map[string]struct {
+		values map[string]string
+		want   want
+	}{
+		"Empty": {
+			values: map[string]string{},
+			want:   want{parsed: map[string]int{}},
+		},
+		"Valid": {
+			values: map[string]string{"Request": "20", "HttpProbe": "5"},
+			want:   want{parsed: map[string]int{"Request": 20, "HttpProbe": 5}},
+		},
+		"NotANumber": {
+			values: map[string]string{"Request": "many"},
+			want:   want{err: errors.Errorf(errMaxConcurrentReconciles, "many", "Request")},
+		},
+		"NotPositive": {
+			values: map[string]string{"Request": "0"},
+			want:   want{err: errors.Errorf(errMaxConcurrentReconciles, "0", "Request")},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseMaxConcurrentReconciles(tc.values)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseMaxConcurrentReconciles(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.parsed, got); diff != "" {
+				t.Errorf("ParseMaxConcurrentReconciles(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ForControllerRuntime(t *testing.T) {
+	o := Options{MaxConcurrentReconciles: map[string]int{"Request": 20}}
+	co := controller.Options{MaxConcurrentReconciles: 10}
+
+	if diff := cmp.Diff(20, o.ForControllerRuntime(co, "Request").MaxConcurrentReconciles); diff != "" {
+		t.Errorf("ForControllerRuntime(Request): -want, +got: %s", diff)
+	}
+	if diff := cmp.Diff(10, o.ForControllerRuntime(co, "HttpProbe").MaxConcurrentReconciles); diff != "" {
+		t.Errorf("ForControllerRuntime(HttpProbe): -want, +got: %s", diff)
+	}
+}
+
+func Test_ConnectionPublishers(t *testing.T) {
+	externalSecretStores := &feature.Flags{}
+	externalSecretStores.Enable(features.EnableAlphaExternalSecretStores)
+
+	type want struct {
+		detailsManager bool
+	}
+	cases := map[string]struct {
+		features *feature.Flags
+		want     want
+	}{
+		"NoFeatures": {
+			want: want{detailsManager: false},
+		},
+		"ExternalSecretStoresDisabled": {
+			features: &feature.Flags{},
+			want:     want{detailsManager: false},
+		},
+		"ExternalSecretStoresEnabled": {
+			features: externalSecretStores,
+			want:     want{detailsManager: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := apisv1alpha1.SchemeBuilder.AddToScheme(scheme); err != nil {
+				t.Fatalf("AddToScheme(...): %s", err)
+			}
+			kube := &test.MockClient{MockScheme: test.NewMockSchemeFn(scheme)}
+			cps := ConnectionPublishers(kube, scheme, controller.Options{Features: tc.features})
+			if _, ok := cps[0].(*managed.APISecretPublisher); !ok {
+				t.Errorf("ConnectionPublishers(...): want the connection secret publisher first, got %T", cps[0])
+			}
+
+			detailsManager := false
+			for _, cp := range cps[1:] {
+				if _, ok := cp.(*connection.DetailsManager); ok {
+					detailsManager = true
+				}
+			}
+			if diff := cmp.Diff(tc.want.detailsManager, detailsManager); diff != "" {
+				t.Errorf("ConnectionPublishers(...): -want details manager, +got details manager: %s", diff)
+			}
+		})
+	}
+}
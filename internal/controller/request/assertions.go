@@ -0,0 +1,63 @@
+package request
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/jq"
+	json_util "github.com/arielsepton/provider-http/internal/json"
+)
+
+const (
+	errEvaluateAssertion = "cannot evaluate assertion %s"
+	errAssertionFailed   = "assertion failed: %s"
+)
+
+// checkAssertions evaluates the given assertions against the observed response, and returns an error with the
+// message of the first failed one.
+func checkAssertions(assertions []v1alpha1.Assertion, response httpClient.HttpResponse) error {
+	if len(assertions) == 0 {
+		return nil
+	}
+
+	responseMap, err := responseObject(response)
+	if err != nil {
+		return err
+	}
+
+	for _, assertion := range assertions {
+		ok, err := jq.ParseBool(assertion.Expression, responseMap)
+		if err != nil {
+			return errors.Wrapf(err, errEvaluateAssertion, assertion.Expression)
+		}
+
+		if !ok {
+			return errors.Errorf(errAssertionFailed, assertion.Message)
+		}
+	}
+
+	return nil
+}
+
+// responseObject returns the given response as the object jq expressions over the response are evaluated against,
+// available as .response.statusCode, .response.headers and .response.body, with a structured body decoded.
+func responseObject(response httpClient.HttpResponse) (map[string]interface{}, error) {
+	responseMap, err := json_util.StructToMap(map[string]interface{}{
+		"response": v1alpha1.Response{
+			StatusCode: response.StatusCode,
+			Headers:    response.Headers,
+			Body:       response.Body,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert response to map")
+	}
+
+	json_util.ConvertJSONStringsToMaps(&responseMap)
+	if body, ok := structuredBody(response); ok {
+		responseMap["response"].(map[string]interface{})["body"] = body
+	}
+
+	return responseMap, nil
+}
@@ -0,0 +1,96 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+func Test_checkAssertions(t *testing.T) {
+	response := httpClient.HttpResponse{
+		StatusCode: 200,
+		Headers:    map[string][]string{"X-Quota-Remaining": {"0"}},
+		Body:       `{"id":"123","state":"error"}`,
+	}
+
+	type args struct {
+		assertions []v1alpha1.Assertion
+		response   *httpClient.HttpResponse
+	}
+	type want struct {
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoAssertions": {
+			args: args{},
+			want: want{},
+		},
+		"Passed": {
+			args: args{
+				assertions: []v1alpha1.Assertion{
+					{Expression: `.response.statusCode == 200`, Message: "unexpected status code"},
+					{Expression: `.response.body.id == "123"`, Message: "unexpected id"},
+				},
+			},
+			want: want{},
+		},
+		"Failed": {
+			args: args{
+				assertions: []v1alpha1.Assertion{
+					{Expression: `.response.statusCode == 200`, Message: "unexpected status code"},
+					{Expression: `.response.body.state != "error"`, Message: "the resource is in an error state"},
+					{Expression: `.response.headers."X-Quota-Remaining"[0] != "0"`, Message: "the quota is exhausted"},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errAssertionFailed, "the resource is in an error state"),
+			},
+		},
+		"XMLBody": {
+			args: args{
+				assertions: []v1alpha1.Assertion{
+					{Expression: `.response.body.user.state != "error"`, Message: "the resource is in an error state"},
+				},
+				response: &httpClient.HttpResponse{
+					StatusCode: 200,
+					Headers:    map[string][]string{"Content-Type": {"application/xml"}},
+					Body:       `<user id="123"><state>error</state></user>`,
+				},
+			},
+			want: want{
+				err: errors.Errorf(errAssertionFailed, "the resource is in an error state"),
+			},
+		},
+		"NotBoolean": {
+			args: args{
+				assertions: []v1alpha1.Assertion{
+					{Expression: `.response.body.id`, Message: "unexpected id"},
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errors.New("failed to parse string: 123"), errEvaluateAssertion, ".response.body.id"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			observed := response
+			if tc.args.response != nil {
+				observed = *tc.args.response
+			}
+			gotErr := checkAssertions(tc.args.assertions, observed)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Errorf("checkAssertions(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
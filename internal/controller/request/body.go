@@ -0,0 +1,169 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	errReadBodySource   = "cannot read body of %s mapping"
+	errEmptyValueSource = "neither a secret nor a configmap is referenced"
+	errEncodeBody       = "cannot encode body of %s mapping as %s"
+
+	contentTypeOctetStream = "application/octet-stream"
+)
+
+// send sends the given request details for the mapping, streaming the body when it is binary, taken from a Secret
+// or a ConfigMap, or a multipart body. Such bodies are read straight from their source as they are sent, without
+// being copied into a buffer first.
+func (c *external) send(ctx context.Context, cr *v1alpha1.Request, mapping *v1alpha1.Mapping, requestDetails requestgen.RequestDetails) (httpClient.HttpDetails, error) {
+	h := c.httpFor(mapping.GetAction())
+	skipTLSVerify := insecureSkipTLSVerify(c.tlsDefaults, &cr.Spec.ForProvider, mapping.GetAction())
+
+	headers := requestDetails.Headers
+	var body io.Reader
+	var err error
+	switch {
+	case len(mapping.Multipart) > 0:
+		var multipartBody io.ReadCloser
+		var contentType string
+		multipartBody, contentType, err = c.multipartBody(ctx, mapping, requestDetails.PartValues)
+		if err == nil {
+			// Stops writing the parts when the request ends before they were all sent.
+			defer func() { _ = multipartBody.Close() }()
+		}
+		body = multipartBody
+		headers = withContentType(headers, contentType)
+	case len(mapping.BodyBase64) > 0:
+		body = bytes.NewReader(mapping.BodyBase64)
+		if !hasHeader(headers, "Content-Type") {
+			headers = withContentType(headers, contentTypeOctetStream)
+		}
+	case mapping.BodyFrom != nil:
+		body, err = c.bodyFrom(ctx, mapping)
+	default:
+		encoded, err := encodeBody(mapping, requestDetails.Body)
+		if err != nil {
+			return httpClient.HttpDetails{}, err
+		}
+		return h.SendRequest(ctx, mapping.Method, requestDetails.Url, encoded, headers, skipTLSVerify)
+	}
+	if err != nil {
+		return httpClient.HttpDetails{}, err
+	}
+
+	if sh, ok := h.(httpClient.StreamingClient); ok {
+		return sh.SendRequestStream(ctx, mapping.Method, requestDetails.Url, body, headers, skipTLSVerify)
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return httpClient.HttpDetails{}, errors.Wrapf(err, errReadBodySource, mapping.Method)
+	}
+
+	return h.SendRequest(ctx, mapping.Method, requestDetails.Url, string(content), headers, skipTLSVerify)
+}
+
+// encodeBody encodes the given rendered body as YAML or as a form when the mapping sends its body so.
+func encodeBody(mapping *v1alpha1.Mapping, body string) (string, error) {
+	if body == "" {
+		return body, nil
+	}
+
+	switch mapping.BodyEncoding {
+	case v1alpha1.BodyEncodingYAML:
+		encoded, err := yaml.JSONToYAML([]byte(body))
+		return string(encoded), errors.Wrapf(err, errEncodeBody, mapping.Method, "YAML")
+	case v1alpha1.BodyEncodingForm:
+		encoded, err := formBody(body)
+		return encoded, errors.Wrapf(err, errEncodeBody, mapping.Method, "a form")
+	}
+
+	return body, nil
+}
+
+// formBody encodes the fields of the given JSON object as an application/x-www-form-urlencoded form. Strings are
+// sent as they are, lists as repeated fields, and other values as JSON.
+func formBody(body string) (string, error) {
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(body), &fields); err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	for key, value := range fields {
+		values, isList := value.([]interface{})
+		if !isList {
+			values = []interface{}{value}
+		}
+
+		for _, v := range values {
+			encoded, err := formValue(v)
+			if err != nil {
+				return "", err
+			}
+			form.Add(key, encoded)
+		}
+	}
+
+	return form.Encode(), nil
+}
+
+// formValue returns the given value of a form field as text.
+func formValue(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	return string(encoded), err
+}
+
+// bodyFrom returns a reader of the body referenced by the bodyFrom of the mapping.
+func (c *external) bodyFrom(ctx context.Context, mapping *v1alpha1.Mapping) (io.Reader, error) {
+	body, err := openValue(ctx, c.localKube, mapping.BodyFrom)
+	return body, errors.Wrapf(err, errReadBodySource, mapping.Method)
+}
+
+// readValue reads the value of the key referenced by the given source.
+func readValue(ctx context.Context, kube client.Client, source *v1alpha1.ValueSource) ([]byte, error) {
+	switch {
+	case source.SecretKeyRef != nil:
+		return kubehandler.GetSecretValue(ctx, kube, *source.SecretKeyRef)
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		return kubehandler.GetConfigMapValue(ctx, kube, ref.Namespace, ref.Name, ref.Key)
+	default:
+		return nil, errors.New(errEmptyValueSource)
+	}
+}
+
+// openValue returns a reader of the value of the key referenced by the given source, which reads it straight from
+// the Secret or the ConfigMap, without copying it.
+func openValue(ctx context.Context, kube client.Client, source *v1alpha1.ValueSource) (io.Reader, error) {
+	switch {
+	case source.SecretKeyRef != nil:
+		value, err := kubehandler.GetSecretValue(ctx, kube, *source.SecretKeyRef)
+		if err != nil {
+			return nil, err
+		}
+		return bytes.NewReader(value), nil
+	case source.ConfigMapKeyRef != nil:
+		ref := source.ConfigMapKeyRef
+		return kubehandler.OpenConfigMapValue(ctx, kube, ref.Namespace, ref.Name, ref.Key)
+	default:
+		return nil, errors.New(errEmptyValueSource)
+	}
+}
@@ -0,0 +1,252 @@
+package request
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
+)
+
+func Test_encodeBody(t *testing.T) {
+	type args struct {
+		mapping *v1alpha1.Mapping
+		body    string
+	}
+	type want struct {
+		body string
+		err  bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoBodyEncoding": {
+			args: args{
+				mapping: &v1alpha1.Mapping{Method: "POST"},
+				body:    `{"username":"john_doe"}`,
+			},
+			want: want{
+				body: `{"username":"john_doe"}`,
+			},
+		},
+		"JSONBodyEncoding": {
+			args: args{
+				mapping: &v1alpha1.Mapping{Method: "POST", BodyEncoding: v1alpha1.BodyEncodingJSON},
+				body:    `{"username":"john_doe"}`,
+			},
+			want: want{
+				body: `{"username":"john_doe"}`,
+			},
+		},
+		"YAMLBodyEncoding": {
+			args: args{
+				mapping: &v1alpha1.Mapping{Method: "POST", BodyEncoding: v1alpha1.BodyEncodingYAML},
+				body:    `{"username":"john_doe","roles":["admin"]}`,
+			},
+			want: want{
+				body: "roles:\n- admin\nusername: john_doe\n",
+			},
+		},
+		"FormBodyEncoding": {
+			args: args{
+				mapping: &v1alpha1.Mapping{Method: "POST", BodyEncoding: v1alpha1.BodyEncodingForm},
+				body:    `{"grant_type":"client_credentials","scope":["read","write"],"expires_in":3600,"redirect_uri":"https://example.com/cb?a=b"}`,
+			},
+			want: want{
+				body: "expires_in=3600&grant_type=client_credentials&redirect_uri=https%3A%2F%2Fexample.com%2Fcb%3Fa%3Db&scope=read&scope=write",
+			},
+		},
+		"FormBodyNotObject": {
+			args: args{
+				mapping: &v1alpha1.Mapping{Method: "POST", BodyEncoding: v1alpha1.BodyEncodingForm},
+				body:    `["read","write"]`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := encodeBody(tc.args.mapping, tc.args.body)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("encodeBody(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.body, got); diff != "" {
+				t.Errorf("encodeBody(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_send(t *testing.T) {
+	binary := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+
+	type args struct {
+		mapping        *v1alpha1.Mapping
+		requestDetails requestgen.RequestDetails
+	}
+	type want struct {
+		body    string
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RenderedBody": {
+			args: args{
+				mapping:        &v1alpha1.Mapping{Method: "POST"},
+				requestDetails: requestgen.RequestDetails{Body: `{"username":"john_doe"}`, Headers: map[string][]string{"Accept": {"application/json"}}},
+			},
+			want: want{
+				body:    `{"username":"john_doe"}`,
+				headers: map[string][]string{"Accept": {"application/json"}},
+			},
+		},
+		"BinaryBody": {
+			args: args{
+				mapping:        &v1alpha1.Mapping{Method: "PUT", BodyBase64: binary},
+				requestDetails: requestgen.RequestDetails{Headers: map[string][]string{"Accept": {"application/json"}}},
+			},
+			want: want{
+				body:    string(binary),
+				headers: map[string][]string{"Accept": {"application/json"}, "Content-Type": {"application/octet-stream"}},
+			},
+		},
+		"BinaryBodyWithContentType": {
+			args: args{
+				mapping:        &v1alpha1.Mapping{Method: "PUT", BodyBase64: binary},
+				requestDetails: requestgen.RequestDetails{Headers: map[string][]string{"content-type": {"image/png"}}},
+			},
+			want: want{
+				body:    string(binary),
+				headers: map[string][]string{"content-type": {"image/png"}},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var gotBody string
+			var gotHeaders map[string][]string
+			e := &external{
+				logger: logging.NewNopLogger(),
+				http: &MockHttpClient{
+					MockSendRequest: func(_ context.Context, _ string, _ string, body string, headers map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+						gotBody, gotHeaders = body, headers
+						return httpClient.HttpDetails{}, nil
+					},
+				},
+			}
+
+			if _, err := e.send(context.Background(), &v1alpha1.Request{}, tc.args.mapping, tc.args.requestDetails); err != nil {
+				t.Fatalf("send(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.body, gotBody); diff != "" {
+				t.Errorf("send(...): -want body, +got body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.headers, gotHeaders); diff != "" {
+				t.Errorf("send(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_sendStreamsBody(t *testing.T) {
+	type received struct {
+		transferEncoding []string
+		contentLength    int64
+		contentType      string
+		body             string
+	}
+	var got received
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got = received{transferEncoding: r.TransferEncoding, contentLength: r.ContentLength, contentType: r.Header.Get("Content-Type"), body: string(body)}
+	}))
+	defer server.Close()
+
+	kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		switch o := obj.(type) {
+		case *corev1.Secret:
+			o.Data = map[string][]byte{"payload": []byte("from a secret")}
+		case *corev1.ConfigMap:
+			o.Data = map[string]string{"payload": "from a configmap"}
+		}
+		return nil
+	})}
+	secret := &v1alpha1.ValueSource{SecretKeyRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "body", Namespace: "default"}, Key: "payload"}}
+	configMap := &v1alpha1.ValueSource{ConfigMapKeyRef: &apisv1alpha1.ConfigMapKeySelector{Name: "body", Namespace: "default", Key: "payload"}}
+
+	type want struct {
+		body  string
+		parts []testPart
+	}
+	cases := map[string]struct {
+		mapping *v1alpha1.Mapping
+		want    want
+	}{
+		"BodyFromSecret": {
+			mapping: &v1alpha1.Mapping{Method: "PUT", BodyFrom: secret},
+			want:    want{body: "from a secret"},
+		},
+		"BodyFromConfigMap": {
+			mapping: &v1alpha1.Mapping{Method: "PUT", BodyFrom: configMap},
+			want:    want{body: "from a configmap"},
+		},
+		"BinaryBody": {
+			mapping: &v1alpha1.Mapping{Method: "PUT", BodyBase64: []byte{0x89, 'P', 'N', 'G'}},
+			want:    want{body: "\x89PNG"},
+		},
+		"Multipart": {
+			mapping: &v1alpha1.Mapping{Method: "POST", Multipart: []v1alpha1.MultipartPart{{Name: "file", ValueFrom: secret}}},
+			want:    want{parts: []testPart{{Name: "file", Filename: "payload", ContentType: "application/octet-stream", Content: "from a secret"}}},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			h, err := httpClient.NewClient(logging.NewNopLogger(), 5*time.Second)
+			if err != nil {
+				t.Fatalf("NewClient(...): unexpected error: %s", err)
+			}
+			e := &external{localKube: kube, logger: logging.NewNopLogger(), http: h}
+
+			if _, err := e.send(context.Background(), &v1alpha1.Request{}, tc.mapping, requestgen.RequestDetails{Url: server.URL}); err != nil {
+				t.Fatalf("send(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff([]string{"chunked"}, got.transferEncoding); diff != "" {
+				t.Errorf("send(...): -want transfer encoding, +got transfer encoding: %s", diff)
+			}
+			if diff := cmp.Diff(int64(-1), got.contentLength); diff != "" {
+				t.Errorf("send(...): -want unknown content length, +got content length: %s", diff)
+			}
+			if tc.want.parts != nil {
+				if diff := cmp.Diff(tc.want.parts, readParts(t, strings.NewReader(got.body), got.contentType)); diff != "" {
+					t.Errorf("send(...): -want parts, +got parts: %s", diff)
+				}
+				return
+			}
+			if diff := cmp.Diff(tc.want.body, got.body); diff != "" {
+				t.Errorf("send(...): -want body, +got body: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package request
+
+import (
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/json"
+	"github.com/arielsepton/provider-http/internal/utils"
+	"github.com/arielsepton/provider-http/internal/xml"
+)
+
+const (
+	errNotValidXML  = "%s is not a valid XML document"
+	errNotValidYAML = "%s is not a valid YAML document"
+)
+
+// compareObjects checks whether the decoded response body holds the decoded desired state, with the compare
+// strategy of the request.
+func (c *external) compareObjects(cr *v1alpha1.Request, details httpClient.HttpDetails, err error, response, desiredState map[string]interface{}) (ObserveRequestDetails, error) {
+	response, compareErr := comparableObject(response, cr.Spec.ForProvider)
+	if compareErr != nil {
+		return FailedObserve(), compareErr
+	}
+	desiredState, compareErr = comparableObject(desiredState, cr.Spec.ForProvider)
+	if compareErr != nil {
+		return FailedObserve(), compareErr
+	}
+
+	extraFields := json.ExtraFields(response, desiredState)
+	synced := json.Contains(response, withoutPlaceholders(desiredState))
+
+	switch cr.Spec.ForProvider.CompareStrategy {
+	case v1alpha1.CompareStrategyExact:
+		synced = synced && len(extraFields) == 0
+	case v1alpha1.CompareStrategySubsetWithWarnings:
+		if len(extraFields) > 0 {
+			c.logger.Info(msgExtraFields, "fields", extraFields)
+		}
+	}
+
+	observed := NewObserve(details, err, synced && utils.IsSuccess(c.statusCodes, details.HttpResponse.StatusCode))
+	if !synced {
+		observed.Drift = drift(cr.Spec.ForProvider.CompareStrategy, response, withoutPlaceholders(desiredState))
+	}
+
+	return observed, nil
+}
+
+// drift returns the fields of the response that differ from the desired state. Extra fields of the response are
+// only a drift with the exact compare strategy.
+func drift(strategy string, response, desiredState map[string]interface{}) *apisv1alpha1.Drift {
+	diff := json.Compare(response, desiredState)
+	if strategy != v1alpha1.CompareStrategyExact {
+		diff.Added = nil
+	}
+
+	return utils.Drift(diff)
+}
+
+// compareXMLResponse checks whether an XML response body holds the desired state, which is either an XML or a
+// JSON document. The content of the root elements is compared, in the structure of decoded JSON documents; a JSON
+// desired state is compared with the content of the root element of the response.
+func (c *external) compareXMLResponse(cr *v1alpha1.Request, details httpClient.HttpDetails, err error, desiredState string) (ObserveRequestDetails, error) {
+	response, xmlErr := xml.ToMap(details.HttpResponse.Body)
+	if xmlErr != nil {
+		return FailedObserve(), errors.Wrapf(xmlErr, errNotValidXML, "response body")
+	}
+	responseRoot, responseContent := rootElement(response)
+
+	if json.IsJSONString(desiredState) {
+		return c.compareObjects(cr, details, err, responseContent, json.JsonStringToMap(desiredState))
+	}
+
+	desiredStateMap, xmlErr := xml.ToMap(desiredState)
+	if xmlErr != nil {
+		return FailedObserve(), errors.Wrapf(xmlErr, errNotValidXML, "PUT mapping result")
+	}
+
+	desiredRoot, desiredContent := rootElement(desiredStateMap)
+	if desiredRoot != responseRoot {
+		return NewObserve(details, err, false), nil
+	}
+
+	return c.compareObjects(cr, details, err, responseContent, desiredContent)
+}
+
+// compareYAMLResponse checks whether a YAML response body holds the desired state, which is either a YAML or a
+// JSON document. Both are compared as JSON documents.
+func (c *external) compareYAMLResponse(cr *v1alpha1.Request, details httpClient.HttpDetails, err error, desiredState string) (ObserveRequestDetails, error) {
+	response, yamlErr := yamlToMap(details.HttpResponse.Body)
+	if yamlErr != nil {
+		return FailedObserve(), errors.Wrapf(yamlErr, errNotValidYAML, "response body")
+	}
+
+	desiredStateMap, yamlErr := yamlToMap(desiredState)
+	if yamlErr != nil {
+		return FailedObserve(), errors.Wrapf(yamlErr, errNotValidYAML, "PUT mapping result")
+	}
+
+	return c.compareObjects(cr, details, err, response, desiredStateMap)
+}
+
+// yamlToMap decodes the given YAML document, which should hold an object.
+func yamlToMap(document string) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	return object, yaml.Unmarshal([]byte(document), &object)
+}
+
+// rootElement returns the name and the content of the root element of the given decoded XML document. The text of
+// an element holding only text is returned as #text.
+func rootElement(document map[string]interface{}) (string, map[string]interface{}) {
+	for name, value := range document {
+		if content, ok := value.(map[string]interface{}); ok {
+			return name, content
+		}
+		return name, map[string]interface{}{xml.TextKey: value}
+	}
+
+	return "", nil
+}
+
+// isXMLResponse checks whether the given response holds an XML document, either by its content type, or when it
+// has none, by its body when the desired state it is compared with is an XML document as well.
+func isXMLResponse(response httpClient.HttpResponse, desiredState string) bool {
+	if contentType := responseContentType(response); contentType != "" {
+		return isXMLContentType(contentType)
+	}
+
+	return xml.IsXML(response.Body) && xml.IsXML(desiredState)
+}
+
+// structuredBody returns the body of the given response decoded in the structure of a decoded JSON document, when
+// its content type is XML or YAML.
+func structuredBody(response httpClient.HttpResponse) (map[string]interface{}, bool) {
+	contentType := responseContentType(response)
+	switch {
+	case isXMLContentType(contentType):
+		body, err := xml.ToMap(response.Body)
+		return body, err == nil
+	case isYAMLContentType(contentType):
+		body, err := yamlToMap(response.Body)
+		return body, err == nil
+	}
+
+	return nil, false
+}
+
+// comparableObject returns the given decoded body without the ignored fields of the request and with its values
+// normalized, ready to be compared by the default expected response check.
+func comparableObject(object map[string]interface{}, parameters v1alpha1.RequestParameters) (map[string]interface{}, error) {
+	object, err := withoutIgnoredFields(object, parameters.IgnoreFields)
+	if err != nil || object == nil || parameters.Normalization == nil {
+		return object, err
+	}
+
+	normalized, _ := json.Normalize(object, json.Normalization{
+		BooleanStrings: parameters.Normalization.BooleanStrings,
+		NumericStrings: parameters.Normalization.NumericStrings,
+		NullAsAbsent:   parameters.Normalization.NullAsAbsent,
+	}).(map[string]interface{})
+
+	return normalized, nil
+}
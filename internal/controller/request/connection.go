@@ -0,0 +1,143 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/jq"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+	"github.com/arielsepton/provider-http/internal/redact"
+)
+
+const (
+	errSelectConnectionDetail = "cannot select connection detail %s"
+	errWriteResponseSecret    = "cannot write response Secret %s/%s"
+	errWriteResponseConfigMap = "cannot write response ConfigMap %s/%s"
+)
+
+// connectionDetails returns the connection details of the Request captured from the given response: the values
+// selected by its connection detail queries and the cookies it captures. Nothing is captured from a missing
+// response.
+func connectionDetails(forProvider v1alpha1.RequestParameters, response httpClient.HttpResponse) (managed.ConnectionDetails, error) {
+	details := cookieDetails(forProvider.Cookies, response)
+	if len(forProvider.ConnectionDetails) == 0 || response.StatusCode == 0 {
+		return details, nil
+	}
+
+	selected, err := selectValues(forProvider.ConnectionDetails, response)
+	if err != nil {
+		return nil, err
+	}
+
+	if details == nil {
+		details = managed.ConnectionDetails{}
+	}
+	for key, value := range selected {
+		details[key] = value
+	}
+
+	if len(details) == 0 {
+		return nil, nil
+	}
+	return details, nil
+}
+
+// writeResponseObjects keeps the response Secrets and ConfigMaps of the Request updated with the values selected
+// from the given response.
+func writeResponseObjects(ctx context.Context, kube client.Client, forProvider v1alpha1.RequestParameters, response httpClient.HttpResponse) error {
+	if err := writeResponseSecrets(ctx, kube, forProvider.ResponseSecrets, response); err != nil {
+		return err
+	}
+	return writeResponseConfigMaps(ctx, kube, forProvider.ResponseConfigMaps, response)
+}
+
+// writeResponseSecrets keeps the response Secrets of the Request updated with the values selected from the given
+// response. Nothing is written from a missing response.
+func writeResponseSecrets(ctx context.Context, kube client.Client, secrets []v1alpha1.ResponseSecret, response httpClient.HttpResponse) error {
+	if response.StatusCode == 0 {
+		return nil
+	}
+
+	for _, secret := range secrets {
+		data, err := selectValues(secret.Data, response)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		if err := kubehandler.SetSecretData(ctx, kube, secret.SecretRef.Namespace, secret.SecretRef.Name, data); err != nil {
+			return errors.Wrapf(err, errWriteResponseSecret, secret.SecretRef.Namespace, secret.SecretRef.Name)
+		}
+	}
+
+	return nil
+}
+
+// writeResponseConfigMaps keeps the response ConfigMaps of the Request updated with the values selected from the
+// given response. Nothing is written from a missing response.
+func writeResponseConfigMaps(ctx context.Context, kube client.Client, configMaps []v1alpha1.ResponseConfigMap, response httpClient.HttpResponse) error {
+	if response.StatusCode == 0 {
+		return nil
+	}
+
+	for _, configMap := range configMaps {
+		data, err := selectValues(configMap.Data, response)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			continue
+		}
+
+		if err := kubehandler.SetConfigMapData(ctx, kube, configMap.ConfigMapRef.Namespace, configMap.ConfigMapRef.Name, data); err != nil {
+			return errors.Wrapf(err, errWriteResponseConfigMap, configMap.ConfigMapRef.Namespace, configMap.ConfigMapRef.Name)
+		}
+	}
+
+	return nil
+}
+
+// selectValues returns the values the given selectors select from the response, by key. Strings are returned as
+// they are and other values as JSON, null results and masked values are left out.
+func selectValues(selectors []v1alpha1.ConnectionDetail, response httpClient.HttpResponse) (map[string][]byte, error) {
+	responseMap, err := responseObject(response)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]byte, len(selectors))
+	for _, selector := range selectors {
+		value, err := jq.Parse(selector.Query, responseMap)
+		if err != nil {
+			return nil, errors.Wrapf(err, errSelectConnectionDetail, selector.Key)
+		}
+		if value == nil {
+			continue
+		}
+
+		if s, ok := value.(string); ok {
+			// A value masked in a response recorded in the status is left out, so that the value written before
+			// is kept.
+			if s == redact.Mask {
+				continue
+			}
+			values[selector.Key] = []byte(s)
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, errSelectConnectionDetail, selector.Key)
+		}
+		values[selector.Key] = encoded
+	}
+
+	return values, nil
+}
@@ -0,0 +1,255 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+func Test_connectionDetails(t *testing.T) {
+	response := httpClient.HttpResponse{
+		StatusCode: 201,
+		Headers: map[string][]string{
+			"Location":   {"https://api.example.com/databases/123"},
+			"Set-Cookie": {"session=38afes7a8"},
+		},
+		Body: `{"id":"123","credentials":{"username":"admin","password":"s3cr3t"},"port":5432,"replica":null}`,
+	}
+
+	type args struct {
+		forProvider v1alpha1.RequestParameters
+		response    httpClient.HttpResponse
+	}
+	type want struct {
+		details managed.ConnectionDetails
+		err     bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoConnectionDetails": {
+			args: args{
+				response: response,
+			},
+			want: want{},
+		},
+		"Selected": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					ConnectionDetails: []v1alpha1.ConnectionDetail{
+						{Key: "password", Query: ".response.body.credentials.password"},
+						{Key: "port", Query: ".response.body.port"},
+						{Key: "endpoint", Query: `.response.headers.Location[0]`},
+						{Key: "replica", Query: ".response.body.replica"},
+					},
+					Cookies: []v1alpha1.CookieCapture{{Name: "session"}},
+				},
+				response: response,
+			},
+			want: want{
+				details: managed.ConnectionDetails{
+					"password": []byte("s3cr3t"),
+					"port":     []byte("5432"),
+					"endpoint": []byte("https://api.example.com/databases/123"),
+					"session":  []byte("38afes7a8"),
+				},
+			},
+		},
+		"NoResponse": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					ConnectionDetails: []v1alpha1.ConnectionDetail{{Key: "password", Query: ".response.body.credentials.password"}},
+				},
+			},
+			want: want{},
+		},
+		"InvalidQuery": {
+			args: args{
+				forProvider: v1alpha1.RequestParameters{
+					ConnectionDetails: []v1alpha1.ConnectionDetail{{Key: "password", Query: ".response.body.["}},
+				},
+				response: response,
+			},
+			want: want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := connectionDetails(tc.args.forProvider, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("connectionDetails(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.details, got); diff != "" {
+				t.Errorf("connectionDetails(...): -want details, +got details: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_writeResponseSecrets(t *testing.T) {
+	response := httpClient.HttpResponse{
+		StatusCode: 200,
+		Body:       `{"id":"123","token":"t0k3n","expiresIn":3600}`,
+	}
+	secrets := []v1alpha1.ResponseSecret{{
+		SecretRef: xpv1.SecretReference{Namespace: "default", Name: "api-token"},
+		Data: []v1alpha1.ConnectionDetail{
+			{Key: "token", Query: ".response.body.token"},
+			{Key: "expiresIn", Query: ".response.body.expiresIn"},
+		},
+	}}
+
+	var written *corev1.Secret
+	type args struct {
+		kube     client.Client
+		secrets  []v1alpha1.ResponseSecret
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		data map[string][]byte
+		err  error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Written": {
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "api-token")),
+					MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+						written = obj.(*corev1.Secret)
+						return nil
+					},
+				},
+				secrets:  secrets,
+				response: response,
+			},
+			want: want{
+				data: map[string][]byte{"token": []byte("t0k3n"), "expiresIn": []byte("3600")},
+			},
+		},
+		"NoResponse": {
+			args: args{
+				kube:    &test.MockClient{},
+				secrets: secrets,
+			},
+			want: want{},
+		},
+		"WriteFailed": {
+			args: args{
+				kube:     &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				secrets:  secrets,
+				response: response,
+			},
+			want: want{
+				err: errors.Wrapf(errors.Wrapf(errBoom, "cannot get secret %s/%s", "default", "api-token"), errWriteResponseSecret, "default", "api-token"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			written = nil
+			err := writeResponseSecrets(context.Background(), tc.args.kube, tc.args.secrets, tc.args.response)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("writeResponseSecrets(...): -want error, +got error: %s", diff)
+			}
+			var got map[string][]byte
+			if written != nil {
+				got = written.Data
+			}
+			if diff := cmp.Diff(tc.want.data, got); diff != "" {
+				t.Errorf("writeResponseSecrets(...): -want data, +got data: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_writeResponseConfigMaps(t *testing.T) {
+	response := httpClient.HttpResponse{
+		StatusCode: 200,
+		Body:       `{"id":"123","endpoint":"https://db.example.com","ports":[5432]}`,
+	}
+	configMaps := []v1alpha1.ResponseConfigMap{{
+		ConfigMapRef: apisv1alpha1.ConfigMapReference{Namespace: "default", Name: "database"},
+		Data: []v1alpha1.ConnectionDetail{
+			{Key: "endpoint", Query: ".response.body.endpoint"},
+			{Key: "ports", Query: ".response.body.ports"},
+		},
+	}}
+
+	var written *corev1.ConfigMap
+	record := func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		written = obj.(*corev1.ConfigMap)
+		return nil
+	}
+	type want struct {
+		data map[string]string
+		err  error
+	}
+	cases := map[string]struct {
+		kube client.Client
+		want want
+	}{
+		"Updated": {
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.ConfigMap).Data = map[string]string{"owner": "team-a", "endpoint": "https://old.example.com"}
+					return nil
+				}),
+				MockUpdate: record,
+			},
+			want: want{
+				data: map[string]string{"owner": "team-a", "endpoint": "https://db.example.com", "ports": "[5432]"},
+			},
+		},
+		"Unchanged": {
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.ConfigMap).Data = map[string]string{"endpoint": "https://db.example.com", "ports": "[5432]"}
+					return nil
+				}),
+			},
+			want: want{},
+		},
+		"WriteFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want: want{
+				err: errors.Wrapf(errors.Wrapf(errBoom, "cannot get configmap %s/%s", "default", "database"), errWriteResponseConfigMap, "default", "database"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			written = nil
+			err := writeResponseConfigMaps(context.Background(), tc.kube, configMaps, response)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("writeResponseConfigMaps(...): -want error, +got error: %s", diff)
+			}
+			var got map[string]string
+			if written != nil {
+				got = written.Data
+			}
+			if diff := cmp.Diff(tc.want.data, got); diff != "" {
+				t.Errorf("writeResponseConfigMaps(...): -want data, +got data: %s", diff)
+			}
+		})
+	}
+}
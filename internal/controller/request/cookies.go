@@ -0,0 +1,42 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+// cookieDetails returns the values of the given cookies set by the response, keyed by their connection Secret keys.
+// Cookies the response does not set are left out, so that the values set by earlier responses are kept.
+func cookieDetails(cookies []v1alpha1.CookieCapture, response httpClient.HttpResponse) managed.ConnectionDetails {
+	if len(cookies) == 0 || len(response.Headers) == 0 {
+		return nil
+	}
+
+	set := map[string]string{}
+	for _, cookie := range (&http.Response{Header: response.Headers}).Cookies() {
+		set[cookie.Name] = cookie.Value
+	}
+
+	details := managed.ConnectionDetails{}
+	for _, cookie := range cookies {
+		value, ok := set[cookie.Name]
+		if !ok {
+			continue
+		}
+
+		key := cookie.Key
+		if key == "" {
+			key = cookie.Name
+		}
+		details[key] = []byte(value)
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+	return details
+}
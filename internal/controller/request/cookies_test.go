@@ -0,0 +1,67 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+func Test_cookieDetails(t *testing.T) {
+	response := httpClient.HttpResponse{
+		StatusCode: 201,
+		Headers: map[string][]string{
+			"Set-Cookie": {"session=38afes7a8; Path=/; HttpOnly", "csrf=wJalrXUt; Secure"},
+		},
+	}
+
+	type args struct {
+		cookies  []v1alpha1.CookieCapture
+		response httpClient.HttpResponse
+	}
+	type want struct {
+		details managed.ConnectionDetails
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoCookies": {
+			args: args{
+				response: response,
+			},
+			want: want{},
+		},
+		"Captured": {
+			args: args{
+				cookies:  []v1alpha1.CookieCapture{{Name: "session"}, {Name: "csrf", Key: "csrfToken"}},
+				response: response,
+			},
+			want: want{
+				details: managed.ConnectionDetails{
+					"session":   []byte("38afes7a8"),
+					"csrfToken": []byte("wJalrXUt"),
+				},
+			},
+		},
+		"NotSet": {
+			args: args{
+				cookies:  []v1alpha1.CookieCapture{{Name: "session"}},
+				response: httpClient.HttpResponse{StatusCode: 200, Headers: map[string][]string{"Content-Type": {"application/json"}}},
+			},
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := cookieDetails(tc.args.cookies, tc.args.response)
+			if diff := cmp.Diff(tc.want.details, got); diff != "" {
+				t.Errorf("cookieDetails(...): -want details, +got details: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package request
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/controller/request/statushandler"
+	"github.com/arielsepton/provider-http/internal/envelope"
+)
+
+const (
+	errDecryptResponseBody = "cannot decrypt response body stored in status"
+)
+
+// statusHandlerOptions returns the options of the status handlers of the Request.
+func (c *external) statusHandlerOptions() []statushandler.StatusHandlerOption {
+	opts := []statushandler.StatusHandlerOption{statushandler.WithStatusCodePolicy(c.statusCodes), statushandler.WithRedactor(c.redactor), statushandler.WithCorrelationID(c.correlationID)}
+	if c.responseKey != nil {
+		opts = append(opts, statushandler.WithResponseEncryption(c.responseKey))
+	}
+	if c.responseStorage != nil {
+		opts = append(opts, statushandler.WithResponseStorage(c.responseStorage))
+	}
+	if c.payload != nil {
+		opts = append(opts, statushandler.WithPayload(*c.payload))
+	}
+	if c.resolved.References != nil || c.resolved.Environment != nil {
+		opts = append(opts, statushandler.WithValues(c.resolved))
+	}
+
+	return opts
+}
+
+// decrypted returns a copy of the Request with the encrypted response bodies of its status decrypted, or the
+// Request itself when response encryption is disabled. Bodies stored before encryption was enabled are left
+// as they are.
+func (c *external) decrypted(cr *v1alpha1.Request) (*v1alpha1.Request, error) {
+	if c.responseKey == nil {
+		return cr, nil
+	}
+
+	plain := cr.DeepCopy()
+	for _, body := range statusBodies(plain) {
+		if !envelope.IsEncrypted(*body) {
+			continue
+		}
+
+		decrypted, err := envelope.Decrypt(c.responseKey, *body)
+		if err != nil {
+			return nil, errors.Wrap(err, errDecryptResponseBody)
+		}
+		*body = decrypted
+	}
+
+	return plain, nil
+}
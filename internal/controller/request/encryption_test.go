@@ -0,0 +1,101 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/envelope"
+)
+
+func Test_decrypted(t *testing.T) {
+	key := []byte("key")
+	body := `{"id":"123"}`
+	encrypted, _ := envelope.Encrypt(key, body)
+
+	type args struct {
+		responseKey []byte
+		mg          *v1alpha1.Request
+	}
+	type want struct {
+		responseBody string
+		cacheBody    string
+		err          error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"EncryptionDisabled": {
+			args: args{
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = body
+				}),
+			},
+			want: want{
+				responseBody: body,
+			},
+		},
+		"Decrypted": {
+			args: args{
+				responseKey: key,
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = encrypted
+					r.Status.Cache.Response.Body = encrypted
+				}),
+			},
+			want: want{
+				responseBody: body,
+				cacheBody:    body,
+			},
+		},
+		"StoredBeforeEncryption": {
+			args: args{
+				responseKey: key,
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = body
+				}),
+			},
+			want: want{
+				responseBody: body,
+			},
+		},
+		"WrongKey": {
+			args: args{
+				responseKey: []byte("other key"),
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = encrypted
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrap(errors.New("cipher: message authentication failed"), "cannot decrypt value"), errDecryptResponseBody),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			stored := tc.args.mg.Status.Response.Body
+			e := &external{responseKey: tc.args.responseKey}
+			got, gotErr := e.decrypted(tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("decrypted(...): -want error, +got error: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.responseBody, got.Status.Response.Body); diff != "" {
+				t.Errorf("decrypted(...): -want response body, +got response body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cacheBody, got.Status.Cache.Response.Body); diff != "" {
+				t.Errorf("decrypted(...): -want cache body, +got cache body: %s", diff)
+			}
+			if diff := cmp.Diff(stored, tc.args.mg.Status.Response.Body); diff != "" {
+				t.Errorf("decrypted(...): stored response body was modified: %s", diff)
+			}
+		})
+	}
+}
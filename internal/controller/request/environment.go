@@ -0,0 +1,80 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	json_util "github.com/arielsepton/provider-http/internal/json"
+)
+
+const (
+	errGetEnvironmentConfig    = "cannot get EnvironmentConfig %s"
+	errGetEnvironmentConfigMap = "cannot get environment ConfigMap %s/%s"
+	errEmptyEnvironmentSource  = "environment source should reference an EnvironmentConfig or a ConfigMap"
+	errEnvironmentConfigData   = "cannot read data of EnvironmentConfig %s"
+)
+
+// environmentConfigKind is the kind of Crossplane EnvironmentConfigs.
+var environmentConfigKind = schema.GroupVersionKind{
+	Group:   "apiextensions.crossplane.io",
+	Version: "v1alpha1",
+	Kind:    "EnvironmentConfig",
+}
+
+// resolveEnvironment reads the data of the given environment sources, merging the data of later sources over the
+// data of earlier ones. It returns nil when there are no sources.
+func resolveEnvironment(ctx context.Context, kube client.Client, sources []v1alpha1.EnvironmentSource) (map[string]interface{}, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	environment := map[string]interface{}{}
+	for _, source := range sources {
+		data, err := readEnvironmentSource(ctx, kube, source)
+		if err != nil {
+			return nil, err
+		}
+		environment = json_util.Merge(environment, data)
+	}
+
+	return environment, nil
+}
+
+// readEnvironmentSource reads the data of the given EnvironmentConfig or ConfigMap. The values of ConfigMaps holding
+// JSON objects are decoded.
+func readEnvironmentSource(ctx context.Context, kube client.Client, source v1alpha1.EnvironmentSource) (map[string]interface{}, error) {
+	switch {
+	case source.EnvironmentConfigRef != nil:
+		name := source.EnvironmentConfigRef.Name
+		environmentConfig := &unstructured.Unstructured{}
+		environmentConfig.SetGroupVersionKind(environmentConfigKind)
+		if err := kube.Get(ctx, types.NamespacedName{Name: name}, environmentConfig); err != nil {
+			return nil, errors.Wrapf(err, errGetEnvironmentConfig, name)
+		}
+
+		data, _, err := unstructured.NestedMap(environmentConfig.Object, "data")
+		return data, errors.Wrapf(err, errEnvironmentConfigData, name)
+	case source.ConfigMapRef != nil:
+		ref := source.ConfigMapRef
+		configMap := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, configMap); err != nil {
+			return nil, errors.Wrapf(err, errGetEnvironmentConfigMap, ref.Namespace, ref.Name)
+		}
+
+		data := make(map[string]interface{}, len(configMap.Data))
+		for key, value := range configMap.Data {
+			data[key] = value
+		}
+		json_util.ConvertJSONStringsToMaps(&data)
+		return data, nil
+	default:
+		return nil, errors.New(errEmptyEnvironmentSource)
+	}
+}
@@ -0,0 +1,109 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func Test_resolveEnvironment(t *testing.T) {
+	environmentConfig := v1alpha1.EnvironmentSource{EnvironmentConfigRef: &v1alpha1.EnvironmentConfigReference{Name: "production"}}
+	configMap := v1alpha1.EnvironmentSource{ConfigMapRef: &apisv1alpha1.ConfigMapReference{Name: "environment", Namespace: "crossplane-system"}}
+	kube := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		switch o := obj.(type) {
+		case *unstructured.Unstructured:
+			o.Object["data"] = map[string]interface{}{
+				"host":   "api.example.com",
+				"region": map[string]interface{}{"name": "eu-west-1", "zone": "a"},
+			}
+		case *corev1.ConfigMap:
+			o.Data = map[string]string{
+				"host":   "api.staging.example.com",
+				"region": `{"zone": "b"}`,
+			}
+		}
+		return nil
+	})}
+
+	type args struct {
+		kube    client.Client
+		sources []v1alpha1.EnvironmentSource
+	}
+	type want struct {
+		environment map[string]interface{}
+		err         bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoSources": {
+			args: args{
+				kube: &test.MockClient{},
+			},
+			want: want{},
+		},
+		"EnvironmentConfig": {
+			args: args{
+				kube:    kube,
+				sources: []v1alpha1.EnvironmentSource{environmentConfig},
+			},
+			want: want{
+				environment: map[string]interface{}{
+					"host":   "api.example.com",
+					"region": map[string]interface{}{"name": "eu-west-1", "zone": "a"},
+				},
+			},
+		},
+		"ConfigMapMergedOverEnvironmentConfig": {
+			args: args{
+				kube:    kube,
+				sources: []v1alpha1.EnvironmentSource{environmentConfig, configMap},
+			},
+			want: want{
+				environment: map[string]interface{}{
+					"host":   "api.staging.example.com",
+					"region": map[string]interface{}{"name": "eu-west-1", "zone": "b"},
+				},
+			},
+		},
+		"EmptySource": {
+			args: args{
+				kube:    kube,
+				sources: []v1alpha1.EnvironmentSource{{}},
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"NotFound": {
+			args: args{
+				kube:    &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				sources: []v1alpha1.EnvironmentSource{environmentConfig},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveEnvironment(context.Background(), tc.args.kube, tc.args.sources)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("resolveEnvironment(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.environment, got); diff != "" {
+				t.Errorf("resolveEnvironment(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package request
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+const (
+	errGenerateValue         = "cannot generate value %s"
+	errPersistGeneratedValue = "cannot persist generated values in status"
+	errUnknownGeneratedType  = "unknown type of generated value: %s"
+
+	defaultRandomStringLength = 16
+	randomStringAlphabet      = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// generateValues generates the generated values of the Request that were not generated yet, and persists them in
+// its status, so that they are reused by subsequent reconciles.
+func (c *external) generateValues(ctx context.Context, cr *v1alpha1.Request) error {
+	generated := false
+	for _, value := range cr.Spec.ForProvider.GeneratedValues {
+		if _, ok := cr.Status.GeneratedValues[value.Name]; ok {
+			continue
+		}
+
+		v, err := generateValue(value)
+		if err != nil {
+			return errors.Wrapf(err, errGenerateValue, value.Name)
+		}
+
+		if cr.Status.GeneratedValues == nil {
+			cr.Status.GeneratedValues = map[string]string{}
+		}
+		cr.Status.GeneratedValues[value.Name] = v
+		generated = true
+	}
+
+	if !generated {
+		return nil
+	}
+
+	return errors.Wrap(c.localKube.Status().Update(ctx, cr), errPersistGeneratedValue)
+}
+
+// generateValue generates a value of the type of the given generated value.
+func generateValue(value v1alpha1.GeneratedValue) (string, error) {
+	switch value.Type {
+	case v1alpha1.GeneratedValueUUID:
+		id, err := uuid.NewRandom()
+		return id.String(), err
+	case v1alpha1.GeneratedValueRandomString:
+		length := defaultRandomStringLength
+		if value.Length != nil {
+			length = *value.Length
+		}
+		return randomString(length)
+	default:
+		return "", errors.Errorf(errUnknownGeneratedType, value.Type)
+	}
+}
+
+// randomString returns a cryptographically random alphanumeric string of the given length.
+func randomString(length int) (string, error) {
+	max := big.NewInt(int64(len(randomStringAlphabet)))
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b[i] = randomStringAlphabet[n.Int64()]
+	}
+
+	return string(b), nil
+}
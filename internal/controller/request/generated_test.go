@@ -0,0 +1,112 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+func Test_generateValues(t *testing.T) {
+	length := 8
+
+	type args struct {
+		values    []v1alpha1.GeneratedValue
+		generated map[string]string
+		statusErr error
+	}
+	type want struct {
+		kept      map[string]string
+		lengths   map[string]int
+		persisted bool
+		err       bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoGeneratedValues": {
+			args: args{},
+			want: want{},
+		},
+		"Generated": {
+			args: args{
+				values: []v1alpha1.GeneratedValue{
+					{Name: "key", Type: v1alpha1.GeneratedValueUUID},
+					{Name: "token", Type: v1alpha1.GeneratedValueRandomString, Length: &length},
+					{Name: "nonce", Type: v1alpha1.GeneratedValueRandomString},
+				},
+			},
+			want: want{
+				lengths:   map[string]int{"key": 36, "token": 8, "nonce": 16},
+				persisted: true,
+			},
+		},
+		"AlreadyGenerated": {
+			args: args{
+				values:    []v1alpha1.GeneratedValue{{Name: "key", Type: v1alpha1.GeneratedValueUUID}},
+				generated: map[string]string{"key": "existing"},
+			},
+			want: want{
+				kept: map[string]string{"key": "existing"},
+			},
+		},
+		"PersistFailed": {
+			args: args{
+				values:    []v1alpha1.GeneratedValue{{Name: "key", Type: v1alpha1.GeneratedValueUUID}},
+				statusErr: errBoom,
+			},
+			want: want{
+				persisted: true,
+				err:       true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			persisted := false
+			e := &external{
+				logger: logging.NewNopLogger(),
+				localKube: &test.MockClient{
+					MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+						persisted = true
+						return tc.args.statusErr
+					},
+				},
+			}
+			cr := &v1alpha1.Request{}
+			cr.Spec.ForProvider.GeneratedValues = tc.args.values
+			cr.Status.GeneratedValues = tc.args.generated
+
+			err := e.generateValues(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("generateValues(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.persisted, persisted); diff != "" {
+				t.Errorf("generateValues(...): -want persisted, +got persisted: %s", diff)
+			}
+			for key, value := range tc.want.kept {
+				if diff := cmp.Diff(value, cr.Status.GeneratedValues[key]); diff != "" {
+					t.Errorf("generateValues(...): -want kept value, +got value: %s", diff)
+				}
+			}
+			for key, length := range tc.want.lengths {
+				if diff := cmp.Diff(length, len(cr.Status.GeneratedValues[key])); diff != "" {
+					t.Errorf("generateValues(...): -want length of %s, +got length: %s", key, diff)
+				}
+			}
+			if key, ok := cr.Status.GeneratedValues["key"]; ok && tc.want.kept == nil {
+				if _, err := uuid.Parse(key); err != nil {
+					t.Errorf("generateValues(...): generated key is not a UUID: %s", err)
+				}
+			}
+		})
+	}
+}
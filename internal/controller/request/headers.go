@@ -0,0 +1,59 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+)
+
+const (
+	errReadHeaderValue = "cannot read value of header %s"
+)
+
+// effectiveHeaderValues returns the header values of the request, with those of the mapping with the given
+// action merged over them by name.
+func effectiveHeaderValues(forProvider *v1alpha1.RequestParameters, action string) []v1alpha1.Header {
+	mapping, ok := getMapping(forProvider, action)
+	if !ok || len(mapping.HeaderValues) == 0 {
+		return forProvider.HeaderValues
+	}
+
+	overridden := map[string]bool{}
+	for _, header := range mapping.HeaderValues {
+		overridden[header.Name] = true
+	}
+
+	headers := make([]v1alpha1.Header, 0, len(forProvider.HeaderValues)+len(mapping.HeaderValues))
+	for _, header := range forProvider.HeaderValues {
+		if !overridden[header.Name] {
+			headers = append(headers, header)
+		}
+	}
+
+	return append(headers, mapping.HeaderValues...)
+}
+
+// headerOptions returns the HTTP client options sending the given headers, reading the values referenced from
+// Secrets.
+func headerOptions(ctx context.Context, kube client.Client, headers []v1alpha1.Header) ([]httpClient.ClientOption, error) {
+	opts := make([]httpClient.ClientOption, 0, len(headers))
+	for _, header := range headers {
+		value := header.Value
+		if header.ValueFrom != nil {
+			secretValue, err := kubehandler.GetSecretValue(ctx, kube, header.ValueFrom.SecretKeyRef)
+			if err != nil {
+				return nil, errors.Wrapf(err, errReadHeaderValue, header.Name)
+			}
+			value = string(secretValue)
+		}
+
+		opts = append(opts, httpClient.WithHeader(header.Name, value))
+	}
+
+	return opts, nil
+}
@@ -0,0 +1,80 @@
+package request
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+func Test_effectiveHeaderValues(t *testing.T) {
+	apiKey := v1alpha1.Header{Name: "X-Api-Key", Value: "request-key"}
+	tenant := v1alpha1.Header{Name: "X-Tenant", Value: "tenant"}
+	mappingKey := v1alpha1.Header{Name: "X-Api-Key", Value: "mapping-key"}
+
+	type args struct {
+		forProvider *v1alpha1.RequestParameters
+		action      string
+	}
+	type want struct {
+		headers []v1alpha1.Header
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RequestHeaders": {
+			args: args{
+				forProvider: &v1alpha1.RequestParameters{
+					HeaderValues: []v1alpha1.Header{apiKey, tenant},
+					Mappings:     []v1alpha1.Mapping{testPostMapping},
+				},
+				action: v1alpha1.ActionCreate,
+			},
+			want: want{
+				headers: []v1alpha1.Header{apiKey, tenant},
+			},
+		},
+		"MappingHeadersMerged": {
+			args: args{
+				forProvider: &v1alpha1.RequestParameters{
+					HeaderValues: []v1alpha1.Header{apiKey, tenant},
+					Mappings: []v1alpha1.Mapping{
+						testPostMapping,
+						{Method: http.MethodGet, HeaderValues: []v1alpha1.Header{mappingKey}},
+					},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				headers: []v1alpha1.Header{tenant, mappingKey},
+			},
+		},
+		"OtherMappingNotAffected": {
+			args: args{
+				forProvider: &v1alpha1.RequestParameters{
+					HeaderValues: []v1alpha1.Header{apiKey},
+					Mappings: []v1alpha1.Mapping{
+						testPostMapping,
+						{Method: http.MethodGet, HeaderValues: []v1alpha1.Header{mappingKey}},
+					},
+				},
+				action: v1alpha1.ActionCreate,
+			},
+			want: want{
+				headers: []v1alpha1.Header{apiKey},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := effectiveHeaderValues(tc.args.forProvider, tc.args.action)
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Errorf("effectiveHeaderValues(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/internal/jq"
+	"github.com/arielsepton/provider-http/internal/json"
+)
+
+const (
+	errIgnoreField = "cannot ignore field %s"
+)
+
+// withoutIgnoredFields returns the given object without the fields referred to by the given JSON Pointers
+// (starting with /) or jq paths (starting with .).
+func withoutIgnoredFields(object map[string]interface{}, fields []string) (map[string]interface{}, error) {
+	if object == nil {
+		return object, nil
+	}
+
+	for _, field := range fields {
+		if strings.HasPrefix(field, "/") {
+			json.RemovePointer(object, field)
+			continue
+		}
+
+		stripped, err := jq.ParseMapInterface(fmt.Sprintf("del(%s)", field), object)
+		if err != nil {
+			return nil, errors.Wrapf(err, errIgnoreField, field)
+		}
+		object = stripped
+	}
+
+	return object, nil
+}
@@ -0,0 +1,133 @@
+package request
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+const (
+	errReadPart  = "cannot read content of multipart part %s"
+	errWritePart = "cannot write multipart part %s"
+)
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// multipartBody returns a reader of the parts of the mapping encoded as a multipart/form-data body, with the given
+// rendered values of its field parts and the content of its file parts read from their Secret or ConfigMap keys,
+// along with its content type. The parts are encoded as the body is read, and the content of the file parts is
+// copied straight from their source. Closing the body stops encoding them.
+func (c *external) multipartBody(ctx context.Context, mapping *v1alpha1.Mapping, values []string) (io.ReadCloser, string, error) {
+	// The sources of the file parts are read first, so that a missing one fails before the request is sent.
+	contents := make([]io.Reader, len(mapping.Multipart))
+	for i, part := range mapping.Multipart {
+		if part.ValueFrom == nil {
+			continue
+		}
+
+		content, err := openValue(ctx, c.localKube, part.ValueFrom)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, errReadPart, part.Name)
+		}
+		contents[i] = content
+	}
+
+	pr, pw := io.Pipe()
+	writer := multipart.NewWriter(pw)
+	contentType := writer.FormDataContentType()
+	go func() {
+		pw.CloseWithError(writeParts(writer, mapping.Multipart, values, contents))
+	}()
+
+	return pr, contentType, nil
+}
+
+// writeParts writes the given parts with the given rendered values of the field parts and contents of the file
+// parts, and closes the writer.
+func writeParts(writer *multipart.Writer, parts []v1alpha1.MultipartPart, values []string, contents []io.Reader) error {
+	for i, part := range parts {
+		if contents[i] == nil {
+			value := ""
+			if i < len(values) {
+				value = values[i]
+			}
+			if err := writer.WriteField(part.Name, value); err != nil {
+				return errors.Wrapf(err, errWritePart, part.Name)
+			}
+			continue
+		}
+
+		w, err := writer.CreatePart(filePartHeader(part))
+		if err != nil {
+			return errors.Wrapf(err, errWritePart, part.Name)
+		}
+		if _, err := io.Copy(w, contents[i]); err != nil {
+			return errors.Wrapf(err, errWritePart, part.Name)
+		}
+	}
+
+	return errors.Wrapf(writer.Close(), errWritePart, "boundary")
+}
+
+// filePartHeader returns the header of the given file part, defaulting its file name to the referenced key.
+func filePartHeader(part v1alpha1.MultipartPart) textproto.MIMEHeader {
+	filename := part.Filename
+	if filename == "" {
+		filename = sourceKey(part.ValueFrom)
+	}
+
+	contentType := part.ContentType
+	if contentType == "" {
+		contentType = contentTypeOctetStream
+	}
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="%s"`, quoteEscaper.Replace(part.Name), quoteEscaper.Replace(filename)))
+	header.Set("Content-Type", contentType)
+
+	return header
+}
+
+// sourceKey returns the key referenced by the given source.
+func sourceKey(source *v1alpha1.ValueSource) string {
+	switch {
+	case source.SecretKeyRef != nil:
+		return source.SecretKeyRef.Key
+	case source.ConfigMapKeyRef != nil:
+		return source.ConfigMapKeyRef.Key
+	default:
+		return ""
+	}
+}
+
+// hasHeader checks whether the given headers hold the given header.
+func hasHeader(headers map[string][]string, name string) bool {
+	for key := range headers {
+		if textproto.CanonicalMIMEHeaderKey(key) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withContentType returns a copy of the given headers with the given Content-Type, replacing the one set.
+func withContentType(headers map[string][]string, contentType string) map[string][]string {
+	copied := make(map[string][]string, len(headers)+1)
+	for key, values := range headers {
+		if textproto.CanonicalMIMEHeaderKey(key) == "Content-Type" {
+			continue
+		}
+		copied[key] = values
+	}
+	copied["Content-Type"] = []string{contentType}
+
+	return copied
+}
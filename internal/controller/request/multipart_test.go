@@ -0,0 +1,128 @@
+package request
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+// testPart is a decoded part of a multipart body.
+type testPart struct {
+	Name        string
+	Filename    string
+	ContentType string
+	Content     string
+}
+
+func Test_multipartBody(t *testing.T) {
+	certificate := &v1alpha1.ValueSource{
+		SecretKeyRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "certificate", Namespace: "crossplane-system"},
+			Key:             "tls.crt",
+		},
+	}
+	secretValue := &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"tls.crt": []byte("-----BEGIN CERTIFICATE-----")}
+		return nil
+	})}
+
+	type args struct {
+		kube   client.Client
+		parts  []v1alpha1.MultipartPart
+		values []string
+	}
+	type want struct {
+		parts []testPart
+		err   bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FieldsAndFiles": {
+			args: args{
+				kube: secretValue,
+				parts: []v1alpha1.MultipartPart{
+					{Name: "name", Value: ".payload.body.name"},
+					{Name: "certificate", ValueFrom: certificate},
+					{Name: "bundle", ValueFrom: certificate, Filename: "ca.pem", ContentType: "application/x-pem-file"},
+				},
+				values: []string{"web", "", ""},
+			},
+			want: want{
+				parts: []testPart{
+					{Name: "name", Content: "web"},
+					{Name: "certificate", Filename: "tls.crt", ContentType: "application/octet-stream", Content: "-----BEGIN CERTIFICATE-----"},
+					{Name: "bundle", Filename: "ca.pem", ContentType: "application/x-pem-file", Content: "-----BEGIN CERTIFICATE-----"},
+				},
+			},
+		},
+		"SecretNotFound": {
+			args: args{
+				kube:   &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				parts:  []v1alpha1.MultipartPart{{Name: "certificate", ValueFrom: certificate}},
+				values: []string{""},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := &external{localKube: tc.args.kube, logger: logging.NewNopLogger()}
+			body, contentType, err := e.multipartBody(context.Background(), &v1alpha1.Mapping{Method: "POST", Multipart: tc.args.parts}, tc.args.values)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("multipartBody(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.parts, readParts(t, body, contentType)); diff != "" {
+				t.Errorf("multipartBody(...): -want parts, +got parts: %s", diff)
+			}
+		})
+	}
+}
+
+// readParts decodes the parts of the given multipart body.
+func readParts(t *testing.T, body io.Reader, contentType string) []testPart {
+	t.Helper()
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("cannot parse content type %s: %s", contentType, err)
+	}
+
+	var parts []testPart
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return parts
+		}
+		if err != nil {
+			t.Fatalf("cannot read part: %s", err)
+		}
+
+		content, _ := io.ReadAll(part)
+		parts = append(parts, testPart{
+			Name:        part.FormName(),
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Content:     string(content),
+		})
+	}
+}
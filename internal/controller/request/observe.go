@@ -2,10 +2,14 @@ package request
 
 import (
 	"context"
+	"fmt"
+	"mime"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
 	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
 	"github.com/arielsepton/provider-http/internal/json"
@@ -16,12 +20,22 @@ import (
 const (
 	errObjectNotFound = "object wasn't found"
 	errNotValidJSON   = "%s is not a valid JSON string: %s"
+	errNotJSONContent = "response has content type %s instead of JSON, consider setting an Accept header using contentNegotiation"
+
+	msgExtraFields = "Response body holds fields that are not in the desired state"
 )
 
 type ObserveRequestDetails struct {
 	Details       httpClient.HttpDetails
 	ResponseError error
 	Synced        bool
+
+	// Fresh is true when the stored response was reused instead of sending a new request.
+	Fresh bool
+
+	// Drift lists the fields of the response that differ from the desired state, when they were compared as
+	// structured documents.
+	Drift *apisv1alpha1.Drift
 }
 
 // NewObserveRequestDetails is a constructor function that initializes
@@ -48,40 +62,111 @@ func (c *external) isUpToDate(ctx context.Context, cr *v1alpha1.Request) (Observ
 		return FailedObserve(), errors.New(errObjectNotFound)
 	}
 
-	requestDetails, err := c.requestDetails(cr, http.MethodGet)
+	cr, err := c.withStoredBodies(ctx, c.withPayload(cr))
+	if err != nil {
+		return FailedObserve(), err
+	}
+	cr, err = c.decrypted(cr)
 	if err != nil {
 		return FailedObserve(), err
 	}
 
-	details, responseErr := c.http.SendRequest(ctx, http.MethodGet, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
+	if awaitingCreation(cr, time.Now()) {
+		return ObserveRequestDetails{Synced: true, Fresh: true}, nil
+	}
+
+	if details, ok := freshObservation(cr); ok {
+		return c.compareFreshObservation(cr, details)
+	}
+
+	mapping, ok := getMapping(&cr.Spec.ForProvider, v1alpha1.ActionObserve)
+	if !ok {
+		return FailedObserve(), errors.Errorf(errMappingNotFound, v1alpha1.ActionObserve)
+	}
+
+	requestDetails, err := c.generateValidRequestDetails(cr, mapping)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	if cr.Status.Location != "" {
+		requestDetails.Url = cr.Status.Location
+	}
+
+	unsignedURL := requestDetails.Url
+	if err := c.signURL(ctx, mapping, &requestDetails); err != nil {
+		return FailedObserve(), err
+	}
+
+	details, responseErr := c.httpFor(v1alpha1.ActionObserve).SendRequest(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, insecureSkipTLSVerify(c.tlsDefaults, &cr.Spec.ForProvider, v1alpha1.ActionObserve))
+	details = withUnsignedURL(mapping, details, unsignedURL)
 	if details.HttpResponse.StatusCode == http.StatusNotFound {
 		return FailedObserve(), errors.New(errObjectNotFound)
 	}
 
+	// The responses of HEAD and OPTIONS requests hold no representation of the resource to compare with the
+	// desired state, they only tell that it exists.
+	if observesExistence(mapping.Method) {
+		return NewObserve(details, responseErr, utils.IsSuccess(c.statusCodes, details.HttpResponse.StatusCode)), nil
+	}
+
+	if err := c.validateResponse(mapping, details.HttpResponse); err != nil {
+		return FailedObserve(), err
+	}
+
+	desiredState, err := c.desiredState(cr)
+	if err != nil {
+		return FailedObserve(), err
+	}
+
+	return c.checkResponse(cr, details, responseErr, desiredState)
+}
+
+// compareFreshObservation checks for drift against the stored response of the last OBSERVE request. The stored
+// responses of HEAD and OPTIONS requests only tell that the resource exists.
+func (c *external) compareFreshObservation(cr *v1alpha1.Request, details httpClient.HttpDetails) (ObserveRequestDetails, error) {
+	if observesExistence(details.HttpRequest.Method) {
+		observeRequestDetails := NewObserve(details, nil, utils.IsSuccess(c.statusCodes, details.HttpResponse.StatusCode))
+		observeRequestDetails.Fresh = true
+		return observeRequestDetails, nil
+	}
+
 	desiredState, err := c.desiredState(cr)
 	if err != nil {
 		return FailedObserve(), err
 	}
 
-	return c.compareResponseAndDesiredState(details, responseErr, desiredState)
+	observeRequestDetails, err := c.checkResponse(cr, details, nil, desiredState)
+	observeRequestDetails.Fresh = err == nil
+	return observeRequestDetails, err
 }
 
 func (c *external) isObjectValidForObservation(cr *v1alpha1.Request) bool {
-	return cr.Status.Response.Body != "" &&
-		!(cr.Status.RequestDetails.Method == http.MethodPost && utils.IsHTTPError(cr.Status.Response.StatusCode))
+	if createAcknowledged(cr) || operationSucceeded(cr) || importing(cr) {
+		return true
+	}
+
+	return (cr.Status.Response.Body != "" || cr.Status.Location != "") &&
+		!(cr.Status.RequestDetails.GetAction() == v1alpha1.ActionCreate && utils.IsFailure(c.statusCodes, cr.Status.Response.StatusCode))
 }
 
-func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails, err error, desiredState string) (ObserveRequestDetails, error) {
-	observeRequestDetails := NewObserve(details, err, false)
+func (c *external) compareResponseAndDesiredState(cr *v1alpha1.Request, details httpClient.HttpDetails, err error, desiredState string) (ObserveRequestDetails, error) {
+	if isXMLResponse(details.HttpResponse, desiredState) {
+		return c.compareXMLResponse(cr, details, err, desiredState)
+	}
+
+	if isYAMLContentType(responseContentType(details.HttpResponse)) {
+		return c.compareYAMLResponse(cr, details, err, desiredState)
+	}
 
 	if json.IsJSONString(details.HttpResponse.Body) && json.IsJSONString(desiredState) {
-		responseBodyMap := json.JsonStringToMap(details.HttpResponse.Body)
-		desiredStateMap := json.JsonStringToMap(desiredState)
-		observeRequestDetails.Synced = json.Contains(responseBodyMap, desiredStateMap) && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
-		return observeRequestDetails, nil
+		return c.compareObjects(cr, details, err, json.JsonStringToMap(details.HttpResponse.Body), json.JsonStringToMap(desiredState))
 	}
 
 	if !json.IsJSONString(details.HttpResponse.Body) && json.IsJSONString(desiredState) {
+		if contentType := responseContentType(details.HttpResponse); contentType != "" && !isJSONContentType(contentType) {
+			return FailedObserve(), errors.Errorf(errNotJSONContent, contentType)
+		}
 		return FailedObserve(), errors.Errorf(errNotValidJSON, "response body", details.HttpResponse.Body)
 	}
 
@@ -89,20 +174,69 @@ func (c *external) compareResponseAndDesiredState(details httpClient.HttpDetails
 		return FailedObserve(), errors.Errorf(errNotValidJSON, "PUT mapping result", desiredState)
 	}
 
-	observeRequestDetails.Synced = strings.Contains(details.HttpResponse.Body, desiredState) && utils.IsHTTPSuccess(details.HttpResponse.StatusCode)
-	return observeRequestDetails, nil
+	synced := strings.Contains(details.HttpResponse.Body, desiredState)
+	if cr.Spec.ForProvider.CompareStrategy == v1alpha1.CompareStrategyExact {
+		synced = details.HttpResponse.Body == desiredState
+	}
+
+	return NewObserve(details, err, synced && utils.IsSuccess(c.statusCodes, details.HttpResponse.StatusCode)), nil
+}
+
+// withoutPlaceholders returns the given desired state without the fields holding Vault secret placeholders, as the
+// resolved values cannot be compared with the observed state.
+func withoutPlaceholders(desiredState map[string]interface{}) map[string]interface{} {
+	filtered := make(map[string]interface{}, len(desiredState))
+	for key, value := range desiredState {
+		if httpClient.ContainsPlaceholder(fmt.Sprint(value)) {
+			continue
+		}
+		filtered[key] = value
+	}
+
+	return filtered
+}
+
+// responseContentType returns the media type of the given response, or an empty string if it is unknown.
+func responseContentType(response httpClient.HttpResponse) string {
+	mediaType, _, err := mime.ParseMediaType(http.Header(response.Headers).Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
+
+// isXMLContentType checks whether the given media type describes an XML document, e.g. application/xml or application/atom+xml.
+func isXMLContentType(mediaType string) bool {
+	return mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// isYAMLContentType checks whether the given media type describes a YAML document, e.g. application/yaml or
+// text/x-yaml.
+func isYAMLContentType(mediaType string) bool {
+	switch mediaType {
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return true
+	}
+
+	return strings.HasSuffix(mediaType, "+yaml")
+}
+
+// isJSONContentType checks whether the given media type describes a JSON document, e.g. application/json or application/problem+json.
+func isJSONContentType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
 }
 
 func (c *external) desiredState(cr *v1alpha1.Request) (string, error) {
-	requestDetails, err := c.requestDetails(cr, http.MethodPut)
+	requestDetails, err := c.requestDetails(cr, v1alpha1.ActionUpdate)
 	return requestDetails.Body, err
 }
 
-func (c *external) requestDetails(cr *v1alpha1.Request, method string) (requestgen.RequestDetails, error) {
-	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, method)
+func (c *external) requestDetails(cr *v1alpha1.Request, action string) (requestgen.RequestDetails, error) {
+	mapping, ok := getMapping(&cr.Spec.ForProvider, action)
 	if !ok {
-		return requestgen.RequestDetails{}, errors.Errorf(errMappingNotFound, method)
+		return requestgen.RequestDetails{}, errors.Errorf(errMappingNotFound, action)
 	}
 
-	return generateValidRequestDetails(cr, mapping)
+	return c.generateValidRequestDetails(cr, mapping)
 }
@@ -4,13 +4,18 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/jsonschema"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -19,6 +24,10 @@ var (
 )
 
 func Test_isUpToDate(t *testing.T) {
+	testResponseSchema := `{"type":"object","required":["username"]}`
+	testYAMLResponse := "id: \"123\"\nusername: john_doe_new_username\n"
+	testXMLResponse := `<user id="123"><username>john_doe</username><email>john.doe@example.com</email></user>`
+
 	type args struct {
 		http      httpClient.Client
 		localKube client.Client
@@ -110,6 +119,130 @@ func Test_isUpToDate(t *testing.T) {
 				err: errors.Errorf(errNotValidJSON, "response body", "not a JSON"),
 			},
 		},
+		"FailResponseNotJSONContentType": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:    "username=john_doe",
+								Headers: map[string][]string{"Content-Type": {"text/plain; charset=utf-8"}},
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errors.Errorf(errNotJSONContent, "text/plain"),
+			},
+		},
+		"SuccessYAMLResponse": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       testYAMLResponse,
+								Headers:    map[string][]string{"Content-Type": {"application/yaml"}},
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       testYAMLResponse,
+							Headers:    map[string][]string{"Content-Type": {"application/yaml"}},
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"SuccessXMLResponse": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       testXMLResponse,
+								Headers:    map[string][]string{"Content-Type": {"application/xml; charset=utf-8"}},
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						testGetMapping,
+						{Method: http.MethodPut, URL: testPutMapping.URL, Body: `"<user><username>john_doe</username></user>"`},
+						testDeleteMapping,
+					}
+					r.Status.Response.Body = `{"id":"123"}`
+				}),
+			},
+			want: want{
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       testXMLResponse,
+							Headers:    map[string][]string{"Content-Type": {"application/xml; charset=utf-8"}},
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"FailResponseSchema": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"user_name":"john_doe"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{Method: http.MethodGet, URL: testGetMapping.URL, ResponseSchema: testResponseSchema},
+						testPutMapping,
+						testDeleteMapping,
+					}
+					r.Status.Response.Body = `{"id":"123","username":"john_doe"}`
+				}),
+			},
+			want: want{
+				err: errors.Wrapf(jsonschema.Validate(testResponseSchema, `{"user_name":"john_doe"}`), errInvalidResponse, http.MethodGet),
+			},
+		},
 		"SuccessNotSynced": {
 			args: args{
 				http: &MockHttpClient{
@@ -141,6 +274,470 @@ func Test_isUpToDate(t *testing.T) {
 					},
 					ResponseError: nil,
 					Synced:        false,
+					Drift:         &apisv1alpha1.Drift{Changed: []string{"/username"}},
+				},
+			},
+		},
+		"SuccessObserveCapturedLocation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if url != "https://api.example.com/users/123" {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{
+									StatusCode: 404,
+								},
+							}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.StatusCode = 303
+					r.Status.Location = "https://api.example.com/users/123"
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"SuccessImportExternalName": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if url != "https://api.example.com/users/123" {
+							return httpClient.HttpDetails{
+								HttpResponse: httpClient.HttpResponse{
+									StatusCode: 404,
+								},
+							}, nil
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"id":"123","username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					meta.SetExternalName(r, "123")
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{Method: "GET", URL: `(.payload.baseUrl + "/" + (.response.body.id // .externalName))`},
+						{Method: "PUT", Body: `{ username: "john_doe_new_username" }`, URL: `(.payload.baseUrl + "/" + (.response.body.id // .externalName))`},
+					}
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123","username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"ObjectNotFoundDefaultExternalName": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					meta.SetExternalName(r, r.GetName())
+				}),
+			},
+			want: want{
+				err: errNotFound,
+			},
+		},
+		"SuccessObserveAcknowledgedCreation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.CreateResponse = &v1alpha1.CreateResponse{StatusCodes: []int{202}}
+					r.Status.RequestDetails.Method = http.MethodPost
+					r.Status.Response.StatusCode = 202
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					ResponseError: nil,
+					Synced:        true,
+				},
+			},
+		},
+		"SuccessAwaitingCreation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.CreateResponse = &v1alpha1.CreateResponse{
+						StatusCodes: []int{202},
+						Then:        v1alpha1.AfterCreateWait,
+					}
+					meta.SetExternalCreateSucceeded(r, time.Now())
+					r.Status.RequestDetails.Method = http.MethodPost
+					r.Status.Response.StatusCode = 202
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Synced: true,
+					Fresh:  true,
+				},
+			},
+		},
+		"SuccessFreshObservation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.StaleAfter = &metav1.Duration{Duration: time.Hour}
+					r.Status.LastFetchedTime = &metav1.Time{Time: time.Now()}
+					r.Status.RequestDetails.Method = http.MethodGet
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+						HttpRequest: httpClient.HttpRequest{
+							Method: http.MethodGet,
+						},
+					},
+					Synced: true,
+					Fresh:  true,
+				},
+			},
+		},
+		"SuccessRedactedFreshObservation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body:       `{"username":"john_doe_new_username"}`,
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.StaleAfter = &metav1.Duration{Duration: time.Hour}
+					r.Spec.ForProvider.Redact = &v1alpha1.Redaction{BodyFields: []string{".username"}}
+					r.Status.LastFetchedTime = &metav1.Time{Time: time.Now()}
+					r.Status.RequestDetails.Method = http.MethodGet
+					r.Status.Response.Body = `{"username":"REDACTED"}`
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
+				},
+			},
+		},
+		"SuccessFreshObservationOfPostMapping": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.StaleAfter = &metav1.Duration{Duration: time.Hour}
+					r.Status.LastFetchedTime = &metav1.Time{Time: time.Now()}
+					r.Status.RequestDetails = v1alpha1.Mapping{Method: http.MethodPost, Action: v1alpha1.ActionObserve}
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							StatusCode: 200,
+						},
+						HttpRequest: httpClient.HttpRequest{
+							Method: http.MethodPost,
+						},
+					},
+					Synced: true,
+					Fresh:  true,
+				},
+			},
+		},
+		"SuccessFreshHeadObservation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.StaleAfter = &metav1.Duration{Duration: time.Hour}
+					r.Status.LastFetchedTime = &metav1.Time{Time: time.Now()}
+					r.Status.RequestDetails = v1alpha1.Mapping{Method: http.MethodHead, Action: v1alpha1.ActionObserve}
+					r.Status.Response.Body = `{"id":"123"}`
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"id":"123"}`,
+							StatusCode: 200,
+						},
+						HttpRequest: httpClient.HttpRequest{
+							Method: http.MethodHead,
+						},
+					},
+					Synced: true,
+					Fresh:  true,
+				},
+			},
+		},
+		"FailGetMappingOfAnotherAction": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body: "not a JSON",
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.StaleAfter = &metav1.Duration{Duration: time.Hour}
+					r.Status.LastFetchedTime = &metav1.Time{Time: time.Now()}
+					r.Status.RequestDetails = v1alpha1.Mapping{Method: http.MethodGet, Action: v1alpha1.ActionCreate}
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errors.Errorf(errNotValidJSON, "response body", "not a JSON"),
+			},
+		},
+		"FailStaleObservation": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body: "not a JSON",
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.StaleAfter = &metav1.Duration{Duration: time.Minute}
+					r.Status.LastFetchedTime = &metav1.Time{Time: time.Now().Add(-time.Hour)}
+					r.Status.RequestDetails.Method = http.MethodGet
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+				}),
+			},
+			want: want{
+				err: errors.Errorf(errNotValidJSON, "response body", "not a JSON"),
+			},
+		},
+		"SuccessFreshByCacheHeaders": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{}, errBoom
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.HonorCacheHeaders = true
+					r.Status.LastFetchedTime = &metav1.Time{Time: time.Now()}
+					r.Status.RequestDetails.Method = http.MethodGet
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.Headers = map[string][]string{"Cache-Control": {"max-age=3600"}}
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							Body:       `{"username":"john_doe_new_username"}`,
+							Headers:    map[string][]string{"Cache-Control": {"max-age=3600"}},
+							StatusCode: 200,
+						},
+						HttpRequest: httpClient.HttpRequest{
+							Method: http.MethodGet,
+						},
+					},
+					Synced: true,
+					Fresh:  true,
+				},
+			},
+		},
+		"FailNoStoreCacheHeader": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								Body: "not a JSON",
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.HonorCacheHeaders = true
+					r.Spec.ForProvider.StaleAfter = &metav1.Duration{Duration: time.Hour}
+					r.Status.LastFetchedTime = &metav1.Time{Time: time.Now()}
+					r.Status.RequestDetails.Method = http.MethodGet
+					r.Status.Response.Body = `{"username":"john_doe_new_username"}`
+					r.Status.Response.Headers = map[string][]string{"Cache-Control": {"no-store"}}
+				}),
+			},
+			want: want{
+				err: errors.Errorf(errNotValidJSON, "response body", "not a JSON"),
+			},
+		},
+		"SuccessHeadMapping": {
+			args: args{
+				http: &MockHttpClient{
+					MockSendRequest: func(ctx context.Context, method string, url string, body string, headers map[string][]string, skipTLSVerify bool) (resp httpClient.HttpDetails, err error) {
+						if method != http.MethodHead {
+							return httpClient.HttpDetails{}, errBoom
+						}
+						return httpClient.HttpDetails{
+							HttpResponse: httpClient.HttpResponse{
+								StatusCode: 200,
+							},
+						}, nil
+					},
+				},
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Spec.ForProvider.Mappings = []v1alpha1.Mapping{
+						testPostMapping,
+						{Method: http.MethodHead, URL: testGetMapping.URL},
+						testPutMapping,
+						testDeleteMapping,
+					}
+					r.Status.Response.Body = `{"id":"123","username":"john_doe"}`
+					r.Status.Response.StatusCode = 200
+				}),
+			},
+			want: want{
+				err: nil,
+				result: ObserveRequestDetails{
+					Details: httpClient.HttpDetails{
+						HttpResponse: httpClient.HttpResponse{
+							StatusCode: 200,
+						},
+					},
+					Synced: true,
 				},
 			},
 		},
@@ -199,3 +796,65 @@ func Test_isUpToDate(t *testing.T) {
 		})
 	}
 }
+
+func Test_withoutPlaceholders(t *testing.T) {
+	cases := map[string]struct {
+		desiredState map[string]interface{}
+		want         map[string]interface{}
+	}{
+		"NoPlaceholders": {
+			desiredState: map[string]interface{}{"username": "john_doe"},
+			want:         map[string]interface{}{"username": "john_doe"},
+		},
+		"PlaceholderField": {
+			desiredState: map[string]interface{}{"username": "john_doe", "password": "{{ vault:secret/data/api#password }}"},
+			want:         map[string]interface{}{"username": "john_doe"},
+		},
+		"NestedPlaceholder": {
+			desiredState: map[string]interface{}{"username": "john_doe", "credentials": map[string]interface{}{"token": "{{ vault:secret/data/api#token }}"}},
+			want:         map[string]interface{}{"username": "john_doe"},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := withoutPlaceholders(tc.desiredState)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("withoutPlaceholders(...): -want desired state, +got desired state: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_drift(t *testing.T) {
+	response := map[string]interface{}{"id": "123", "username": "john_doe", "email": "john@example.com"}
+
+	cases := map[string]struct {
+		strategy     string
+		desiredState map[string]interface{}
+		want         *apisv1alpha1.Drift
+	}{
+		"NoDrift": {
+			desiredState: map[string]interface{}{"username": "john_doe"},
+			want:         nil,
+		},
+		"Subset": {
+			desiredState: map[string]interface{}{"username": "jane_doe", "age": 30},
+			want:         &apisv1alpha1.Drift{Changed: []string{"/username"}, Removed: []string{"/age"}},
+		},
+		"Exact": {
+			strategy:     v1alpha1.CompareStrategyExact,
+			desiredState: map[string]interface{}{"username": "jane_doe"},
+			want:         &apisv1alpha1.Drift{Added: []string{"/email", "/id"}, Changed: []string{"/username"}},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := drift(tc.strategy, response, tc.desiredState)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("drift(...): -want drift, +got drift: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/request/responseconverter"
+	"github.com/arielsepton/provider-http/internal/jq"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	errPollOperation      = "cannot poll asynchronous operation %s"
+	errEvaluateOperation  = "cannot evaluate %s expression of asynchronous operation"
+	errOperationFailed    = "asynchronous operation %s of %s failed"
+	errPersistOperation   = "cannot persist phase of asynchronous operation"
+	errOperationNotPolled = "asynchronous operation %s is pending, but the Request has no asyncOperation"
+	errOperationGone      = "asynchronous operation %s is gone: status code %d"
+	errOperationPoll      = "asynchronous operation %s cannot be polled yet: status code %d"
+
+	defaultOperationPollInterval = 10 * time.Second
+)
+
+// pendingOperation returns the pending asynchronous operation of the Request, or nil when there is none.
+func pendingOperation(cr *v1alpha1.Request) *v1alpha1.Operation {
+	if operation := cr.Status.Operation; operation != nil && operation.Phase == v1alpha1.OperationPending {
+		return operation
+	}
+
+	return nil
+}
+
+// pollOperation polls the pending asynchronous operation of the Request, and persists its phase once it
+// completed. It returns whether the operation is still pending, and an error when it failed. Operations that can
+// no longer be polled - the asyncOperation was removed, the operation URL is gone or its response cannot be
+// evaluated - are recorded as failed. Failed or throttled polls leave the operation pending, and are retried once
+// the Retry-After header of the response allows it. The operations of deleted Requests are not polled.
+func (c *external) pollOperation(ctx context.Context, cr *v1alpha1.Request) (bool, error) {
+	operation := pendingOperation(cr)
+	if operation == nil || meta.WasDeleted(cr) {
+		return false, nil
+	}
+
+	async := cr.Spec.ForProvider.AsyncOperation
+	if async == nil {
+		return false, c.failOperation(ctx, cr, errors.Errorf(errOperationNotPolled, operation.URL))
+	}
+
+	details, err := c.httpFor(operation.Action).SendRequest(ctx, http.MethodGet, operation.URL, "", nil, insecureSkipTLSVerify(c.tlsDefaults, &cr.Spec.ForProvider, operation.Action))
+	if err != nil {
+		return true, errors.Wrapf(err, errPollOperation, operation.URL)
+	}
+
+	code := details.HttpResponse.StatusCode
+	if code == http.StatusNotFound || code == http.StatusGone {
+		return false, c.failOperation(ctx, cr, errors.Errorf(errOperationGone, operation.URL, code))
+	}
+	if utils.IsFailure(c.statusCodes, code) {
+		return true, c.deferOperation(ctx, cr, details.HttpResponse, errors.Errorf(errOperationPoll, operation.URL, code))
+	}
+
+	response := responseconverter.V1alpha1ResponseToMap(responseconverter.HttpResponseToV1alpha1Response(details.HttpResponse))
+	phase, err := operationPhase(async, response)
+	if err != nil {
+		return false, c.failOperation(ctx, cr, err)
+	}
+	if phase == v1alpha1.OperationPending {
+		return true, nil
+	}
+
+	operation.Phase = phase
+	if err := c.localKube.Status().Update(ctx, cr); err != nil {
+		return false, errors.Wrap(err, errPersistOperation)
+	}
+
+	if phase == v1alpha1.OperationFailed {
+		return false, errors.Errorf(errOperationFailed, operation.URL, operation.Action)
+	}
+
+	return false, nil
+}
+
+// failOperation records the pending asynchronous operation of the Request as failed, and returns the reason.
+func (c *external) failOperation(ctx context.Context, cr *v1alpha1.Request, reason error) error {
+	cr.Status.Operation.Phase = v1alpha1.OperationFailed
+	if err := c.localKube.Status().Update(ctx, cr); err != nil {
+		return errors.Wrap(err, errPersistOperation)
+	}
+
+	return reason
+}
+
+// deferOperation keeps the asynchronous operation of the Request pending after a failed poll, records until when
+// the remote API asked not to be called, and returns the reason.
+func (c *external) deferOperation(ctx context.Context, cr *v1alpha1.Request, response httpClient.HttpResponse, reason error) error {
+	until := utils.RetryAfter(response.StatusCode, response.Headers, time.Now())
+	if until == nil {
+		return reason
+	}
+
+	cr.Status.ThrottledUntil = until
+	if err := c.localKube.Status().Update(ctx, cr); err != nil {
+		return errors.Wrap(err, errPersistOperation)
+	}
+
+	return reason
+}
+
+// operationPhase evaluates the done and failed expressions against the response of an operation.
+func operationPhase(async *v1alpha1.AsyncOperation, response map[string]interface{}) (string, error) {
+	if async.Failed != "" {
+		failed, err := jq.ParseBool(async.Failed, response)
+		if err != nil {
+			return "", errors.Wrapf(err, errEvaluateOperation, "failed")
+		}
+		if failed {
+			return v1alpha1.OperationFailed, nil
+		}
+	}
+
+	done, err := jq.ParseBool(async.Done, response)
+	if err != nil {
+		return "", errors.Wrapf(err, errEvaluateOperation, "done")
+	}
+	if done {
+		return v1alpha1.OperationSucceeded, nil
+	}
+
+	return v1alpha1.OperationPending, nil
+}
+
+// requeueOperation requeues a Request with a pending asynchronous operation when it should be polled next, unless
+// the remote API is throttling it.
+func requeueOperation(mg resource.Managed, result reconcile.Result) reconcile.Result {
+	cr, ok := mg.(*v1alpha1.Request)
+	if !ok || pendingOperation(cr) == nil || cr.Spec.ForProvider.AsyncOperation == nil || throttledUntil(cr) != nil {
+		return result
+	}
+
+	interval := defaultOperationPollInterval
+	if pollInterval := cr.Spec.ForProvider.AsyncOperation.PollInterval; pollInterval != nil {
+		interval = pollInterval.Duration
+	}
+
+	return reconcile.Result{RequeueAfter: interval}
+}
+
+// operationSucceeded checks whether the last asynchronous operation of the Request completed successfully.
+func operationSucceeded(cr *v1alpha1.Request) bool {
+	return cr.Status.Operation != nil && cr.Status.Operation.Phase == v1alpha1.OperationSucceeded
+}
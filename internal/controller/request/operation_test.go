@@ -0,0 +1,288 @@
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+func Test_pollOperation(t *testing.T) {
+	async := &v1alpha1.AsyncOperation{
+		Done:   `.body.status == "Succeeded"`,
+		Failed: `.body.status == "Failed"`,
+	}
+	operationResponse := func(code int, status string, err error) httpClient.Client {
+		return &MockHttpClient{
+			MockSendRequest: func(_ context.Context, _ string, _ string, _ string, _ map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+				return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: code, Body: `{"status": "` + status + `"}`}}, err
+			},
+		}
+	}
+	operationStatus := func(status string, err error) httpClient.Client {
+		return operationResponse(200, status, err)
+	}
+	throttledResponse := func(code int, retryAfter string) httpClient.Client {
+		return &MockHttpClient{
+			MockSendRequest: func(_ context.Context, _ string, _ string, _ string, _ map[string][]string, _ bool) (httpClient.HttpDetails, error) {
+				return httpClient.HttpDetails{HttpResponse: httpClient.HttpResponse{StatusCode: code, Headers: map[string][]string{"Retry-After": {retryAfter}}}}, nil
+			},
+		}
+	}
+
+	type args struct {
+		http    httpClient.Client
+		async   *v1alpha1.AsyncOperation
+		deleted bool
+	}
+	type want struct {
+		pending   bool
+		phase     string
+		err       bool
+		throttled bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Pending": {
+			args: args{
+				http:  operationStatus("Running", nil),
+				async: async,
+			},
+			want: want{
+				pending: true,
+				phase:   v1alpha1.OperationPending,
+			},
+		},
+		"Succeeded": {
+			args: args{
+				http:  operationStatus("Succeeded", nil),
+				async: async,
+			},
+			want: want{
+				phase: v1alpha1.OperationSucceeded,
+			},
+		},
+		"Failed": {
+			args: args{
+				http:  operationStatus("Failed", nil),
+				async: async,
+			},
+			want: want{
+				phase: v1alpha1.OperationFailed,
+				err:   true,
+			},
+		},
+		"PollFailed": {
+			args: args{
+				http:  operationStatus("", errBoom),
+				async: async,
+			},
+			want: want{
+				pending: true,
+				phase:   v1alpha1.OperationPending,
+				err:     true,
+			},
+		},
+		"ServerError": {
+			args: args{
+				http:  operationResponse(500, "", nil),
+				async: async,
+			},
+			want: want{
+				pending: true,
+				phase:   v1alpha1.OperationPending,
+				err:     true,
+			},
+		},
+		"Throttled": {
+			args: args{
+				http:  throttledResponse(429, "30"),
+				async: async,
+			},
+			want: want{
+				pending:   true,
+				phase:     v1alpha1.OperationPending,
+				err:       true,
+				throttled: true,
+			},
+		},
+		"NoAsyncOperation": {
+			args: args{
+				http: operationStatus("Succeeded", nil),
+			},
+			want: want{
+				phase: v1alpha1.OperationFailed,
+				err:   true,
+			},
+		},
+		"OperationNotFound": {
+			args: args{
+				http:  operationResponse(404, "", nil),
+				async: async,
+			},
+			want: want{
+				phase: v1alpha1.OperationFailed,
+				err:   true,
+			},
+		},
+		"OperationGone": {
+			args: args{
+				http:  operationResponse(410, "", nil),
+				async: async,
+			},
+			want: want{
+				phase: v1alpha1.OperationFailed,
+				err:   true,
+			},
+		},
+		"EvaluationFailed": {
+			args: args{
+				http:  operationStatus("Running", nil),
+				async: &v1alpha1.AsyncOperation{Done: `.body.status ==`},
+			},
+			want: want{
+				phase: v1alpha1.OperationFailed,
+				err:   true,
+			},
+		},
+		"Deleted": {
+			args: args{
+				http:    operationStatus("", errBoom),
+				async:   async,
+				deleted: true,
+			},
+			want: want{
+				phase: v1alpha1.OperationPending,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: &test.MockClient{
+					MockStatusUpdate: func(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+						return nil
+					},
+				},
+				logger: logging.NewNopLogger(),
+				http:   tc.args.http,
+			}
+			cr := &v1alpha1.Request{}
+			cr.Spec.ForProvider.AsyncOperation = tc.args.async
+			cr.Status.Operation = &v1alpha1.Operation{
+				URL:    "https://api.example.com/operations/1",
+				Action: v1alpha1.ActionCreate,
+				Phase:  v1alpha1.OperationPending,
+			}
+			if tc.args.deleted {
+				cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()})
+			}
+
+			pending, err := e.pollOperation(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("pollOperation(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.pending, pending); diff != "" {
+				t.Errorf("pollOperation(...): -want pending, +got pending: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.phase, cr.Status.Operation.Phase); diff != "" {
+				t.Errorf("pollOperation(...): -want phase, +got phase: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.throttled, throttledUntil(cr) != nil); diff != "" {
+				t.Errorf("pollOperation(...): -want throttled, +got throttled: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_requeueOperation(t *testing.T) {
+	type args struct {
+		async     *v1alpha1.AsyncOperation
+		operation *v1alpha1.Operation
+		throttled *metav1.Time
+		result    reconcile.Result
+	}
+	type want struct {
+		result reconcile.Result
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoOperation": {
+			args: args{
+				async:  &v1alpha1.AsyncOperation{},
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+		},
+		"OperationCompleted": {
+			args: args{
+				async:     &v1alpha1.AsyncOperation{},
+				operation: &v1alpha1.Operation{Phase: v1alpha1.OperationSucceeded},
+				result:    reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+		},
+		"DefaultPollInterval": {
+			args: args{
+				async:     &v1alpha1.AsyncOperation{},
+				operation: &v1alpha1.Operation{Phase: v1alpha1.OperationPending},
+				result:    reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: defaultOperationPollInterval},
+			},
+		},
+		"PollInterval": {
+			args: args{
+				async:     &v1alpha1.AsyncOperation{PollInterval: &metav1.Duration{Duration: 30 * time.Second}},
+				operation: &v1alpha1.Operation{Phase: v1alpha1.OperationPending},
+				result:    reconcile.Result{RequeueAfter: time.Minute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: 30 * time.Second},
+			},
+		},
+		"Throttled": {
+			args: args{
+				async:     &v1alpha1.AsyncOperation{},
+				operation: &v1alpha1.Operation{Phase: v1alpha1.OperationPending},
+				throttled: &metav1.Time{Time: time.Now().Add(time.Hour)},
+				result:    reconcile.Result{RequeueAfter: time.Hour},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Hour},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{}
+			cr.Spec.ForProvider.AsyncOperation = tc.args.async
+			cr.Status.Operation = tc.args.operation
+			cr.Status.ThrottledUntil = tc.args.throttled
+			got := requeueOperation(cr, tc.args.result)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("requeueOperation(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package request
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
+	json_util "github.com/arielsepton/provider-http/internal/json"
+)
+
+const (
+	errPatchBodyNotJSON = "body of PATCH mapping is not a JSON object: %s"
+	errEncodePatch      = "cannot encode PATCH body"
+
+	mergePatchContentType = "application/merge-patch+json"
+	jsonPatchContentType  = "application/json-patch+json"
+)
+
+// patchRequestDetails replaces the body of the given PATCH request details, which is the desired state, with
+// a patch setting the fields of the desired state that differ from the last observed response. The whole
+// desired state is patched when the observed response is not a JSON object.
+func patchRequestDetails(cr *v1alpha1.Request, mapping *v1alpha1.Mapping, requestDetails requestgen.RequestDetails) (requestgen.RequestDetails, error) {
+	if !json_util.IsJSONString(requestDetails.Body) {
+		return requestgen.RequestDetails{}, errors.Errorf(errPatchBodyNotJSON, requestDetails.Body)
+	}
+
+	desired := json_util.JsonStringToMap(requestDetails.Body)
+	current := map[string]interface{}{}
+	if json_util.IsJSONString(cr.Status.Response.Body) {
+		current = json_util.JsonStringToMap(cr.Status.Response.Body)
+	}
+
+	var patch interface{} = json_util.MergePatch(current, desired)
+	contentType := mergePatchContentType
+	if mapping.PatchType == v1alpha1.PatchTypeJSON {
+		patch = json_util.JSONPatch(current, desired)
+		contentType = jsonPatchContentType
+	}
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return requestgen.RequestDetails{}, errors.Wrap(err, errEncodePatch)
+	}
+
+	requestDetails.Body = string(body)
+	requestDetails.Headers = withDefaultContentType(requestDetails.Headers, contentType)
+	return requestDetails, nil
+}
+
+// withDefaultContentType returns the given headers with the given Content-Type, unless they set one already.
+func withDefaultContentType(headers map[string][]string, contentType string) map[string][]string {
+	for key := range headers {
+		if strings.EqualFold(key, "Content-Type") {
+			return headers
+		}
+	}
+
+	result := make(map[string][]string, len(headers)+1)
+	for key, values := range headers {
+		result[key] = values
+	}
+	result["Content-Type"] = []string{contentType}
+	return result
+}
@@ -0,0 +1,99 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
+)
+
+const testPatchURL = "https://api.example.com/users/123"
+
+func Test_patchRequestDetails(t *testing.T) {
+	type args struct {
+		patchType string
+		observed  string
+		details   requestgen.RequestDetails
+	}
+	type want struct {
+		details requestgen.RequestDetails
+		err     error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"MergePatch": {
+			args: args{
+				observed: `{"id": "123", "username": "john_doe", "email": "john.doe@example.com"}`,
+				details:  requestgen.RequestDetails{Url: testPatchURL, Body: `{"username": "john_doe_new_username", "email": "john.doe@example.com"}`},
+			},
+			want: want{
+				details: requestgen.RequestDetails{
+					Url:     testPatchURL,
+					Body:    `{"username":"john_doe_new_username"}`,
+					Headers: map[string][]string{"Content-Type": {mergePatchContentType}},
+				},
+			},
+		},
+		"JSONPatch": {
+			args: args{
+				patchType: v1alpha1.PatchTypeJSON,
+				observed:  `{"id": "123", "username": "john_doe"}`,
+				details:   requestgen.RequestDetails{Url: testPatchURL, Body: `{"username": "john_doe_new_username", "email": "john.doe@example.com"}`},
+			},
+			want: want{
+				details: requestgen.RequestDetails{
+					Url:     testPatchURL,
+					Body:    `[{"op":"add","path":"/email","value":"john.doe@example.com"},{"op":"replace","path":"/username","value":"john_doe_new_username"}]`,
+					Headers: map[string][]string{"Content-Type": {jsonPatchContentType}},
+				},
+			},
+		},
+		"NotObserved": {
+			args: args{
+				details: requestgen.RequestDetails{
+					Url:     testPatchURL,
+					Body:    `{"username": "john_doe"}`,
+					Headers: map[string][]string{"content-type": {"application/json"}},
+				},
+			},
+			want: want{
+				details: requestgen.RequestDetails{
+					Url:     testPatchURL,
+					Body:    `{"username":"john_doe"}`,
+					Headers: map[string][]string{"content-type": {"application/json"}},
+				},
+			},
+		},
+		"BodyNotJSON": {
+			args: args{
+				details: requestgen.RequestDetails{Url: testPatchURL, Body: "john_doe"},
+			},
+			want: want{
+				err: errors.Errorf(errPatchBodyNotJSON, "john_doe"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := httpRequest(func(r *v1alpha1.Request) {
+				r.Status.Response.Body = tc.args.observed
+			})
+			mapping := &v1alpha1.Mapping{Method: "PATCH", PatchType: tc.args.patchType}
+
+			got, err := patchRequestDetails(cr, mapping, tc.args.details)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("patchRequestDetails(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.details, got); diff != "" {
+				t.Errorf("patchRequestDetails(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
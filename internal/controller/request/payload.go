@@ -0,0 +1,75 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	json_util "github.com/arielsepton/provider-http/internal/json"
+)
+
+const (
+	errReadPayloadSource = "cannot read %s of payload"
+	errEncodePayloadBody = "cannot encode body of payload"
+)
+
+// resolvePayload returns the given payload with the values referenced by its baseUrlFrom and bodyFrom read, or
+// nil when it references none.
+func resolvePayload(ctx context.Context, kube client.Client, payload v1alpha1.Payload) (*v1alpha1.Payload, error) {
+	if payload.BaseUrlFrom == nil && payload.BodyFrom == nil {
+		return nil, nil
+	}
+
+	resolved := payload.DeepCopy()
+	if payload.BaseUrlFrom != nil {
+		baseUrl, err := readValue(ctx, kube, payload.BaseUrlFrom)
+		if err != nil {
+			return nil, errors.Wrapf(err, errReadPayloadSource, "baseUrlFrom")
+		}
+		resolved.BaseUrl = strings.TrimSpace(string(baseUrl))
+	}
+
+	if payload.BodyFrom != nil {
+		body, err := readValue(ctx, kube, payload.BodyFrom)
+		if err != nil {
+			return nil, errors.Wrapf(err, errReadPayloadSource, "bodyFrom")
+		}
+
+		if resolved.Body, err = mergeBody(payload.Body, string(body)); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// mergeBody merges the referenced body over the body of the payload when both are JSON objects. Otherwise the
+// referenced body replaces it.
+func mergeBody(body, referenced string) (string, error) {
+	if !json_util.IsJSONString(body) || !json_util.IsJSONString(referenced) {
+		return referenced, nil
+	}
+
+	merged, err := json.Marshal(json_util.Merge(json_util.JsonStringToMap(body), json_util.JsonStringToMap(referenced)))
+	if err != nil {
+		return "", errors.Wrap(err, errEncodePayloadBody)
+	}
+
+	return string(merged), nil
+}
+
+// withPayload returns a copy of the Request with the payload resolved when the request was connected, or the
+// Request itself when its payload references no values.
+func (c *external) withPayload(cr *v1alpha1.Request) *v1alpha1.Request {
+	if c.payload == nil {
+		return cr
+	}
+
+	resolved := cr.DeepCopy()
+	resolved.Spec.ForProvider.Payload = *c.payload
+	return resolved
+}
@@ -0,0 +1,98 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+func Test_resolvePayload(t *testing.T) {
+	source := &v1alpha1.ValueSource{
+		SecretKeyRef: &xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "payload", Namespace: "crossplane-system"},
+			Key:             "value",
+		},
+	}
+	secretValue := func(value string) client.Client {
+		return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"value": []byte(value)}
+			return nil
+		})}
+	}
+
+	type args struct {
+		kube    client.Client
+		payload v1alpha1.Payload
+	}
+	type want struct {
+		payload *v1alpha1.Payload
+		err     bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoReferences": {
+			args: args{
+				kube:    &test.MockClient{},
+				payload: v1alpha1.Payload{BaseUrl: "https://api.example.com/users"},
+			},
+			want: want{},
+		},
+		"BaseUrlFrom": {
+			args: args{
+				kube:    secretValue("https://api.example.com/users\n"),
+				payload: v1alpha1.Payload{BaseUrlFrom: source},
+			},
+			want: want{
+				payload: &v1alpha1.Payload{BaseUrl: "https://api.example.com/users", BaseUrlFrom: source},
+			},
+		},
+		"BodyFromMerged": {
+			args: args{
+				kube:    secretValue(`{"password": "secret"}`),
+				payload: v1alpha1.Payload{Body: `{"username": "john_doe"}`, BodyFrom: source},
+			},
+			want: want{
+				payload: &v1alpha1.Payload{Body: `{"password":"secret","username":"john_doe"}`, BodyFrom: source},
+			},
+		},
+		"BodyFromNotJSON": {
+			args: args{
+				kube:    secretValue("plain text"),
+				payload: v1alpha1.Payload{Body: `{"username": "john_doe"}`, BodyFrom: source},
+			},
+			want: want{
+				payload: &v1alpha1.Payload{Body: "plain text", BodyFrom: source},
+			},
+		},
+		"SecretNotFound": {
+			args: args{
+				kube:    &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				payload: v1alpha1.Payload{BodyFrom: source},
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := resolvePayload(context.Background(), tc.args.kube, tc.args.payload)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("resolvePayload(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got); diff != "" {
+				t.Errorf("resolvePayload(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
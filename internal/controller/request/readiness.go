@@ -0,0 +1,51 @@
+package request
+
+import (
+	"encoding/json"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/cel"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+const (
+	errEvaluateReadiness = "cannot evaluate readiness condition"
+	errNotReady          = "readiness condition is not met: %s"
+)
+
+// readiness returns the Ready condition of the Request given its observed response. The Request is available
+// unless its readiness condition is not met.
+func readiness(cr *v1alpha1.Request, response httpClient.HttpResponse) xpv1.Condition {
+	condition := cr.Spec.ForProvider.Readiness
+	if condition == nil {
+		return xpv1.Available()
+	}
+
+	ready, err := cel.EvaluateCondition(condition.CEL, response.StatusCode, response.Headers, responseBody(response))
+	if err != nil {
+		return xpv1.Unavailable().WithMessage(errors.Wrap(err, errEvaluateReadiness).Error())
+	}
+
+	if !ready {
+		return xpv1.Unavailable().WithMessage(errors.Errorf(errNotReady, condition.CEL).Error())
+	}
+
+	return xpv1.Available()
+}
+
+// responseBody returns the body of the given response decoded when it is JSON or XML, or as it is otherwise.
+func responseBody(response httpClient.HttpResponse) interface{} {
+	if body, ok := structuredBody(response); ok {
+		return body
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(response.Body), &decoded); err != nil {
+		return response.Body
+	}
+
+	return decoded
+}
@@ -0,0 +1,88 @@
+package request
+
+import (
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+func Test_readiness(t *testing.T) {
+	response := httpClient.HttpResponse{
+		StatusCode: 200,
+		Headers:    map[string][]string{"X-State": {"active"}},
+		Body:       `{"id":"123","state":"PROVISIONING"}`,
+	}
+
+	type args struct {
+		readiness *v1alpha1.Readiness
+		response  httpClient.HttpResponse
+	}
+	type want struct {
+		condition xpv1.Condition
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoReadiness": {
+			args: args{
+				response: response,
+			},
+			want: want{
+				condition: xpv1.Available(),
+			},
+		},
+		"Ready": {
+			args: args{
+				readiness: &v1alpha1.Readiness{CEL: `statusCode == 200 && headers["X-State"][0] == "active"`},
+				response:  response,
+			},
+			want: want{
+				condition: xpv1.Available(),
+			},
+		},
+		"NotReady": {
+			args: args{
+				readiness: &v1alpha1.Readiness{CEL: `body.state == "ACTIVE"`},
+				response:  response,
+			},
+			want: want{
+				condition: xpv1.Unavailable().WithMessage(errors.Errorf(errNotReady, `body.state == "ACTIVE"`).Error()),
+			},
+		},
+		"PlainTextBody": {
+			args: args{
+				readiness: &v1alpha1.Readiness{CEL: `body == "ACTIVE"`},
+				response:  httpClient.HttpResponse{StatusCode: 200, Body: "ACTIVE"},
+			},
+			want: want{
+				condition: xpv1.Available(),
+			},
+		},
+		"NotBool": {
+			args: args{
+				readiness: &v1alpha1.Readiness{CEL: `body.state`},
+				response:  response,
+			},
+			want: want{
+				condition: xpv1.Unavailable().WithMessage(errors.Wrap(errors.New(`CEL condition "body.state" should return a bool, got: string`), errEvaluateReadiness).Error()),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{Spec: v1alpha1.RequestSpec{ForProvider: v1alpha1.RequestParameters{Readiness: tc.args.readiness}}}
+			got := readiness(cr, tc.args.response)
+			if diff := cmp.Diff(tc.want.condition, got, test.EquateConditions()); diff != "" {
+				t.Errorf("readiness(...): -want condition, +got condition: %s", diff)
+			}
+		})
+	}
+}
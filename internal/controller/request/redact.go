@@ -0,0 +1,34 @@
+package request
+
+import (
+	"net/http"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/redact"
+)
+
+// newRedactor returns the redactor masking the sensitive values of the Request: those of its redaction, and the
+// sensitive headers of its mappings along with their expressions. It masks the default sensitive headers only
+// when the Request has neither.
+func newRedactor(forProvider *v1alpha1.RequestParameters) *redact.Redactor {
+	var headers, bodyFields, expressions []string
+	if redaction := forProvider.Redact; redaction != nil {
+		headers, bodyFields = append(headers, redaction.Headers...), redaction.BodyFields
+	}
+
+	for _, mapping := range forProvider.Mappings {
+		for _, name := range mapping.SensitiveHeaders {
+			headers = append(headers, name)
+			for header, values := range mapping.Headers {
+				if http.CanonicalHeaderKey(header) == http.CanonicalHeaderKey(name) {
+					expressions = append(expressions, values...)
+				}
+			}
+		}
+	}
+
+	if len(headers) == 0 && len(bodyFields) == 0 {
+		return nil
+	}
+	return redact.New(headers, bodyFields).WithValues(expressions...)
+}
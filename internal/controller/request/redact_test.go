@@ -0,0 +1,44 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/redact"
+)
+
+func Test_newRedactor(t *testing.T) {
+	forProvider := &v1alpha1.RequestParameters{
+		Mappings: []v1alpha1.Mapping{
+			{
+				Method:           "POST",
+				Headers:          map[string][]string{"x-signing-key": {"k3y-1234"}, "Content-Type": {"application/json"}},
+				SensitiveHeaders: []string{"X-Signing-Key"},
+			},
+			{
+				Method:  "GET",
+				Headers: map[string][]string{"X-Signing-Key": {"k3y-1234"}, "Accept": {"application/json"}},
+			},
+		},
+	}
+
+	r := newRedactor(forProvider)
+	got := r.Headers(forProvider.Mappings[1].Headers)
+	want := map[string][]string{"X-Signing-Key": {redact.Mask}, "Accept": {"application/json"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("newRedactor(...).Headers(...): -want, +got: %s", diff)
+	}
+
+	// The expression of a sensitive header is masked in the errors rendering it fails with.
+	err := redact.Error(errors.New("failed to parse given mapping - k3y-1234 jq error"), r.Values(nil, ""))
+	if diff := cmp.Diff("failed to parse given mapping - REDACTED jq error", err.Error()); diff != "" {
+		t.Errorf("newRedactor(...): -want error, +got error: %s", diff)
+	}
+
+	if r := newRedactor(&v1alpha1.RequestParameters{}); r != nil {
+		t.Errorf("newRedactor(...): want no redactor without redaction, got %v", r)
+	}
+}
@@ -0,0 +1,74 @@
+package request
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/bodystore"
+	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
+	"github.com/arielsepton/provider-http/internal/controller/request/responseconverter"
+	"github.com/arielsepton/provider-http/internal/envelope"
+	"github.com/arielsepton/provider-http/internal/jq"
+)
+
+const (
+	errGetReferencedRequest        = "cannot get referenced Request %s"
+	errReferencedRequestNoResponse = "referenced Request %s has no response yet"
+	errReferencedResponseEncrypted = "response of referenced Request %s is encrypted"
+	errLoadReferencedResponse      = "cannot load stored response of referenced Request %s"
+	errResolveReference            = "cannot resolve reference %s"
+)
+
+// resolveReferences reads the values referenced by the given references from the last responses of the
+// referenced Requests, keyed by the names of the references. It returns nil when there are no references.
+func resolveReferences(ctx context.Context, kube client.Client, references []v1alpha1.RequestReference) (map[string]interface{}, error) {
+	if len(references) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]interface{}, len(references))
+	for _, reference := range references {
+		referenced := &v1alpha1.Request{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: reference.RequestName}, referenced); err != nil {
+			return nil, errors.Wrapf(err, errGetReferencedRequest, reference.RequestName)
+		}
+
+		response := referenced.Status.Response
+		if response.StatusCode == 0 {
+			return nil, errors.Errorf(errReferencedRequestNoResponse, reference.RequestName)
+		}
+		if bodystore.IsStored(response.Body) {
+			body, err := bodystore.Load(ctx, kube, response.Body)
+			if err != nil {
+				return nil, errors.Wrapf(err, errLoadReferencedResponse, reference.RequestName)
+			}
+			response.Body = body
+		}
+		if envelope.IsEncrypted(response.Body) {
+			return nil, errors.Errorf(errReferencedResponseEncrypted, reference.RequestName)
+		}
+
+		value, err := jq.Parse(reference.Path, responseconverter.V1alpha1ResponseToMap(response))
+		if err != nil {
+			return nil, errors.Wrapf(err, errResolveReference, reference.Name)
+		}
+		values[reference.Name] = value
+	}
+
+	return values, nil
+}
+
+// values returns the values available to the mappings of the Request besides its parameters and response.
+func (c *external) values(cr *v1alpha1.Request) requestgen.Values {
+	values := c.resolved
+	values.Generated = cr.Status.GeneratedValues
+	values.CreateResponse = cr.Status.CreateResponse
+	values.Location = cr.Status.Location
+	values.ExternalName = meta.GetExternalName(cr)
+	return values
+}
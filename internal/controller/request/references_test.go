@@ -0,0 +1,90 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+func Test_resolveReferences(t *testing.T) {
+	orgID := []v1alpha1.RequestReference{{Name: "org", RequestName: "org", Path: ".body.id"}}
+	referencedResponse := func(response v1alpha1.Response) client.Client {
+		return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*v1alpha1.Request).Status.Response = response
+			return nil
+		})}
+	}
+
+	type args struct {
+		kube       client.Client
+		references []v1alpha1.RequestReference
+	}
+	type want struct {
+		values map[string]interface{}
+		err    bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoReferences": {
+			args: args{
+				kube: &test.MockClient{},
+			},
+			want: want{},
+		},
+		"Resolved": {
+			args: args{
+				kube:       referencedResponse(v1alpha1.Response{StatusCode: 201, Body: `{"id": "org-42", "name": "acme"}`}),
+				references: orgID,
+			},
+			want: want{
+				values: map[string]interface{}{"org": "org-42"},
+			},
+		},
+		"NoResponseYet": {
+			args: args{
+				kube:       referencedResponse(v1alpha1.Response{}),
+				references: orgID,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"EncryptedResponse": {
+			args: args{
+				kube:       referencedResponse(v1alpha1.Response{StatusCode: 201, Body: "enc:v1:c2VjcmV0"}),
+				references: orgID,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"RequestNotFound": {
+			args: args{
+				kube:       &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				references: orgID,
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := resolveReferences(context.Background(), tc.args.kube, tc.args.references)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("resolveReferences(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.values, got); diff != "" {
+				t.Errorf("resolveReferences(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -26,68 +26,100 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
 	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/clients/auth"
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/options"
 	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
 	"github.com/arielsepton/provider-http/internal/controller/request/statushandler"
+	"github.com/arielsepton/provider-http/internal/controller/requeue"
+	"github.com/arielsepton/provider-http/internal/controller/timestamps"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+	"github.com/arielsepton/provider-http/internal/redact"
+	"github.com/arielsepton/provider-http/internal/tracing"
 	"github.com/arielsepton/provider-http/internal/utils"
 )
 
 const (
 	errNotRequest                   = "managed resource is not a Request custom resource"
-	errTrackPCUsage                 = "cannot track ProviderConfig usage"
 	errNewHttpClient                = "cannot create new Http client"
-	errProviderNotRetrieved         = "provider could not be retrieved"
+	errNewMappingHttpClient         = "cannot create new Http client for %s mapping"
+	errAuthenticate                 = "cannot configure request authentication"
+	errAuthenticateMapping          = "cannot configure authentication of %s mapping"
 	errFailedToSendHttpRequest      = "something went wrong"
 	errFailedToCheckIfUpToDate      = "failed to check if request is up to date"
 	errFailedToUpdateStatusFailures = "failed to reset status failures counter"
 	errFailedUpdateStatusConditions = "failed updating status conditions"
 	errMappingNotFound              = "%s mapping doesn't exist in request, skipping operation"
+	errThrottled                    = "remote API is throttling requests until %s"
+	errTerminalFailure              = "request failed with terminal status code %d, it is not sent again until the Request changes"
+	errAnnotateRemoteRequestID      = "cannot annotate remote request ID"
+	errGetResponseKey               = "cannot get response encryption key"
 )
 
 // Setup adds a controller that reconciles Request managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options, timeout time.Duration) error {
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
 	name := managed.ControllerName(v1alpha1.RequestGroupKind)
-	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	cps := options.ConnectionPublishers(mgr.GetClient(), mgr.GetScheme(), o)
 
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.RequestGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
-			logger:          o.Logger,
-			kube:            mgr.GetClient(),
-			usage:           resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newHttpClientFn: httpClient.NewClient,
-		}),
+		managed.WithExternalConnecter(timestamps.NewConnecter(&connector{
+			Clients: opts.Clients(mgr.GetClient()),
+			logger:  o.Logger,
+		})),
+		// The external name is only set to import an existing remote object, rather than to the name of the
+		// resource.
+		managed.WithInitializers(),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithTimeout(timeout),
+		managed.WithTimeout(opts.Timeout),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	schedules := []requeue.ScheduleFn{
+		requeue.PollJitter(o.PollInterval, opts.PollJitter),
+		requeue.PollInterval(o.PollInterval, opts.PollJitter, requeue.ResourcePollInterval(mgr.GetClient())),
+		opts.LoadShedder(name).Schedule,
+		requeueThrottled,
+		requeueOperation,
+	}
+	requeuer := requeue.NewReconciler(r, mgr.GetClient(), newRequest, schedules...)
+	traced := tracing.NewReconciler(name, requeuer)
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		WithOptions(o.ForControllerRuntime()).
+		WithOptions(opts.ForControllerRuntime(o, v1alpha1.RequestKind)).
 		WithEventFilter(resource.DesiredStateChanged()).
-		For(&v1alpha1.Request{}).
-		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+		For(&v1alpha1.Request{})
+
+	if opts.Webhook != nil {
+		b = b.Watches(&source.Channel{Source: opts.Webhook.Events()}, &handler.EnqueueRequestForObject{})
+	}
+
+	return b.Complete(ratelimiter.NewReconciler(name, traced, o.GlobalRateLimiter))
+}
+
+func newRequest() resource.Managed {
+	return &v1alpha1.Request{}
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
-	logger          logging.Logger
-	kube            client.Client
-	usage           resource.Tracker
-	newHttpClientFn func(log logging.Logger, timeout time.Duration) (httpClient.Client, error)
+	options.Clients
+	logger logging.Logger
 }
 
 // Connect typically produces an ExternalClient by:
@@ -103,34 +135,144 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 
 	l := c.logger.WithValues("request", cr.Name)
 
-	if err := c.usage.Track(ctx, mg); err != nil {
-		return nil, errors.Wrap(err, errTrackPCUsage)
+	pc, err := c.ProviderConfig(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+
+	requestAuth := auth.Effective(cr.Spec.ForProvider.Auth, pc.Spec.Auth)
+	authOpts, err := auth.ClientOptions(ctx, c.Kube, c.Tokens, requestAuth)
+	if err != nil {
+		return nil, errors.Wrap(err, errAuthenticate)
+	}
+
+	// The options of the ProviderConfig, the response body limit and the redaction are shared by all the clients of
+	// the Request.
+	sharedOpts, correlationID, err := c.ClientOptions(ctx, pc, cr, v1alpha1.RequestKind)
+	if err != nil {
+		return nil, err
 	}
 
-	pc := &apisv1alpha1.ProviderConfig{}
-	n := types.NamespacedName{Name: cr.GetProviderConfigReference().Name}
-	if err := c.kube.Get(ctx, n, pc); err != nil {
-		return nil, errors.Wrap(err, errProviderNotRetrieved)
+	redactor := newRedactor(&cr.Spec.ForProvider)
+	sharedOpts = append(sharedOpts, httpClient.WithMaxResponseBytes(maxResponseBodyBytes(cr.Spec.ForProvider.MaxResponseBodyBytes, pc.Spec.MaxResponseBodyBytes)))
+	sharedOpts = append(sharedOpts, httpClient.WithRedactor(redactor))
+
+	timeout := utils.RequestTimeout(cr.Spec.ForProvider.WaitTimeout, pc.Spec.Timeouts)
+	opts, err := clientOptions(ctx, c.Kube, cr, pc.Spec.TLS, "")
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHttpClient)
 	}
 
-	h, err := c.newHttpClientFn(l, utils.WaitTimeout(cr.Spec.ForProvider.WaitTimeout))
+	h, err := c.NewHttpClient(l, timeout, append(append(opts, authOpts...), sharedOpts...)...)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewHttpClient)
 	}
 
+	// Mappings with their own CA bundle, client certificate or auth need a dedicated client.
+	mappingHttp := map[string]httpClient.Client{}
+	for _, mapping := range cr.Spec.ForProvider.Mappings {
+		if !hasOwnClient(mapping) {
+			continue
+		}
+
+		action := mapping.GetAction()
+		opts, err := clientOptions(ctx, c.Kube, cr, pc.Spec.TLS, action)
+		if err != nil {
+			return nil, errors.Wrapf(err, errNewMappingHttpClient, action)
+		}
+
+		mappingAuthOpts := authOpts
+		if mapping.Auth != nil {
+			mappingAuthOpts, err = auth.ClientOptions(ctx, c.Kube, c.Tokens, auth.Effective(mapping.Auth, requestAuth))
+			if err != nil {
+				return nil, errors.Wrapf(err, errAuthenticateMapping, action)
+			}
+		}
+
+		mh, err := c.NewHttpClient(l, timeout, append(append(opts, mappingAuthOpts...), sharedOpts...)...)
+		if err != nil {
+			return nil, errors.Wrapf(err, errNewMappingHttpClient, action)
+		}
+		mappingHttp[action] = mh
+	}
+
+	payload, err := resolvePayload(ctx, c.Kube, cr.Spec.ForProvider.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	references, err := resolveReferences(ctx, c.Kube, cr.Spec.ForProvider.References)
+	if err != nil {
+		return nil, err
+	}
+
+	environment, err := resolveEnvironment(ctx, c.Kube, cr.Spec.ForProvider.Environment)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseKey []byte
+	if encryption := cr.Spec.ForProvider.ResponseEncryption; encryption != nil {
+		responseKey, err = kubehandler.GetSecretValue(ctx, c.Kube, encryption.KeySecretRef)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetResponseKey)
+		}
+	}
+
 	return &external{
-		localKube: c.kube,
-		logger:    l,
-		http:      h,
+		localKube:       c.Kube,
+		logger:          l,
+		http:            h,
+		mappingHttp:     mappingHttp,
+		statusCodes:     utils.StatusCodePolicy(cr.Spec.ForProvider.StatusCodes, pc.Spec.StatusCodes),
+		tlsDefaults:     pc.Spec.TLS,
+		responseKey:     responseKey,
+		responseStorage: cr.Spec.ForProvider.ResponseStorage,
+		redactor:        redactor,
+		payload:         payload,
+		resolved:        requestgen.Values{References: references, Environment: environment},
+		correlationID:   correlationID,
 	}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	localKube client.Client
-	logger    logging.Logger
-	http      httpClient.Client
+	localKube   client.Client
+	logger      logging.Logger
+	http        httpClient.Client
+	mappingHttp map[string]httpClient.Client
+	statusCodes *apisv1alpha1.StatusCodePolicy
+
+	// tlsDefaults are the TLS settings of the ProviderConfig, the settings of the request are merged over them.
+	tlsDefaults *apisv1alpha1.TLSConfig
+
+	// responseKey encrypts the response bodies stored in the status when set.
+	responseKey []byte
+
+	// responseStorage stores large response bodies outside of the status when set.
+	responseStorage *v1alpha1.ResponseStorage
+
+	// redactor masks the sensitive values of requests and responses in the status and the logs.
+	redactor *redact.Redactor
+
+	// payload is the payload of the request with its referenced values read, when it references any.
+	payload *v1alpha1.Payload
+
+	// resolved are the values read from the referenced Requests and the environment sources of the request.
+	resolved requestgen.Values
+
+	// correlationID is the value of the correlation header set on the requests, recorded in the status.
+	correlationID string
+}
+
+// httpFor returns the HTTP client used to send the mapping with the given action.
+func (c *external) httpFor(action string) httpClient.Client {
+	if h, ok := c.mappingHttp[action]; ok {
+		return h
+	}
+
+	return c.http
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -139,6 +281,29 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotRequest)
 	}
 
+	if until := throttledUntil(cr); until != nil {
+		return managed.ExternalObservation{}, errors.Errorf(errThrottled, until.UTC().Format(time.RFC3339))
+	}
+
+	// Deleted Requests are observed to send their DELETE request.
+	if terminalFailure(cr) && !meta.WasDeleted(cr) {
+		return managed.ExternalObservation{}, errors.Errorf(errTerminalFailure, cr.Status.Response.StatusCode)
+	}
+
+	if err := c.generateValues(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	if pendingOperation(cr) != nil {
+		pending, err := c.pollOperation(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if pending {
+			return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+		}
+	}
+
 	observeRequestDetails, err := c.isUpToDate(ctx, cr)
 	if err != nil && err.Error() == errObjectNotFound {
 		return managed.ExternalObservation{
@@ -150,12 +315,19 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.Wrap(err, errFailedToCheckIfUpToDate)
 	}
 
+	if observeRequestDetails.Fresh {
+		return managed.ExternalObservation{
+			ResourceExists:   true,
+			ResourceUpToDate: observeRequestDetails.Synced,
+		}, c.assertResponse(cr, observeRequestDetails.Details.HttpResponse)
+	}
+
 	// Get the latest version of the resource before updating
 	if err := c.localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, "failed to get the latest version of the resource")
 	}
 
-	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, observeRequestDetails.Details, observeRequestDetails.ResponseError, c.localKube, c.logger)
+	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, observeRequestDetails.Details, observeRequestDetails.ResponseError, c.localKube, c.logger, append(c.statusHandlerOptions(), statushandler.WithAction(v1alpha1.ActionObserve))...)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	}
@@ -165,39 +337,131 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		statusHandler.ResetFailures()
 	}
 
-	cr.Status.SetConditions(xpv1.Available())
+	cr.Status.Drift = observeRequestDetails.Drift
+	cr.Status.SetConditions(readiness(cr, observeRequestDetails.Details.HttpResponse))
 	err = statusHandler.SetRequestStatus()
 	if err != nil {
 		return managed.ExternalObservation{}, errors.Wrap(err, " failed updating status")
 	}
 
+	published, err := connectionDetails(cr.Spec.ForProvider, observeRequestDetails.Details.HttpResponse)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// The response objects of deleted Requests are not written, e.g. to a terminating namespace, so that they do
+	// not prevent the DELETE request.
+	if !meta.WasDeleted(cr) {
+		if err := writeResponseObjects(ctx, c.localKube, cr.Spec.ForProvider, observeRequestDetails.Details.HttpResponse); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
 	return managed.ExternalObservation{
 		ResourceExists:    true,
 		ResourceUpToDate:  synced,
-		ConnectionDetails: nil,
-	}, nil
+		ConnectionDetails: published,
+	}, c.assertResponse(cr, observeRequestDetails.Details.HttpResponse)
 }
 
-func (c *external) deployAction(ctx context.Context, cr *v1alpha1.Request, method string) error {
-	mapping, ok := getMappingByMethod(&cr.Spec.ForProvider, method)
-	if !ok {
-		c.logger.Info(errMappingNotFound, method)
+// assertResponse checks the assertions of the Request against an observed response. Nothing is checked when no
+// response was observed, e.g. while waiting for an acknowledged creation, nor for deleted Requests, whose DELETE
+// request would never be sent otherwise.
+func (c *external) assertResponse(cr *v1alpha1.Request, response httpClient.HttpResponse) error {
+	if response.StatusCode == 0 || meta.WasDeleted(cr) {
 		return nil
 	}
 
-	requestDetails, err := generateValidRequestDetails(cr, mapping)
+	return checkAssertions(cr.Spec.ForProvider.Assertions, response)
+}
+
+// deployAction sends the request of the mapping with the given action and records its response. It returns the
+// connection details captured from the response.
+func (c *external) deployAction(ctx context.Context, cr *v1alpha1.Request, action string) (managed.ConnectionDetails, error) {
+	if until := throttledUntil(cr); until != nil {
+		return nil, errors.Errorf(errThrottled, until.UTC().Format(time.RFC3339))
+	}
+
+	mapping, ok := getMapping(&cr.Spec.ForProvider, action)
+	if !ok {
+		c.logger.Info(errMappingNotFound, action)
+		return nil, nil
+	}
+
+	statusHandlerOptions := append(c.statusHandlerOptions(), statushandler.WithAction(action))
+
+	plain, err := c.withStoredBodies(ctx, c.withPayload(cr))
+	if err != nil {
+		return nil, err
+	}
+	plain, err = c.decrypted(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	requestDetails, err := c.generateValidRequestDetails(plain, mapping)
 	if err != nil {
-		return err
+		statusHandler, handlerErr := statushandler.NewStatusHandler(ctx, cr, httpClient.HttpDetails{}, utils.NewRenderError(err), c.localKube, c.logger, statusHandlerOptions...)
+		if handlerErr != nil {
+			return nil, handlerErr
+		}
+
+		return nil, statusHandler.SetRequestStatus()
+	}
+
+	if mapping.Method == http.MethodPatch {
+		if requestDetails, err = patchRequestDetails(plain, mapping, requestDetails); err != nil {
+			return nil, err
+		}
+	}
+
+	unsignedURL := requestDetails.Url
+	if err := c.signURL(ctx, mapping, &requestDetails); err != nil {
+		return nil, err
 	}
 
-	details, err := c.http.SendRequest(ctx, mapping.Method, requestDetails.Url, requestDetails.Body, requestDetails.Headers, cr.Spec.ForProvider.InsecureSkipTLSVerify)
+	details, err := c.send(ctx, cr, mapping, requestDetails)
+	details = withUnsignedURL(mapping, details, unsignedURL)
 
-	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, details, err, c.localKube, c.logger)
+	statusHandler, err := statushandler.NewStatusHandler(ctx, cr, details, err, c.localKube, c.logger, statusHandlerOptions...)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := statusHandler.SetRequestStatus(); err != nil {
+		return nil, err
+	}
+
+	if err := c.annotateRemoteRequestID(ctx, cr); err != nil {
+		return nil, err
+	}
+
+	published, err := connectionDetails(cr.Spec.ForProvider, details.HttpResponse)
+	if err != nil {
+		return nil, err
+	}
+
+	if !meta.WasDeleted(cr) {
+		if err := writeResponseObjects(ctx, c.localKube, cr.Spec.ForProvider, details.HttpResponse); err != nil {
+			return nil, err
+		}
+	}
+
+	// The response is recorded even when it does not match the schema of the mapping, so that a created
+	// resource is observed rather than created again.
+	return published, c.validateResponse(mapping, details.HttpResponse)
+}
+
+// annotateRemoteRequestID copies the recorded remote request ID to the annotations of the Request, so that it
+// is visible without inspecting the status.
+func (c *external) annotateRemoteRequestID(ctx context.Context, cr *v1alpha1.Request) error {
+	id := cr.Status.RemoteRequestID
+	if id == "" || cr.GetAnnotations()[v1alpha1.AnnotationKeyRemoteRequestID] == id {
+		return nil
 	}
 
-	return statusHandler.SetRequestStatus()
+	meta.AddAnnotations(cr, map[string]string{v1alpha1.AnnotationKeyRemoteRequestID: id})
+	return errors.Wrap(c.localKube.Update(ctx, cr), errAnnotateRemoteRequestID)
 }
 
 func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -206,7 +470,8 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotRequest)
 	}
 
-	return managed.ExternalCreation{}, errors.Wrap(c.deployAction(ctx, cr, http.MethodPost), errFailedToSendHttpRequest)
+	details, err := c.deployAction(ctx, cr, v1alpha1.ActionCreate)
+	return managed.ExternalCreation{ConnectionDetails: details}, errors.Wrap(err, errFailedToSendHttpRequest)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -215,7 +480,8 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotRequest)
 	}
 
-	return managed.ExternalUpdate{}, errors.Wrap(c.deployAction(ctx, cr, http.MethodPut), errFailedToSendHttpRequest)
+	details, err := c.deployAction(ctx, cr, v1alpha1.ActionUpdate)
+	return managed.ExternalUpdate{ConnectionDetails: details}, errors.Wrap(err, errFailedToSendHttpRequest)
 }
 
 func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
@@ -224,7 +490,8 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 		return errors.New(errNotRequest)
 	}
 
-	return errors.Wrap(c.deployAction(ctx, cr, http.MethodDelete), errFailedToSendHttpRequest)
+	_, err := c.deployAction(ctx, cr, v1alpha1.ActionRemove)
+	return errors.Wrap(err, errFailedToSendHttpRequest)
 }
 
 // generateValidRequestDetails generates valid request details based on the given Request resource and Mapping configuration.
@@ -232,13 +499,13 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 // details are valid, the function returns them. If not, it falls back to using the cached response in the Request's status
 // and attempts to generate request details again. The function returns the generated request details or an error if the
 // generation process fails.
-func generateValidRequestDetails(cr *v1alpha1.Request, mapping *v1alpha1.Mapping) (requestgen.RequestDetails, error) {
-	requestDetails, _, ok := requestgen.GenerateRequestDetails(*mapping, cr.Spec.ForProvider, cr.Status.Response)
+func (c *external) generateValidRequestDetails(cr *v1alpha1.Request, mapping *v1alpha1.Mapping) (requestgen.RequestDetails, error) {
+	requestDetails, _, ok := requestgen.GenerateRequestDetails(*mapping, cr.Spec.ForProvider, cr.Status.Response, c.values(cr))
 	if requestgen.IsRequestValid(requestDetails) && ok {
 		return requestDetails, nil
 	}
 
-	requestDetails, err, _ := requestgen.GenerateRequestDetails(*mapping, cr.Spec.ForProvider, cr.Status.Cache.Response)
+	requestDetails, err, _ := requestgen.GenerateRequestDetails(*mapping, cr.Spec.ForProvider, cr.Status.Cache.Response, c.values(cr))
 	if err != nil {
 		return requestgen.RequestDetails{}, err
 	}
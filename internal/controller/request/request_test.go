@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -101,6 +102,136 @@ func (s *MockStatusHandler) SetRequestStatus(ctx context.Context, cr *v1alpha1.R
 	return s.MockSetRequest()
 }
 
+func Test_httpExternal_Observe(t *testing.T) {
+	assertions := func(r *v1alpha1.Request) {
+		r.Spec.ForProvider.Assertions = []v1alpha1.Assertion{
+			{Expression: `.response.body.state != "error"`, Message: "the resource is in an error state"},
+		}
+		r.Status.Response = v1alpha1.Response{StatusCode: 200, Body: `{"id":"123","state":"error"}`}
+		r.Status.RequestDetails = v1alpha1.Mapping{Method: "POST", Action: v1alpha1.ActionCreate}
+	}
+	deleted := func(r *v1alpha1.Request) {
+		now := v1.Now()
+		r.SetDeletionTimestamp(&now)
+	}
+	responseSecret := func(r *v1alpha1.Request) {
+		r.Spec.ForProvider.ResponseSecrets = []v1alpha1.ResponseSecret{{
+			SecretRef: xpv1.SecretReference{Name: "user", Namespace: "terminating"},
+			Data:      []v1alpha1.ConnectionDetail{{Key: "id", Query: ".response.body.id"}},
+		}}
+		r.Status.Response = v1alpha1.Response{StatusCode: 200, Body: `{"id":"123","state":"error"}`}
+		r.Status.RequestDetails = v1alpha1.Mapping{Method: "POST", Action: v1alpha1.ActionCreate}
+	}
+	errorState := &MockHttpClient{
+		MockSendRequest: func(_ context.Context, method string, url string, body string, headers map[string][]string, _ bool) (resp httpClient.HttpDetails, err error) {
+			return httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"id":"123","state":"error"}`},
+				HttpRequest:  httpClient.HttpRequest{Method: method, URL: url, Body: body, Headers: headers},
+			}, nil
+		},
+	}
+
+	type args struct {
+		http      httpClient.Client
+		localKube client.Client
+		mg        resource.Managed
+	}
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotRequestResource": {
+			args: args{
+				mg: notHttpRequest{},
+			},
+			want: want{
+				err: errors.New(errNotRequest),
+			},
+		},
+		"AssertionFailed": {
+			args: args{
+				http: errorState,
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(assertions),
+			},
+			want: want{
+				err: errors.Errorf(errAssertionFailed, "the resource is in an error state"),
+			},
+		},
+		"DeletedAssertionFailed": {
+			args: args{
+				http: errorState,
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet:          test.NewMockGetFn(nil),
+				},
+				mg: httpRequest(assertions, deleted),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ResponseSecretNotWritten": {
+			args: args{
+				http: errorState,
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						if _, ok := obj.(*corev1.Secret); ok {
+							return errBoom
+						}
+						return nil
+					},
+				},
+				mg: httpRequest(responseSecret),
+			},
+			want: want{
+				err: errors.Wrapf(errors.Wrapf(errBoom, "cannot get secret %s/%s", "terminating", "user"), errWriteResponseSecret, "terminating", "user"),
+			},
+		},
+		"DeletedResponseSecretNotWritten": {
+			args: args{
+				http: errorState,
+				localKube: &test.MockClient{
+					MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+					MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+						if _, ok := obj.(*corev1.Secret); ok {
+							return errBoom
+						}
+						return nil
+					},
+				},
+				mg: httpRequest(responseSecret, deleted),
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc // Create local copies of loop variables
+
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: tc.args.localKube,
+				logger:    logging.NewNopLogger(),
+				http:      tc.args.http,
+			}
+			_, gotErr := e.Observe(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("e.Observe(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_httpExternal_Create(t *testing.T) {
 	type args struct {
 		http      httpClient.Client
@@ -325,3 +456,78 @@ func Test_httpExternal_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_annotateRemoteRequestID(t *testing.T) {
+	type args struct {
+		localKube client.Client
+		mg        *v1alpha1.Request
+	}
+	type want struct {
+		annotations map[string]string
+		err         error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoRemoteRequestID": {
+			args: args{
+				localKube: &test.MockClient{},
+				mg:        httpRequest(),
+			},
+			want: want{},
+		},
+		"AlreadyAnnotated": {
+			args: args{
+				localKube: &test.MockClient{},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.RemoteRequestID = "req-123"
+					r.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyRemoteRequestID: "req-123"})
+				}),
+			},
+			want: want{
+				annotations: map[string]string{v1alpha1.AnnotationKeyRemoteRequestID: "req-123"},
+			},
+		},
+		"UpdateFailed": {
+			args: args{
+				localKube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom)},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.RemoteRequestID = "req-123"
+				}),
+			},
+			want: want{
+				annotations: map[string]string{v1alpha1.AnnotationKeyRemoteRequestID: "req-123"},
+				err:         errors.Wrap(errBoom, errAnnotateRemoteRequestID),
+			},
+		},
+		"Annotated": {
+			args: args{
+				localKube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.RemoteRequestID = "req-456"
+					r.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyRemoteRequestID: "req-123"})
+				}),
+			},
+			want: want{
+				annotations: map[string]string{v1alpha1.AnnotationKeyRemoteRequestID: "req-456"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: tc.args.localKube,
+				logger:    logging.NewNopLogger(),
+			}
+			gotErr := e.annotateRemoteRequestID(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("annotateRemoteRequestID(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, tc.args.mg.GetAnnotations()); diff != "" {
+				t.Errorf("annotateRemoteRequestID(...): -want annotations, +got annotations: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package requestgen
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/internal/cel"
+)
+
+const (
+	errCELNotString  = "CEL expression %q should return a string, got: %s"
+	errEncodeCELBody = "cannot encode result of CEL expression %q"
+)
+
+var celRenderer = renderer{
+	url:     evaluateCELString,
+	body:    evaluateCELBody,
+	headers: evaluateCELHeaders,
+}
+
+// celVariables returns the variables of CEL expressions from the request object.
+func celVariables(jqObject map[string]interface{}) map[string]interface{} {
+	externalName, _ := jqObject[cel.VariableExternalName].(string)
+
+	return map[string]interface{}{
+		cel.VariablePayload:        jqObject[cel.VariablePayload],
+		cel.VariableResponse:       jqObject[cel.VariableResponse],
+		cel.VariableGenerated:      objectOrEmpty(jqObject[cel.VariableGenerated]),
+		cel.VariableReferences:     objectOrEmpty(jqObject[cel.VariableReferences]),
+		cel.VariableEnvironment:    objectOrEmpty(jqObject[cel.VariableEnvironment]),
+		cel.VariableCreateResponse: objectOrEmpty(jqObject[cel.VariableCreateResponse]),
+		cel.VariableExternalName:   externalName,
+	}
+}
+
+// objectOrEmpty returns the given value when it is an object, or an empty object otherwise.
+func objectOrEmpty(value interface{}) map[string]interface{} {
+	if object, ok := value.(map[string]interface{}); ok {
+		return object
+	}
+	return map[string]interface{}{}
+}
+
+// evaluateCELString evaluates a CEL expression returning a string.
+func evaluateCELString(expression string, jqObject map[string]interface{}) (string, error) {
+	result, err := cel.Evaluate(expression, celVariables(jqObject))
+	if err != nil {
+		return "", err
+	}
+
+	str, ok := result.(string)
+	if !ok {
+		return "", errors.Errorf(errCELNotString, expression, fmt.Sprint(result))
+	}
+
+	return str, nil
+}
+
+// evaluateCELBody evaluates a CEL expression to generate the request body. A string result is the body as it is,
+// other results are encoded as JSON without their null fields, so that fields can be set conditionally.
+func evaluateCELBody(expression string, jqObject map[string]interface{}) (string, error) {
+	if expression == "" {
+		return "", nil
+	}
+
+	result, err := cel.Evaluate(expression, celVariables(jqObject))
+	if err != nil {
+		return "", err
+	}
+
+	if str, ok := result.(string); ok {
+		return str, nil
+	}
+
+	body, err := json.Marshal(withoutNulls(result))
+	if err != nil {
+		return "", errors.Wrapf(err, errEncodeCELBody, expression)
+	}
+
+	return string(body), nil
+}
+
+// evaluateCELHeaders evaluates the values of the given headers as CEL expressions returning strings. Like jq
+// queries, values that cannot be evaluated are kept as they are, e.g. the literal default headers of the request.
+func evaluateCELHeaders(headers map[string][]string, jqObject map[string]interface{}) (map[string][]string, error) {
+	evaluated := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		evaluatedValues := make([]string, len(values))
+		for i, value := range values {
+			result, err := cel.Evaluate(value, celVariables(jqObject))
+			if err != nil {
+				evaluatedValues[i] = value
+				continue
+			}
+
+			str, ok := result.(string)
+			if !ok {
+				return nil, errors.Errorf(errCELNotString, value, fmt.Sprint(result))
+			}
+			evaluatedValues[i] = str
+		}
+		evaluated[key] = evaluatedValues
+	}
+
+	return evaluated, nil
+}
+
+// withoutNulls removes the fields with null values from the objects of the given value.
+func withoutNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			if field != nil {
+				result[key] = withoutNulls(field)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = withoutNulls(item)
+		}
+		return result
+	default:
+		return value
+	}
+}
@@ -0,0 +1,55 @@
+package requestgen
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+var duplicateSlashes = regexp.MustCompile(`/{2,}`)
+
+// normalizeURL applies the given URL normalization settings to a generated URL.
+// Only the path is affected by the path related settings, the query string and fragment are preserved as is.
+func normalizeURL(rawURL string, normalization *v1alpha1.URLNormalization) (string, error) {
+	if normalization == nil {
+		return rawURL, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+
+	path := u.EscapedPath()
+	if normalization.CollapseSlashes {
+		path = duplicateSlashes.ReplaceAllString(path, "/")
+	}
+
+	if normalization.LowercasePath {
+		path = strings.ToLower(path)
+	}
+
+	switch normalization.TrailingSlash {
+	case v1alpha1.TrailingSlashAdd:
+		if !strings.HasSuffix(path, "/") {
+			path += "/"
+		}
+	case v1alpha1.TrailingSlashRemove:
+		path = strings.TrimRight(path, "/")
+	}
+
+	unescapedPath, err := url.PathUnescape(path)
+	if err != nil {
+		return "", err
+	}
+
+	u.Path = unescapedPath
+	u.RawPath = path
+
+	return u.String(), nil
+}
@@ -0,0 +1,99 @@
+package requestgen
+
+import (
+	"testing"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_normalizeURL(t *testing.T) {
+	type args struct {
+		url           string
+		normalization *v1alpha1.URLNormalization
+	}
+	type want struct {
+		url string
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NilNormalization": {
+			args: args{
+				url:           "https://API.example.com//users/",
+				normalization: nil,
+			},
+			want: want{
+				url: "https://API.example.com//users/",
+			},
+		},
+		"LowercaseSchemeAndHost": {
+			args: args{
+				url:           "HTTPS://API.Example.com/Users",
+				normalization: &v1alpha1.URLNormalization{},
+			},
+			want: want{
+				url: "https://api.example.com/Users",
+			},
+		},
+		"CollapseSlashes": {
+			args: args{
+				url: "https://api.example.com//users///123?filter=a//b",
+				normalization: &v1alpha1.URLNormalization{
+					CollapseSlashes: true,
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/123?filter=a//b",
+			},
+		},
+		"AddTrailingSlash": {
+			args: args{
+				url: "https://api.example.com/users?page=1",
+				normalization: &v1alpha1.URLNormalization{
+					TrailingSlash: v1alpha1.TrailingSlashAdd,
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/?page=1",
+			},
+		},
+		"RemoveTrailingSlash": {
+			args: args{
+				url: "https://api.example.com/users//",
+				normalization: &v1alpha1.URLNormalization{
+					TrailingSlash: v1alpha1.TrailingSlashRemove,
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users",
+			},
+		},
+		"LowercasePathKeepsEscaping": {
+			args: args{
+				url: "https://api.example.com/Users/John%20Doe",
+				normalization: &v1alpha1.URLNormalization{
+					LowercasePath: true,
+					TrailingSlash: v1alpha1.TrailingSlashKeep,
+				},
+			},
+			want: want{
+				url: "https://api.example.com/users/john%20doe",
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := normalizeURL(tc.args.url, tc.args.normalization)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("normalizeURL(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Errorf("normalizeURL(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
@@ -2,28 +2,76 @@ package requestgen
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
 	"github.com/arielsepton/provider-http/internal/controller/request/requestprocessing"
+	"github.com/arielsepton/provider-http/internal/jq"
 	json_util "github.com/arielsepton/provider-http/internal/json"
 	"github.com/arielsepton/provider-http/internal/utils"
 
 	"golang.org/x/exp/maps"
+	"sigs.k8s.io/yaml"
 )
 
+const (
+	errMissingURL  = "mapping has no url, and no location of the resource was recorded"
+	errConvertBody = "cannot convert body of mapping from YAML"
+	errRenderParts = "cannot render multipart parts of mapping"
+)
+
+// contentTypes are the content types of the body encodings.
+var contentTypes = map[string]string{
+	v1alpha1.BodyEncodingJSON: "application/json",
+	v1alpha1.BodyEncodingYAML: "application/yaml",
+	v1alpha1.BodyEncodingForm: "application/x-www-form-urlencoded",
+}
+
 type RequestDetails struct {
 	Url     string
 	Body    string
 	Headers map[string][]string
+
+	// PartValues are the rendered values of the multipart parts of the mapping, by part index. File parts have no
+	// value.
+	PartValues []string
+}
+
+// Values are the values available to mappings besides the request parameters and the response.
+type Values struct {
+	// Generated are the generated values of the request, available as .generated.
+	Generated map[string]string
+
+	// References are the values read from the responses of referenced requests, available as .references.
+	References map[string]interface{}
+
+	// Environment is the data of the environment sources of the request, available as .environment.
+	Environment map[string]interface{}
+
+	// CreateResponse is the response of the last successful CREATE request, available as .createResponse.
+	CreateResponse *v1alpha1.Response
+	// Location is the recorded URL of the resource, used as the URL of mappings that omit theirs.
+	Location string
+
+	// ExternalName is the external name of the request, identifying the remote object it imports, available as
+	// .externalName.
+	ExternalName string
 }
 
 // GenerateRequestDetails generates request details.
-func GenerateRequestDetails(methodMapping v1alpha1.Mapping, forProvider v1alpha1.RequestParameters, response v1alpha1.Response) (RequestDetails, error, bool) {
-	jqObject := generateRequestObject(forProvider, response)
-	url, err := generateURL(methodMapping.URL, jqObject)
+func GenerateRequestDetails(methodMapping v1alpha1.Mapping, forProvider v1alpha1.RequestParameters, response v1alpha1.Response, values Values) (RequestDetails, error, bool) {
+	jqObject := generateRequestObject(forProvider, response, values)
+	render := rendererFor(methodMapping)
+	url, err := mappingURL(render, methodMapping.URL, values.Location, jqObject)
+	if err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	url, err = normalizeURL(url, forProvider.URLNormalization)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
@@ -32,26 +80,62 @@ func GenerateRequestDetails(methodMapping v1alpha1.Mapping, forProvider v1alpha1
 		return RequestDetails{}, errors.Errorf(utils.ErrInvalidURL, url), false
 	}
 
-	body, err := generateBody(methodMapping.Body, jqObject)
+	generateMappingBody, mappingBody := render.body, methodMapping.Body
+	if methodMapping.BodyJQ != "" {
+		generateMappingBody, mappingBody = generateBodyJQ, methodMapping.BodyJQ
+	}
+
+	body, err := generateMappingBody(mappingBody, jqObject)
+	if err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	headers, err := render.headers(coalesceHeaders(methodMapping.Headers, forProvider.Headers), jqObject)
+	if err != nil {
+		return RequestDetails{}, err, false
+	}
+
+	partValues, err := renderPartValues(render, methodMapping.Multipart, jqObject)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
-	headers, err := generateHeaders(coalesceHeaders(methodMapping.Headers, forProvider.Headers), jqObject)
+	headers, body, err = applyBodyEncoding(headers, body, methodMapping.BodyEncoding)
 	if err != nil {
 		return RequestDetails{}, err, false
 	}
 
-	return RequestDetails{Body: body, Url: url, Headers: headers}, nil, true
+	headers = applyContentNegotiation(headers, methodMapping.ContentNegotiation, forProvider.ContentNegotiation)
+	headers = applyCompression(headers, body, methodMapping.GzipBody, forProvider.Compression)
+
+	return RequestDetails{Body: body, Url: url, Headers: headers, PartValues: partValues}, nil, true
 }
 
-// generateRequestObject creates a JSON-compatible map from the specified Request's ForProvider and Response fields.
-// It merges the two maps, converts JSON strings to nested maps, and returns the resulting map.
-func generateRequestObject(forProvider v1alpha1.RequestParameters, response v1alpha1.Response) map[string]interface{} {
+// generateRequestObject creates a JSON-compatible map from the specified Request's ForProvider and Response fields,
+// and the generated, referenced and environment values, the create response and the external name when there are
+// any. It merges the maps, converts JSON strings to nested
+// maps, and returns the resulting map.
+func generateRequestObject(forProvider v1alpha1.RequestParameters, response v1alpha1.Response, values Values) map[string]interface{} {
 	baseMap, _ := json_util.StructToMap(forProvider)
-	statusMap, _ := json_util.StructToMap(map[string]interface{}{
+	status := map[string]interface{}{
 		"response": response,
-	})
+	}
+	if len(values.Generated) > 0 {
+		status["generated"] = values.Generated
+	}
+	if len(values.References) > 0 {
+		status["references"] = values.References
+	}
+	if len(values.Environment) > 0 {
+		status["environment"] = values.Environment
+	}
+	if values.CreateResponse != nil {
+		status["createResponse"] = values.CreateResponse
+	}
+	if values.ExternalName != "" {
+		status["externalName"] = values.ExternalName
+	}
+	statusMap, _ := json_util.StructToMap(status)
 
 	maps.Copy(baseMap, statusMap)
 	json_util.ConvertJSONStringsToMaps(&baseMap)
@@ -59,6 +143,19 @@ func generateRequestObject(forProvider v1alpha1.RequestParameters, response v1al
 	return baseMap
 }
 
+// mappingURL renders the URL of a mapping, or returns the recorded location when the mapping omits its URL.
+func mappingURL(render renderer, url, location string, jqObject map[string]interface{}) (string, error) {
+	if url != "" {
+		return render.url(url, jqObject)
+	}
+
+	if location == "" {
+		return "", errors.New(errMissingURL)
+	}
+
+	return location, nil
+}
+
 func IsRequestValid(requestDetails RequestDetails) bool {
 	return (!strings.Contains(fmt.Sprint(requestDetails), "null")) && (requestDetails.Url != "")
 }
@@ -71,6 +168,114 @@ func coalesceHeaders(mappingHeaders, defaultHeaders map[string][]string) map[str
 	return defaultHeaders
 }
 
+// applyContentNegotiation sets the Accept and Accept-Language headers, preferring the mapping values over the
+// default ones. Headers that were set explicitly are left untouched.
+func applyContentNegotiation(headers map[string][]string, mappingNegotiation, defaultNegotiation *v1alpha1.ContentNegotiation) map[string][]string {
+	accept, acceptLanguage := "", ""
+	for _, negotiation := range []*v1alpha1.ContentNegotiation{defaultNegotiation, mappingNegotiation} {
+		if negotiation == nil {
+			continue
+		}
+		if negotiation.Accept != "" {
+			accept = negotiation.Accept
+		}
+		if negotiation.AcceptLanguage != "" {
+			acceptLanguage = negotiation.AcceptLanguage
+		}
+	}
+
+	if headers == nil && (accept != "" || acceptLanguage != "") {
+		headers = map[string][]string{}
+	}
+
+	setHeaderIfMissing(headers, "Accept", accept)
+	setHeaderIfMissing(headers, "Accept-Language", acceptLanguage)
+
+	return headers
+}
+
+// renderPartValues renders the values of the multipart field parts of a mapping like its header values, and
+// returns them by part index.
+func renderPartValues(render renderer, parts []v1alpha1.MultipartPart, jqObject map[string]interface{}) ([]string, error) {
+	if len(parts) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string][]string, len(parts))
+	for i, part := range parts {
+		if part.ValueFrom == nil {
+			values[strconv.Itoa(i)] = []string{part.Value}
+		}
+	}
+
+	rendered, err := render.headers(values, jqObject)
+	if err != nil {
+		return nil, errors.Wrap(err, errRenderParts)
+	}
+
+	partValues := make([]string, len(parts))
+	for i := range parts {
+		if value := rendered[strconv.Itoa(i)]; len(value) > 0 {
+			partValues[i] = value[0]
+		}
+	}
+
+	return partValues, nil
+}
+
+// applyBodyEncoding converts the given body, written as YAML or JSON, to JSON when the mapping has a body
+// encoding, and sets the content type of the encoding unless a Content-Type header was set explicitly. Bodies sent
+// as YAML or as forms are only encoded when they are sent, so that they are compared with the observed state as
+// JSON.
+func applyBodyEncoding(headers map[string][]string, body string, encoding string) (map[string][]string, string, error) {
+	contentType, ok := contentTypes[encoding]
+	if !ok || body == "" {
+		return headers, body, nil
+	}
+
+	converted, err := yaml.YAMLToJSON([]byte(body))
+	if err != nil {
+		return nil, "", errors.Wrap(err, errConvertBody)
+	}
+
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+	setHeaderIfMissing(headers, "Content-Type", contentType)
+
+	return headers, string(converted), nil
+}
+
+// applyCompression asks for the body to be gzip compressed when the mapping compresses its body, whatever its
+// kind, or when it reaches the configured size, unless a Content-Encoding header was set explicitly.
+func applyCompression(headers map[string][]string, body string, gzipBody bool, compression *v1alpha1.Compression) map[string][]string {
+	if !gzipBody && (compression == nil || body == "" || len(body) < compression.MinBodySize) {
+		return headers
+	}
+
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+
+	setHeaderIfMissing(headers, "Content-Encoding", httpClient.EncodingGzip)
+	return headers
+}
+
+// setHeaderIfMissing sets the given header unless it already exists, header names are compared case-insensitively.
+func setHeaderIfMissing(headers map[string][]string, key, value string) {
+	if value == "" {
+		return
+	}
+
+	for existingKey := range headers {
+		if strings.EqualFold(existingKey, key) {
+			return
+		}
+	}
+
+	headers[key] = []string{value}
+}
+
 // generateURL applies a JQ filter to generate a URL.
 func generateURL(urlJQFilter string, jqObject map[string]interface{}) (string, error) {
 	getURL, err := requestprocessing.ApplyJQOnStr(urlJQFilter, jqObject)
@@ -96,6 +301,12 @@ func generateBody(mappingBody string, jqObject map[string]interface{}) (string,
 	return body, nil
 }
 
+// generateBodyJQ evaluates a jq program to generate the request body. A string result is the body as it is,
+// other results are encoded as JSON.
+func generateBodyJQ(program string, jqObject map[string]interface{}) (string, error) {
+	return jq.ParseText(program, jqObject)
+}
+
 // generateHeaders applies JQ queries to generate headers.
 func generateHeaders(headers map[string][]string, jqObject map[string]interface{}) (map[string][]string, error) {
 	generatedHeaders, err := requestprocessing.ApplyJQOnMapStrings(headers, jqObject)
@@ -7,6 +7,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 )
 
 var testHeaders = map[string][]string{
@@ -66,6 +67,7 @@ func Test_GenerateRequestDetails(t *testing.T) {
 		methodMapping v1alpha1.Mapping
 		forProvider   v1alpha1.RequestParameters
 		response      v1alpha1.Response
+		values        Values
 		logger        logging.Logger
 	}
 	type want struct {
@@ -155,10 +157,256 @@ func Test_GenerateRequestDetails(t *testing.T) {
 				ok:  true,
 			},
 		},
+		"SuccessGenerated": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:  "POST",
+					Body:    "{ username: .payload.body.username, idempotencyKey: .generated.key }",
+					URL:     ".payload.baseUrl",
+					Headers: map[string][]string{"Idempotency-Key": {".generated.key"}},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				values:      Values{Generated: map[string]string{"key": "0b5b4b5e-53a4-4e8f-9b0e-1c6a2f0e7b2d"}},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users",
+					Body:    `{"idempotencyKey":"0b5b4b5e-53a4-4e8f-9b0e-1c6a2f0e7b2d","username":"john_doe"}`,
+					Headers: map[string][]string{"Idempotency-Key": {"0b5b4b5e-53a4-4e8f-9b0e-1c6a2f0e7b2d"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessReferences": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "POST",
+					Body:   "{ username: .payload.body.username, organization: .references.org }",
+					URL:    `(.payload.baseUrl + "?org=" + .references.org)`,
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				values:      Values{References: map[string]interface{}{"org": "org-42"}},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users?org=org-42",
+					Body:    `{"organization":"org-42","username":"john_doe"}`,
+					Headers: map[string][]string{},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessCreateResponse": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "DELETE",
+					URL:    `(.payload.baseUrl + "/" + .createResponse.body.id)`,
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{StatusCode: 204},
+				values:      Values{CreateResponse: &v1alpha1.Response{StatusCode: 201, Body: `{"id": "123"}`}},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Headers: map[string][]string{},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessLocation": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "DELETE",
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{StatusCode: 201},
+				values:      Values{Location: "https://api.example.com/users/123"},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Headers: map[string][]string{},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"MissingURL": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "DELETE",
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{StatusCode: 201},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				err: errors.New(errMissingURL),
+				ok:  false,
+			},
+		},
+		"SuccessBodyJQ": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "POST",
+					BodyJQ: `# one entry per field of the payload body
+def entry: {name: .key, value: .value};
+.payload.body | to_entries | map(entry)`,
+					URL: ".payload.baseUrl",
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users",
+					Body:    `[{"name":"email","value":"john.doe@example.com"},{"name":"username","value":"john_doe"}]`,
+					Headers: map[string][]string{},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessYAMLBodyEncoding": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:         "POST",
+					TemplateEngine: v1alpha1.TemplateEngineGoTemplate,
+					BodyEncoding:   v1alpha1.BodyEncodingYAML,
+					Body:           "username: {{ .payload.body.username }}\nemail: {{ .payload.body.email }}\n",
+					URL:            "{{ .payload.baseUrl }}",
+					Headers:        map[string][]string{"Accept": {"application/yaml"}},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users",
+					Body:    `{"email":"john.doe@example.com","username":"john_doe"}`,
+					Headers: map[string][]string{"Accept": {"application/yaml"}, "Content-Type": {"application/yaml"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessFormBodyEncoding": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:       "POST",
+					BodyEncoding: v1alpha1.BodyEncodingForm,
+					Body:         `{grant_type: "password", username: .payload.body.username}`,
+					URL:          ".payload.baseUrl",
+					Headers:      map[string][]string{},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users",
+					Body:    `{"grant_type":"password","username":"john_doe"}`,
+					Headers: map[string][]string{"Content-Type": {"application/x-www-form-urlencoded"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessMultipart": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method: "POST",
+					URL:    ".payload.baseUrl",
+					Multipart: []v1alpha1.MultipartPart{
+						{Name: "username", Value: ".payload.body.username"},
+						{Name: "certificate", ValueFrom: &v1alpha1.ValueSource{}},
+						{Name: "kind", Value: "user"},
+					},
+					Headers: map[string][]string{},
+				},
+				forProvider: testForProvider,
+				response:    v1alpha1.Response{},
+				logger:      logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:        "https://api.example.com/users",
+					Headers:    map[string][]string{},
+					PartValues: []string{"john_doe", "", "user"},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessCEL": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:         "PUT",
+					TemplateEngine: v1alpha1.TemplateEngineCEL,
+					Body:           `{"username": payload.body.username, "nickname": has(payload.body.nickname) ? payload.body.nickname : null}`,
+					URL:            `payload.baseUrl + "/" + response.body.id`,
+					Headers:        map[string][]string{"X-User": {"payload.body.email"}, "Accept": {"application/json"}},
+				},
+				forProvider: testForProvider,
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Body:    `{"username":"john_doe"}`,
+					Headers: map[string][]string{"X-User": {"john.doe@example.com"}, "Accept": {"application/json"}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
+		"SuccessGoTemplate": {
+			args: args{
+				methodMapping: v1alpha1.Mapping{
+					Method:         "PUT",
+					TemplateEngine: v1alpha1.TemplateEngineGoTemplate,
+					Body:           `{"username": {{ .payload.body.username | upper | quote }}}`,
+					URL:            `{{ .payload.baseUrl }}/{{ .response.body.id }}`,
+					Headers:        map[string][]string{"X-User": {"{{ .payload.body.email | b64enc }}"}},
+				},
+				forProvider: testForProvider,
+				response: v1alpha1.Response{
+					StatusCode: 200,
+					Body:       `{"id":"123","username":"john_doe"}`,
+				},
+				logger: logging.NewNopLogger(),
+			},
+			want: want{
+				requestDetails: RequestDetails{
+					Url:     "https://api.example.com/users/123",
+					Body:    `{"username": "JOHN_DOE"}`,
+					Headers: map[string][]string{"X-User": {"am9obi5kb2VAZXhhbXBsZS5jb20="}},
+				},
+				err: nil,
+				ok:  true,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got, gotErr, ok := GenerateRequestDetails(tc.args.methodMapping, tc.args.forProvider, tc.args.response)
+			got, gotErr, ok := GenerateRequestDetails(tc.args.methodMapping, tc.args.forProvider, tc.args.response, tc.args.values)
 			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
 				t.Fatalf("GenerateRequestDetails(...): -want error, +got error: %s", diff)
 			}
@@ -376,10 +624,169 @@ func Test_generateRequestObject(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := generateRequestObject(tc.args.forProvider, tc.args.response)
+			got := generateRequestObject(tc.args.forProvider, tc.args.response, Values{})
 			if diff := cmp.Diff(tc.want.result, got); diff != "" {
 				t.Fatalf("generateRequestObject(...): -want result, +got result: %s", diff)
 			}
 		})
 	}
 }
+
+func Test_applyContentNegotiation(t *testing.T) {
+	type args struct {
+		headers            map[string][]string
+		mappingNegotiation *v1alpha1.ContentNegotiation
+		defaultNegotiation *v1alpha1.ContentNegotiation
+	}
+	type want struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoNegotiation": {
+			args: args{
+				headers: nil,
+			},
+			want: want{
+				headers: nil,
+			},
+		},
+		"DefaultNegotiation": {
+			args: args{
+				headers: map[string][]string{},
+				defaultNegotiation: &v1alpha1.ContentNegotiation{
+					Accept:         "application/json",
+					AcceptLanguage: "en-US",
+				},
+			},
+			want: want{
+				headers: map[string][]string{
+					"Accept":          {"application/json"},
+					"Accept-Language": {"en-US"},
+				},
+			},
+		},
+		"MappingOverridesDefault": {
+			args: args{
+				headers: map[string][]string{},
+				mappingNegotiation: &v1alpha1.ContentNegotiation{
+					Accept: "application/xml",
+				},
+				defaultNegotiation: &v1alpha1.ContentNegotiation{
+					Accept:         "application/json",
+					AcceptLanguage: "en-US",
+				},
+			},
+			want: want{
+				headers: map[string][]string{
+					"Accept":          {"application/xml"},
+					"Accept-Language": {"en-US"},
+				},
+			},
+		},
+		"ExplicitHeaderWins": {
+			args: args{
+				headers: map[string][]string{
+					"accept": {"text/plain"},
+				},
+				defaultNegotiation: &v1alpha1.ContentNegotiation{
+					Accept: "application/json",
+				},
+			},
+			want: want{
+				headers: map[string][]string{
+					"accept": {"text/plain"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := applyContentNegotiation(tc.args.headers, tc.args.mappingNegotiation, tc.args.defaultNegotiation)
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Fatalf("applyContentNegotiation(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_applyCompression(t *testing.T) {
+	type args struct {
+		headers     map[string][]string
+		body        string
+		gzipBody    bool
+		compression *v1alpha1.Compression
+	}
+	type want struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoCompression": {
+			args: args{
+				body: `{"username":"john_doe"}`,
+			},
+			want: want{
+				headers: nil,
+			},
+		},
+		"BelowThreshold": {
+			args: args{
+				body:        `{"username":"john_doe"}`,
+				compression: &v1alpha1.Compression{MinBodySize: 1024},
+			},
+			want: want{
+				headers: nil,
+			},
+		},
+		"AboveThreshold": {
+			args: args{
+				body:        `{"username":"john_doe"}`,
+				compression: &v1alpha1.Compression{MinBodySize: 10},
+			},
+			want: want{
+				headers: map[string][]string{
+					"Content-Encoding": {"gzip"},
+				},
+			},
+		},
+		"MappingGzipBody": {
+			args: args{
+				gzipBody: true,
+			},
+			want: want{
+				headers: map[string][]string{
+					"Content-Encoding": {"gzip"},
+				},
+			},
+		},
+		"ExplicitEncodingKept": {
+			args: args{
+				headers: map[string][]string{
+					"content-encoding": {"identity"},
+				},
+				body:        `{"username":"john_doe"}`,
+				compression: &v1alpha1.Compression{MinBodySize: 0},
+			},
+			want: want{
+				headers: map[string][]string{
+					"content-encoding": {"identity"},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := applyCompression(tc.args.headers, tc.args.body, tc.args.gzipBody, tc.args.compression)
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Errorf("applyCompression(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package requestgen
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+const (
+	errParseTemplate  = "cannot parse template %q"
+	errRenderTemplate = "cannot render template %q"
+)
+
+// A renderer renders the URL, body and headers of a mapping from the request object.
+type renderer struct {
+	url     func(string, map[string]interface{}) (string, error)
+	body    func(string, map[string]interface{}) (string, error)
+	headers func(map[string][]string, map[string]interface{}) (map[string][]string, error)
+}
+
+var (
+	jqRenderer = renderer{
+		url:     generateURL,
+		body:    generateBody,
+		headers: generateHeaders,
+	}
+
+	goTemplateRenderer = renderer{
+		url: func(text string, data map[string]interface{}) (string, error) {
+			url, err := renderTemplate(text, data)
+			return strings.TrimSpace(url), err
+		},
+		body:    renderTemplate,
+		headers: renderHeaderTemplates,
+	}
+)
+
+// rendererFor returns the renderer of the template engine of the given mapping.
+func rendererFor(mapping v1alpha1.Mapping) renderer {
+	switch mapping.TemplateEngine {
+	case v1alpha1.TemplateEngineGoTemplate:
+		return goTemplateRenderer
+	case v1alpha1.TemplateEngineCEL:
+		return celRenderer
+	default:
+		return jqRenderer
+	}
+}
+
+// renderTemplate renders the given Go template with the sprig function library. Referencing a missing key is
+// an error.
+func renderTemplate(text string, data map[string]interface{}) (string, error) {
+	if text == "" {
+		return "", nil
+	}
+
+	tmpl, err := template.New("mapping").Option("missingkey=error").Funcs(sprig.TxtFuncMap()).Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, errParseTemplate, text)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", errors.Wrapf(err, errRenderTemplate, text)
+	}
+
+	return rendered.String(), nil
+}
+
+// renderHeaderTemplates renders the values of the given headers as Go templates.
+func renderHeaderTemplates(headers map[string][]string, data map[string]interface{}) (map[string][]string, error) {
+	rendered := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		renderedValues := make([]string, len(values))
+		for i, value := range values {
+			renderedValue, err := renderTemplate(value, data)
+			if err != nil {
+				return nil, err
+			}
+			renderedValues[i] = renderedValue
+		}
+		rendered[key] = renderedValues
+	}
+
+	return rendered, nil
+}
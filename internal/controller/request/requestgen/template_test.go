@@ -0,0 +1,59 @@
+package requestgen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_renderTemplate(t *testing.T) {
+	data := map[string]interface{}{
+		"payload": map[string]interface{}{
+			"body": map[string]interface{}{"tags": []interface{}{"a", "b"}, "admin": true},
+		},
+	}
+
+	type want struct {
+		rendered string
+		err      bool
+	}
+	cases := map[string]struct {
+		text string
+		want want
+	}{
+		"Empty": {
+			text: "",
+			want: want{},
+		},
+		"LoopAndConditional": {
+			text: `{"tags": [{{ range $i, $tag := .payload.body.tags }}{{ if $i }},{{ end }}{{ $tag | quote }}{{ end }}], "role": {{ if .payload.body.admin }}"admin"{{ else }}"user"{{ end }}}`,
+			want: want{
+				rendered: `{"tags": ["a","b"], "role": "admin"}`,
+			},
+		},
+		"MissingKey": {
+			text: `{{ .payload.missing }}`,
+			want: want{
+				err: true,
+			},
+		},
+		"InvalidTemplate": {
+			text: `{{ .payload.body`,
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := renderTemplate(tc.text, data)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("renderTemplate(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.rendered, got); diff != "" {
+				t.Errorf("renderTemplate(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package request
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/request/responseconverter"
+	"github.com/arielsepton/provider-http/internal/jq"
+	json_util "github.com/arielsepton/provider-http/internal/json"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	errMissingCheckLogic  = "expected response check of type CUSTOM has no logic"
+	errEvaluateCheckLogic = "cannot evaluate logic of expected response check"
+
+	errMissingCheckPattern = "expected response check of type REGEX has no pattern"
+	errCompileCheckPattern = "cannot compile pattern of expected response check"
+)
+
+// checkResponse checks whether the observed response is up to date with the desired state, with the logic or the
+// pattern of the expected response check of the Request when it is custom or a regular expression.
+func (c *external) checkResponse(cr *v1alpha1.Request, details httpClient.HttpDetails, err error, desiredState string) (ObserveRequestDetails, error) {
+	check := cr.Spec.ForProvider.ExpectedResponseCheck
+	if check == nil || check.Type == v1alpha1.ExpectedResponseCheckTypeDefault || check.Type == "" {
+		return c.compareResponseAndDesiredState(cr, details, err, desiredState)
+	}
+
+	if check.Type == v1alpha1.ExpectedResponseCheckTypeRegex {
+		return c.matchResponse(check, details, err)
+	}
+
+	if check.Logic == "" {
+		return FailedObserve(), errors.New(errMissingCheckLogic)
+	}
+
+	synced, checkErr := jq.ParseBool(check.Logic, responseCheckObject(cr.Spec.ForProvider.Payload, details.HttpResponse, desiredState))
+	if checkErr != nil {
+		return FailedObserve(), errors.Wrap(checkErr, errEvaluateCheckLogic)
+	}
+
+	return NewObserve(details, err, synced && utils.IsSuccess(c.statusCodes, details.HttpResponse.StatusCode)), nil
+}
+
+// matchResponse checks whether the observed response body matches the pattern of the given expected response
+// check.
+func (c *external) matchResponse(check *v1alpha1.ExpectedResponseCheck, details httpClient.HttpDetails, err error) (ObserveRequestDetails, error) {
+	if check.Pattern == "" {
+		return FailedObserve(), errors.New(errMissingCheckPattern)
+	}
+
+	pattern, compileErr := regexp.Compile(check.Pattern)
+	if compileErr != nil {
+		return FailedObserve(), errors.Wrap(compileErr, errCompileCheckPattern)
+	}
+
+	return NewObserve(details, err, pattern.MatchString(details.HttpResponse.Body) && utils.IsSuccess(c.statusCodes, details.HttpResponse.StatusCode)), nil
+}
+
+// responseCheckObject returns the object the logic of an expected response check is evaluated against, with the
+// JSON bodies decoded.
+func responseCheckObject(payload v1alpha1.Payload, response httpClient.HttpResponse, desiredState string) map[string]interface{} {
+	payloadMap, _ := json_util.StructToMap(payload)
+	object := map[string]interface{}{
+		"payload":      payloadMap,
+		"response":     responseconverter.V1alpha1ResponseToMap(responseconverter.HttpResponseToV1alpha1Response(response)),
+		"desiredState": desiredState,
+	}
+	json_util.ConvertJSONStringsToMaps(&object)
+
+	return object
+}
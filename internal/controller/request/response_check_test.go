@@ -0,0 +1,259 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+func Test_checkResponse(t *testing.T) {
+	observed := func(statusCode int) httpClient.HttpDetails {
+		return httpClient.HttpDetails{
+			HttpResponse: httpClient.HttpResponse{
+				StatusCode: statusCode,
+				Body:       `{"name":"john_doe","updatedAt":"2024-01-01T00:00:00Z","tags":["b","a"]}`,
+			},
+		}
+	}
+	custom := func(logic string) *v1alpha1.ExpectedResponseCheck {
+		return &v1alpha1.ExpectedResponseCheck{Type: v1alpha1.ExpectedResponseCheckTypeCustom, Logic: logic}
+	}
+
+	regex := func(pattern string) *v1alpha1.ExpectedResponseCheck {
+		return &v1alpha1.ExpectedResponseCheck{Type: v1alpha1.ExpectedResponseCheckTypeRegex, Pattern: pattern}
+	}
+	plainText := func(statusCode int) httpClient.HttpDetails {
+		return httpClient.HttpDetails{
+			HttpResponse: httpClient.HttpResponse{StatusCode: statusCode, Body: "name=john_doe\nenabled=false\n"},
+		}
+	}
+
+	type args struct {
+		check        *v1alpha1.ExpectedResponseCheck
+		strategy     string
+		ignoreFields []string
+		normalize    *v1alpha1.Normalization
+		details      httpClient.HttpDetails
+		desiredState string
+	}
+	type want struct {
+		synced bool
+		err    bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultCheck": {
+			args: args{
+				details:      observed(200),
+				desiredState: `{"name":"john_doe","tags":["a","b"]}`,
+			},
+			want: want{
+				synced: false,
+			},
+		},
+		"SubsetStrategy": {
+			args: args{
+				strategy:     v1alpha1.CompareStrategySubset,
+				details:      observed(200),
+				desiredState: `{"name":"john_doe"}`,
+			},
+			want: want{
+				synced: true,
+			},
+		},
+		"SubsetWithWarningsStrategy": {
+			args: args{
+				strategy:     v1alpha1.CompareStrategySubsetWithWarnings,
+				details:      observed(200),
+				desiredState: `{"name":"john_doe"}`,
+			},
+			want: want{
+				synced: true,
+			},
+		},
+		"ExactStrategyExtraFields": {
+			args: args{
+				strategy:     v1alpha1.CompareStrategyExact,
+				details:      observed(200),
+				desiredState: `{"name":"john_doe"}`,
+			},
+			want: want{
+				synced: false,
+			},
+		},
+		"ExactStrategySynced": {
+			args: args{
+				strategy:     v1alpha1.CompareStrategyExact,
+				details:      observed(200),
+				desiredState: `{"name":"john_doe","updatedAt":"2024-01-01T00:00:00Z","tags":["b","a"]}`,
+			},
+			want: want{
+				synced: true,
+			},
+		},
+		"IgnoreFields": {
+			args: args{
+				strategy:     v1alpha1.CompareStrategyExact,
+				ignoreFields: []string{"/updatedAt", ".tags"},
+				details:      observed(200),
+				desiredState: `{"name":"john_doe","updatedAt":"2023-01-01T00:00:00Z"}`,
+			},
+			want: want{
+				synced: true,
+			},
+		},
+		"InvalidIgnoredField": {
+			args: args{
+				ignoreFields: []string{".tags |"},
+				details:      observed(200),
+				desiredState: `{"name":"john_doe"}`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"NotNormalized": {
+			args: args{
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"enabled":"true","count":"1"}`},
+				},
+				desiredState: `{"enabled":true,"count":1.0,"owner":null}`,
+			},
+			want: want{
+				synced: false,
+			},
+		},
+		"Normalized": {
+			args: args{
+				normalize: &v1alpha1.Normalization{BooleanStrings: true, NumericStrings: true, NullAsAbsent: true},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 200, Body: `{"enabled":"true","count":"1"}`},
+				},
+				desiredState: `{"enabled":true,"count":1.0,"owner":null}`,
+			},
+			want: want{
+				synced: true,
+			},
+		},
+		"CustomCheckSynced": {
+			args: args{
+				check:        custom(`.response.body.name == .desiredState.name and (.response.body.tags | sort) == (.desiredState.tags | sort)`),
+				details:      observed(200),
+				desiredState: `{"name":"john_doe","tags":["a","b"]}`,
+			},
+			want: want{
+				synced: true,
+			},
+		},
+		"CustomCheckNotSynced": {
+			args: args{
+				check:        custom(`.response.body.name == .desiredState.name`),
+				details:      observed(200),
+				desiredState: `{"name":"jane_doe"}`,
+			},
+			want: want{
+				synced: false,
+			},
+		},
+		"CustomCheckFailedResponse": {
+			args: args{
+				check:        custom(`true`),
+				details:      observed(500),
+				desiredState: `{"name":"john_doe"}`,
+			},
+			want: want{
+				synced: false,
+			},
+		},
+		"RegexCheckSynced": {
+			args: args{
+				check:   regex(`(?m)^enabled=false$`),
+				details: plainText(200),
+			},
+			want: want{
+				synced: true,
+			},
+		},
+		"RegexCheckNotSynced": {
+			args: args{
+				check:   regex(`(?m)^enabled=true$`),
+				details: plainText(200),
+			},
+			want: want{
+				synced: false,
+			},
+		},
+		"RegexCheckFailedResponse": {
+			args: args{
+				check:   regex(`enabled=false`),
+				details: plainText(500),
+			},
+			want: want{
+				synced: false,
+			},
+		},
+		"MissingPattern": {
+			args: args{
+				check:   regex(""),
+				details: plainText(200),
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"InvalidPattern": {
+			args: args{
+				check:   regex(`enabled=(true`),
+				details: plainText(200),
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"MissingLogic": {
+			args: args{
+				check:        custom(""),
+				details:      observed(200),
+				desiredState: `{"name":"john_doe"}`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"LogicNotBoolean": {
+			args: args{
+				check:        custom(`.response.body.name`),
+				details:      observed(200),
+				desiredState: `{"name":"john_doe"}`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger()}
+			cr := &v1alpha1.Request{}
+			cr.Spec.ForProvider.ExpectedResponseCheck = tc.args.check
+			cr.Spec.ForProvider.CompareStrategy = tc.args.strategy
+			cr.Spec.ForProvider.IgnoreFields = tc.args.ignoreFields
+			cr.Spec.ForProvider.Normalization = tc.args.normalize
+
+			got, err := e.checkResponse(cr, tc.args.details, nil, tc.args.desiredState)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("checkResponse(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.synced, got.Synced); diff != "" {
+				t.Errorf("checkResponse(...): -want synced, +got synced: %s", diff)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package responseconverter
 import (
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	json_util "github.com/arielsepton/provider-http/internal/json"
 )
 
 // Convert HttpResponse to Response
@@ -13,3 +14,20 @@ func HttpResponseToV1alpha1Response(httpResponse httpClient.HttpResponse) v1alph
 		Headers:    httpResponse.Headers,
 	}
 }
+
+// V1alpha1ResponseToMap converts a Response to a JSON compatible map holding its statusCode, headers and body, with
+// the body decoded when it is a JSON object, e.g. to evaluate jq queries against it.
+func V1alpha1ResponseToMap(response v1alpha1.Response) map[string]interface{} {
+	responseMap, _ := json_util.StructToMap(response)
+	json_util.ConvertJSONStringsToMaps(&responseMap)
+	return responseMap
+}
+
+// Convert Response to HttpResponse
+func V1alpha1ResponseToHttpResponse(response v1alpha1.Response) httpClient.HttpResponse {
+	return httpClient.HttpResponse{
+		StatusCode: response.StatusCode,
+		Body:       response.Body,
+		Headers:    response.Headers,
+	}
+}
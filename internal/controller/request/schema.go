@@ -0,0 +1,24 @@
+package request
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/jsonschema"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	errInvalidResponse = "response to the %s mapping does not match its schema"
+)
+
+// validateResponse checks the body of a successful response against the response schema of the mapping it
+// answers.
+func (c *external) validateResponse(mapping *v1alpha1.Mapping, response httpClient.HttpResponse) error {
+	if mapping.ResponseSchema == "" || !utils.IsSuccess(c.statusCodes, response.StatusCode) {
+		return nil
+	}
+
+	return errors.Wrapf(jsonschema.Validate(mapping.ResponseSchema, response.Body), errInvalidResponse, mapping.Method)
+}
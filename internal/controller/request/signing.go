@@ -0,0 +1,58 @@
+package request
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
+	"github.com/arielsepton/provider-http/internal/kubehandler"
+	"github.com/arielsepton/provider-http/internal/signedurl"
+)
+
+const (
+	errSignURL = "cannot sign URL of %s mapping"
+)
+
+// signURL presigns the URL of the given request details when the mapping asks for it.
+func (c *external) signURL(ctx context.Context, mapping *v1alpha1.Mapping, requestDetails *requestgen.RequestDetails) error {
+	signing := mapping.SignedURL
+	if signing == nil {
+		return nil
+	}
+
+	key, err := kubehandler.GetSecretValue(ctx, c.localKube, signing.KeySecretRef)
+	if err != nil {
+		return errors.Wrapf(err, errSignURL, mapping.Method)
+	}
+
+	opts := signedurl.Options{
+		Algorithm:      signing.Algorithm,
+		ExpiresParam:   signing.ExpiresParam,
+		SignatureParam: signing.SignatureParam,
+	}
+	if signing.Expiry != nil {
+		opts.Expiry = signing.Expiry.Duration
+	}
+
+	signed, err := signedurl.Sign(mapping.Method, requestDetails.Url, key, opts, time.Now())
+	if err != nil {
+		return errors.Wrapf(err, errSignURL, mapping.Method)
+	}
+
+	requestDetails.Url = signed
+	return nil
+}
+
+// withUnsignedURL records the URL a request was presigned from instead of the presigned URL, whose signature and
+// expiry must not be written to the status.
+func withUnsignedURL(mapping *v1alpha1.Mapping, details httpClient.HttpDetails, unsignedURL string) httpClient.HttpDetails {
+	if mapping.SignedURL != nil && details.HttpRequest.URL != "" {
+		details.HttpRequest.URL = unsignedURL
+	}
+
+	return details
+}
@@ -0,0 +1,142 @@
+package request
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
+)
+
+func Test_signURL(t *testing.T) {
+	signing := &v1alpha1.URLSigning{
+		KeySecretRef: xpv1.SecretKeySelector{
+			SecretReference: xpv1.SecretReference{Name: "signing-key", Namespace: "crossplane-system"},
+			Key:             "key",
+		},
+	}
+
+	type args struct {
+		localKube client.Client
+		mapping   *v1alpha1.Mapping
+	}
+	type want struct {
+		signed bool
+		err    bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotSigned": {
+			args: args{
+				localKube: &test.MockClient{},
+				mapping:   &testGetMapping,
+			},
+			want: want{},
+		},
+		"SecretNotFound": {
+			args: args{
+				localKube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				mapping:   &v1alpha1.Mapping{Method: "GET", SignedURL: signing},
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"Signed": {
+			args: args{
+				localKube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"key": []byte("secret")}
+					return nil
+				})},
+				mapping: &v1alpha1.Mapping{Method: "GET", SignedURL: signing},
+			},
+			want: want{
+				signed: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := &external{
+				localKube: tc.args.localKube,
+				logger:    logging.NewNopLogger(),
+			}
+			requestDetails := requestgen.RequestDetails{Url: "https://api.example.com/files/1"}
+			err := e.signURL(context.Background(), tc.args.mapping, &requestDetails)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("signURL(...): -want error, +got error: %s", diff)
+			}
+
+			u, _ := url.Parse(requestDetails.Url)
+			signed := u.Query().Get("signature") != "" && u.Query().Get("expires") != ""
+			if diff := cmp.Diff(tc.want.signed, signed); diff != "" {
+				t.Errorf("signURL(...): -want signed, +got signed: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_withUnsignedURL(t *testing.T) {
+	unsignedURL := "https://api.example.com/files/1"
+	signedURL := unsignedURL + "?expires=1700000000&signature=abc"
+
+	type args struct {
+		mapping *v1alpha1.Mapping
+		details httpClient.HttpDetails
+	}
+	type want struct {
+		url string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotSigned": {
+			args: args{
+				mapping: &testGetMapping,
+				details: httpClient.HttpDetails{HttpRequest: httpClient.HttpRequest{URL: unsignedURL}},
+			},
+			want: want{
+				url: unsignedURL,
+			},
+		},
+		"Signed": {
+			args: args{
+				mapping: &v1alpha1.Mapping{Method: "GET", SignedURL: &v1alpha1.URLSigning{}},
+				details: httpClient.HttpDetails{HttpRequest: httpClient.HttpRequest{URL: signedURL}},
+			},
+			want: want{
+				url: unsignedURL,
+			},
+		},
+		"NotSent": {
+			args: args{
+				mapping: &v1alpha1.Mapping{Method: "GET", SignedURL: &v1alpha1.URLSigning{}},
+			},
+			want: want{
+				url: "",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := withUnsignedURL(tc.args.mapping, tc.args.details, unsignedURL)
+			if diff := cmp.Diff(tc.want.url, got.HttpRequest.URL); diff != "" {
+				t.Errorf("withUnsignedURL(...): -want URL, +got URL: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package statushandler
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+// maxDebugBodyBytes is the maximum length of the response body recorded in the debug round trip.
+const maxDebugBodyBytes = 4096
+
+// debugging returns whether the debug annotation of the Request is set.
+func (r *requestStatusHandler) debugging() bool {
+	return r.resource.Resource.GetAnnotations()[v1alpha1.AnnotationKeyDebug] == "true"
+}
+
+// debug returns the round trip of the request, with the given error when it failed, or nil when the Request is not
+// being debugged. It has to be called before the request and the response are redacted and protected, it masks
+// their sensitive values itself.
+func (r *requestStatusHandler) debug(err error) *v1alpha1.Debug {
+	if !r.debugging() {
+		return nil
+	}
+
+	request := r.resource.HttpRequest
+	debug := &v1alpha1.Debug{
+		Action: r.requestAction(),
+		Request: v1alpha1.Mapping{
+			Method:  request.Method,
+			URL:     request.URL,
+			Headers: r.redactor.Headers(request.Headers),
+			Body:    r.redactor.Body(request.Body),
+		},
+		Time: metav1.Now(),
+	}
+	if request.Method == "" {
+		debug.Action = r.action
+	}
+
+	if err != nil {
+		debug.Error = err.Error()
+		return debug
+	}
+
+	response := r.resource.HttpResponse
+	debug.Response = &v1alpha1.Response{
+		StatusCode: response.StatusCode,
+		Headers:    r.redactor.Headers(response.Headers),
+	}
+	if r.encryptionKey == nil {
+		debug.Response.Body = r.redactor.Body(response.Body)
+		if len(debug.Response.Body) > maxDebugBodyBytes {
+			debug.Response.Body = debug.Response.Body[:maxDebugBodyBytes]
+			debug.Truncated = true
+		}
+	}
+
+	return debug
+}
+
+// setDebug records the round trip of the request with the given error, or clears it when the Request is not being
+// debugged.
+func (r *requestStatusHandler) setDebug(err error) utils.SetRequestStatusFunc {
+	debug := r.debug(err)
+	return func() {
+		if cr, ok := r.resource.Resource.(*v1alpha1.Request); ok {
+			cr.SetDebug(debug)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package statushandler
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/redact"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+func Test_debug(t *testing.T) {
+	debugged := map[string]string{v1alpha1.AnnotationKeyDebug: "true"}
+	request := httpClient.HttpRequest{
+		Method:  http.MethodPost,
+		URL:     "http://api.example.com/users",
+		Headers: map[string][]string{"Authorization": {"Bearer token"}},
+		Body:    `{"name":"john"}`,
+	}
+	redactedRequest := v1alpha1.Mapping{
+		Method:  http.MethodPost,
+		URL:     "http://api.example.com/users",
+		Headers: map[string][]string{"Authorization": {redact.Mask}},
+		Body:    `{"name":"john"}`,
+	}
+	response := httpClient.HttpResponse{
+		StatusCode: http.StatusCreated,
+		Headers:    map[string][]string{"Set-Cookie": {"session=secret"}, "X-Trace": {"abc"}},
+		Body:       `{"id":"1"}`,
+	}
+	redactedHeaders := map[string][]string{"Set-Cookie": {redact.Mask}, "X-Trace": {"abc"}}
+
+	cases := map[string]struct {
+		annotations   map[string]string
+		response      httpClient.HttpResponse
+		encryptionKey []byte
+		err           error
+		want          *v1alpha1.Debug
+	}{
+		"NotDebugged": {
+			response: response,
+			want:     nil,
+		},
+		"RoundTrip": {
+			annotations: debugged,
+			response:    response,
+			want: &v1alpha1.Debug{
+				Action:   v1alpha1.ActionCreate,
+				Request:  redactedRequest,
+				Response: &v1alpha1.Response{StatusCode: http.StatusCreated, Headers: redactedHeaders, Body: `{"id":"1"}`},
+			},
+		},
+		"TruncatedBody": {
+			annotations: debugged,
+			response:    httpClient.HttpResponse{StatusCode: http.StatusCreated, Body: strings.Repeat("a", maxDebugBodyBytes+1)},
+			want: &v1alpha1.Debug{
+				Action:    v1alpha1.ActionCreate,
+				Request:   redactedRequest,
+				Response:  &v1alpha1.Response{StatusCode: http.StatusCreated, Body: strings.Repeat("a", maxDebugBodyBytes)},
+				Truncated: true,
+			},
+		},
+		"EncryptedBody": {
+			annotations:   debugged,
+			response:      response,
+			encryptionKey: []byte("key"),
+			want: &v1alpha1.Debug{
+				Action:   v1alpha1.ActionCreate,
+				Request:  redactedRequest,
+				Response: &v1alpha1.Response{StatusCode: http.StatusCreated, Headers: redactedHeaders},
+			},
+		},
+		"Error": {
+			annotations: debugged,
+			err:         errBoom,
+			want: &v1alpha1.Debug{
+				Action:  v1alpha1.ActionCreate,
+				Request: redactedRequest,
+				Error:   errBoom.Error(),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			r := &requestStatusHandler{
+				resource: &utils.RequestResource{
+					Resource:     &v1alpha1.Request{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}},
+					HttpRequest:  request,
+					HttpResponse: tc.response,
+				},
+				encryptionKey: tc.encryptionKey,
+			}
+
+			got := r.debug(tc.err)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(v1alpha1.Debug{}, "Time")); diff != "" {
+				t.Errorf("debug(...): -want round trip, +got round trip: %s", diff)
+			}
+		})
+	}
+}
@@ -6,16 +6,41 @@ import (
 	"strconv"
 
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/bodystore"
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
 	"github.com/arielsepton/provider-http/internal/controller/request/requestgen"
 	"github.com/arielsepton/provider-http/internal/controller/request/responseconverter"
+	"github.com/arielsepton/provider-http/internal/envelope"
+	"github.com/arielsepton/provider-http/internal/jq"
+	"github.com/arielsepton/provider-http/internal/redact"
 	"github.com/arielsepton/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	errEncryptResponseBody = "cannot encrypt response body"
+	errStoreResponseBody   = "cannot store response body"
+	errLoadResponseBody    = "cannot load stored response body"
+	errRemoveResponseBody  = "cannot remove response body no longer referenced by the status"
+	errOperationURL        = "cannot read URL of asynchronous operation"
+
+	defaultOperationURLHeader = "Location"
+)
+
+// sensitiveHeaders are the response headers that are only recorded when they are selected explicitly.
+var sensitiveHeaders = map[string]bool{
+	"Set-Cookie":          true,
+	"Set-Cookie2":         true,
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+}
+
 // RequestStatusHandler is the interface to interact with status setting for v1alpha1.Request
 type RequestStatusHandler interface {
 	SetRequestStatus() error
@@ -30,6 +55,88 @@ type requestStatusHandler struct {
 	resource      *utils.RequestResource
 	responseError error
 	forProvider   v1alpha1.RequestParameters
+	statusCodes   *apisv1alpha1.StatusCodePolicy
+
+	// encryptionKey encrypts the response body before it is stored when set.
+	encryptionKey []byte
+
+	// storage stores large response bodies outside of the status when set.
+	storage *v1alpha1.ResponseStorage
+
+	// redactor masks the sensitive values of the request and the response recorded in the status, the default
+	// sensitive headers when unset.
+	redactor *redact.Redactor
+
+	// action is the action of the mapping of the request, implied by its method when unset.
+	action string
+
+	// values are the values available to the mappings besides the generated values of the request.
+	values requestgen.Values
+
+	// correlationID is the value of the correlation header set on the request.
+	correlationID string
+}
+
+// A StatusHandlerOption configures a RequestStatusHandler.
+type StatusHandlerOption func(*requestStatusHandler)
+
+// WithStatusCodePolicy sets the policy used to decide whether a response is successful or failed.
+func WithStatusCodePolicy(policy *apisv1alpha1.StatusCodePolicy) StatusHandlerOption {
+	return func(r *requestStatusHandler) {
+		r.statusCodes = policy
+	}
+}
+
+// WithAction records the given action as the action of the mapping of the request.
+func WithAction(action string) StatusHandlerOption {
+	return func(r *requestStatusHandler) {
+		r.action = action
+	}
+}
+
+// WithPayload replaces the payload of the request with the given one, whose referenced values were read.
+func WithPayload(payload v1alpha1.Payload) StatusHandlerOption {
+	return func(r *requestStatusHandler) {
+		r.forProvider.Payload = payload
+	}
+}
+
+// WithValues makes the given values, read from referenced Requests and environment sources, available to the
+// mappings. The generated values are taken from the status of the request.
+func WithValues(values requestgen.Values) StatusHandlerOption {
+	return func(r *requestStatusHandler) {
+		r.values = values
+	}
+}
+
+// WithResponseEncryption encrypts the response body stored in the status with the given key encryption key.
+func WithResponseEncryption(key []byte) StatusHandlerOption {
+	return func(r *requestStatusHandler) {
+		r.encryptionKey = key
+	}
+}
+
+// WithResponseStorage stores the response bodies of at least the minimum size of the given storage in its
+// ConfigMap or Secret, instead of the status.
+func WithResponseStorage(storage *v1alpha1.ResponseStorage) StatusHandlerOption {
+	return func(r *requestStatusHandler) {
+		r.storage = storage
+	}
+}
+
+// WithRedactor masks the sensitive values the given redactor selects in the request, the response and the error
+// recorded in the status.
+func WithRedactor(redactor *redact.Redactor) StatusHandlerOption {
+	return func(r *requestStatusHandler) {
+		r.redactor = redactor
+	}
+}
+
+// WithCorrelationID records the given value of the correlation header set on the request.
+func WithCorrelationID(id string) StatusHandlerOption {
+	return func(r *requestStatusHandler) {
+		r.correlationID = id
+	}
 }
 
 // SetRequestStatus updates the current Request's status to reflect the details of the last HTTP request that occurred.
@@ -38,7 +145,7 @@ type requestStatusHandler struct {
 // based on the outcome of the HTTP request and the presence of an error.
 func (r *requestStatusHandler) SetRequestStatus() error {
 	if r.responseError != nil {
-		return r.setErrorAndReturn(r.responseError)
+		return r.setErrorAndReturn(redact.Error(r.responseError, r.sensitiveValues()))
 	}
 
 	basicSetters := []utils.SetRequestStatusFunc{
@@ -46,48 +153,323 @@ func (r *requestStatusHandler) SetRequestStatus() error {
 		r.resource.SetHeaders(),
 		r.resource.SetBody(),
 		r.resource.SetRequestDetails(),
+		r.setAction(),
+		r.resource.SetThrottledUntil(),
+		r.resource.SetLocation(),
+		r.setLastFetched(),
+		r.resource.SetRemoteRequestID(),
+		r.resource.SetCorrelationID(r.correlationID),
+		r.setDebug(nil),
+		r.resource.SetTimings(),
+	}
+
+	if action := r.requestAction(); action == v1alpha1.ActionCreate || action == v1alpha1.ActionUpdate {
+		basicSetters = append(basicSetters, r.resource.SetTerminal(r.statusCodes))
 	}
 
 	basicSetters = append(basicSetters, *r.extraSetters...)
+	stored := r.storedBodies()
 
-	if utils.IsHTTPError(r.resource.HttpResponse.StatusCode) {
-		return r.incrementFailuresAndReturn(basicSetters)
+	r.redactRequest()
+	if utils.IsFailure(r.statusCodes, r.resource.HttpResponse.StatusCode) {
+		if err := r.protectBody(); err != nil {
+			return err
+		}
+		return r.incrementFailuresAndReturn(append(basicSetters, r.selectHeaders()), stored)
 	}
 
-	if utils.IsHTTPSuccess(r.resource.HttpResponse.StatusCode) {
+	if utils.IsSuccess(r.statusCodes, r.resource.HttpResponse.StatusCode) {
 		r.appendExtraSetters(r.forProvider, &basicSetters)
 	}
+	basicSetters = append(basicSetters, r.selectHeaders())
+
+	if err := r.protectBody(); err != nil {
+		return err
+	}
 
 	if settingError := utils.SetRequestResourceStatus(*r.resource, basicSetters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
+	r.removeUnreferencedBodies(stored)
+	return nil
+}
+
+// sensitiveValues returns the sensitive values of the request and the response, to mask them in error messages.
+func (r *requestStatusHandler) sensitiveValues() []string {
+	return append(r.redactor.Values(r.resource.HttpRequest.Headers, r.resource.HttpRequest.Body),
+		r.redactor.Values(r.resource.HttpResponse.Headers, r.resource.HttpResponse.Body)...)
+}
+
+// redactRequest masks the sensitive headers and body fields of the request recorded in the status.
+func (r *requestStatusHandler) redactRequest() {
+	r.resource.HttpRequest.Headers = r.redactor.Headers(r.resource.HttpRequest.Headers)
+	r.resource.HttpRequest.Body = r.redactor.Body(r.resource.HttpRequest.Body)
+}
+
+// selectHeaders keeps the selected response headers in the responses recorded in the status, with the values of the
+// sensitive ones masked. It has to be the last setter, the response headers of the request are used as they are by
+// the other setters, e.g. to record the location of the resource.
+func (r *requestStatusHandler) selectHeaders() utils.SetRequestStatusFunc {
+	return func() {
+		cr, ok := r.resource.Resource.(*v1alpha1.Request)
+		if !ok {
+			return
+		}
+
+		selection := r.forProvider.ResponseHeaders
+		cr.Status.Response.Headers = r.redactor.Headers(recordedHeaders(cr.Status.Response.Headers, selection))
+		cr.Status.Cache.Response.Headers = r.redactor.Headers(recordedHeaders(cr.Status.Cache.Response.Headers, selection))
+		if cr.Status.CreateResponse != nil {
+			cr.Status.CreateResponse.Headers = r.redactor.Headers(recordedHeaders(cr.Status.CreateResponse.Headers, selection))
+		}
+	}
+}
+
+// recordedHeaders returns the given response headers selected by the given selection, or all of them but the
+// sensitive ones without selection.
+func recordedHeaders(headers map[string][]string, selection *v1alpha1.ResponseHeaders) map[string][]string {
+	if headers == nil {
+		return nil
+	}
+
+	var included map[string]bool
+	if selection != nil {
+		included = make(map[string]bool, len(selection.Include))
+		for _, name := range selection.Include {
+			included[http.CanonicalHeaderKey(name)] = true
+		}
+	}
+
+	recorded := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		canonical := http.CanonicalHeaderKey(name)
+		if (included != nil && !included[canonical]) || (included == nil && sensitiveHeaders[canonical]) {
+			continue
+		}
+		recorded[name] = values
+	}
+
+	return recorded
+}
+
+// requestAction returns the action of the mapping of the request.
+func (r *requestStatusHandler) requestAction() string {
+	if r.action != "" {
+		return r.action
+	}
+
+	mapping := v1alpha1.Mapping{Method: r.resource.HttpRequest.Method}
+	return mapping.GetAction()
+}
+
+// setAction records the action of the mapping of the request along with its details.
+func (r *requestStatusHandler) setAction() utils.SetRequestStatusFunc {
+	return func() {
+		if cr, ok := r.resource.Resource.(*v1alpha1.Request); ok && r.resource.HttpRequest.Method != "" {
+			cr.SetAction(r.requestAction())
+		}
+	}
+}
+
+// setLastFetched records the time of the response of an OBSERVE request, from which it is considered fresh.
+func (r *requestStatusHandler) setLastFetched() utils.SetRequestStatusFunc {
+	return func() {
+		if cr, ok := r.resource.Resource.(*v1alpha1.Request); ok && r.resource.HttpRequest.Method != "" && r.requestAction() == v1alpha1.ActionObserve {
+			cr.SetLastFetched(metav1.Now())
+		}
+	}
+}
+
+// setCreateResponse records the response of the request as the response of the last CREATE request.
+func (r *requestStatusHandler) setCreateResponse() utils.SetRequestStatusFunc {
+	return func() {
+		if cr, ok := r.resource.Resource.(*v1alpha1.Request); ok {
+			cr.SetCreateResponse(r.resource.HttpResponse.StatusCode, r.resource.HttpResponse.Headers, r.resource.HttpResponse.Body)
+		}
+	}
+}
+
+// createResponse returns the response of the last CREATE request with its body decrypted: the response of the
+// request when it is a CREATE request, or the one stored in the status otherwise.
+func (r *requestStatusHandler) createResponse(cr *v1alpha1.Request) *v1alpha1.Response {
+	if r.requestAction() == v1alpha1.ActionCreate {
+		response := responseconverter.HttpResponseToV1alpha1Response(r.resource.HttpResponse)
+		return &response
+	}
+
+	if cr.Status.CreateResponse == nil {
+		return nil
+	}
+
+	response := *cr.Status.CreateResponse
+	if bodystore.IsStored(response.Body) {
+		body, err := bodystore.Load(r.resource.RequestContext, r.resource.LocalClient, response.Body)
+		if err != nil {
+			r.logger.Info(errLoadResponseBody, "error", err)
+		}
+		response.Body = body
+	}
+	if r.encryptionKey != nil && envelope.IsEncrypted(response.Body) {
+		response.Body, _ = envelope.Decrypt(r.encryptionKey, response.Body)
+	}
+	return &response
+}
+
+// operation returns the asynchronous operation started by the request, or nil when it didn't start one.
+func (r *requestStatusHandler) operation() *v1alpha1.Operation {
+	async, action := r.forProvider.AsyncOperation, r.requestAction()
+	if async == nil || action == v1alpha1.ActionObserve || r.resource.HttpResponse.StatusCode != http.StatusAccepted {
+		return nil
+	}
+
+	url, err := operationURL(async, r.resource.HttpResponse)
+	if err != nil || url == "" {
+		r.logger.Info(errOperationURL, "error", err)
+		return nil
+	}
+
+	return &v1alpha1.Operation{
+		URL:    utils.ResolveLocation(r.resource.HttpRequest.URL, url),
+		Action: action,
+		Phase:  v1alpha1.OperationPending,
+	}
+}
+
+// operationURL returns the URL of the operation started by the given response.
+func operationURL(async *v1alpha1.AsyncOperation, response httpClient.HttpResponse) (string, error) {
+	if async.URL != "" {
+		return jq.ParseString(async.URL, responseconverter.V1alpha1ResponseToMap(responseconverter.HttpResponseToV1alpha1Response(response)))
+	}
+
+	header := async.URLHeader
+	if header == "" {
+		header = defaultOperationURLHeader
+	}
+
+	return http.Header(response.Headers).Get(header), nil
+}
+
+// setOperation records the given asynchronous operation.
+func (r *requestStatusHandler) setOperation(operation *v1alpha1.Operation) utils.SetRequestStatusFunc {
+	return func() {
+		if cr, ok := r.resource.Resource.(*v1alpha1.Request); ok {
+			cr.Status.Operation = operation
+		}
+	}
+}
+
+// protectBody replaces the response body with the form it is stored in the status: with its sensitive fields
+// masked, encrypted, and a reference to it when it is stored outside of the status. It has to be called once the
+// plain body is not needed anymore.
+func (r *requestStatusHandler) protectBody() error {
+	r.resource.HttpResponse.Body = r.redactor.Body(r.resource.HttpResponse.Body)
+	if err := r.encryptBody(); err != nil {
+		return err
+	}
+	return r.storeBody()
+}
+
+// encryptBody replaces the response body with its encrypted form, so that the setters store it encrypted.
+func (r *requestStatusHandler) encryptBody() error {
+	if r.encryptionKey == nil || r.resource.HttpResponse.Body == "" {
+		return nil
+	}
+
+	encrypted, err := envelope.Encrypt(r.encryptionKey, r.resource.HttpResponse.Body)
+	if err != nil {
+		return errors.Wrap(err, errEncryptResponseBody)
+	}
+
+	r.resource.HttpResponse.Body = encrypted
+	return nil
+}
+
+// storeBody stores the response body outside of the status when it reaches the minimum size of the storage, and
+// replaces it with a reference to it.
+func (r *requestStatusHandler) storeBody() error {
+	body := r.resource.HttpResponse.Body
+	if r.storage == nil || body == "" || len(body) < r.storage.MinBodySize {
+		return nil
+	}
+
+	location := bodystore.Location{Kind: r.storage.Kind, Namespace: r.storage.Namespace, Name: r.storage.Name}
+	reference, err := bodystore.Store(r.resource.RequestContext, r.resource.LocalClient, location, body)
+	if err != nil {
+		return errors.Wrap(err, errStoreResponseBody)
+	}
+
+	r.resource.HttpResponse.Body = reference
 	return nil
 }
 
+// storedBodies returns the references to the response bodies of the status stored outside of it.
+func (r *requestStatusHandler) storedBodies() map[string]bool {
+	cr, ok := r.resource.Resource.(*v1alpha1.Request)
+	if !ok {
+		return nil
+	}
+
+	bodies := []string{cr.Status.Response.Body, cr.Status.Cache.Response.Body}
+	if cr.Status.CreateResponse != nil {
+		bodies = append(bodies, cr.Status.CreateResponse.Body)
+	}
+
+	stored := map[string]bool{}
+	for _, body := range bodies {
+		if bodystore.IsStored(body) {
+			stored[body] = true
+		}
+	}
+	return stored
+}
+
+// removeUnreferencedBodies removes the given stored response bodies that the status no longer references. Bodies
+// that cannot be removed are left in place.
+func (r *requestStatusHandler) removeUnreferencedBodies(previous map[string]bool) {
+	current := r.storedBodies()
+	for reference := range previous {
+		if current[reference] {
+			continue
+		}
+		if err := bodystore.Remove(r.resource.RequestContext, r.resource.LocalClient, reference); err != nil {
+			r.logger.Info(errRemoveResponseBody, "error", err)
+		}
+	}
+}
+
 func (r *requestStatusHandler) setErrorAndReturn(err error) error {
-	if settingError := utils.SetRequestResourceStatus(*r.resource, r.resource.SetError(err)); settingError != nil {
+	if settingError := utils.SetRequestResourceStatus(*r.resource, r.resource.SetCorrelationID(r.correlationID), r.setDebug(err), r.resource.SetTimings(), r.resource.SetError(err)); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
 
 	return err
 }
 
-func (r *requestStatusHandler) incrementFailuresAndReturn(combinedSetters []utils.SetRequestStatusFunc) error {
+func (r *requestStatusHandler) incrementFailuresAndReturn(combinedSetters []utils.SetRequestStatusFunc, stored map[string]bool) error {
 	combinedSetters = append(combinedSetters, r.resource.SetError(nil)) // should increment failures counter
 
 	if settingError := utils.SetRequestResourceStatus(*r.resource, combinedSetters...); settingError != nil {
 		return errors.Wrap(settingError, utils.ErrFailedToSetStatus)
 	}
+	r.removeUnreferencedBodies(stored)
 
 	return errors.Errorf(utils.ErrStatusCode, r.resource.HttpRequest.Method, strconv.Itoa(r.resource.HttpResponse.StatusCode))
 }
 
 func (r *requestStatusHandler) appendExtraSetters(forProvider v1alpha1.RequestParameters, combinedSetters *[]utils.SetRequestStatusFunc) {
-	if r.resource.HttpRequest.Method != http.MethodGet {
+	if r.requestAction() != v1alpha1.ActionObserve {
 		*combinedSetters = append(*combinedSetters, r.resource.ResetFailures())
 	}
 
+	if r.requestAction() == v1alpha1.ActionCreate {
+		*combinedSetters = append(*combinedSetters, r.setCreateResponse())
+	}
+
+	if operation := r.operation(); operation != nil {
+		*combinedSetters = append(*combinedSetters, r.setOperation(operation))
+	}
+
 	if r.shouldSetCache(forProvider) {
 		*combinedSetters = append(*combinedSetters, r.resource.SetCache())
 	}
@@ -97,9 +479,22 @@ func (r *requestStatusHandler) appendExtraSetters(forProvider v1alpha1.RequestPa
 // and RequestParameters. It generates request details according to the given mapping and response. If the request
 // details are not valid, it means that instead of using the response, the cache should be used.
 func (r *requestStatusHandler) shouldSetCache(forProvider v1alpha1.RequestParameters) bool {
+	values := r.values
+	if cr, ok := r.resource.Resource.(*v1alpha1.Request); ok {
+		values.Generated = cr.Status.GeneratedValues
+		values.CreateResponse = r.createResponse(cr)
+		values.Location = cr.Status.Location
+		values.ExternalName = meta.GetExternalName(cr)
+	}
+
 	for _, mapping := range forProvider.Mappings {
+		// The location used by mappings omitting their url may only be recorded along with this response.
+		if mapping.URL == "" && values.Location == "" {
+			continue
+		}
+
 		response := responseconverter.HttpResponseToV1alpha1Response(r.resource.HttpResponse)
-		requestDetails, _, ok := requestgen.GenerateRequestDetails(mapping, forProvider, response)
+		requestDetails, _, ok := requestgen.GenerateRequestDetails(mapping, forProvider, response, values)
 		if !(requestgen.IsRequestValid(requestDetails) && ok) {
 			return false
 		}
@@ -117,7 +512,7 @@ func (r *requestStatusHandler) ResetFailures() {
 }
 
 // NewClient returns a new Request statusHandler
-func NewStatusHandler(ctx context.Context, cr *v1alpha1.Request, requestDetails httpClient.HttpDetails, err error, localKube client.Client, logger logging.Logger) (RequestStatusHandler, error) {
+func NewStatusHandler(ctx context.Context, cr *v1alpha1.Request, requestDetails httpClient.HttpDetails, err error, localKube client.Client, logger logging.Logger, opts ...StatusHandlerOption) (RequestStatusHandler, error) {
 	// Get the latest version of the resource before updating
 	if err := localKube.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr); err != nil {
 		return nil, errors.Wrap(err, "failed to get the latest version of the resource")
@@ -132,10 +527,15 @@ func NewStatusHandler(ctx context.Context, cr *v1alpha1.Request, requestDetails
 			HttpRequest:    requestDetails.HttpRequest,
 			RequestContext: ctx,
 			LocalClient:    localKube,
+			Timings:        requestDetails.Timings,
 		},
 		responseError: err,
 		forProvider:   cr.Spec.ForProvider,
 	}
 
+	for _, opt := range opts {
+		opt(requestStatusHandler)
+	}
+
 	return requestStatusHandler, nil
 }
@@ -3,12 +3,20 @@ package statushandler
 import (
 	"context"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/bodystore"
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/envelope"
+	"github.com/arielsepton/provider-http/internal/redact"
 	"github.com/arielsepton/provider-http/internal/utils"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
@@ -248,3 +256,562 @@ func Test_SetRequestStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_SetRequestStatusEncrypted(t *testing.T) {
+	key := []byte("key")
+	body := `{"id":"123","username":"john_doe"}`
+	cr := &v1alpha1.Request{
+		Spec: v1alpha1.RequestSpec{
+			ForProvider: testForProvider,
+		},
+	}
+	localKube := &test.MockClient{
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		MockGet:          test.NewMockGetFn(nil),
+	}
+	details := httpClient.HttpDetails{
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: 200,
+			Body:       body,
+		},
+		HttpRequest: testRequest,
+	}
+
+	r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger(), WithResponseEncryption(key))
+	if err := r.SetRequestStatus(); err != nil {
+		t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+	}
+
+	if cr.Status.CreateResponse == nil {
+		t.Fatalf("SetRequestStatus(...): Status.CreateResponse is not set")
+	}
+
+	for name, stored := range map[string]string{"Status.Response.Body": cr.Status.Response.Body, "Status.Cache.Response.Body": cr.Status.Cache.Response.Body, "Status.CreateResponse.Body": cr.Status.CreateResponse.Body} {
+		got, err := envelope.Decrypt(key, stored)
+		if err != nil {
+			t.Fatalf("SetRequestStatus(...): %s is not encrypted: %s", name, err)
+		}
+		if diff := cmp.Diff(body, got); diff != "" {
+			t.Errorf("SetRequestStatus(...): -want %s, +got %s: %s", name, name, diff)
+		}
+	}
+}
+
+func Test_SetRequestStatusStored(t *testing.T) {
+	type want struct {
+		stored bool
+	}
+	cases := map[string]struct {
+		body string
+		want want
+	}{
+		"LargeBody": {
+			body: `{"id":"123","username":"john_doe","email":"john.doe@example.com"}`,
+			want: want{stored: true},
+		},
+		"SmallBody": {
+			body: `{"id":"123"}`,
+			want: want{stored: false},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{
+				Spec: v1alpha1.RequestSpec{
+					ForProvider: testForProvider,
+				},
+			}
+			var configMap *corev1.ConfigMap
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					if _, ok := obj.(*corev1.ConfigMap); ok {
+						return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+					}
+					return nil
+				},
+				MockCreate: func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					configMap = obj.(*corev1.ConfigMap)
+					return nil
+				},
+			}
+			details := httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{
+					StatusCode: 200,
+					Body:       tc.body,
+				},
+				HttpRequest: testRequest,
+			}
+			storage := &v1alpha1.ResponseStorage{MinBodySize: 32, Namespace: "default", Name: "responses"}
+
+			r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger(), WithResponseStorage(storage))
+			if err := r.SetRequestStatus(); err != nil {
+				t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.stored, bodystore.IsStored(cr.Status.Response.Body)); diff != "" {
+				t.Fatalf("SetRequestStatus(...): -want stored Status.Response.Body, +got stored Status.Response.Body: %s", diff)
+			}
+			if !tc.want.stored {
+				if diff := cmp.Diff(tc.body, cr.Status.Response.Body); diff != "" {
+					t.Errorf("SetRequestStatus(...): -want Status.Response.Body, +got Status.Response.Body: %s", diff)
+				}
+				return
+			}
+
+			if configMap == nil {
+				t.Fatalf("SetRequestStatus(...): the body was not stored in a ConfigMap")
+			}
+			want := map[string]string{strings.TrimPrefix(cr.Status.Response.Body, bodystore.Prefix+"ConfigMap/default/responses/"): tc.body}
+			if diff := cmp.Diff(want, configMap.Data); diff != "" {
+				t.Errorf("SetRequestStatus(...): -want ConfigMap data, +got ConfigMap data: %s", diff)
+			}
+			if diff := cmp.Diff(cr.Status.Response.Body, cr.Status.Cache.Response.Body); diff != "" {
+				t.Errorf("SetRequestStatus(...): -want Status.Cache.Response.Body, +got Status.Cache.Response.Body: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetRequestStatusRedacted(t *testing.T) {
+	cr := &v1alpha1.Request{
+		Spec: v1alpha1.RequestSpec{
+			ForProvider: testForProvider,
+		},
+	}
+	localKube := &test.MockClient{
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		MockGet:          test.NewMockGetFn(nil),
+	}
+	details := httpClient.HttpDetails{
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: 200,
+			Body:       `{"id":"123","token":"t0k3n"}`,
+			Headers:    map[string][]string{"X-Token": {"t0k3n"}, "Content-Type": {"application/json"}},
+		},
+		HttpRequest: httpClient.HttpRequest{
+			Method:  "POST",
+			URL:     "http://example.com/users",
+			Body:    `{"password":"s3cr3t"}`,
+			Headers: map[string][]string{"Authorization": {"Bearer s3cr3t"}},
+		},
+	}
+	redactor := redact.New([]string{"x-token"}, []string{".token", ".password"})
+
+	r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger(), WithRedactor(redactor))
+	if err := r.SetRequestStatus(); err != nil {
+		t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(`{"id":"123","token":"REDACTED"}`, cr.Status.Response.Body); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.Response.Body, +got Status.Response.Body: %s", diff)
+	}
+	if diff := cmp.Diff(map[string][]string{"X-Token": {"REDACTED"}, "Content-Type": {"application/json"}}, cr.Status.Response.Headers); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.Response.Headers, +got Status.Response.Headers: %s", diff)
+	}
+	if diff := cmp.Diff(`{"password":"REDACTED"}`, cr.Status.RequestDetails.Body); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.RequestDetails.Body, +got Status.RequestDetails.Body: %s", diff)
+	}
+	if diff := cmp.Diff(map[string][]string{"Authorization": {"REDACTED"}}, cr.Status.RequestDetails.Headers); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.RequestDetails.Headers, +got Status.RequestDetails.Headers: %s", diff)
+	}
+
+	failed := details
+	failed.HttpResponse = httpClient.HttpResponse{}
+	r, _ = NewStatusHandler(context.Background(), cr, failed, errors.New("cannot send request with Bearer s3cr3t"), localKube, logging.NewNopLogger(), WithRedactor(redactor))
+	err := r.SetRequestStatus()
+	if diff := cmp.Diff("cannot send request with REDACTED", err.Error()); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want error, +got error: %s", diff)
+	}
+	if diff := cmp.Diff("cannot send request with REDACTED", cr.Status.Error); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.Error, +got Status.Error: %s", diff)
+	}
+}
+
+func Test_SetRequestStatusCreateResponse(t *testing.T) {
+	cr := &v1alpha1.Request{
+		Spec: v1alpha1.RequestSpec{
+			ForProvider: testForProvider,
+		},
+	}
+	localKube := &test.MockClient{
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		MockGet:          test.NewMockGetFn(nil),
+	}
+	created := httpClient.HttpResponse{
+		StatusCode: 201,
+		Body:       `{"id":"123","username":"john_doe"}`,
+		Headers:    testHeaders,
+	}
+
+	responses := []struct {
+		action  string
+		details httpClient.HttpDetails
+	}{
+		{
+			action:  v1alpha1.ActionCreate,
+			details: httpClient.HttpDetails{HttpResponse: created, HttpRequest: testRequest},
+		},
+		{
+			action: v1alpha1.ActionUpdate,
+			details: httpClient.HttpDetails{
+				HttpResponse: httpClient.HttpResponse{StatusCode: 204},
+				HttpRequest:  httpClient.HttpRequest{Method: "PUT", URL: "https://api.example.com/users/123"},
+			},
+		},
+	}
+	for _, response := range responses {
+		r, _ := NewStatusHandler(context.Background(), cr, response.details, nil, localKube, logging.NewNopLogger(), WithAction(response.action))
+		if err := r.SetRequestStatus(); err != nil {
+			t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+		}
+	}
+
+	want := &v1alpha1.Response{StatusCode: created.StatusCode, Body: created.Body, Headers: created.Headers}
+	if diff := cmp.Diff(want, cr.Status.CreateResponse); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.CreateResponse, +got Status.CreateResponse: %s", diff)
+	}
+}
+
+func Test_SetRequestStatusTerminal(t *testing.T) {
+	cr := &v1alpha1.Request{
+		Spec: v1alpha1.RequestSpec{
+			ForProvider: testForProvider,
+		},
+	}
+	localKube := &test.MockClient{
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		MockGet:          test.NewMockGetFn(nil),
+	}
+	policy := &apisv1alpha1.StatusCodePolicy{Terminal: []apisv1alpha1.StatusCodeRange{"422"}}
+
+	steps := []struct {
+		generation int64
+		action     string
+		statusCode int
+		want       int64
+		wantFailed corev1.ConditionStatus
+	}{
+		{
+			generation: 1,
+			action:     v1alpha1.ActionCreate,
+			statusCode: 422,
+			want:       1,
+			wantFailed: corev1.ConditionTrue,
+		},
+		{
+			// Observations do not clear terminal failures.
+			generation: 1,
+			action:     v1alpha1.ActionObserve,
+			statusCode: 404,
+			want:       1,
+			wantFailed: corev1.ConditionTrue,
+		},
+		{
+			generation: 2,
+			action:     v1alpha1.ActionCreate,
+			statusCode: 201,
+			want:       0,
+			wantFailed: corev1.ConditionFalse,
+		},
+		{
+			generation: 3,
+			action:     v1alpha1.ActionUpdate,
+			statusCode: 422,
+			want:       3,
+			wantFailed: corev1.ConditionTrue,
+		},
+	}
+	for i, step := range steps {
+		cr.Generation = step.generation
+		details := httpClient.HttpDetails{
+			HttpResponse: httpClient.HttpResponse{StatusCode: step.statusCode},
+			HttpRequest:  testRequest,
+		}
+
+		// Failed responses are recorded before their error is returned.
+		r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger(), WithAction(step.action), WithStatusCodePolicy(policy))
+		err := r.SetRequestStatus()
+		if diff := cmp.Diff(utils.IsFailure(policy, step.statusCode), err != nil); diff != "" {
+			t.Errorf("step %d: SetRequestStatus(...): -want error, +got error: %s", i, diff)
+		}
+
+		if diff := cmp.Diff(step.want, cr.Status.TerminalGeneration); diff != "" {
+			t.Errorf("step %d: SetRequestStatus(...): -want Status.TerminalGeneration, +got Status.TerminalGeneration: %s", i, diff)
+		}
+		if diff := cmp.Diff(step.wantFailed, cr.Status.GetCondition(apisv1alpha1.TypeFailed).Status); diff != "" {
+			t.Errorf("step %d: SetRequestStatus(...): -want Failed condition, +got Failed condition: %s", i, diff)
+		}
+	}
+}
+
+func Test_SetRequestStatusCreateLocation(t *testing.T) {
+	forProvider := testForProvider
+	forProvider.UseCreateLocation = true
+	forProvider.Mappings = []v1alpha1.Mapping{testPostMapping, {Method: "GET"}, {Method: "DELETE"}}
+	cr := &v1alpha1.Request{
+		Spec: v1alpha1.RequestSpec{
+			ForProvider: forProvider,
+		},
+	}
+	localKube := &test.MockClient{
+		MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+		MockGet:          test.NewMockGetFn(nil),
+	}
+	details := httpClient.HttpDetails{
+		HttpResponse: httpClient.HttpResponse{
+			StatusCode: 201,
+			Headers:    map[string][]string{"Location": {"/users/123"}},
+		},
+		HttpRequest: httpClient.HttpRequest{Method: "POST", URL: "https://api.example.com/users"},
+	}
+
+	r, _ := NewStatusHandler(context.Background(), cr, details, nil, localKube, logging.NewNopLogger(), WithAction(v1alpha1.ActionCreate))
+	if err := r.SetRequestStatus(); err != nil {
+		t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff("https://api.example.com/users/123", cr.Status.Location); diff != "" {
+		t.Errorf("SetRequestStatus(...): -want Status.Location, +got Status.Location: %s", diff)
+	}
+}
+
+func Test_SetRequestStatusClearLocation(t *testing.T) {
+	type args struct {
+		action  string
+		details httpClient.HttpDetails
+	}
+	type want struct {
+		location string
+		failed   bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DeleteSucceeded": {
+			args: args{
+				action: v1alpha1.ActionRemove,
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 204},
+					HttpRequest:  httpClient.HttpRequest{Method: "DELETE", URL: "https://api.example.com/users/123"},
+				},
+			},
+			want: want{},
+		},
+		"ObserveNotFound": {
+			args: args{
+				action: v1alpha1.ActionObserve,
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 404},
+					HttpRequest:  httpClient.HttpRequest{Method: "GET", URL: "https://api.example.com/users/123"},
+				},
+			},
+			want: want{failed: true},
+		},
+		"DeleteFailed": {
+			args: args{
+				action: v1alpha1.ActionRemove,
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{StatusCode: 500},
+					HttpRequest:  httpClient.HttpRequest{Method: "DELETE", URL: "https://api.example.com/users/123"},
+				},
+			},
+			want: want{location: "https://api.example.com/users/123", failed: true},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			forProvider := testForProvider
+			forProvider.UseCreateLocation = true
+			forProvider.Mappings = []v1alpha1.Mapping{testPostMapping, {Method: "GET"}, {Method: "DELETE"}}
+			cr := &v1alpha1.Request{
+				Spec: v1alpha1.RequestSpec{
+					ForProvider: forProvider,
+				},
+				Status: v1alpha1.RequestStatus{
+					Location: "https://api.example.com/users/123",
+				},
+			}
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet:          test.NewMockGetFn(nil),
+			}
+
+			r, _ := NewStatusHandler(context.Background(), cr, tc.args.details, nil, localKube, logging.NewNopLogger(), WithAction(tc.args.action))
+			if err := r.SetRequestStatus(); (err != nil) != tc.want.failed {
+				t.Fatalf("SetRequestStatus(...): unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tc.want.location, cr.Status.Location); diff != "" {
+				t.Errorf("SetRequestStatus(...): -want Status.Location, +got Status.Location: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetRequestStatusOperation(t *testing.T) {
+	type args struct {
+		async   *v1alpha1.AsyncOperation
+		details httpClient.HttpDetails
+	}
+	type want struct {
+		operation *v1alpha1.Operation
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"LocationHeader": {
+			args: args{
+				async: &v1alpha1.AsyncOperation{Done: ".body.done"},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 202,
+						Headers:    map[string][]string{"Location": {"/operations/1"}},
+					},
+					HttpRequest: httpClient.HttpRequest{Method: "POST", URL: "https://api.example.com/users"},
+				},
+			},
+			want: want{
+				operation: &v1alpha1.Operation{URL: "https://api.example.com/operations/1", Action: v1alpha1.ActionCreate, Phase: v1alpha1.OperationPending},
+			},
+		},
+		"URLQuery": {
+			args: args{
+				async: &v1alpha1.AsyncOperation{URL: ".body.operation", Done: ".body.done"},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 202,
+						Body:       `{"operation": "https://ops.example.com/1"}`,
+					},
+					HttpRequest: httpClient.HttpRequest{Method: "POST", URL: "https://api.example.com/users"},
+				},
+			},
+			want: want{
+				operation: &v1alpha1.Operation{URL: "https://ops.example.com/1", Action: v1alpha1.ActionCreate, Phase: v1alpha1.OperationPending},
+			},
+		},
+		"NotAccepted": {
+			args: args{
+				async: &v1alpha1.AsyncOperation{Done: ".body.done"},
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 201,
+						Headers:    map[string][]string{"Location": {"/users/1"}},
+					},
+					HttpRequest: httpClient.HttpRequest{Method: "POST", URL: "https://api.example.com/users"},
+				},
+			},
+			want: want{},
+		},
+		"NoAsyncOperation": {
+			args: args{
+				details: httpClient.HttpDetails{
+					HttpResponse: httpClient.HttpResponse{
+						StatusCode: 202,
+						Headers:    map[string][]string{"Location": {"/operations/1"}},
+					},
+					HttpRequest: httpClient.HttpRequest{Method: "POST", URL: "https://api.example.com/users"},
+				},
+			},
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			forProvider := testForProvider
+			forProvider.AsyncOperation = tc.args.async
+			cr := &v1alpha1.Request{
+				Spec: v1alpha1.RequestSpec{
+					ForProvider: forProvider,
+				},
+			}
+			localKube := &test.MockClient{
+				MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil),
+				MockGet:          test.NewMockGetFn(nil),
+			}
+
+			r, _ := NewStatusHandler(context.Background(), cr, tc.args.details, nil, localKube, logging.NewNopLogger(), WithAction(v1alpha1.ActionCreate))
+			if err := r.SetRequestStatus(); err != nil {
+				t.Fatalf("SetRequestStatus(...): unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(tc.want.operation, cr.Status.Operation); diff != "" {
+				t.Errorf("SetRequestStatus(...): -want Status.Operation, +got Status.Operation: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_recordedHeaders(t *testing.T) {
+	headers := map[string][]string{
+		"Content-Type":          {"application/json"},
+		"Etag":                  {`"33a64df5"`},
+		"X-RateLimit-Remaining": {"42"},
+		"Set-Cookie":            {"session=38afes7a8"},
+		"authorization":         {"Bearer token"},
+	}
+
+	type args struct {
+		headers   map[string][]string
+		selection *v1alpha1.ResponseHeaders
+	}
+	type want struct {
+		headers map[string][]string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoHeaders": {
+			args: args{},
+			want: want{},
+		},
+		"NonSensitive": {
+			args: args{
+				headers: headers,
+			},
+			want: want{
+				headers: map[string][]string{
+					"Content-Type":          {"application/json"},
+					"Etag":                  {`"33a64df5"`},
+					"X-RateLimit-Remaining": {"42"},
+				},
+			},
+		},
+		"Selected": {
+			args: args{
+				headers:   headers,
+				selection: &v1alpha1.ResponseHeaders{Include: []string{"ETag", "x-ratelimit-remaining", "Set-Cookie"}},
+			},
+			want: want{
+				headers: map[string][]string{
+					"Etag":                  {`"33a64df5"`},
+					"X-RateLimit-Remaining": {"42"},
+					"Set-Cookie":            {"session=38afes7a8"},
+				},
+			},
+		},
+		"NoneSelected": {
+			args: args{
+				headers:   headers,
+				selection: &v1alpha1.ResponseHeaders{},
+			},
+			want: want{
+				headers: map[string][]string{},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := recordedHeaders(tc.args.headers, tc.args.selection)
+			if diff := cmp.Diff(tc.want.headers, got); diff != "" {
+				t.Errorf("recordedHeaders(...): -want headers, +got headers: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/bodystore"
+)
+
+const (
+	errLoadResponseBody = "cannot load response body stored outside of status"
+)
+
+// withStoredBodies returns a copy of the Request with the response bodies of its status that are stored outside of
+// it read, or the Request itself when none is.
+func (c *external) withStoredBodies(ctx context.Context, cr *v1alpha1.Request) (*v1alpha1.Request, error) {
+	loaded := cr
+	for i, body := range statusBodies(cr) {
+		if !bodystore.IsStored(*body) {
+			continue
+		}
+
+		if loaded == cr {
+			loaded = cr.DeepCopy()
+		}
+		value, err := bodystore.Load(ctx, c.localKube, *body)
+		if err != nil {
+			return nil, errors.Wrap(err, errLoadResponseBody)
+		}
+		*statusBodies(loaded)[i] = value
+	}
+
+	return loaded, nil
+}
+
+// storedBodies returns the response bodies of the status of the Request.
+func statusBodies(cr *v1alpha1.Request) []*string {
+	bodies := []*string{&cr.Status.Response.Body, &cr.Status.Cache.Response.Body}
+	if cr.Status.CreateResponse != nil {
+		bodies = append(bodies, &cr.Status.CreateResponse.Body)
+	}
+	return bodies
+}
@@ -0,0 +1,92 @@
+package request
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+func Test_withStoredBodies(t *testing.T) {
+	body := `{"id":"123"}`
+	key := "10272c85af469ad81f66d9f5ec3efa70ee0ac7b91fa9e05dda9add54a606203e"
+	reference := "stored:v1:ConfigMap/default/responses/" + key
+
+	type args struct {
+		mg *v1alpha1.Request
+	}
+	type want struct {
+		responseBody string
+		cacheBody    string
+		err          error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotStored": {
+			args: args{
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = body
+				}),
+			},
+			want: want{
+				responseBody: body,
+			},
+		},
+		"Stored": {
+			args: args{
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = reference
+					r.Status.Cache.Response.Body = body
+				}),
+			},
+			want: want{
+				responseBody: body,
+				cacheBody:    body,
+			},
+		},
+		"Tampered": {
+			args: args{
+				mg: httpRequest(func(r *v1alpha1.Request) {
+					r.Status.Response.Body = "stored:v1:ConfigMap/default/responses/0000"
+				}),
+			},
+			want: want{
+				err: errors.Wrap(errors.New("stored value does not match its digest"), errLoadResponseBody),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			stored := tc.args.mg.Status.Response.Body
+			e := &external{localKube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+				obj.(*corev1.ConfigMap).Data = map[string]string{key: body, "0000": body}
+				return nil
+			})}}
+			got, gotErr := e.withStoredBodies(context.Background(), tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("withStoredBodies(...): -want error, +got error: %s", diff)
+			}
+			if gotErr != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.responseBody, got.Status.Response.Body); diff != "" {
+				t.Errorf("withStoredBodies(...): -want response body, +got response body: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cacheBody, got.Status.Cache.Response.Body); diff != "" {
+				t.Errorf("withStoredBodies(...): -want cache body, +got cache body: %s", diff)
+			}
+			if diff := cmp.Diff(stored, tc.args.mg.Status.Response.Body); diff != "" {
+				t.Errorf("withStoredBodies(...): stored response body was modified: %s", diff)
+			}
+		})
+	}
+}
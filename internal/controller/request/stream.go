@@ -0,0 +1,28 @@
+package request
+
+import (
+	"encoding/json"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/jq"
+)
+
+// responseStream returns the bounds of streamed responses of the given response stream, stopping after the first
+// record satisfying its until condition when it has one.
+func responseStream(stream *v1alpha1.ResponseStream) httpClient.ResponseStream {
+	bounds := httpClient.ResponseStream{MaxBytes: stream.MaxBytes, MaxRecords: stream.MaxRecords}
+	if stream.Until == "" {
+		return bounds
+	}
+
+	bounds.Until = func(record []byte) (bool, error) {
+		var value interface{}
+		if err := json.Unmarshal(record, &value); err != nil {
+			return false, err
+		}
+		return jq.ParseBool(stream.Until, value)
+	}
+
+	return bounds
+}
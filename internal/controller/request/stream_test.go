@@ -0,0 +1,76 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+func Test_responseStream(t *testing.T) {
+	type args struct {
+		stream *v1alpha1.ResponseStream
+		record string
+	}
+	type want struct {
+		hasUntil bool
+		done     bool
+		err      bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoUntil": {
+			args: args{
+				stream: &v1alpha1.ResponseStream{MaxRecords: 10},
+			},
+			want: want{},
+		},
+		"UntilSatisfied": {
+			args: args{
+				stream: &v1alpha1.ResponseStream{Until: `.status == "done"`},
+				record: `{"id":2,"status":"done"}`,
+			},
+			want: want{hasUntil: true, done: true},
+		},
+		"UntilNotSatisfied": {
+			args: args{
+				stream: &v1alpha1.ResponseStream{Until: `.status == "done"`},
+				record: `{"id":1,"status":"running"}`,
+			},
+			want: want{hasUntil: true},
+		},
+		"UntilNotBoolean": {
+			args: args{
+				stream: &v1alpha1.ResponseStream{Until: `.status`},
+				record: `{"id":1,"status":"running"}`,
+			},
+			want: want{hasUntil: true, err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := responseStream(tc.args.stream)
+			if diff := cmp.Diff(tc.args.stream.MaxRecords, got.MaxRecords); diff != "" {
+				t.Errorf("responseStream(...): -want max records, +got max records: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.hasUntil, got.Until != nil); diff != "" {
+				t.Fatalf("responseStream(...): -want until, +got until: %s", diff)
+			}
+			if got.Until == nil {
+				return
+			}
+
+			done, err := got.Until([]byte(tc.args.record))
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("Until(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.done, done); diff != "" {
+				t.Errorf("Until(...): -want done, +got done: %s", diff)
+			}
+		})
+	}
+}
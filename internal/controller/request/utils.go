@@ -1,14 +1,235 @@
 package request
 
 import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/clients/auth"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/request/responseconverter"
+	"github.com/arielsepton/provider-http/internal/utils"
 )
 
-func getMappingByMethod(requestParams *v1alpha1.RequestParameters, method string) (*v1alpha1.Mapping, bool) {
+const defaultCreateWait = 30 * time.Second
+
+// actionMethods are the methods implying each action, in order of preference.
+var actionMethods = map[string][]string{
+	v1alpha1.ActionCreate:  {http.MethodPost},
+	v1alpha1.ActionObserve: {http.MethodGet, http.MethodHead, http.MethodOptions},
+	v1alpha1.ActionUpdate:  {http.MethodPatch, http.MethodPut},
+	v1alpha1.ActionRemove:  {http.MethodDelete},
+}
+
+// getMapping returns the mapping performing the given action: the mapping declaring it explicitly, or else the
+// mapping whose method implies it, preferring GET over HEAD and OPTIONS to observe, and PATCH over PUT to update.
+func getMapping(requestParams *v1alpha1.RequestParameters, action string) (*v1alpha1.Mapping, bool) {
 	for _, mapping := range requestParams.Mappings {
-		if mapping.Method == method {
+		if mapping.Action == action {
 			return &mapping, true
 		}
 	}
+
+	for _, method := range actionMethods[action] {
+		for _, mapping := range requestParams.Mappings {
+			if mapping.Action == "" && mapping.Method == method {
+				return &mapping, true
+			}
+		}
+	}
+
 	return nil, false
 }
+
+// observesExistence reports whether observing the resource with the given method only tells whether it exists.
+func observesExistence(method string) bool {
+	return method == http.MethodHead || method == http.MethodOptions
+}
+
+// throttledUntil returns the time until which the remote API asked not to be called, or nil if it is not throttling.
+func throttledUntil(cr *v1alpha1.Request) *metav1.Time {
+	if cr.Status.ThrottledUntil != nil && time.Now().Before(cr.Status.ThrottledUntil.Time) {
+		return cr.Status.ThrottledUntil
+	}
+
+	return nil
+}
+
+// terminalFailure checks whether the last CREATE or UPDATE request of the Request failed with a terminal status code,
+// and the Request did not change since.
+func terminalFailure(cr *v1alpha1.Request) bool {
+	return cr.Status.TerminalGeneration != 0 && cr.Status.TerminalGeneration == cr.Generation
+}
+
+// requeueThrottled requeues a throttled Request exactly when the remote API allows it to be called again.
+func requeueThrottled(mg resource.Managed, result reconcile.Result) reconcile.Result {
+	cr, ok := mg.(*v1alpha1.Request)
+	if !ok {
+		return result
+	}
+
+	if until := throttledUntil(cr); until != nil {
+		return reconcile.Result{RequeueAfter: time.Until(until.Time)}
+	}
+
+	return result
+}
+
+// maxResponseBodyBytes returns the response body limit of the Request when it has one, or the given default of the
+// ProviderConfig.
+func maxResponseBodyBytes(maxBytes, defaultMaxBytes int64) int64 {
+	if maxBytes > 0 {
+		return maxBytes
+	}
+	return defaultMaxBytes
+}
+
+// clientOptions returns the HTTP client options required by the mapping of the given Request with the given action,
+// with the TLS settings merged over the given defaults of the ProviderConfig, and the header values merged over
+// those of the request.
+func clientOptions(ctx context.Context, kube client.Client, cr *v1alpha1.Request, defaults *apisv1alpha1.TLSConfig, action string) ([]httpClient.ClientOption, error) {
+	var opts []httpClient.ClientOption
+	if redirects := cr.Spec.ForProvider.Redirects; redirects != nil {
+		opts = append(opts, httpClient.WithCapturedRedirects(redirects.CaptureStatusCodes...))
+	}
+	if stream := cr.Spec.ForProvider.ResponseStream; stream != nil {
+		opts = append(opts, httpClient.WithResponseStream(responseStream(stream)))
+	}
+
+	tlsOpts, err := auth.TLSClientOptions(ctx, kube, effectiveTLSConfig(defaults, &cr.Spec.ForProvider, action))
+	if err != nil {
+		return nil, err
+	}
+
+	headerOpts, err := headerOptions(ctx, kube, effectiveHeaderValues(&cr.Spec.ForProvider, action))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(append(opts, tlsOpts...), headerOpts...), nil
+}
+
+// hasOwnClient checks whether the given mapping overrides settings that need a dedicated HTTP client.
+func hasOwnClient(mapping v1alpha1.Mapping) bool {
+	if mapping.Auth != nil || len(mapping.HeaderValues) > 0 {
+		return true
+	}
+
+	if mapping.TLS == nil {
+		return false
+	}
+
+	return mapping.TLS.CABundle != "" || mapping.TLS.CABundleSecretRef != nil || mapping.TLS.CABundleConfigMapRef != nil ||
+		mapping.TLS.ClientCertSecretRef != nil
+}
+
+// effectiveTLSConfig returns the TLS settings of the mapping with the given action, merged over those of the request,
+// which are merged over the given defaults of the ProviderConfig.
+func effectiveTLSConfig(defaults *apisv1alpha1.TLSConfig, forProvider *v1alpha1.RequestParameters, action string) apisv1alpha1.TLSConfig {
+	overrides := []*apisv1alpha1.TLSConfig{defaults, auth.SkipTLSVerify(forProvider.InsecureSkipTLSVerify), forProvider.TLS}
+	if mapping, ok := getMapping(forProvider, action); ok {
+		overrides = append(overrides, mapping.TLS)
+	}
+
+	return auth.EffectiveTLS(overrides...)
+}
+
+// insecureSkipTLSVerify checks whether TLS certificate checks are skipped for the mapping with the given action.
+func insecureSkipTLSVerify(defaults *apisv1alpha1.TLSConfig, forProvider *v1alpha1.RequestParameters, action string) bool {
+	return *effectiveTLSConfig(defaults, forProvider, action).InsecureSkipVerify
+}
+
+// freshObservation returns the stored response of the last OBSERVE request if it is still fresh, according to its
+// caching headers when honorCacheHeaders is set, or otherwise within the staleAfter window. The stored response
+// is never fresh when its body is masked by redact.bodyFields, as comparing the masked fields would report drift.
+func freshObservation(cr *v1alpha1.Request) (httpClient.HttpDetails, bool) {
+	lastFetched := cr.Status.LastFetchedTime
+	if lastFetched == nil || cr.Status.RequestDetails.GetAction() != v1alpha1.ActionObserve || redactsBody(&cr.Spec.ForProvider) {
+		return httpClient.HttpDetails{}, false
+	}
+
+	expiry, ok := observationExpiry(&cr.Spec.ForProvider, cr.Status.Response, lastFetched.Time)
+	if !ok || !time.Now().Before(expiry) {
+		return httpClient.HttpDetails{}, false
+	}
+
+	return httpClient.HttpDetails{
+		HttpResponse: responseconverter.V1alpha1ResponseToHttpResponse(cr.Status.Response),
+		HttpRequest: httpClient.HttpRequest{
+			Method:  cr.Status.RequestDetails.Method,
+			URL:     cr.Status.RequestDetails.URL,
+			Body:    cr.Status.RequestDetails.Body,
+			Headers: cr.Status.RequestDetails.Headers,
+		},
+	}, true
+}
+
+// redactsBody checks whether the Request masks fields of the response body stored in its status.
+func redactsBody(forProvider *v1alpha1.RequestParameters) bool {
+	return forProvider.Redact != nil && len(forProvider.Redact.BodyFields) > 0
+}
+
+// observationExpiry returns the time at which a response observed at the given time becomes stale.
+func observationExpiry(forProvider *v1alpha1.RequestParameters, response v1alpha1.Response, observed time.Time) (time.Time, bool) {
+	if forProvider.HonorCacheHeaders {
+		if expiry, ok := utils.CacheExpiry(response.Headers, observed); ok {
+			return expiry, true
+		}
+	}
+
+	if forProvider.StaleAfter == nil {
+		return time.Time{}, false
+	}
+
+	return observed.Add(forProvider.StaleAfter.Duration), true
+}
+
+// createAcknowledged checks whether the last request was a POST answered with one of the status codes listed
+// in createResponse.
+func createAcknowledged(cr *v1alpha1.Request) bool {
+	createResponse := cr.Spec.ForProvider.CreateResponse
+	if createResponse == nil || cr.Status.RequestDetails.GetAction() != v1alpha1.ActionCreate {
+		return false
+	}
+
+	for _, statusCode := range createResponse.StatusCodes {
+		if statusCode == cr.Status.Response.StatusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+// awaitingCreation checks whether an acknowledged creation should not be observed yet, because createResponse
+// asks to wait for it.
+func awaitingCreation(cr *v1alpha1.Request, now time.Time) bool {
+	if !createAcknowledged(cr) || cr.Spec.ForProvider.CreateResponse.Then != v1alpha1.AfterCreateWait {
+		return false
+	}
+
+	wait := defaultCreateWait
+	if waitFor := cr.Spec.ForProvider.CreateResponse.WaitFor; waitFor != nil {
+		wait = waitFor.Duration
+	}
+
+	created := meta.GetExternalCreateSucceeded(cr)
+	return !created.IsZero() && now.Before(created.Add(wait))
+}
+
+// importing checks whether the Request adopts the existing remote object identified by its external name, as no
+// request was sent for it yet. The object is then observed instead of created. External names equal to the name
+// of the Request are not import IDs, as earlier versions of the provider set them by default. This is a known
+// limitation: a remote object whose ID equals the name of the Request cannot be imported, and is created instead.
+func importing(cr *v1alpha1.Request) bool {
+	name := meta.GetExternalName(cr)
+	return name != "" && name != cr.GetName() && cr.Status.RequestDetails.Method == "" && cr.Status.Response.StatusCode == 0
+}
@@ -1,10 +1,17 @@
 package request
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var (
@@ -31,10 +38,13 @@ var (
 	}
 )
 
-func Test_getMappingByMethod(t *testing.T) {
+func Test_getMapping(t *testing.T) {
+	testObserveAction := v1alpha1.Mapping{Action: v1alpha1.ActionObserve, Method: http.MethodPost, URL: "(.payload.baseUrl + \"/search\")"}
+	testUpdateAction := v1alpha1.Mapping{Action: v1alpha1.ActionUpdate, Method: http.MethodPost, URL: "(.payload.baseUrl + \"/update\")"}
+
 	type args struct {
 		requestParams *v1alpha1.RequestParameters
-		method        string
+		action        string
 	}
 	type want struct {
 		mapping *v1alpha1.Mapping
@@ -57,7 +67,7 @@ func Test_getMappingByMethod(t *testing.T) {
 						testDeleteMapping,
 					},
 				},
-				method: "POST",
+				action: v1alpha1.ActionCreate,
 			},
 			want: want{
 				mapping: nil,
@@ -78,23 +88,438 @@ func Test_getMappingByMethod(t *testing.T) {
 						testDeleteMapping,
 					},
 				},
-				method: "POST",
+				action: v1alpha1.ActionCreate,
 			},
 			want: want{
 				mapping: &testPostMapping,
 				ok:      true,
 			},
 		},
+		"ObservePrefersGet": {
+			args: args{
+				requestParams: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{testPostMapping, {Method: http.MethodHead}, testGetMapping},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				mapping: &testGetMapping,
+				ok:      true,
+			},
+		},
+		"ObservePrefersHeadOverOptions": {
+			args: args{
+				requestParams: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{testPostMapping, {Method: http.MethodOptions}, {Method: http.MethodHead}},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				mapping: &v1alpha1.Mapping{Method: http.MethodHead},
+				ok:      true,
+			},
+		},
+		"UpdatePrefersPatch": {
+			args: args{
+				requestParams: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{testPostMapping, testPutMapping, {Method: http.MethodPatch}},
+				},
+				action: v1alpha1.ActionUpdate,
+			},
+			want: want{
+				mapping: &v1alpha1.Mapping{Method: http.MethodPatch},
+				ok:      true,
+			},
+		},
+		"ExplicitAction": {
+			args: args{
+				requestParams: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{testPostMapping, testObserveAction, testUpdateAction},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				mapping: &testObserveAction,
+				ok:      true,
+			},
+		},
+		"ExplicitActionMethodNotImplied": {
+			args: args{
+				requestParams: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{testObserveAction, testUpdateAction},
+				},
+				action: v1alpha1.ActionCreate,
+			},
+			want: want{
+				mapping: nil,
+				ok:      false,
+			},
+		},
 	}
 	for name, tc := range cases {
+		tc := tc
 		t.Run(name, func(t *testing.T) {
-			got, ok := getMappingByMethod(tc.args.requestParams, tc.args.method)
+			got, ok := getMapping(tc.args.requestParams, tc.args.action)
 			if diff := cmp.Diff(tc.want.mapping, got); diff != "" {
-				t.Fatalf("getMappingByMethod(...): -want result, +got result: %s", diff)
+				t.Fatalf("getMapping(...): -want result, +got result: %s", diff)
 			}
 
 			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
-				t.Fatalf("getMappingByMethod(...): -want result, +got result: %s", diff)
+				t.Fatalf("getMapping(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_requeueThrottled(t *testing.T) {
+	past := metav1.NewTime(time.Now().Add(-time.Minute))
+	future := metav1.NewTime(time.Now().Add(time.Minute))
+
+	type args struct {
+		throttledUntil *metav1.Time
+		result         reconcile.Result
+	}
+	type want struct {
+		result reconcile.Result
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotThrottled": {
+			args: args{
+				result: reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+			},
+		},
+		"ThrottlingExpired": {
+			args: args{
+				throttledUntil: &past,
+				result:         reconcile.Result{RequeueAfter: time.Hour},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Hour},
+			},
+		},
+		"Throttled": {
+			args: args{
+				throttledUntil: &future,
+				result:         reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{}
+			cr.Status.ThrottledUntil = tc.args.throttledUntil
+			got := requeueThrottled(cr, tc.args.result)
+			if diff := cmp.Diff(tc.want.result, got, cmp.Comparer(func(a, b time.Duration) bool {
+				return a-b < time.Second && b-a < time.Second
+			})); diff != "" {
+				t.Errorf("requeueThrottled(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_effectiveTLSConfig(t *testing.T) {
+	skip := true
+	verify := false
+	clientCertRef := xpv1.SecretReference{Name: "client-cert", Namespace: "crossplane-system"}
+	statusCertRef := xpv1.SecretReference{Name: "status-client-cert", Namespace: "crossplane-system"}
+
+	type args struct {
+		defaults    *apisv1alpha1.TLSConfig
+		forProvider *v1alpha1.RequestParameters
+		action      string
+	}
+	type want struct {
+		tlsConfig apisv1alpha1.TLSConfig
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"InsecureSkipTLSVerify": {
+			args: args{
+				forProvider: &v1alpha1.RequestParameters{
+					InsecureSkipTLSVerify: true,
+					Mappings:              []v1alpha1.Mapping{testGetMapping},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				tlsConfig: apisv1alpha1.TLSConfig{InsecureSkipVerify: &skip},
+			},
+		},
+		"RequestTLS": {
+			args: args{
+				forProvider: &v1alpha1.RequestParameters{
+					InsecureSkipTLSVerify: true,
+					TLS:                   &apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify, CABundle: "request-ca"},
+					Mappings:              []v1alpha1.Mapping{testGetMapping},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				tlsConfig: apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify, CABundle: "request-ca"},
+			},
+		},
+		"MappingOverride": {
+			args: args{
+				forProvider: &v1alpha1.RequestParameters{
+					TLS: &apisv1alpha1.TLSConfig{CABundle: "request-ca"},
+					Mappings: []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method: "GET",
+							URL:    "https://status.example.com",
+							TLS:    &apisv1alpha1.TLSConfig{InsecureSkipVerify: &skip},
+						},
+					},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				tlsConfig: apisv1alpha1.TLSConfig{InsecureSkipVerify: &skip, CABundle: "request-ca"},
+			},
+		},
+		"OtherMappingNotAffected": {
+			args: args{
+				forProvider: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method: "GET",
+							URL:    "https://status.example.com",
+							TLS:    &apisv1alpha1.TLSConfig{InsecureSkipVerify: &skip, CABundle: "status-ca"},
+						},
+					},
+				},
+				action: v1alpha1.ActionCreate,
+			},
+			want: want{
+				tlsConfig: apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify},
+			},
+		},
+		"ProviderConfigDefaults": {
+			args: args{
+				defaults: &apisv1alpha1.TLSConfig{CABundle: "provider-ca", ClientCertSecretRef: &clientCertRef},
+				forProvider: &v1alpha1.RequestParameters{
+					Mappings: []v1alpha1.Mapping{
+						testPostMapping,
+						{
+							Method: "GET",
+							URL:    "https://status.example.com",
+							TLS:    &apisv1alpha1.TLSConfig{ClientCertSecretRef: &statusCertRef},
+						},
+					},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				tlsConfig: apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify, CABundle: "provider-ca", ClientCertSecretRef: &statusCertRef},
+			},
+		},
+		"ProviderConfigSkipVerifyOverridden": {
+			args: args{
+				defaults: &apisv1alpha1.TLSConfig{InsecureSkipVerify: &skip},
+				forProvider: &v1alpha1.RequestParameters{
+					TLS:      &apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify},
+					Mappings: []v1alpha1.Mapping{testGetMapping},
+				},
+				action: v1alpha1.ActionObserve,
+			},
+			want: want{
+				tlsConfig: apisv1alpha1.TLSConfig{InsecureSkipVerify: &verify},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := effectiveTLSConfig(tc.args.defaults, tc.args.forProvider, tc.args.action)
+			if diff := cmp.Diff(tc.want.tlsConfig, got); diff != "" {
+				t.Errorf("effectiveTLSConfig(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_awaitingCreation(t *testing.T) {
+	now := time.Now()
+
+	type args struct {
+		createResponse *v1alpha1.CreateResponse
+		method         string
+		statusCode     int
+		created        time.Time
+	}
+	type want struct {
+		awaiting bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoCreateResponse": {
+			args: args{
+				method:     http.MethodPost,
+				statusCode: 202,
+				created:    now,
+			},
+			want: want{
+				awaiting: false,
+			},
+		},
+		"Observe": {
+			args: args{
+				createResponse: &v1alpha1.CreateResponse{StatusCodes: []int{202}, Then: v1alpha1.AfterCreateObserve},
+				method:         http.MethodPost,
+				statusCode:     202,
+				created:        now,
+			},
+			want: want{
+				awaiting: false,
+			},
+		},
+		"UnlistedStatusCode": {
+			args: args{
+				createResponse: &v1alpha1.CreateResponse{StatusCodes: []int{202}, Then: v1alpha1.AfterCreateWait},
+				method:         http.MethodPost,
+				statusCode:     201,
+				created:        now,
+			},
+			want: want{
+				awaiting: false,
+			},
+		},
+		"AlreadyObserved": {
+			args: args{
+				createResponse: &v1alpha1.CreateResponse{StatusCodes: []int{202}, Then: v1alpha1.AfterCreateWait},
+				method:         http.MethodGet,
+				statusCode:     202,
+				created:        now,
+			},
+			want: want{
+				awaiting: false,
+			},
+		},
+		"WaitDefault": {
+			args: args{
+				createResponse: &v1alpha1.CreateResponse{StatusCodes: []int{202}, Then: v1alpha1.AfterCreateWait},
+				method:         http.MethodPost,
+				statusCode:     202,
+				created:        now.Add(-10 * time.Second),
+			},
+			want: want{
+				awaiting: true,
+			},
+		},
+		"WaitElapsed": {
+			args: args{
+				createResponse: &v1alpha1.CreateResponse{
+					StatusCodes: []int{202},
+					Then:        v1alpha1.AfterCreateWait,
+					WaitFor:     &metav1.Duration{Duration: 5 * time.Second},
+				},
+				method:     http.MethodPost,
+				statusCode: 202,
+				created:    now.Add(-10 * time.Second),
+			},
+			want: want{
+				awaiting: false,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{}
+			cr.Spec.ForProvider.CreateResponse = tc.args.createResponse
+			cr.Status.RequestDetails.Method = tc.args.method
+			cr.Status.Response.StatusCode = tc.args.statusCode
+			meta.SetExternalCreateSucceeded(cr, tc.args.created)
+			got := awaitingCreation(cr, now)
+			if diff := cmp.Diff(tc.want.awaiting, got); diff != "" {
+				t.Errorf("awaitingCreation(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_hasOwnClient(t *testing.T) {
+	cases := map[string]struct {
+		mapping v1alpha1.Mapping
+		want    bool
+	}{
+		"NoOverrides": {
+			mapping: testPostMapping,
+			want:    false,
+		},
+		"SkipVerifyOnly": {
+			mapping: v1alpha1.Mapping{TLS: &apisv1alpha1.TLSConfig{InsecureSkipVerify: func() *bool { b := true; return &b }()}},
+			want:    false,
+		},
+		"CABundle": {
+			mapping: v1alpha1.Mapping{TLS: &apisv1alpha1.TLSConfig{CABundle: "-----BEGIN CERTIFICATE-----"}},
+			want:    true,
+		},
+		"Auth": {
+			mapping: v1alpha1.Mapping{Auth: &apisv1alpha1.Auth{Disabled: true}},
+			want:    true,
+		},
+		"HeaderValues": {
+			mapping: v1alpha1.Mapping{HeaderValues: []v1alpha1.Header{{Name: "X-Api-Key", Value: "key"}}},
+			want:    true,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := hasOwnClient(tc.mapping)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("hasOwnClient(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_terminalFailure(t *testing.T) {
+	cases := map[string]struct {
+		generation         int64
+		terminalGeneration int64
+		want               bool
+	}{
+		"NoTerminalFailure": {
+			generation: 2,
+			want:       false,
+		},
+		"TerminalFailure": {
+			generation:         2,
+			terminalGeneration: 2,
+			want:               true,
+		},
+		"ChangedSinceTerminalFailure": {
+			generation:         3,
+			terminalGeneration: 2,
+			want:               false,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{}
+			cr.Generation = tc.generation
+			cr.Status.TerminalGeneration = tc.terminalGeneration
+			if diff := cmp.Diff(tc.want, terminalFailure(cr)); diff != "" {
+				t.Errorf("terminalFailure(...): -want, +got: %s", diff)
 			}
 		})
 	}
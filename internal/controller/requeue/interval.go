@@ -0,0 +1,92 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requeue
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// A PollIntervalGetter is a resource that may override the poll interval of
+// its controller.
+type PollIntervalGetter interface {
+	GetPollInterval() *metav1.Duration
+}
+
+// An IntervalFn returns the interval at which the supplied resource is polled,
+// or zero to poll it at the poll interval of its controller.
+type IntervalFn func(mg resource.Managed) time.Duration
+
+// ResourcePollInterval returns an IntervalFn returning the poll interval of a
+// resource, or of its ProviderConfig when it sets none.
+func ResourcePollInterval(kube client.Reader) IntervalFn {
+	return func(mg resource.Managed) time.Duration {
+		if g, ok := mg.(PollIntervalGetter); ok && g.GetPollInterval() != nil {
+			return g.GetPollInterval().Duration
+		}
+
+		ref := mg.GetProviderConfigReference()
+		if ref == nil {
+			return 0
+		}
+		pc := &apisv1alpha1.ProviderConfig{}
+		// The ProviderConfig is read from the cache, the resource was just
+		// reconciled with it.
+		if err := kube.Get(context.Background(), types.NamespacedName{Name: ref.Name}, pc); err != nil || pc.Spec.PollInterval == nil {
+			return 0
+		}
+		return pc.Spec.PollInterval.Duration
+	}
+}
+
+// PollInterval returns a ScheduleFn that polls resources at the interval
+// returned by the supplied function rather than at the poll interval of their
+// controller. Only results requeued after the poll interval, delayed by up to
+// maxJitter by PollJitter, are affected; it has to follow PollJitter, whose
+// delay is kept.
+func PollInterval(pollInterval, maxJitter time.Duration, interval IntervalFn) ScheduleFn {
+	return func(mg resource.Managed, result reconcile.Result) reconcile.Result {
+		if result.Requeue || !polling(result.RequeueAfter, pollInterval, maxJitter) {
+			return result
+		}
+
+		override := interval(mg)
+		if override <= 0 {
+			return result
+		}
+
+		result.RequeueAfter = override + result.RequeueAfter - pollInterval
+		return result
+	}
+}
+
+// polling returns whether the supplied requeue delay is a poll, the poll
+// interval delayed by less than maxJitter.
+func polling(after, pollInterval, maxJitter time.Duration) bool {
+	if maxJitter <= 0 {
+		return after == pollInterval
+	}
+	return after >= pollInterval && after < pollInterval+maxJitter
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requeue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+type intervalResource struct {
+	fake.Managed
+	interval *metav1.Duration
+}
+
+func (r *intervalResource) GetPollInterval() *metav1.Duration {
+	return r.interval
+}
+
+func Test_PollInterval(t *testing.T) {
+	pollInterval := time.Minute
+	maxJitter := 10 * time.Second
+	thirtyMinutes := func(resource.Managed) time.Duration { return 30 * time.Minute }
+	noOverride := func(resource.Managed) time.Duration { return 0 }
+
+	type args struct {
+		maxJitter time.Duration
+		interval  IntervalFn
+		result    reconcile.Result
+	}
+	cases := map[string]struct {
+		args args
+		want reconcile.Result
+	}{
+		"Polling": {
+			args: args{interval: thirtyMinutes, result: reconcile.Result{RequeueAfter: pollInterval}},
+			want: reconcile.Result{RequeueAfter: 30 * time.Minute},
+		},
+		"PollingWithJitter": {
+			args: args{maxJitter: maxJitter, interval: thirtyMinutes, result: reconcile.Result{RequeueAfter: pollInterval + 3*time.Second}},
+			want: reconcile.Result{RequeueAfter: 30*time.Minute + 3*time.Second},
+		},
+		"NoOverride": {
+			args: args{interval: noOverride, result: reconcile.Result{RequeueAfter: pollInterval}},
+			want: reconcile.Result{RequeueAfter: pollInterval},
+		},
+		"NotPolling": {
+			args: args{maxJitter: maxJitter, interval: thirtyMinutes, result: reconcile.Result{RequeueAfter: 5 * time.Second}},
+			want: reconcile.Result{RequeueAfter: 5 * time.Second},
+		},
+		"BeyondJitter": {
+			args: args{maxJitter: maxJitter, interval: thirtyMinutes, result: reconcile.Result{RequeueAfter: pollInterval + maxJitter}},
+			want: reconcile.Result{RequeueAfter: pollInterval + maxJitter},
+		},
+		"Requeued": {
+			args: args{interval: thirtyMinutes, result: reconcile.Result{Requeue: true}},
+			want: reconcile.Result{Requeue: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := PollInterval(pollInterval, tc.args.maxJitter, tc.args.interval)(&fake.Managed{}, tc.args.result)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("PollInterval(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_ResourcePollInterval(t *testing.T) {
+	withProviderConfig := func(interval *metav1.Duration) *test.MockClient {
+		return &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			pc, ok := obj.(*apisv1alpha1.ProviderConfig)
+			if !ok {
+				return errBoom
+			}
+			pc.Spec.PollInterval = interval
+			return nil
+		}}
+	}
+
+	cases := map[string]struct {
+		mg   resource.Managed
+		kube client.Reader
+		want time.Duration
+	}{
+		"Resource": {
+			mg:   &intervalResource{interval: &metav1.Duration{Duration: 30 * time.Second}},
+			kube: withProviderConfig(&metav1.Duration{Duration: 30 * time.Minute}),
+			want: 30 * time.Second,
+		},
+		"ProviderConfig": {
+			mg:   &intervalResource{Managed: fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "pc"}}}},
+			kube: withProviderConfig(&metav1.Duration{Duration: 30 * time.Minute}),
+			want: 30 * time.Minute,
+		},
+		"Default": {
+			mg:   &intervalResource{Managed: fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "pc"}}}},
+			kube: withProviderConfig(nil),
+			want: 0,
+		},
+		"ProviderConfigNotFound": {
+			mg:   &intervalResource{Managed: fake.Managed{ProviderConfigReferencer: fake.ProviderConfigReferencer{Ref: &xpv1.Reference{Name: "pc"}}}},
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			want: 0,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := ResourcePollInterval(tc.kube)(tc.mg)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ResourcePollInterval(...): -want interval, +got interval: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package requeue adjusts when managed resources are reconciled next, based on
+// their state after a reconcile.
+package requeue
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// A ScheduleFn returns the result to use for the supplied managed resource,
+// given the result of the wrapped reconciler.
+type ScheduleFn func(mg resource.Managed, result reconcile.Result) reconcile.Result
+
+// nextPollTolerance is how much the recorded next poll time may differ from
+// the actual one before the status is patched.
+const nextPollTolerance = 10 * time.Second
+
+// A NextPollRecorder is a resource recording when it is reconciled next.
+type NextPollRecorder interface {
+	GetNextPollTime() *metav1.Time
+	SetNextPollTime(t *metav1.Time)
+}
+
+// A Reconciler wraps another reconciler and overrides when the reconciled
+// resource is requeued.
+type Reconciler struct {
+	inner      reconcile.Reconciler
+	kube       client.Client
+	newManaged func() resource.Managed
+	schedules  []ScheduleFn
+	now        func() time.Time
+}
+
+// NewReconciler returns a Reconciler that wraps the supplied reconciler. The
+// schedule functions are applied in order after every successful reconcile.
+func NewReconciler(inner reconcile.Reconciler, kube client.Client, newManaged func() resource.Managed, schedules ...ScheduleFn) *Reconciler {
+	return &Reconciler{
+		inner:      inner,
+		kube:       kube,
+		newManaged: newManaged,
+		schedules:  schedules,
+		now:        time.Now,
+	}
+}
+
+// Reconcile the supplied request using the wrapped reconciler, then adjust
+// the result using the schedule functions and record when the resource is
+// reconciled next.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.inner.Reconcile(ctx, req)
+	if err != nil || len(r.schedules) == 0 {
+		return result, err
+	}
+
+	mg := r.newManaged()
+	if err := r.kube.Get(ctx, req.NamespacedName, mg); err != nil {
+		// The resource may have been deleted, keep the original result.
+		return result, nil
+	}
+
+	for _, schedule := range r.schedules {
+		result = schedule(mg, result)
+	}
+
+	r.recordNextPoll(ctx, mg, result)
+	return result, nil
+}
+
+// recordNextPoll records when the supplied resource is reconciled next given
+// the supplied result, if it records it. The status is only patched when the
+// recorded time is off by more than nextPollTolerance, so that reconciles
+// triggered in quick succession do not write it each time. It is patched
+// rather than updated, as the cached resource may not reflect the status the
+// wrapped reconciler just updated. Errors are ignored: the time is
+// informational, and recorded again by the next reconcile.
+func (r *Reconciler) recordNextPoll(ctx context.Context, mg resource.Managed, result reconcile.Result) {
+	recorder, ok := mg.(NextPollRecorder)
+	if !ok {
+		return
+	}
+
+	var next *metav1.Time
+	if result.Requeue || result.RequeueAfter > 0 {
+		t := metav1.NewTime(r.now().Add(result.RequeueAfter))
+		next = &t
+	}
+	if withinTolerance(recorder.GetNextPollTime(), next) {
+		return
+	}
+
+	original, ok := mg.DeepCopyObject().(client.Object)
+	if !ok {
+		return
+	}
+	recorder.SetNextPollTime(next)
+	_ = r.kube.Status().Patch(ctx, mg, client.MergeFrom(original))
+}
+
+// withinTolerance checks whether the recorded next poll time is off from the
+// actual one by at most nextPollTolerance.
+func withinTolerance(recorded, next *metav1.Time) bool {
+	if recorded == nil || next == nil {
+		return recorded == nil && next == nil
+	}
+
+	diff := recorded.Sub(next.Time)
+	return diff >= -nextPollTolerance && diff <= nextPollTolerance
+}
+
+// PollJitter returns a ScheduleFn that delays the next poll of a resource by
+// a deterministic amount of up to maxJitter, derived from the resource's UID.
+// Only results requeued after exactly the poll interval are affected.
+func PollJitter(pollInterval, maxJitter time.Duration) ScheduleFn {
+	return func(mg resource.Managed, result reconcile.Result) reconcile.Result {
+		if maxJitter <= 0 || result.Requeue || result.RequeueAfter != pollInterval {
+			return result
+		}
+
+		result.RequeueAfter += jitter(mg, maxJitter)
+		return result
+	}
+}
+
+// jitter returns a stable duration in [0, maxJitter) for the supplied resource.
+func jitter(mg resource.Managed, maxJitter time.Duration) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(mg.GetUID()))
+	return time.Duration(h.Sum64() % uint64(maxJitter))
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requeue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var errBoom = errors.New("boom")
+
+func Test_Reconcile(t *testing.T) {
+	requeueAfterMinute := func(mg resource.Managed, result reconcile.Result) reconcile.Result {
+		return reconcile.Result{RequeueAfter: time.Minute}
+	}
+
+	type args struct {
+		inner     reconcile.Reconciler
+		kube      *test.MockClient
+		schedules []ScheduleFn
+	}
+	type want struct {
+		result reconcile.Result
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"InnerError": {
+			args: args{
+				inner: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{Requeue: true}, errBoom
+				}),
+				kube:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				schedules: []ScheduleFn{requeueAfterMinute},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+				err:    errBoom,
+			},
+		},
+		"GetError": {
+			args: args{
+				inner: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{Requeue: true}, nil
+				}),
+				kube:      &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				schedules: []ScheduleFn{requeueAfterMinute},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+			},
+		},
+		"NoSchedules": {
+			args: args{
+				inner: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{Requeue: true}, nil
+				}),
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+			},
+		},
+		"Scheduled": {
+			args: args{
+				inner: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+					return reconcile.Result{Requeue: true}, nil
+				}),
+				kube:      &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+				schedules: []ScheduleFn{requeueAfterMinute},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Minute},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			r := NewReconciler(tc.args.inner, tc.args.kube, func() resource.Managed { return &fake.Managed{} }, tc.args.schedules...)
+			got, gotErr := r.Reconcile(context.Background(), reconcile.Request{})
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Reconcile(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Reconcile(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_PollJitter(t *testing.T) {
+	pollInterval := time.Minute
+	maxJitter := 10 * time.Second
+	mg := &fake.Managed{}
+	mg.SetUID("b8bc41f4-7a11-4b2e-8a6b-5d7e2c5e7d0c")
+
+	type args struct {
+		maxJitter time.Duration
+		result    reconcile.Result
+	}
+	type want struct {
+		result reconcile.Result
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Disabled": {
+			args: args{
+				result: reconcile.Result{RequeueAfter: pollInterval},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: pollInterval},
+			},
+		},
+		"NotPolling": {
+			args: args{
+				maxJitter: maxJitter,
+				result:    reconcile.Result{Requeue: true},
+			},
+			want: want{
+				result: reconcile.Result{Requeue: true},
+			},
+		},
+		"Polling": {
+			args: args{
+				maxJitter: maxJitter,
+				result:    reconcile.Result{RequeueAfter: pollInterval},
+			},
+			want: want{
+				result: reconcile.Result{RequeueAfter: pollInterval + jitter(mg, maxJitter)},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := PollJitter(pollInterval, tc.args.maxJitter)(mg, tc.args.result)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("PollJitter(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_jitter(t *testing.T) {
+	maxJitter := 10 * time.Second
+	first := &fake.Managed{}
+	first.SetUID("b8bc41f4-7a11-4b2e-8a6b-5d7e2c5e7d0c")
+	second := &fake.Managed{}
+	second.SetUID("0f1e9a3c-4d6b-4c1e-9e57-3a2b1c0d9e8f")
+
+	if jitter(first, maxJitter) != jitter(first, maxJitter) {
+		t.Errorf("jitter(...): expected the same jitter for the same resource")
+	}
+	if jitter(first, maxJitter) == jitter(second, maxJitter) {
+		t.Errorf("jitter(...): expected different jitters for different resources")
+	}
+	for _, mg := range []*fake.Managed{first, second} {
+		if got := jitter(mg, maxJitter); got < 0 || got >= maxJitter {
+			t.Errorf("jitter(...): %s is not within [0, %s)", got, maxJitter)
+		}
+	}
+}
+
+type pollRecorder struct {
+	fake.Managed
+	next *metav1.Time
+}
+
+func (p *pollRecorder) GetNextPollTime() *metav1.Time {
+	return p.next
+}
+
+func (p *pollRecorder) SetNextPollTime(t *metav1.Time) {
+	p.next = t
+}
+
+func Test_recordNextPoll(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	inMinute := metav1.NewTime(now.Add(time.Minute))
+	immediately := metav1.NewTime(now)
+	recently := metav1.NewTime(now.Add(55 * time.Second))
+	longAgo := metav1.NewTime(now.Add(-time.Hour))
+
+	type want struct {
+		next    *metav1.Time
+		patched bool
+	}
+	cases := map[string]struct {
+		recorded *metav1.Time
+		result   reconcile.Result
+		want     want
+	}{
+		"RequeuedAfter": {
+			recorded: &longAgo,
+			result:   reconcile.Result{RequeueAfter: time.Minute},
+			want:     want{next: &inMinute, patched: true},
+		},
+		"Requeued": {
+			recorded: &longAgo,
+			result:   reconcile.Result{Requeue: true},
+			want:     want{next: &immediately, patched: true},
+		},
+		"NotRequeued": {
+			recorded: &longAgo,
+			result:   reconcile.Result{},
+			want:     want{next: nil, patched: true},
+		},
+		"FirstRecorded": {
+			result: reconcile.Result{RequeueAfter: time.Minute},
+			want:   want{next: &inMinute, patched: true},
+		},
+		"WithinTolerance": {
+			recorded: &recently,
+			result:   reconcile.Result{RequeueAfter: time.Minute},
+			want:     want{next: &recently},
+		},
+		"StillNotRequeued": {
+			result: reconcile.Result{},
+			want:   want{next: nil},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			patched := false
+			kube := &test.MockClient{MockStatusPatch: func(_ context.Context, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+				patched = true
+				return errBoom
+			}}
+			r := &Reconciler{kube: kube, now: func() time.Time { return now }}
+			mg := &pollRecorder{next: tc.recorded}
+
+			r.recordNextPoll(context.Background(), mg, tc.result)
+			if diff := cmp.Diff(tc.want.next, mg.next); diff != "" {
+				t.Errorf("recordNextPoll(...): -want next poll time, +got next poll time: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.patched, patched); diff != "" {
+				t.Errorf("recordNextPoll(...): -want patched, +got patched: %s", diff)
+			}
+		})
+	}
+}
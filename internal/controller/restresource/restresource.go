@@ -0,0 +1,298 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restresource
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/arielsepton/provider-http/apis/restresource/v1alpha1"
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/options"
+	"github.com/arielsepton/provider-http/internal/controller/requeue"
+	"github.com/arielsepton/provider-http/internal/controller/timestamps"
+	"github.com/arielsepton/provider-http/internal/jq"
+	json_util "github.com/arielsepton/provider-http/internal/json"
+	"github.com/arielsepton/provider-http/internal/tracing"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	defaultIDPath = ".id"
+
+	errNotRestResource = "managed resource is not a RestResource custom resource"
+	errInvalidBaseURL  = "base URL is not a valid URL"
+	errObserve         = "cannot get resource"
+	errCreate          = "cannot create resource"
+	errUpdate          = "cannot update resource"
+	errDelete          = "cannot delete resource"
+	errExtractID       = "cannot extract resource ID from create response with %s"
+)
+
+// Setup adds a controller that reconciles RestResource managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
+	name := managed.ControllerName(v1alpha1.RestResourceGroupKind)
+	cps := options.ConnectionPublishers(mgr.GetClient(), mgr.GetScheme(), o)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.RestResourceGroupVersionKind),
+		managed.WithExternalConnecter(timestamps.NewConnecter(&connector{
+			Clients: opts.Clients(mgr.GetClient()),
+			logger:  o.Logger,
+		})),
+		// The external name is the ID assigned by the server on create, rather than the name of the resource.
+		managed.WithInitializers(),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(opts.Timeout),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	schedules := []requeue.ScheduleFn{
+		requeue.PollJitter(o.PollInterval, opts.PollJitter),
+		requeue.PollInterval(o.PollInterval, opts.PollJitter, requeue.ResourcePollInterval(mgr.GetClient())),
+		opts.LoadShedder(name).Schedule,
+	}
+	requeuer := requeue.NewReconciler(r, mgr.GetClient(), newRestResource, schedules...)
+	traced := tracing.NewReconciler(name, requeuer)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(opts.ForControllerRuntime(o, v1alpha1.RestResourceKind)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.RestResource{}).
+		Complete(ratelimiter.NewReconciler(name, traced, o.GlobalRateLimiter))
+}
+
+func newRestResource() resource.Managed {
+	return &v1alpha1.RestResource{}
+}
+
+type connector struct {
+	options.Clients
+	logger logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RestResource)
+	if !ok {
+		return nil, errors.New(errNotRestResource)
+	}
+
+	l := c.logger.WithValues("restResource", cr.Name)
+
+	h, err := c.NewClient(ctx, l, cr, options.ClientParameters{
+		Kind:                  v1alpha1.RestResourceKind,
+		Auth:                  cr.Spec.ForProvider.Auth,
+		WaitTimeout:           cr.Spec.ForProvider.WaitTimeout,
+		InsecureSkipTLSVerify: cr.Spec.ForProvider.InsecureSkipTLSVerify,
+		TLS:                   cr.Spec.ForProvider.TLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		logger:        l,
+		http:          h.Client,
+		statusCodes:   utils.StatusCodePolicy(cr.Spec.ForProvider.StatusCodes, h.ProviderConfig.Spec.StatusCodes),
+		skipTLSVerify: h.SkipTLSVerify,
+		correlationID: h.CorrelationID,
+	}, nil
+}
+
+type external struct {
+	logger      logging.Logger
+	http        httpClient.Client
+	statusCodes *apisv1alpha1.StatusCodePolicy
+
+	// skipTLSVerify skips TLS certificate checks, when set by the resource or its ProviderConfig.
+	skipTLSVerify bool
+
+	// correlationID is the value of the correlation header set on the requests, recorded in the status.
+	correlationID string
+}
+
+// collectionURL returns the URL of the collection of the resource, to which it is posted.
+func collectionURL(params v1alpha1.RestResourceParameters) string {
+	return strings.TrimSuffix(params.BaseURL, "/") + "/" + strings.Trim(params.Path, "/")
+}
+
+// itemURL returns the URL of the resource with the given ID.
+func itemURL(params v1alpha1.RestResourceParameters, id string) string {
+	return collectionURL(params) + "/" + url.PathEscape(id)
+}
+
+// send sends a request for the resource and records its response, or the reason it failed, in the status of the
+// resource. Responses with a status code the StatusCodePolicy considers a failure are returned as errors, except
+// for the ones with one of the given tolerated status codes.
+func (c *external) send(ctx context.Context, cr *v1alpha1.RestResource, method, url, body string, tolerated ...int) (httpClient.HttpResponse, error) {
+	details, err := c.http.SendRequest(ctx, method, url, body, cr.Spec.ForProvider.Headers, c.skipTLSVerify)
+	cr.SetTimings(utils.Timings(details.Timings))
+	cr.SetCorrelationID(c.correlationID)
+	res := details.HttpResponse
+	if err != nil {
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, err), err)
+		return res, err
+	}
+
+	cr.SetResponse(res.StatusCode, res.Body, res.Headers)
+	for _, code := range tolerated {
+		if res.StatusCode == code {
+			cr.SetError("", nil)
+			return res, nil
+		}
+	}
+
+	if utils.IsFailure(c.statusCodes, res.StatusCode) {
+		err := errors.Errorf(utils.ErrStatusCode, method, strconv.Itoa(res.StatusCode))
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, nil), err)
+		return res, err
+	}
+
+	cr.SetError("", nil)
+	return res, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RestResource)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRestResource)
+	}
+
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.URL = itemURL(cr.Spec.ForProvider, id)
+	res, err := c.send(ctx, cr, http.MethodGet, cr.Status.URL, "", http.StatusNotFound, http.StatusGone)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserve)
+	}
+
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isUpToDate(cr.Spec.ForProvider.Body, res.Body),
+	}, nil
+}
+
+// isUpToDate checks whether the observed representation of the resource holds the fields of its desired one.
+// Fields holding Vault secret placeholders are ignored, as their values are not known. Bodies that are not JSON
+// objects are not compared.
+func isUpToDate(desired, observed string) bool {
+	if !json_util.IsJSONString(desired) {
+		return true
+	}
+
+	desiredMap := json_util.JsonStringToMap(desired)
+	for key, value := range desiredMap {
+		if httpClient.ContainsPlaceholder(fmt.Sprint(value)) {
+			delete(desiredMap, key)
+		}
+	}
+
+	return json_util.IsJSONString(observed) && json_util.Contains(json_util.JsonStringToMap(observed), desiredMap)
+}
+
+// extractID returns the ID the server assigned to the resource, from the body of the create response. Numeric
+// IDs are returned as strings.
+func extractID(idPath, body string) (string, error) {
+	if idPath == "" {
+		idPath = defaultIDPath
+	}
+
+	if !json_util.IsJSONString(body) {
+		return "", errors.Errorf(errExtractID, idPath)
+	}
+
+	id, err := jq.ParseString(idPath+" | tostring", json_util.JsonStringToMap(body))
+	if err != nil || id == "" || id == "null" {
+		return "", errors.Errorf(errExtractID, idPath)
+	}
+
+	return id, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RestResource)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRestResource)
+	}
+
+	if !utils.IsUrlValid(cr.Spec.ForProvider.BaseURL) {
+		return managed.ExternalCreation{}, errors.New(errInvalidBaseURL)
+	}
+
+	res, err := c.send(ctx, cr, http.MethodPost, collectionURL(cr.Spec.ForProvider), cr.Spec.ForProvider.Body)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	id, err := extractID(cr.Spec.ForProvider.IDPath, res.Body)
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreate)
+	}
+
+	meta.SetExternalName(cr, id)
+	cr.Status.URL = itemURL(cr.Spec.ForProvider, id)
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RestResource)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRestResource)
+	}
+
+	_, err := c.send(ctx, cr, http.MethodPut, itemURL(cr.Spec.ForProvider, meta.GetExternalName(cr)), cr.Spec.ForProvider.Body)
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdate)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RestResource)
+	if !ok {
+		return errors.New(errNotRestResource)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := c.send(ctx, cr, http.MethodDelete, itemURL(cr.Spec.ForProvider, meta.GetExternalName(cr)), "", http.StatusNotFound, http.StatusGone)
+	return errors.Wrap(err, errDelete)
+}
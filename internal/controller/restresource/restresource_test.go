@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restresource
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arielsepton/provider-http/apis/restresource/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/clients/http/fake"
+)
+
+var errBoom = errors.New("boom")
+
+const (
+	testBaseURL = "https://api.example.com/v1/"
+	testPath    = "/users"
+	testBody    = `{"name": "jane"}`
+)
+
+func restResource(id string) *v1alpha1.RestResource {
+	cr := &v1alpha1.RestResource{
+		ObjectMeta: v1.ObjectMeta{Name: "user"},
+		Spec: v1alpha1.RestResourceSpec{
+			ForProvider: v1alpha1.RestResourceParameters{
+				BaseURL: testBaseURL,
+				Path:    testPath,
+				Body:    testBody,
+				IDPath:  ".id",
+			},
+		},
+	}
+	if id != "" {
+		meta.SetExternalName(cr, id)
+	}
+	return cr
+}
+
+func Test_Observe(t *testing.T) {
+	type want struct {
+		obs managed.ExternalObservation
+		err error
+	}
+	cases := map[string]struct {
+		id   string
+		send fake.MockSendRequestFn
+		want want
+	}{
+		"NotCreated": {
+			want: want{obs: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"UpToDate": {
+			id:   "42",
+			send: fake.Route(http.MethodGet, "https://api.example.com/v1/users/42", http.StatusOK, `{"id": 42, "name": "jane"}`),
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}},
+		},
+		"Outdated": {
+			id:   "42",
+			send: fake.Route(http.MethodGet, "https://api.example.com/v1/users/42", http.StatusOK, `{"id": 42, "name": "john"}`),
+			want: want{obs: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}},
+		},
+		"Deleted": {
+			id:   "42",
+			send: fake.Route(http.MethodGet, "https://api.example.com/v1/users/42", http.StatusNotFound, ""),
+			want: want{obs: managed.ExternalObservation{ResourceExists: false}},
+		},
+		"ServerError": {
+			id:   "42",
+			send: fake.Route(http.MethodGet, "https://api.example.com/v1/users/42", http.StatusInternalServerError, ""),
+			want: want{err: errors.Wrap(errors.New("HTTP GET request failed with status code: 500"), errObserve)},
+		},
+		"RequestFailed": {
+			id:   "42",
+			send: fake.Fail(errBoom),
+			want: want{err: errors.Wrap(errBoom, errObserve)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: tc.send}}
+			got, err := e.Observe(context.Background(), restResource(tc.id))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Observe(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.obs, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Create(t *testing.T) {
+	type want struct {
+		id  string
+		err error
+	}
+	cases := map[string]struct {
+		idPath string
+		send   fake.MockSendRequestFn
+		want   want
+	}{
+		"NumericID": {
+			idPath: ".id",
+			send:   fake.Route(http.MethodPost, "https://api.example.com/v1/users", http.StatusCreated, `{"id": 42, "name": "jane"}`),
+			want:   want{id: "42"},
+		},
+		"NestedID": {
+			idPath: ".data.uuid",
+			send:   fake.Route(http.MethodPost, "https://api.example.com/v1/users", http.StatusCreated, `{"data": {"uuid": "a1b2"}}`),
+			want:   want{id: "a1b2"},
+		},
+		"MissingID": {
+			idPath: ".id",
+			send:   fake.Route(http.MethodPost, "https://api.example.com/v1/users", http.StatusCreated, `{"name": "jane"}`),
+			want:   want{err: errors.Wrap(errors.Errorf(errExtractID, ".id"), errCreate)},
+		},
+		"Conflict": {
+			idPath: ".id",
+			send:   fake.Route(http.MethodPost, "https://api.example.com/v1/users", http.StatusConflict, ""),
+			want:   want{err: errors.Wrap(errors.New("HTTP POST request failed with status code: 409"), errCreate)},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := restResource("")
+			cr.Spec.ForProvider.IDPath = tc.idPath
+
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: tc.send}}
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Create(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.id, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("Create(...): -want external name, +got external name: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	cases := map[string]struct {
+		send fake.MockSendRequestFn
+		want error
+	}{
+		"Deleted": {
+			send: fake.Route(http.MethodDelete, "https://api.example.com/v1/users/42", http.StatusNoContent, ""),
+		},
+		"AlreadyDeleted": {
+			send: fake.Route(http.MethodDelete, "https://api.example.com/v1/users/42", http.StatusNotFound, ""),
+		},
+		"Forbidden": {
+			send: fake.Route(http.MethodDelete, "https://api.example.com/v1/users/42", http.StatusForbidden, ""),
+			want: errors.Wrap(errors.New("HTTP DELETE request failed with status code: 403"), errDelete),
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: tc.send}}
+			err := e.Delete(context.Background(), restResource("42"))
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package timestamps records in the status of managed resources when they were
+// last observed and when an operation was last performed on them.
+package timestamps
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A Recorder is a resource recording when it was last observed and when an
+// operation was last performed on it.
+type Recorder interface {
+	SetLastObservedTime(t metav1.Time)
+	GetLastOperationTime() *metav1.Time
+	SetLastOperationTime(t metav1.Time)
+}
+
+// A Connecter wraps an ExternalConnecter so that the ExternalClients it
+// produces record their successful observations and operations.
+type Connecter struct {
+	inner managed.ExternalConnecter
+	now   func() time.Time
+}
+
+// NewConnecter returns a Connecter wrapping the supplied connecter.
+func NewConnecter(inner managed.ExternalConnecter) *Connecter {
+	return &Connecter{inner: inner, now: time.Now}
+}
+
+// Connect using the wrapped connecter.
+func (c *Connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	e, err := c.inner.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	return &external{inner: e, now: c.now}, nil
+}
+
+type external struct {
+	inner managed.ExternalClient
+	now   func() time.Time
+}
+
+// Observe records the time of a successful observation. The time of a
+// successful creation is recorded here too, from the annotation the managed
+// reconciler persists: the status set while creating is overwritten when the
+// reconciler reads the resource back to annotate it.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.inner.Observe(ctx, mg)
+	if err != nil {
+		return o, err
+	}
+
+	if r, ok := mg.(Recorder); ok {
+		r.SetLastObservedTime(metav1.NewTime(e.now()))
+		if created := meta.GetExternalCreateSucceeded(mg); !created.IsZero() {
+			if last := r.GetLastOperationTime(); last == nil || last.Time.Before(created) {
+				r.SetLastOperationTime(metav1.NewTime(created))
+			}
+		}
+	}
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	c, err := e.inner.Create(ctx, mg)
+	if err == nil {
+		e.operated(mg)
+	}
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.inner.Update(ctx, mg)
+	if err == nil {
+		e.operated(mg)
+	}
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	err := e.inner.Delete(ctx, mg)
+	if err == nil {
+		e.operated(mg)
+	}
+	return err
+}
+
+// operated records the time of a successful operation.
+func (e *external) operated(mg resource.Managed) {
+	if r, ok := mg.(Recorder); ok {
+		r.SetLastOperationTime(metav1.NewTime(e.now()))
+	}
+}
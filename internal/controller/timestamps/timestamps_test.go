@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timestamps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var errBoom = errors.New("boom")
+
+type recorder struct {
+	fake.Managed
+	observed, operated *metav1.Time
+}
+
+func (r *recorder) SetLastObservedTime(t metav1.Time)  { r.observed = &t }
+func (r *recorder) GetLastOperationTime() *metav1.Time { return r.operated }
+func (r *recorder) SetLastOperationTime(t metav1.Time) { r.operated = &t }
+
+func Test_external(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-time.Minute)
+	updated := now.Add(-time.Second)
+
+	type args struct {
+		operation func(e managed.ExternalClient, mg resource.Managed) error
+		created   *time.Time
+		operated  *time.Time
+		err       error
+	}
+	type want struct {
+		observed *metav1.Time
+		operated *metav1.Time
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Observed": {
+			args: args{
+				operation: func(e managed.ExternalClient, mg resource.Managed) error {
+					_, err := e.Observe(context.Background(), mg)
+					return err
+				},
+			},
+			want: want{observed: &metav1.Time{Time: now}},
+		},
+		"ObservedAfterCreation": {
+			args: args{
+				operation: func(e managed.ExternalClient, mg resource.Managed) error {
+					_, err := e.Observe(context.Background(), mg)
+					return err
+				},
+				created: &created,
+			},
+			want: want{observed: &metav1.Time{Time: now}, operated: &metav1.Time{Time: created}},
+		},
+		"ObservedAfterUpdate": {
+			args: args{
+				operation: func(e managed.ExternalClient, mg resource.Managed) error {
+					_, err := e.Observe(context.Background(), mg)
+					return err
+				},
+				created:  &created,
+				operated: &updated,
+			},
+			want: want{observed: &metav1.Time{Time: now}, operated: &metav1.Time{Time: updated}},
+		},
+		"ObserveError": {
+			args: args{
+				operation: func(e managed.ExternalClient, mg resource.Managed) error {
+					_, err := e.Observe(context.Background(), mg)
+					return err
+				},
+				err: errBoom,
+			},
+			want: want{},
+		},
+		"Updated": {
+			args: args{
+				operation: func(e managed.ExternalClient, mg resource.Managed) error {
+					_, err := e.Update(context.Background(), mg)
+					return err
+				},
+			},
+			want: want{operated: &metav1.Time{Time: now}},
+		},
+		"Deleted": {
+			args: args{
+				operation: func(e managed.ExternalClient, mg resource.Managed) error {
+					return e.Delete(context.Background(), mg)
+				},
+			},
+			want: want{operated: &metav1.Time{Time: now}},
+		},
+		"DeleteError": {
+			args: args{
+				operation: func(e managed.ExternalClient, mg resource.Managed) error {
+					return e.Delete(context.Background(), mg)
+				},
+				err: errBoom,
+			},
+			want: want{},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			mg := &recorder{}
+			if tc.args.created != nil {
+				meta.SetExternalCreateSucceeded(mg, *tc.args.created)
+			}
+			if tc.args.operated != nil {
+				mg.operated = &metav1.Time{Time: *tc.args.operated}
+			}
+			inner := &managed.ExternalClientFns{
+				ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+					return managed.ExternalObservation{}, tc.args.err
+				},
+				UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+					return managed.ExternalUpdate{}, tc.args.err
+				},
+				DeleteFn: func(_ context.Context, _ resource.Managed) error {
+					return tc.args.err
+				},
+			}
+			e := &external{inner: inner, now: func() time.Time { return now }}
+
+			_ = tc.args.operation(e, mg)
+			if diff := cmp.Diff(tc.want.observed, mg.observed); diff != "" {
+				t.Errorf("-want last observed time, +got last observed time: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.operated, mg.operated); diff != "" {
+				t.Errorf("-want last operation time, +got last operation time: %s", diff)
+			}
+		})
+	}
+}
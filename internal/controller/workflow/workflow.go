@@ -0,0 +1,387 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/apis/workflow/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/controller/options"
+	"github.com/arielsepton/provider-http/internal/controller/requeue"
+	"github.com/arielsepton/provider-http/internal/controller/timestamps"
+	"github.com/arielsepton/provider-http/internal/jq"
+	"github.com/arielsepton/provider-http/internal/tracing"
+	"github.com/arielsepton/provider-http/internal/utils"
+)
+
+const (
+	errNotWorkflow                  = "managed resource is not a Workflow custom resource"
+	errParseParameters              = "parameters must be a JSON object"
+	errRenderURL                    = "cannot render url"
+	errRenderBody                   = "cannot render body"
+	errRenderHeaders                = "cannot render headers"
+	errOutput                       = "cannot evaluate output %s"
+	errStep                         = "%s step %s failed"
+	errObserve                      = "observe step failed"
+	errExpectedFormat               = "JQ filter should return a boolean, but returned error: %s"
+	errFailedUpdateStatusConditions = "failed updating status conditions"
+)
+
+// Setup adds a controller that reconciles Workflow managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, opts options.Options) error {
+	name := managed.ControllerName(v1alpha1.WorkflowGroupKind)
+	cps := options.ConnectionPublishers(mgr.GetClient(), mgr.GetScheme(), o)
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.WorkflowGroupVersionKind),
+		managed.WithExternalConnecter(timestamps.NewConnecter(&connector{
+			Clients: opts.Clients(mgr.GetClient()),
+			logger:  o.Logger,
+		})),
+		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithPollInterval(o.PollInterval),
+		managed.WithTimeout(opts.Timeout),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...))
+
+	schedules := []requeue.ScheduleFn{
+		requeue.PollJitter(o.PollInterval, opts.PollJitter),
+		requeue.PollInterval(o.PollInterval, opts.PollJitter, requeue.ResourcePollInterval(mgr.GetClient())),
+		opts.LoadShedder(name).Schedule,
+	}
+	requeuer := requeue.NewReconciler(r, mgr.GetClient(), newWorkflow, schedules...)
+	traced := tracing.NewReconciler(name, requeuer)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(opts.ForControllerRuntime(o, v1alpha1.WorkflowKind)).
+		WithEventFilter(resource.DesiredStateChanged()).
+		For(&v1alpha1.Workflow{}).
+		Complete(ratelimiter.NewReconciler(name, traced, o.GlobalRateLimiter))
+}
+
+func newWorkflow() resource.Managed {
+	return &v1alpha1.Workflow{}
+}
+
+type connector struct {
+	options.Clients
+	logger logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Workflow)
+	if !ok {
+		return nil, errors.New(errNotWorkflow)
+	}
+
+	l := c.logger.WithValues("workflow", cr.Name)
+
+	h, err := c.NewClient(ctx, l, cr, options.ClientParameters{
+		Kind:                  v1alpha1.WorkflowKind,
+		Auth:                  cr.Spec.ForProvider.Auth,
+		WaitTimeout:           cr.Spec.ForProvider.WaitTimeout,
+		InsecureSkipTLSVerify: cr.Spec.ForProvider.InsecureSkipTLSVerify,
+		TLS:                   cr.Spec.ForProvider.TLS,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &external{
+		localKube:     c.Kube,
+		logger:        l,
+		http:          h.Client,
+		statusCodes:   utils.StatusCodePolicy(cr.Spec.ForProvider.StatusCodes, h.ProviderConfig.Spec.StatusCodes),
+		skipTLSVerify: h.SkipTLSVerify,
+		correlationID: h.CorrelationID,
+	}, nil
+}
+
+type external struct {
+	localKube   client.Client
+	logger      logging.Logger
+	http        httpClient.Client
+	statusCodes *apisv1alpha1.StatusCodePolicy
+
+	// skipTLSVerify skips TLS certificate checks, when set by the resource or its ProviderConfig.
+	skipTLSVerify bool
+
+	// correlationID is the value of the correlation header set on the requests, recorded in the status.
+	correlationID string
+}
+
+// templateContext returns the input of the jq expressions of the steps: the parameters, the outputs recorded
+// so far and the responses of the given previous steps.
+func templateContext(cr *v1alpha1.Workflow, steps map[string]interface{}) (map[string]interface{}, error) {
+	parameters := map[string]interface{}{}
+	if cr.Spec.ForProvider.Parameters != "" {
+		if err := json.Unmarshal([]byte(cr.Spec.ForProvider.Parameters), &parameters); err != nil {
+			return nil, errors.Wrap(err, errParseParameters)
+		}
+	}
+
+	outputs := make(map[string]interface{}, len(cr.Status.Outputs))
+	for name, value := range cr.Status.Outputs {
+		outputs[name] = value
+	}
+
+	return map[string]interface{}{"parameters": parameters, "outputs": outputs, "steps": steps}, nil
+}
+
+// responseMap returns the given response as the input of jq expressions. JSON bodies are decoded.
+func responseMap(res httpClient.HttpResponse) map[string]interface{} {
+	headers := make(map[string]interface{}, len(res.Headers))
+	for name, values := range res.Headers {
+		list := make([]interface{}, 0, len(values))
+		for _, v := range values {
+			list = append(list, v)
+		}
+		headers[name] = list
+	}
+
+	var body interface{} = res.Body
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(res.Body), &decoded); err == nil {
+		body = decoded
+	}
+
+	return map[string]interface{}{"statusCode": res.StatusCode, "headers": headers, "body": body}
+}
+
+// render returns the url, body and headers of the given step.
+func render(cr *v1alpha1.Workflow, step v1alpha1.Step, tctx map[string]interface{}) (string, string, map[string][]string, error) {
+	url, err := jq.ParseString(step.URL, tctx)
+	if err != nil {
+		return "", "", nil, utils.NewRenderError(errors.Wrap(err, errRenderURL))
+	}
+
+	body := ""
+	if step.Body != "" {
+		fields, err := jq.ParseMapInterface(step.Body, tctx)
+		if err != nil {
+			return "", "", nil, utils.NewRenderError(errors.Wrap(err, errRenderBody))
+		}
+		encoded, err := json.Marshal(fields)
+		if err != nil {
+			return "", "", nil, utils.NewRenderError(errors.Wrap(err, errRenderBody))
+		}
+		body = string(encoded)
+	}
+
+	merged := make(map[string][]string, len(cr.Spec.ForProvider.Headers)+len(step.Headers))
+	for name, values := range cr.Spec.ForProvider.Headers {
+		merged[name] = values
+	}
+	for name, values := range step.Headers {
+		merged[name] = values
+	}
+	headers, err := jq.ParseMapStrings(merged, tctx)
+	if err != nil {
+		return "", "", nil, utils.NewRenderError(errors.Wrap(err, errRenderHeaders))
+	}
+
+	return url, body, headers, nil
+}
+
+// runStep sends the request of the given step and records its response and outputs, or the reason it failed,
+// in the status of the Workflow. Responses with one of the given tolerated status codes are not failures.
+func (c *external) runStep(ctx context.Context, cr *v1alpha1.Workflow, step v1alpha1.Step, tctx map[string]interface{}, tolerated ...int) (httpClient.HttpResponse, error) {
+	url, body, headers, err := render(cr, step, tctx)
+	if err != nil {
+		cr.SetError(utils.ClassifyFailure(0, err), err)
+		return httpClient.HttpResponse{}, err
+	}
+
+	details, err := c.http.SendRequest(ctx, step.Method, url, body, headers, c.skipTLSVerify)
+	cr.SetTimings(utils.Timings(details.Timings))
+	cr.SetCorrelationID(c.correlationID)
+	res := details.HttpResponse
+	if err != nil {
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, err), err)
+		return res, err
+	}
+
+	cr.SetResponse(res.StatusCode, res.Body, res.Headers)
+	for _, code := range tolerated {
+		if res.StatusCode == code {
+			return res, nil
+		}
+	}
+
+	if utils.IsFailure(c.statusCodes, res.StatusCode) {
+		err := errors.Errorf(utils.ErrStatusCode, step.Method, strconv.Itoa(res.StatusCode))
+		cr.SetError(utils.ClassifyFailure(res.StatusCode, nil), err)
+		return res, err
+	}
+
+	outputCtx := make(map[string]interface{}, len(tctx)+1)
+	for key, value := range tctx {
+		outputCtx[key] = value
+	}
+	outputCtx["response"] = responseMap(res)
+
+	for name, expression := range step.Outputs {
+		value, err := jq.ParseString(expression+" | tostring", outputCtx)
+		if err != nil {
+			err = errors.Wrapf(err, errOutput, name)
+			cr.SetError(apisv1alpha1.FailureReasonUnexpectedResponse, err)
+			return res, err
+		}
+		cr.SetOutput(name, value)
+	}
+
+	return res, nil
+}
+
+// runAction runs the given steps of the given action in order. An action that failed resumes from the step that
+// failed, provided the steps did not change.
+func (c *external) runAction(ctx context.Context, cr *v1alpha1.Workflow, action string, steps []v1alpha1.Step, tolerated ...int) error {
+	start := 0
+	if failed := cr.Status.FailedStep; failed != nil && failed.Action == action && failed.Index < len(steps) && steps[failed.Index].Name == failed.Name {
+		start = failed.Index
+	}
+
+	responses := map[string]interface{}{}
+	for i := start; i < len(steps); i++ {
+		tctx, err := templateContext(cr, responses)
+		if err != nil {
+			return err
+		}
+
+		res, err := c.runStep(ctx, cr, steps[i], tctx, tolerated...)
+		if err != nil {
+			cr.Status.FailedStep = &v1alpha1.StepReference{Action: action, Index: i, Name: steps[i].Name}
+			return errors.Wrapf(err, errStep, action, steps[i].Name)
+		}
+		responses[steps[i].Name] = responseMap(res)
+	}
+
+	cr.Status.FailedStep = nil
+	cr.SetError("", nil)
+	return nil
+}
+
+// updateStatus persists the status of the Workflow, so that the outputs recorded by an action are kept even
+// when it failed.
+func (c *external) updateStatus(ctx context.Context, cr *v1alpha1.Workflow, err error) error {
+	if updateErr := c.localKube.Status().Update(ctx, cr); updateErr != nil {
+		return errors.Wrap(updateErr, errFailedUpdateStatusConditions)
+	}
+
+	return err
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Workflow)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotWorkflow)
+	}
+
+	// Without delete steps, deleted resources are left as they are remotely.
+	if !cr.Status.Created || (meta.WasDeleted(cr) && len(cr.Spec.ForProvider.Delete) == 0) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// An update that failed is resumed.
+	upToDate := cr.Status.FailedStep == nil || cr.Status.FailedStep.Action != v1alpha1.ActionUpdate
+
+	if cr.Spec.ForProvider.Observe != nil {
+		tctx, err := templateContext(cr, map[string]interface{}{})
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+
+		res, err := c.runStep(ctx, cr, *cr.Spec.ForProvider.Observe, tctx, http.StatusNotFound, http.StatusGone)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errObserve)
+		}
+
+		if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+			cr.Status.Created = false
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+
+		if cr.Spec.ForProvider.ExpectedResponse != "" {
+			tctx["response"] = responseMap(res)
+			expected, err := jq.ParseBool(cr.Spec.ForProvider.ExpectedResponse, tctx)
+			if err != nil {
+				return managed.ExternalObservation{}, errors.Errorf(errExpectedFormat, err.Error())
+			}
+			upToDate = upToDate && expected
+		}
+	}
+
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Workflow)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotWorkflow)
+	}
+
+	err := c.runAction(ctx, cr, v1alpha1.ActionCreate, cr.Spec.ForProvider.Create)
+	cr.Status.Created = err == nil
+	return managed.ExternalCreation{}, c.updateStatus(ctx, cr, err)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.Workflow)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotWorkflow)
+	}
+
+	err := c.runAction(ctx, cr, v1alpha1.ActionUpdate, cr.Spec.ForProvider.Update)
+	return managed.ExternalUpdate{}, c.updateStatus(ctx, cr, err)
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.Workflow)
+	if !ok {
+		return errors.New(errNotWorkflow)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	err := c.runAction(ctx, cr, v1alpha1.ActionDelete, cr.Spec.ForProvider.Delete, http.StatusNotFound, http.StatusGone)
+	if err == nil {
+		cr.Status.Created = false
+	}
+	return c.updateStatus(ctx, cr, err)
+}
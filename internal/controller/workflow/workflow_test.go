@@ -0,0 +1,288 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workflow
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/arielsepton/provider-http/apis/workflow/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/clients/http/fake"
+)
+
+func workflow(modifiers ...func(cr *v1alpha1.Workflow)) *v1alpha1.Workflow {
+	cr := &v1alpha1.Workflow{
+		ObjectMeta: v1.ObjectMeta{Name: "team"},
+		Spec: v1alpha1.WorkflowSpec{
+			ForProvider: v1alpha1.WorkflowParameters{
+				Parameters: `{"baseUrl": "https://api.example.com", "name": "platform", "member": "jane"}`,
+				Create: []v1alpha1.Step{
+					{
+						Name:    "team",
+						Method:  http.MethodPost,
+						URL:     `.parameters.baseUrl + "/teams"`,
+						Body:    `{name: .parameters.name}`,
+						Outputs: map[string]string{"teamID": ".response.body.id"},
+					},
+					{
+						Name:   "member",
+						Method: http.MethodPost,
+						URL:    `.parameters.baseUrl + "/teams/" + .steps.team.body.id + "/members"`,
+						Body:   `{user: .parameters.member, team: .outputs.teamID}`,
+					},
+				},
+				Observe: &v1alpha1.Step{
+					Name:   "team",
+					Method: http.MethodGet,
+					URL:    `.parameters.baseUrl + "/teams/" + .outputs.teamID`,
+				},
+				ExpectedResponse: ".response.body.name == .parameters.name",
+			},
+		},
+	}
+	for _, m := range modifiers {
+		m(cr)
+	}
+	return cr
+}
+
+func Test_Create(t *testing.T) {
+	type want struct {
+		sent    []string
+		outputs map[string]string
+		created bool
+		failed  *v1alpha1.StepReference
+		err     error
+	}
+	cases := map[string]struct {
+		cr        *v1alpha1.Workflow
+		responses map[string]httpClient.HttpResponse
+		want      want
+	}{
+		"Success": {
+			cr: workflow(),
+			responses: map[string]httpClient.HttpResponse{
+				"POST https://api.example.com/teams":            {StatusCode: http.StatusCreated, Body: `{"id": "t1"}`},
+				"POST https://api.example.com/teams/t1/members": {StatusCode: http.StatusCreated},
+			},
+			want: want{
+				sent: []string{
+					`POST https://api.example.com/teams {"name":"platform"}`,
+					`POST https://api.example.com/teams/t1/members {"team":"t1","user":"jane"}`,
+				},
+				outputs: map[string]string{"teamID": "t1"},
+				created: true,
+			},
+		},
+		"StepFailed": {
+			cr: workflow(),
+			responses: map[string]httpClient.HttpResponse{
+				"POST https://api.example.com/teams": {StatusCode: http.StatusCreated, Body: `{"id": "t1"}`},
+			},
+			want: want{
+				sent: []string{
+					`POST https://api.example.com/teams {"name":"platform"}`,
+					`POST https://api.example.com/teams/t1/members {"team":"t1","user":"jane"}`,
+				},
+				outputs: map[string]string{"teamID": "t1"},
+				failed:  &v1alpha1.StepReference{Action: v1alpha1.ActionCreate, Index: 1, Name: "member"},
+				err:     errors.Wrapf(errors.New("HTTP POST request failed with status code: 500"), errStep, v1alpha1.ActionCreate, "member"),
+			},
+		},
+		"Resumed": {
+			cr: workflow(func(cr *v1alpha1.Workflow) {
+				cr.Spec.ForProvider.Create[1].URL = `.parameters.baseUrl + "/teams/" + .outputs.teamID + "/members"`
+				cr.Status.Outputs = map[string]string{"teamID": "t1"}
+				cr.Status.FailedStep = &v1alpha1.StepReference{Action: v1alpha1.ActionCreate, Index: 1, Name: "member"}
+			}),
+			responses: map[string]httpClient.HttpResponse{
+				"POST https://api.example.com/teams/t1/members": {StatusCode: http.StatusCreated},
+			},
+			want: want{
+				sent:    []string{`POST https://api.example.com/teams/t1/members {"team":"t1","user":"jane"}`},
+				outputs: map[string]string{"teamID": "t1"},
+				created: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var sent []string
+			e := &external{
+				localKube: &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+				logger:    logging.NewNopLogger(),
+				http:      &fake.MockClient{MockSendRequest: fake.Server(tc.responses, &sent)},
+			}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Create(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.sent, sent); diff != "" {
+				t.Errorf("Create(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.outputs, tc.cr.Status.Outputs); diff != "" {
+				t.Errorf("Create(...): -want outputs, +got outputs: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.created, tc.cr.Status.Created); diff != "" {
+				t.Errorf("Create(...): -want created, +got created: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.failed, tc.cr.Status.FailedStep); diff != "" {
+				t.Errorf("Create(...): -want failed step, +got failed step: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Observe(t *testing.T) {
+	created := func(cr *v1alpha1.Workflow) {
+		cr.Status.Created = true
+		cr.Status.Outputs = map[string]string{"teamID": "t1"}
+	}
+
+	cases := map[string]struct {
+		cr        *v1alpha1.Workflow
+		responses map[string]httpClient.HttpResponse
+		want      managed.ExternalObservation
+	}{
+		"NotCreated": {
+			cr:   workflow(),
+			want: managed.ExternalObservation{ResourceExists: false},
+		},
+		"UpToDate": {
+			cr: workflow(created),
+			responses: map[string]httpClient.HttpResponse{
+				"GET https://api.example.com/teams/t1": {StatusCode: http.StatusOK, Body: `{"id": "t1", "name": "platform"}`},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"Outdated": {
+			cr: workflow(created),
+			responses: map[string]httpClient.HttpResponse{
+				"GET https://api.example.com/teams/t1": {StatusCode: http.StatusOK, Body: `{"id": "t1", "name": "infra"}`},
+			},
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"Deleted": {
+			cr: workflow(created),
+			responses: map[string]httpClient.HttpResponse{
+				"GET https://api.example.com/teams/t1": {StatusCode: http.StatusNotFound},
+			},
+			want: managed.ExternalObservation{ResourceExists: false},
+		},
+		"UpdateFailed": {
+			cr: workflow(created, func(cr *v1alpha1.Workflow) {
+				cr.Spec.ForProvider.Observe = nil
+				cr.Status.FailedStep = &v1alpha1.StepReference{Action: v1alpha1.ActionUpdate, Index: 0, Name: "rename"}
+			}),
+			want: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var sent []string
+			e := &external{logger: logging.NewNopLogger(), http: &fake.MockClient{MockSendRequest: fake.Server(tc.responses, &sent)}}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("Observe(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Observe(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Delete(t *testing.T) {
+	deletable := func(cr *v1alpha1.Workflow) {
+		cr.Spec.ForProvider.Delete = []v1alpha1.Step{{
+			Name:   "team",
+			Method: http.MethodDelete,
+			URL:    `.parameters.baseUrl + "/teams/" + .outputs.teamID`,
+		}}
+		cr.Status.Created = true
+		cr.Status.Outputs = map[string]string{"teamID": "t1"}
+	}
+
+	type want struct {
+		created bool
+		failed  *v1alpha1.StepReference
+		err     error
+	}
+	cases := map[string]struct {
+		responses map[string]httpClient.HttpResponse
+		want      want
+	}{
+		"Deleted": {
+			responses: map[string]httpClient.HttpResponse{
+				"DELETE https://api.example.com/teams/t1": {StatusCode: http.StatusNoContent},
+			},
+			want: want{created: false},
+		},
+		"AlreadyDeleted": {
+			responses: map[string]httpClient.HttpResponse{
+				"DELETE https://api.example.com/teams/t1": {StatusCode: http.StatusNotFound},
+			},
+			want: want{created: false},
+		},
+		"StepFailed": {
+			responses: map[string]httpClient.HttpResponse{
+				"DELETE https://api.example.com/teams/t1": {StatusCode: http.StatusForbidden},
+			},
+			want: want{
+				created: true,
+				failed:  &v1alpha1.StepReference{Action: v1alpha1.ActionDelete, Index: 0, Name: "team"},
+				err:     errors.Wrapf(errors.New("HTTP DELETE request failed with status code: 403"), errStep, v1alpha1.ActionDelete, "team"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var sent []string
+			cr := workflow(deletable)
+			e := &external{
+				localKube: &test.MockClient{MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil)},
+				logger:    logging.NewNopLogger(),
+				http:      &fake.MockClient{MockSendRequest: fake.Server(tc.responses, &sent)},
+			}
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Delete(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff([]string{"DELETE https://api.example.com/teams/t1 "}, sent); diff != "" {
+				t.Errorf("Delete(...): -want requests, +got requests: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.created, cr.Status.Created); diff != "" {
+				t.Errorf("Delete(...): -want created, +got created: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.failed, cr.Status.FailedStep); diff != "" {
+				t.Errorf("Delete(...): -want failed step, +got failed step: %s", diff)
+			}
+		})
+	}
+}
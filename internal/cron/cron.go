@@ -0,0 +1,227 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cron parses cron schedules and computes their activation times.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errFieldCount = "cron schedule %q must have 5 fields: minute, hour, day of month, month and day of week"
+	errField      = "invalid %s field %q in cron schedule"
+	errNoTime     = "cron schedule %q never activates"
+
+	// searchLimit bounds the search for the next activation of schedules that match rarely, e.g. on February 29th.
+	searchLimit = 5 * 366 * 24 * time.Hour
+)
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames   = map[string]int{"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6, "jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12}
+	weekdayNames = map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6}
+)
+
+// field describes the values a field of a cron schedule can take.
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	// 7 is accepted for Sunday, as in most cron implementations.
+	{name: "day of week", min: 0, max: 7, names: weekdayNames},
+}
+
+// A Schedule is a parsed cron schedule. Its times are evaluated in UTC.
+type Schedule struct {
+	expression string
+
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+
+	// daysRestricted and weekdaysRestricted record whether the day fields are not wildcards. When both are,
+	// a day matching either of them activates the schedule.
+	daysRestricted     bool
+	weekdaysRestricted bool
+}
+
+// Parse parses a standard five field cron schedule, e.g. "0 3 * * 1-5", or one of the @yearly, @monthly,
+// @weekly, @daily and @hourly descriptors.
+func Parse(expression string) (*Schedule, error) {
+	spec := strings.TrimSpace(expression)
+	if descriptor, ok := descriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+
+	parts := strings.Fields(spec)
+	if len(parts) != len(fields) {
+		return nil, errors.Errorf(errFieldCount, expression)
+	}
+
+	sets := make([]uint64, len(fields))
+	for i, part := range parts {
+		set, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = set
+	}
+
+	// Sunday is both 0 and 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return &Schedule{
+		expression:         expression,
+		minutes:            sets[0],
+		hours:              sets[1],
+		days:               sets[2],
+		months:             sets[3],
+		weekdays:           sets[4],
+		daysRestricted:     !strings.HasPrefix(parts[2], "*"),
+		weekdaysRestricted: !strings.HasPrefix(parts[4], "*"),
+	}, nil
+}
+
+// parseField returns the set of values of a comma separated list of values, ranges and steps.
+func parseField(part string, f field) (uint64, error) {
+	var set uint64
+	for _, item := range strings.Split(part, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+		if hasStep {
+			s, err := strconv.Atoi(stepPart)
+			if err != nil || s <= 0 {
+				return 0, errors.Errorf(errField, f.name, part)
+			}
+			step = s
+		}
+
+		low, high := f.min, f.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = value(lowPart, f); err != nil {
+				return 0, errors.Errorf(errField, f.name, part)
+			}
+			if high, err = value(highPart, f); err != nil {
+				return 0, errors.Errorf(errField, f.name, part)
+			}
+		default:
+			v, err := value(rangePart, f)
+			if err != nil {
+				return 0, errors.Errorf(errField, f.name, part)
+			}
+			low = v
+			// A single value with a step, e.g. 5/15, ranges to the maximum.
+			if !hasStep {
+				high = v
+			}
+		}
+
+		if low > high {
+			return 0, errors.Errorf(errField, f.name, part)
+		}
+
+		for v := low; v <= high; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+// value parses a number or a name of the given field.
+func value(s string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.Errorf(errField, f.name, s)
+	}
+
+	return v, nil
+}
+
+// Next returns the first activation of the schedule after the given time.
+func (s *Schedule) Next(after time.Time) (time.Time, error) {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(searchLimit)
+
+	for t.Before(limit) {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, nil
+	}
+
+	return time.Time{}, errors.Errorf(errNoTime, s.expression)
+}
+
+// matchesDay checks whether the day of the given time matches the day of month and day of week fields.
+func (s *Schedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+
+	if s.daysRestricted && s.weekdaysRestricted {
+		return day || weekday
+	}
+
+	return day && weekday
+}
@@ -0,0 +1,91 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ScheduleNext(t *testing.T) {
+	// A Wednesday.
+	after := time.Date(2024, time.January, 10, 10, 30, 15, 0, time.UTC)
+
+	type want struct {
+		next time.Time
+		err  bool
+	}
+	cases := map[string]struct {
+		expression string
+		want       want
+	}{
+		"EveryMinute": {
+			expression: "* * * * *",
+			want:       want{next: time.Date(2024, time.January, 10, 10, 31, 0, 0, time.UTC)},
+		},
+		"Step": {
+			expression: "*/20 * * * *",
+			want:       want{next: time.Date(2024, time.January, 10, 10, 40, 0, 0, time.UTC)},
+		},
+		"DailyLaterToday": {
+			expression: "0 22 * * *",
+			want:       want{next: time.Date(2024, time.January, 10, 22, 0, 0, 0, time.UTC)},
+		},
+		"DailyTomorrow": {
+			expression: "@daily",
+			want:       want{next: time.Date(2024, time.January, 11, 0, 0, 0, 0, time.UTC)},
+		},
+		"WeekdayNames": {
+			expression: "0 9 * * MON-TUE",
+			want:       want{next: time.Date(2024, time.January, 15, 9, 0, 0, 0, time.UTC)},
+		},
+		"SundayAsSeven": {
+			expression: "0 0 * * 7",
+			want:       want{next: time.Date(2024, time.January, 14, 0, 0, 0, 0, time.UTC)},
+		},
+		"DayOfMonthOrWeekday": {
+			expression: "0 0 12 * FRI",
+			want:       want{next: time.Date(2024, time.January, 12, 0, 0, 0, 0, time.UTC)},
+		},
+		"List": {
+			expression: "15,45 10 * * *",
+			want:       want{next: time.Date(2024, time.January, 10, 10, 45, 0, 0, time.UTC)},
+		},
+		"LeapDay": {
+			expression: "0 0 29 FEB *",
+			want:       want{next: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		},
+		"Never": {
+			expression: "0 0 31 2 *",
+			want:       want{err: true},
+		},
+		"FieldCount": {
+			expression: "0 0 * *",
+			want:       want{err: true},
+		},
+		"OutOfRange": {
+			expression: "60 * * * *",
+			want:       want{err: true},
+		},
+		"InvalidStep": {
+			expression: "*/0 * * * *",
+			want:       want{err: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var got time.Time
+			schedule, err := Parse(tc.expression)
+			if err == nil {
+				got, err = schedule.Next(after)
+			}
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("Next(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.next, got); diff != "" {
+				t.Errorf("Next(...): -want next, +got next: %s", diff)
+			}
+		})
+	}
+}
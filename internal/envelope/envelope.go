@@ -0,0 +1,152 @@
+// Package envelope encrypts values with envelope encryption: every value is encrypted with its own random data
+// key, which is in turn encrypted with a key encryption key.
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Prefix marks encrypted values.
+	Prefix = "enc:v1:"
+
+	dataKeySize = 32
+
+	errEmptyKey         = "key encryption key is empty"
+	errGenerateDataKey  = "cannot generate data key"
+	errEncrypt          = "cannot encrypt value"
+	errNotEncrypted     = "value is not encrypted"
+	errMalformedMessage = "encrypted value is malformed"
+	errDecrypt          = "cannot decrypt value"
+)
+
+// message is the encoded form of an encrypted value.
+type message struct {
+	// Key is the data key, encrypted with the key encryption key.
+	Key []byte `json:"key"`
+
+	// Data is the value, encrypted with the data key.
+	Data []byte `json:"data"`
+}
+
+// IsEncrypted checks whether the given value was returned by Encrypt.
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+// Encrypt encrypts the given value with a new data key using AES-256-GCM, and the data key with the given key
+// encryption key. The key encryption key may have any length, it is hashed with SHA-256.
+func Encrypt(kek []byte, value string) (string, error) {
+	if len(kek) == 0 {
+		return "", errors.New(errEmptyKey)
+	}
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", errors.Wrap(err, errGenerateDataKey)
+	}
+
+	encryptedKey, err := seal(deriveKey(kek), dataKey)
+	if err != nil {
+		return "", errors.Wrap(err, errEncrypt)
+	}
+
+	data, err := seal(dataKey, []byte(value))
+	if err != nil {
+		return "", errors.Wrap(err, errEncrypt)
+	}
+
+	encoded, err := json.Marshal(message{Key: encryptedKey, Data: data})
+	if err != nil {
+		return "", errors.Wrap(err, errEncrypt)
+	}
+
+	return Prefix + base64.StdEncoding.EncodeToString(encoded), nil
+}
+
+// Decrypt decrypts a value returned by Encrypt with the same key encryption key.
+func Decrypt(kek []byte, value string) (string, error) {
+	if len(kek) == 0 {
+		return "", errors.New(errEmptyKey)
+	}
+
+	if !IsEncrypted(value) {
+		return "", errors.New(errNotEncrypted)
+	}
+
+	encoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, Prefix))
+	if err != nil {
+		return "", errors.Wrap(err, errMalformedMessage)
+	}
+
+	m := message{}
+	if err := json.Unmarshal(encoded, &m); err != nil {
+		return "", errors.Wrap(err, errMalformedMessage)
+	}
+
+	dataKey, err := open(deriveKey(kek), m.Key)
+	if err != nil {
+		return "", errors.Wrap(err, errDecrypt)
+	}
+
+	data, err := open(dataKey, m.Data)
+	if err != nil {
+		return "", errors.Wrap(err, errDecrypt)
+	}
+
+	return string(data), nil
+}
+
+// deriveKey returns an AES-256 key for a key encryption key of any length.
+func deriveKey(kek []byte) []byte {
+	sum := sha256.Sum256(kek)
+	return sum[:]
+}
+
+// seal encrypts the plaintext with AES-GCM, the random nonce is prepended to the ciphertext.
+func seal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// open decrypts a ciphertext returned by seal.
+func open(key, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New(errMalformedMessage)
+	}
+
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
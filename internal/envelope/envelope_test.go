@@ -0,0 +1,125 @@
+package envelope
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func Test_EncryptDecrypt(t *testing.T) {
+	type args struct {
+		encryptKey []byte
+		decryptKey []byte
+		value      string
+	}
+	type want struct {
+		value      string
+		encryptErr error
+		decryptErr bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RoundTrip": {
+			args: args{
+				encryptKey: []byte("key"),
+				decryptKey: []byte("key"),
+				value:      `{"id":"123","token":"secret"}`,
+			},
+			want: want{
+				value: `{"id":"123","token":"secret"}`,
+			},
+		},
+		"EmptyValue": {
+			args: args{
+				encryptKey: []byte("key"),
+				decryptKey: []byte("key"),
+			},
+			want: want{},
+		},
+		"EmptyKey": {
+			args: args{
+				value: "value",
+			},
+			want: want{
+				encryptErr: errors.New(errEmptyKey),
+			},
+		},
+		"WrongKey": {
+			args: args{
+				encryptKey: []byte("key"),
+				decryptKey: []byte("other key"),
+				value:      "value",
+			},
+			want: want{
+				decryptErr: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			encrypted, err := Encrypt(tc.args.encryptKey, tc.args.value)
+			if diff := cmp.Diff(tc.want.encryptErr, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("Encrypt(...): -want error, +got error: %s", diff)
+			}
+			if err != nil {
+				return
+			}
+			if !IsEncrypted(encrypted) || (tc.args.value != "" && strings.Contains(encrypted, tc.args.value)) {
+				t.Fatalf("Encrypt(...): value is not encrypted: %s", encrypted)
+			}
+
+			got, err := Decrypt(tc.args.decryptKey, encrypted)
+			if diff := cmp.Diff(tc.want.decryptErr, err != nil); diff != "" {
+				t.Fatalf("Decrypt(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Errorf("Decrypt(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Decrypt(t *testing.T) {
+	type args struct {
+		value string
+	}
+	type want struct {
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NotEncrypted": {
+			args: args{
+				value: `{"id":"123"}`,
+			},
+			want: want{
+				err: errors.New(errNotEncrypted),
+			},
+		},
+		"Truncated": {
+			args: args{
+				value: Prefix + "eyJrZXkiOiIiLCJkYXRhIjoiIn0=",
+			},
+			want: want{
+				err: errors.Wrap(errors.New(errMalformedMessage), errDecrypt),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := Decrypt([]byte("key"), tc.args.value)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Decrypt(...): -want error, +got error: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package jq
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+
+	"github.com/itchyny/gojq"
+	"github.com/pkg/errors"
+)
+
+const (
+	errNotString    = "%s cannot be applied to %T, it expects a string"
+	errDecodeBase64 = "base64decode cannot decode %q"
+	errEncodeJSON   = "toJson cannot encode %v"
+)
+
+const (
+	funcURLQuery     = "urlqueryescape"
+	funcPathEscape   = "pathescape"
+	funcBase64Encode = "base64encode"
+	funcBase64Decode = "base64decode"
+	funcToJSON       = "toJson"
+)
+
+// functions are the functions available to jq queries in addition to the jq builtins, e.g. to build URLs
+// holding user-provided strings safely.
+var functions = []gojq.CompilerOption{
+	gojq.WithFunction(funcURLQuery, 0, 0, stringFunction(funcURLQuery, func(s string) (any, error) {
+		return url.QueryEscape(s), nil
+	})),
+	gojq.WithFunction(funcPathEscape, 0, 0, stringFunction(funcPathEscape, func(s string) (any, error) {
+		return url.PathEscape(s), nil
+	})),
+	gojq.WithFunction(funcBase64Encode, 0, 0, stringFunction(funcBase64Encode, func(s string) (any, error) {
+		return base64.StdEncoding.EncodeToString([]byte(s)), nil
+	})),
+	gojq.WithFunction(funcBase64Decode, 0, 0, stringFunction(funcBase64Decode, func(s string) (any, error) {
+		decoded, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, errors.Errorf(errDecodeBase64, s)
+		}
+		return string(decoded), nil
+	})),
+	gojq.WithFunction(funcToJSON, 0, 0, func(v any, _ []any) any {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return errors.Errorf(errEncodeJSON, v)
+		}
+		return string(encoded)
+	}),
+}
+
+// stringFunction returns a jq function applying the given function to its string input.
+func stringFunction(name string, f func(string) (any, error)) func(any, []any) any {
+	return func(v any, _ []any) any {
+		s, ok := v.(string)
+		if !ok {
+			return errors.Errorf(errNotString, name, v)
+		}
+
+		result, err := f(s)
+		if err != nil {
+			return err
+		}
+		return result
+	}
+}
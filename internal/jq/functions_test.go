@@ -0,0 +1,74 @@
+package jq
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_functions(t *testing.T) {
+	type want struct {
+		result interface{}
+		err    bool
+	}
+	cases := map[string]struct {
+		jqQuery string
+		want    want
+	}{
+		"URLQueryEscape": {
+			jqQuery: `(.payload.baseUrl + "?q=" + ("john doe&co" | urlqueryescape))`,
+			want: want{
+				result: "https://api.example.com/users?q=john+doe%26co",
+			},
+		},
+		"PathEscape": {
+			jqQuery: `(.payload.baseUrl + "/" + ("john/doe" | pathescape))`,
+			want: want{
+				result: "https://api.example.com/users/john%2Fdoe",
+			},
+		},
+		"Base64Encode": {
+			jqQuery: `.payload.body.username | base64encode`,
+			want: want{
+				result: "am9obl9kb2U=",
+			},
+		},
+		"Base64Decode": {
+			jqQuery: `"am9obl9kb2U=" | base64decode`,
+			want: want{
+				result: "john_doe",
+			},
+		},
+		"Base64DecodeInvalid": {
+			jqQuery: `"not base64!" | base64decode`,
+			want: want{
+				err: true,
+			},
+		},
+		"ToJson": {
+			jqQuery: `.response.body | toJson`,
+			want: want{
+				result: `{"id":"123"}`,
+			},
+		},
+		"NotString": {
+			jqQuery: `.response.statusCode | pathescape`,
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := runJQQuery(tc.jqQuery, testJQObject)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("runJQQuery(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("runJQQuery(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
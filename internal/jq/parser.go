@@ -1,6 +1,7 @@
 package jq
 
 import (
+	"encoding/json"
 	"fmt"
 	"sync"
 
@@ -13,8 +14,10 @@ const (
 	errStringParseFailed = "failed to parse string: %s"
 	errResultParseFailed = "failed to parse result on jq query: %s"
 	errMapParseFailed    = "failed to parse map: %s"
+	errArrayParseFailed  = "failed to parse array: %s"
 	errQueryFailed       = "query should return at least one value, failed on: %s"
 	errInvalidQuery      = "failed to parse given mapping - %s jq error: %s"
+	errEncodeResult      = "failed to encode result as JSON: %s"
 )
 
 var mutex = &sync.Mutex{}
@@ -25,8 +28,13 @@ func runJQQuery(jqQuery string, obj interface{}) (interface{}, error) {
 		return nil, err
 	}
 
+	code, err := gojq.Compile(query, functions...)
+	if err != nil {
+		return nil, errors.Errorf(errInvalidQuery, jqQuery, err.Error())
+	}
+
 	mutex.Lock()
-	queryRes, ok := query.Run(obj).Next()
+	queryRes, ok := code.Run(obj).Next()
 	mutex.Unlock()
 
 	if !ok {
@@ -41,6 +49,11 @@ func runJQQuery(jqQuery string, obj interface{}) (interface{}, error) {
 	return queryRes, nil
 }
 
+// Parse returns the result of the given jq query as it is.
+func Parse(jqQuery string, obj interface{}) (interface{}, error) {
+	return runJQQuery(jqQuery, obj)
+}
+
 func ParseString(jqQuery string, obj interface{}) (string, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
 	if err != nil {
@@ -55,6 +68,26 @@ func ParseString(jqQuery string, obj interface{}) (string, error) {
 	return str, nil
 }
 
+// ParseText returns the result of the given jq query as text: strings as they are, and other values encoded as
+// JSON.
+func ParseText(jqQuery string, obj interface{}) (string, error) {
+	queryRes, err := runJQQuery(jqQuery, obj)
+	if err != nil {
+		return "", err
+	}
+
+	if str, ok := queryRes.(string); ok {
+		return str, nil
+	}
+
+	encoded, err := json.Marshal(queryRes)
+	if err != nil {
+		return "", errors.Errorf(errEncodeResult, fmt.Sprint(queryRes))
+	}
+
+	return string(encoded), nil
+}
+
 func ParseBool(jqQuery string, obj interface{}) (bool, error) {
 	queryRes, err := runJQQuery(jqQuery, obj)
 	if err != nil {
@@ -88,6 +121,20 @@ func ParseMapInterface(jqQuery string, obj interface{}) (map[string]interface{},
 	return nil, errors.Errorf(errMapParseFailed, fmt.Sprint(queryRes))
 }
 
+func ParseArray(jqQuery string, obj interface{}) ([]interface{}, error) {
+	queryRes, err := runJQQuery(jqQuery, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	array, ok := queryRes.([]interface{})
+	if !ok {
+		return nil, errors.Errorf(errArrayParseFailed, fmt.Sprint(queryRes))
+	}
+
+	return array, nil
+}
+
 func ParseMapStrings(keyToJQQueries map[string][]string, obj interface{}) (map[string][]string, error) {
 	result := make(map[string][]string, len(keyToJQQueries))
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 )
 
 var testJQObject = map[string]any{
@@ -115,6 +116,54 @@ func Test_ParseString(t *testing.T) {
 	}
 }
 
+func Test_ParseText(t *testing.T) {
+	type args struct {
+		jqQuery string
+		obj     interface{}
+	}
+	type want struct {
+		result interface{}
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"SuccessString": {
+			args: args{
+				jqQuery: `.payload.body.username`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: `john_doe`,
+				err:    nil,
+			},
+		},
+		"SuccessArray": {
+			args: args{
+				jqQuery: `[.payload.body.username, .response.statusCode]`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: `["john_doe",200]`,
+				err:    nil,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseText(tc.args.jqQuery, tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ParseText(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ParseText(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_ParseMapInterface(t *testing.T) {
 	type args struct {
 		jqQuery string
@@ -153,6 +202,54 @@ func Test_ParseMapInterface(t *testing.T) {
 	}
 }
 
+func Test_ParseArray(t *testing.T) {
+	type args struct {
+		jqQuery string
+		obj     interface{}
+	}
+	type want struct {
+		result []interface{}
+		err    error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Success": {
+			args: args{
+				jqQuery: `[.mappings[].method]`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: []any{"POST", "GET", "PUT", "DELETE"},
+				err:    nil,
+			},
+		},
+		"NotAnArray": {
+			args: args{
+				jqQuery: `.payload.baseUrl`,
+				obj:     testJQObject,
+			},
+			want: want{
+				result: nil,
+				err:    errors.Errorf(errArrayParseFailed, "https://api.example.com/users"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := ParseArray(tc.args.jqQuery, tc.args.obj)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("ParseArray(...): -want error, +got error: %s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ParseArray(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_ParseMapStrings(t *testing.T) {
 	// implemented on Test_ApplyJQOnMapStrings
 }
@@ -0,0 +1,56 @@
+package json
+
+import "sort"
+
+// Diff lists the JSON pointers of the fields that differ between an observed and a desired object.
+type Diff struct {
+	// Added are the fields of the observed object that the desired object does not hold.
+	Added []string
+	// Changed are the fields holding different values in both objects.
+	Changed []string
+	// Removed are the fields of the desired object that the observed object does not hold.
+	Removed []string
+}
+
+// Empty checks whether the objects compared by the diff hold the same fields.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// Compare returns the diff between the observed and the desired objects, recursing into the objects they both
+// hold. Each list of fields is sorted.
+func Compare(observed, desired map[string]interface{}) Diff {
+	diff := Diff{}
+	compare("", observed, desired, &diff)
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Changed)
+	sort.Strings(diff.Removed)
+
+	return diff
+}
+
+func compare(prefix string, observed, desired map[string]interface{}, diff *Diff) {
+	for key, value := range desired {
+		path := prefix + "/" + escapePointer(key)
+		observedValue, exists := observed[key]
+		if !exists {
+			diff.Removed = append(diff.Removed, path)
+			continue
+		}
+
+		observedObject, observedIsObject := observedValue.(map[string]interface{})
+		object, isObject := value.(map[string]interface{})
+		switch {
+		case observedIsObject && isObject:
+			compare(path, observedObject, object, diff)
+		case !deepEqual(observedValue, value):
+			diff.Changed = append(diff.Changed, path)
+		}
+	}
+
+	for key := range observed {
+		if _, exists := desired[key]; !exists {
+			diff.Added = append(diff.Added, prefix+"/"+escapePointer(key))
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package json
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Operation is an operation of a JSON Patch (RFC 6902).
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MergePatch returns the JSON merge patch (RFC 7386) setting the fields of desired that differ from current.
+// Fields of current that desired does not hold are left as they are.
+func MergePatch(current, desired map[string]interface{}) map[string]interface{} {
+	patch := map[string]interface{}{}
+	for key, value := range desired {
+		currentValue, exists := current[key]
+		if exists && deepEqual(currentValue, value) {
+			continue
+		}
+
+		currentObject, currentIsObject := currentValue.(map[string]interface{})
+		object, isObject := value.(map[string]interface{})
+		if currentIsObject && isObject {
+			patch[key] = MergePatch(currentObject, object)
+			continue
+		}
+
+		patch[key] = value
+	}
+
+	return patch
+}
+
+// JSONPatch returns the JSON Patch (RFC 6902) operations setting the fields of desired that differ from current.
+// Fields of current that desired does not hold are left as they are.
+func JSONPatch(current, desired map[string]interface{}) []Operation {
+	return jsonPatch("", current, desired)
+}
+
+func jsonPatch(prefix string, current, desired map[string]interface{}) []Operation {
+	keys := make([]string, 0, len(desired))
+	for key := range desired {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	operations := []Operation{}
+	for _, key := range keys {
+		value := desired[key]
+		path := prefix + "/" + escapePointer(key)
+
+		currentValue, exists := current[key]
+		if !exists {
+			operations = append(operations, Operation{Op: "add", Path: path, Value: value})
+			continue
+		}
+
+		if deepEqual(currentValue, value) {
+			continue
+		}
+
+		currentObject, currentIsObject := currentValue.(map[string]interface{})
+		object, isObject := value.(map[string]interface{})
+		if currentIsObject && isObject {
+			operations = append(operations, jsonPatch(path, currentObject, object)...)
+			continue
+		}
+
+		operations = append(operations, Operation{Op: "replace", Path: path, Value: value})
+	}
+
+	return operations
+}
+
+// escapePointer escapes a key as a reference token of a JSON Pointer (RFC 6901).
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+// unescapePointer unescapes a reference token of a JSON Pointer (RFC 6901).
+func unescapePointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+// RemovePointer removes the value the given JSON Pointer (RFC 6901) refers to from object. Pointers referring to
+// values object does not hold are ignored.
+func RemovePointer(object map[string]interface{}, pointer string) {
+	removePointer(object, strings.Split(strings.TrimPrefix(pointer, "/"), "/"))
+}
+
+func removePointer(value interface{}, tokens []string) interface{} {
+	token := unescapePointer(tokens[0])
+	last := len(tokens) == 1
+
+	switch value := value.(type) {
+	case map[string]interface{}:
+		child, exists := value[token]
+		if !exists {
+			return value
+		}
+		if last {
+			delete(value, token)
+			return value
+		}
+		value[token] = removePointer(child, tokens[1:])
+		return value
+	case []interface{}:
+		index, err := strconv.Atoi(token)
+		if err != nil || index < 0 || index >= len(value) {
+			return value
+		}
+		if last {
+			return append(value[:index:index], value[index+1:]...)
+		}
+		value[index] = removePointer(value[index], tokens[1:])
+		return value
+	}
+
+	return value
+}
@@ -0,0 +1,207 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var (
+	testCurrent = map[string]interface{}{
+		"id":       "123",
+		"username": "john_doe",
+		"settings": map[string]interface{}{"theme": "dark", "language": "en"},
+	}
+	testDesired = map[string]interface{}{
+		"username": "john_doe_new_username",
+		"email":    "john.doe@example.com",
+		"settings": map[string]interface{}{"theme": "light", "language": "en"},
+	}
+)
+
+func Test_MergePatch(t *testing.T) {
+	cases := map[string]struct {
+		current map[string]interface{}
+		desired map[string]interface{}
+		want    map[string]interface{}
+	}{
+		"ChangedFields": {
+			current: testCurrent,
+			desired: testDesired,
+			want: map[string]interface{}{
+				"username": "john_doe_new_username",
+				"email":    "john.doe@example.com",
+				"settings": map[string]interface{}{"theme": "light"},
+			},
+		},
+		"UpToDate": {
+			current: testCurrent,
+			desired: map[string]interface{}{"username": "john_doe"},
+			want:    map[string]interface{}{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := MergePatch(tc.current, tc.desired)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("MergePatch(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_JSONPatch(t *testing.T) {
+	cases := map[string]struct {
+		current map[string]interface{}
+		desired map[string]interface{}
+		want    []Operation
+	}{
+		"ChangedFields": {
+			current: testCurrent,
+			desired: testDesired,
+			want: []Operation{
+				{Op: "add", Path: "/email", Value: "john.doe@example.com"},
+				{Op: "replace", Path: "/settings/theme", Value: "light"},
+				{Op: "replace", Path: "/username", Value: "john_doe_new_username"},
+			},
+		},
+		"EscapedKeys": {
+			current: map[string]interface{}{},
+			desired: map[string]interface{}{"a/b~c": "value"},
+			want: []Operation{
+				{Op: "add", Path: "/a~1b~0c", Value: "value"},
+			},
+		},
+		"UpToDate": {
+			current: testCurrent,
+			desired: map[string]interface{}{"username": "john_doe"},
+			want:    []Operation{},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := JSONPatch(tc.current, tc.desired)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("JSONPatch(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_RemovePointer(t *testing.T) {
+	type args struct {
+		pointer string
+	}
+	type want struct {
+		object map[string]interface{}
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Field": {
+			args: args{
+				pointer: "/etag",
+			},
+			want: want{
+				object: map[string]interface{}{
+					"name":     "john_doe",
+					"metadata": map[string]interface{}{"lastModified": "2024-01-01T00:00:00Z", "a/b": "c"},
+					"items":    []interface{}{map[string]interface{}{"id": "1"}, map[string]interface{}{"id": "2"}},
+				},
+			},
+		},
+		"NestedEscapedField": {
+			args: args{
+				pointer: "/metadata/a~1b",
+			},
+			want: want{
+				object: map[string]interface{}{
+					"name":     "john_doe",
+					"etag":     "abc",
+					"metadata": map[string]interface{}{"lastModified": "2024-01-01T00:00:00Z"},
+					"items":    []interface{}{map[string]interface{}{"id": "1"}, map[string]interface{}{"id": "2"}},
+				},
+			},
+		},
+		"ArrayElement": {
+			args: args{
+				pointer: "/items/0",
+			},
+			want: want{
+				object: map[string]interface{}{
+					"name":     "john_doe",
+					"etag":     "abc",
+					"metadata": map[string]interface{}{"lastModified": "2024-01-01T00:00:00Z", "a/b": "c"},
+					"items":    []interface{}{map[string]interface{}{"id": "2"}},
+				},
+			},
+		},
+		"Missing": {
+			args: args{
+				pointer: "/metadata/createdAt/seconds",
+			},
+			want: want{
+				object: map[string]interface{}{
+					"name":     "john_doe",
+					"etag":     "abc",
+					"metadata": map[string]interface{}{"lastModified": "2024-01-01T00:00:00Z", "a/b": "c"},
+					"items":    []interface{}{map[string]interface{}{"id": "1"}, map[string]interface{}{"id": "2"}},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			object := map[string]interface{}{
+				"name":     "john_doe",
+				"etag":     "abc",
+				"metadata": map[string]interface{}{"lastModified": "2024-01-01T00:00:00Z", "a/b": "c"},
+				"items":    []interface{}{map[string]interface{}{"id": "1"}, map[string]interface{}{"id": "2"}},
+			}
+			RemovePointer(object, tc.args.pointer)
+			if diff := cmp.Diff(tc.want.object, object); diff != "" {
+				t.Errorf("RemovePointer(...): -want object, +got object: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Compare(t *testing.T) {
+	cases := map[string]struct {
+		observed map[string]interface{}
+		desired  map[string]interface{}
+		want     Diff
+	}{
+		"Equal": {
+			observed: testDesired,
+			desired:  testDesired,
+			want:     Diff{},
+		},
+		"Nested": {
+			observed: testCurrent,
+			desired:  testDesired,
+			want: Diff{
+				Added:   []string{"/id"},
+				Changed: []string{"/settings/theme", "/username"},
+				Removed: []string{"/email"},
+			},
+		},
+		"ObjectReplaced": {
+			observed: map[string]interface{}{"settings": "default"},
+			desired:  map[string]interface{}{"settings": map[string]interface{}{"theme": "dark"}},
+			want: Diff{
+				Changed: []string{"/settings"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := Compare(tc.observed, tc.desired)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Compare(...): -want diff, +got diff: %s", diff)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package json
 import (
 	"bytes"
 	"encoding/json"
+	"sort"
 )
 
 func Contains(container, containee map[string]interface{}) bool {
@@ -14,6 +15,60 @@ func Contains(container, containee map[string]interface{}) bool {
 	return true
 }
 
+// ExtraFields returns the sorted keys of container that are not set in containee.
+func ExtraFields(container, containee map[string]interface{}) []string {
+	var extra []string
+	for key := range container {
+		if _, exists := containee[key]; !exists {
+			extra = append(extra, key)
+		}
+	}
+	sort.Strings(extra)
+
+	return extra
+}
+
+// Normalization selects the values Normalize replaces.
+type Normalization struct {
+	// BooleanStrings replaces strings holding booleans with the booleans.
+	BooleanStrings bool
+	// NumericStrings replaces strings holding numbers with the numbers.
+	NumericStrings bool
+	// NullAsAbsent removes fields set to null.
+	NullAsAbsent bool
+}
+
+// Normalize returns the given decoded JSON value with the values selected by normalization replaced, recursively.
+func Normalize(value interface{}, normalization Normalization) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(value))
+		for key, field := range value {
+			if field == nil && normalization.NullAsAbsent {
+				continue
+			}
+			normalized[key] = Normalize(field, normalization)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(value))
+		for i, element := range value {
+			normalized[i] = Normalize(element, normalization)
+		}
+		return normalized
+	case string:
+		if normalization.BooleanStrings && (value == "true" || value == "false") {
+			return value == "true"
+		}
+		var number float64
+		if normalization.NumericStrings && json.Unmarshal([]byte(value), &number) == nil {
+			return number
+		}
+	}
+
+	return value
+}
+
 func IsJSONString(jsonStr string) bool {
 	var js map[string]interface{}
 	return json.Unmarshal([]byte(jsonStr), &js) == nil
@@ -44,6 +99,26 @@ func ConvertJSONStringsToMaps(merged *map[string]interface{}) {
 	}
 }
 
+// Merge returns a copy of base with the fields of overlay set over it, merging nested objects recursively.
+func Merge(base, overlay map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overlay))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range overlay {
+		baseObject, baseIsObject := merged[key].(map[string]interface{})
+		overlayObject, overlayIsObject := value.(map[string]interface{})
+		if baseIsObject && overlayIsObject {
+			merged[key] = Merge(baseObject, overlayObject)
+			continue
+		}
+		merged[key] = value
+	}
+
+	return merged
+}
+
 func StructToMap(obj interface{}) (newMap map[string]interface{}, err error) {
 	data, err := json.Marshal(obj) // Convert to a json string
 
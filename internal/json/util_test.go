@@ -98,6 +98,94 @@ func Test_Contains(t *testing.T) {
 	}
 }
 
+func Test_ExtraFields(t *testing.T) {
+	type args struct {
+		container map[string]interface{}
+		containee map[string]interface{}
+	}
+	type want struct {
+		result []string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoExtraFields": {
+			args: args{
+				container: map[string]any{"username": "john_doe"},
+				containee: map[string]any{"email": "john.doe@example.com", "username": "john_doe"},
+			},
+			want: want{},
+		},
+		"ExtraFields": {
+			args: args{
+				container: map[string]any{"username": "john_doe", "id": "123", "createdAt": "2024-01-01T00:00:00Z"},
+				containee: map[string]any{"username": "john_doe"},
+			},
+			want: want{
+				result: []string{"createdAt", "id"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ExtraFields(tc.args.container, tc.args.containee)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("ExtraFields(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Normalize(t *testing.T) {
+	value := map[string]interface{}{
+		"enabled": "true",
+		"count":   "1",
+		"name":    "1st",
+		"owner":   nil,
+		"items":   []interface{}{map[string]interface{}{"active": "false", "parent": nil}},
+	}
+
+	type args struct {
+		normalization Normalization
+	}
+	type want struct {
+		result interface{}
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoNormalization": {
+			args: args{},
+			want: want{
+				result: value,
+			},
+		},
+		"Normalized": {
+			args: args{
+				normalization: Normalization{BooleanStrings: true, NumericStrings: true, NullAsAbsent: true},
+			},
+			want: want{
+				result: map[string]interface{}{
+					"enabled": true,
+					"count":   float64(1),
+					"name":    "1st",
+					"items":   []interface{}{map[string]interface{}{"active": false}},
+				},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Normalize(value, tc.args.normalization)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Fatalf("Normalize(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
 func Test_IsJSONString(t *testing.T) {
 	type args struct {
 		jsonStr string
@@ -277,3 +365,41 @@ func Test_StructToMap(t *testing.T) {
 		})
 	}
 }
+
+func Test_Merge(t *testing.T) {
+	cases := map[string]struct {
+		base    map[string]interface{}
+		overlay map[string]interface{}
+		want    map[string]interface{}
+	}{
+		"NestedObjectsMerged": {
+			base: map[string]interface{}{
+				"username": "john_doe",
+				"settings": map[string]interface{}{"theme": "dark", "language": "en"},
+			},
+			overlay: map[string]interface{}{
+				"password": "secret",
+				"settings": map[string]interface{}{"theme": "light"},
+			},
+			want: map[string]interface{}{
+				"username": "john_doe",
+				"password": "secret",
+				"settings": map[string]interface{}{"theme": "light", "language": "en"},
+			},
+		},
+		"ObjectReplaced": {
+			base:    map[string]interface{}{"settings": map[string]interface{}{"theme": "dark"}},
+			overlay: map[string]interface{}{"settings": "default"},
+			want:    map[string]interface{}{"settings": "default"},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := Merge(tc.base, tc.overlay)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("Merge(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Package jsonschema validates JSON documents against JSON Schemas.
+package jsonschema
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+const (
+	errParseSchema   = "cannot parse JSON Schema"
+	errParseDocument = "cannot parse JSON document"
+)
+
+// Parse parses the given JSON Schema, encoded as JSON.
+func Parse(schema string) (*spec.Schema, error) {
+	parsed := &spec.Schema{}
+	if err := json.Unmarshal([]byte(schema), parsed); err != nil {
+		return nil, errors.Wrap(err, errParseSchema)
+	}
+
+	return parsed, nil
+}
+
+// Validate checks that the given JSON document matches the given JSON Schema.
+func Validate(schema, document string) error {
+	parsed, err := Parse(schema)
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(document), &value); err != nil {
+		return errors.Wrap(err, errParseDocument)
+	}
+
+	return validate.AgainstSchema(parsed, value, strfmt.Default)
+}
@@ -0,0 +1,84 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_Validate(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["id", "name"],
+		"properties": {
+			"id": {"type": "string"},
+			"name": {"type": "string"},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`
+
+	type args struct {
+		schema   string
+		document string
+	}
+	type want struct {
+		err bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Valid": {
+			args: args{
+				schema:   schema,
+				document: `{"id":"123","name":"john_doe","tags":["admin"],"createdAt":"2024-01-01T00:00:00Z"}`,
+			},
+			want: want{},
+		},
+		"MissingField": {
+			args: args{
+				schema:   schema,
+				document: `{"id":"123"}`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"WrongType": {
+			args: args{
+				schema:   schema,
+				document: `{"id":123,"name":"john_doe"}`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"InvalidSchema": {
+			args: args{
+				schema:   `{"type": "object",`,
+				document: `{"id":"123","name":"john_doe"}`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"InvalidDocument": {
+			args: args{
+				schema:   schema,
+				document: `not json`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			err := Validate(tc.args.schema, tc.args.document)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("Validate(...): -want error, +got error: %s (%v)", diff, err)
+			}
+		})
+	}
+}
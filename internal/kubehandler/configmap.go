@@ -0,0 +1,152 @@
+package kubehandler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errGetConfigMap      = "cannot get configmap %s/%s"
+	errConfigMapKeyEmpty = "configmap %s/%s has no value for key %s"
+	errCreateConfigMap   = "cannot create configmap %s/%s"
+	errUpdateConfigMap   = "cannot update configmap %s/%s"
+	errDeleteConfigMap   = "cannot delete configmap %s/%s"
+)
+
+// GetConfigMapValue returns the value of the key of the given ConfigMap, looked up in its data and then in
+// its binaryData.
+func GetConfigMapValue(ctx context.Context, kube client.Client, namespace, name, key string) ([]byte, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap); err != nil {
+		return nil, errors.Wrapf(err, errGetConfigMap, namespace, name)
+	}
+
+	if value, ok := configMap.Data[key]; ok && value != "" {
+		return []byte(value), nil
+	}
+
+	if value, ok := configMap.BinaryData[key]; ok && len(value) > 0 {
+		return value, nil
+	}
+
+	return nil, errors.Errorf(errConfigMapKeyEmpty, namespace, name, key)
+}
+
+// OpenConfigMapValue returns a reader of the value of the key of the given ConfigMap, looked up in its data and
+// then in its binaryData. The value is read straight from the ConfigMap, without being copied.
+func OpenConfigMapValue(ctx context.Context, kube client.Client, namespace, name, key string) (io.Reader, error) {
+	configMap := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap); err != nil {
+		return nil, errors.Wrapf(err, errGetConfigMap, namespace, name)
+	}
+
+	if value, ok := configMap.Data[key]; ok && value != "" {
+		return strings.NewReader(value), nil
+	}
+
+	if value, ok := configMap.BinaryData[key]; ok && len(value) > 0 {
+		return bytes.NewReader(value), nil
+	}
+
+	return nil, errors.Errorf(errConfigMapKeyEmpty, namespace, name, key)
+}
+
+// SetConfigMapValue sets the key of the ConfigMap with the given namespace and name to the given value, creating
+// the ConfigMap when it does not exist. Values that are valid UTF-8 are stored in its data, others in its
+// binaryData.
+func SetConfigMapValue(ctx context.Context, kube client.Client, namespace, name, key string, value []byte) error {
+	return SetConfigMapData(ctx, kube, namespace, name, map[string][]byte{key: value})
+}
+
+// SetConfigMapData sets the given keys of the ConfigMap with the given namespace and name, creating the ConfigMap
+// when it does not exist. Values that are valid UTF-8 are stored in its data, others in its binaryData. Its other
+// keys are left as they are, and it is not updated when no value changes.
+func SetConfigMapData(ctx context.Context, kube client.Client, namespace, name string, data map[string][]byte) error {
+	configMap := &corev1.ConfigMap{}
+	err := kube.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap)
+	notFound := kerrors.IsNotFound(err)
+	if err != nil && !notFound {
+		return errors.Wrapf(err, errGetConfigMap, namespace, name)
+	}
+
+	if notFound {
+		configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+
+	changed := notFound
+	for key, value := range data {
+		if setConfigMapKey(configMap, key, value) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if notFound {
+		return errors.Wrapf(kube.Create(ctx, configMap), errCreateConfigMap, namespace, name)
+	}
+
+	return errors.Wrapf(kube.Update(ctx, configMap), errUpdateConfigMap, namespace, name)
+}
+
+// setConfigMapKey sets the key of the ConfigMap to the given value, and returns whether it changed.
+func setConfigMapKey(configMap *corev1.ConfigMap, key string, value []byte) bool {
+	if utf8.Valid(value) {
+		if current, ok := configMap.Data[key]; ok && current == string(value) {
+			if _, binary := configMap.BinaryData[key]; !binary {
+				return false
+			}
+		}
+	} else if current, ok := configMap.BinaryData[key]; ok && bytes.Equal(current, value) {
+		if _, text := configMap.Data[key]; !text {
+			return false
+		}
+	}
+
+	delete(configMap.Data, key)
+	delete(configMap.BinaryData, key)
+	if utf8.Valid(value) {
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[key] = string(value)
+	} else {
+		if configMap.BinaryData == nil {
+			configMap.BinaryData = map[string][]byte{}
+		}
+		configMap.BinaryData[key] = value
+	}
+	return true
+}
+
+// RemoveConfigMapValue removes the key of the ConfigMap with the given namespace and name, and deletes the
+// ConfigMap when no key remains.
+func RemoveConfigMapValue(ctx context.Context, kube client.Client, namespace, name, key string) error {
+	configMap := &corev1.ConfigMap{}
+	err := kube.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, configMap)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, errGetConfigMap, namespace, name)
+	}
+
+	delete(configMap.Data, key)
+	delete(configMap.BinaryData, key)
+	if len(configMap.Data) == 0 && len(configMap.BinaryData) == 0 {
+		return errors.Wrapf(client.IgnoreNotFound(kube.Delete(ctx, configMap)), errDeleteConfigMap, namespace, name)
+	}
+
+	return errors.Wrapf(kube.Update(ctx, configMap), errUpdateConfigMap, namespace, name)
+}
@@ -0,0 +1,141 @@
+package kubehandler
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func Test_GetConfigMapValue(t *testing.T) {
+	type args struct {
+		kube client.Client
+	}
+	type want struct {
+		value []byte
+		err   error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"GetFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errGetConfigMap, "default", "payload"),
+			},
+		},
+		"KeyMissing": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			},
+			want: want{
+				err: errors.Errorf(errConfigMapKeyEmpty, "default", "payload", "body.json"),
+			},
+		},
+		"Data": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.ConfigMap).Data = map[string]string{"body.json": `{"key": "value"}`}
+					return nil
+				})},
+			},
+			want: want{
+				value: []byte(`{"key": "value"}`),
+			},
+		},
+		"BinaryData": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.ConfigMap).BinaryData = map[string][]byte{"body.json": {0x1f, 0x8b}}
+					return nil
+				})},
+			},
+			want: want{
+				value: []byte{0x1f, 0x8b},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := GetConfigMapValue(context.Background(), tc.args.kube, "default", "payload", "body.json")
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GetConfigMapValue(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Errorf("GetConfigMapValue(...): -want value, +got value: %s", diff)
+			}
+
+			reader, gotErr := OpenConfigMapValue(context.Background(), tc.args.kube, "default", "payload", "body.json")
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("OpenConfigMapValue(...): -want error, +got error: %s", diff)
+			}
+			if reader == nil {
+				return
+			}
+			read, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("OpenConfigMapValue(...): cannot read value: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.value, read); diff != "" {
+				t.Errorf("OpenConfigMapValue(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetConfigMapValue(t *testing.T) {
+	type want struct {
+		data       map[string]string
+		binaryData map[string][]byte
+	}
+	cases := map[string]struct {
+		existing *corev1.ConfigMap
+		value    []byte
+		want     want
+	}{
+		"Text": {
+			existing: &corev1.ConfigMap{BinaryData: map[string][]byte{"bundle": {0x1f, 0x8b}}},
+			value:    []byte("text"),
+			want:     want{data: map[string]string{"bundle": "text"}, binaryData: map[string][]byte{}},
+		},
+		"Binary": {
+			existing: &corev1.ConfigMap{Data: map[string]string{"bundle": "text", "other": "value"}},
+			value:    []byte{0x1f, 0x8b},
+			want:     want{data: map[string]string{"other": "value"}, binaryData: map[string][]byte{"bundle": {0x1f, 0x8b}}},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			var got *corev1.ConfigMap
+			kube := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					tc.existing.DeepCopyInto(obj.(*corev1.ConfigMap))
+					return nil
+				}),
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					got = obj.(*corev1.ConfigMap)
+					return nil
+				},
+			}
+			if err := SetConfigMapValue(context.Background(), kube, "default", "payload", "bundle", tc.value); err != nil {
+				t.Fatalf("SetConfigMapValue(...): unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.data, got.Data); diff != "" {
+				t.Errorf("SetConfigMapValue(...): -want data, +got data: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.binaryData, got.BinaryData); diff != "" {
+				t.Errorf("SetConfigMapValue(...): -want binaryData, +got binaryData: %s", diff)
+			}
+		})
+	}
+}
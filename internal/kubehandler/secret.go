@@ -0,0 +1,107 @@
+package kubehandler
+
+import (
+	"bytes"
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	errGetSecret      = "cannot get secret %s/%s"
+	errSecretKeyEmpty = "secret %s/%s has no value for key %s"
+	errCreateSecret   = "cannot create secret %s/%s"
+	errUpdateSecret   = "cannot update secret %s/%s"
+	errDeleteSecret   = "cannot delete secret %s/%s"
+)
+
+// GetSecretValue returns the value of the key of a Secret referenced by the given selector.
+func GetSecretValue(ctx context.Context, kube client.Client, ref xpv1.SecretKeySelector) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+		return nil, errors.Wrapf(err, errGetSecret, ref.Namespace, ref.Name)
+	}
+
+	value, ok := secret.Data[ref.Key]
+	if !ok || len(value) == 0 {
+		return nil, errors.Errorf(errSecretKeyEmpty, ref.Namespace, ref.Name, ref.Key)
+	}
+
+	return value, nil
+}
+
+// GetSecretData returns all the keys of the Secret referenced by the given reference.
+func GetSecretData(ctx context.Context, kube client.Client, ref xpv1.SecretReference) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, secret); err != nil {
+		return nil, errors.Wrapf(err, errGetSecret, ref.Namespace, ref.Name)
+	}
+
+	return secret.Data, nil
+}
+
+// SetSecretValue sets the key of the Secret with the given namespace and name to the given value, creating the
+// Secret when it does not exist.
+func SetSecretValue(ctx context.Context, kube client.Client, namespace, name, key string, value []byte) error {
+	return SetSecretData(ctx, kube, namespace, name, map[string][]byte{key: value})
+}
+
+// SetSecretData sets the given keys of the Secret with the given namespace and name, creating the Secret when it
+// does not exist. Its other keys are left as they are, and it is not updated when no value changes.
+func SetSecretData(ctx context.Context, kube client.Client, namespace, name string, data map[string][]byte) error {
+	secret := &corev1.Secret{}
+	err := kube.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
+	if kerrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Data:       data,
+		}
+		return errors.Wrapf(kube.Create(ctx, secret), errCreateSecret, namespace, name)
+	}
+	if err != nil {
+		return errors.Wrapf(err, errGetSecret, namespace, name)
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	changed := false
+	for key, value := range data {
+		if current, ok := secret.Data[key]; ok && bytes.Equal(current, value) {
+			continue
+		}
+		secret.Data[key] = value
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+
+	return errors.Wrapf(kube.Update(ctx, secret), errUpdateSecret, namespace, name)
+}
+
+// RemoveSecretValue removes the key of the Secret with the given namespace and name, and deletes the Secret when
+// no key remains.
+func RemoveSecretValue(ctx context.Context, kube client.Client, namespace, name, key string) error {
+	secret := &corev1.Secret{}
+	err := kube.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, errGetSecret, namespace, name)
+	}
+
+	delete(secret.Data, key)
+	if len(secret.Data) == 0 && len(secret.StringData) == 0 {
+		return errors.Wrapf(client.IgnoreNotFound(kube.Delete(ctx, secret)), errDeleteSecret, namespace, name)
+	}
+
+	return errors.Wrapf(kube.Update(ctx, secret), errUpdateSecret, namespace, name)
+}
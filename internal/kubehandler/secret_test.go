@@ -0,0 +1,143 @@
+package kubehandler
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	errBoom   = errors.New("boom")
+	secretRef = xpv1.SecretKeySelector{
+		SecretReference: xpv1.SecretReference{
+			Name:      "api-key",
+			Namespace: "crossplane-system",
+		},
+		Key: "key",
+	}
+)
+
+func Test_GetSecretValue(t *testing.T) {
+	type args struct {
+		kube client.Client
+	}
+	type want struct {
+		value []byte
+		err   error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"GetFailed": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errGetSecret, "crossplane-system", "api-key"),
+			},
+		},
+		"KeyMissing": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			},
+			want: want{
+				err: errors.Errorf(errSecretKeyEmpty, "crossplane-system", "api-key", "key"),
+			},
+		},
+		"Success": {
+			args: args{
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"key": []byte("secret-value")}
+					return nil
+				})},
+			},
+			want: want{
+				value: []byte("secret-value"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := GetSecretValue(context.Background(), tc.args.kube, secretRef)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("GetSecretValue(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.value, got); diff != "" {
+				t.Errorf("GetSecretValue(...): -want value, +got value: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_SetSecretValue(t *testing.T) {
+	var written *corev1.Secret
+	record := func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+		written = obj.(*corev1.Secret)
+		return nil
+	}
+	cases := map[string]struct {
+		kube client.Client
+		want map[string][]byte
+		err  error
+	}{
+		"Created": {
+			kube: &test.MockClient{
+				MockGet:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "ca")),
+				MockCreate: record,
+			},
+			want: map[string][]byte{"ca.crt": []byte("cert")},
+		},
+		"Updated": {
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"other": []byte("value"), "ca.crt": []byte("old")}
+					return nil
+				}),
+				MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+					written = obj.(*corev1.Secret)
+					return nil
+				},
+			},
+			want: map[string][]byte{"other": []byte("value"), "ca.crt": []byte("cert")},
+		},
+		"Unchanged": {
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"ca.crt": []byte("cert")}
+					return nil
+				}),
+			},
+		},
+		"GetFailed": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			err:  errors.Wrapf(errBoom, errGetSecret, "crossplane-system", "ca"),
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			written = nil
+			err := SetSecretValue(context.Background(), tc.kube, "crossplane-system", "ca", "ca.crt", []byte("cert"))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Fatalf("SetSecretValue(...): -want error, +got error: %s", diff)
+			}
+			var got map[string][]byte
+			if written != nil {
+				got = written.Data
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SetSecretValue(...): -want data, +got data: %s", diff)
+			}
+		})
+	}
+}
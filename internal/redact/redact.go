@@ -0,0 +1,208 @@
+// Package redact masks sensitive header values and JSON body fields, so that they are not exposed in the status of
+// resources, in their events or in the logs.
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/arielsepton/provider-http/internal/jq"
+)
+
+// Mask replaces the sensitive values.
+const Mask = "REDACTED"
+
+// minMaskedLength is the length from which sensitive values are masked in messages, so that short values like
+// numbers or booleans do not mask unrelated parts of them.
+const minMaskedLength = 4
+
+// defaultHeaders are the headers whose values are always masked.
+var defaultHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"Set-Cookie2":         true,
+	"X-Api-Key":           true,
+	"Api-Key":             true,
+	"X-Auth-Token":        true,
+}
+
+// A Redactor masks the values of sensitive headers and JSON body fields. The zero value and nil mask the default
+// sensitive headers only.
+type Redactor struct {
+	headers    map[string]bool
+	bodyFields []string
+
+	// values are sensitive values known beforehand, masked in messages besides those found in requests and
+	// responses.
+	values []string
+}
+
+// New returns a Redactor masking the given headers and JSON body fields, given as jq paths like
+// '.credentials.password' or '.items[].token', besides the default sensitive headers.
+func New(headers []string, bodyFields []string) *Redactor {
+	r := &Redactor{headers: make(map[string]bool, len(headers)), bodyFields: bodyFields}
+	for _, name := range headers {
+		r.headers[http.CanonicalHeaderKey(name)] = true
+	}
+	return r
+}
+
+// WithValues adds the given sensitive values, masked in messages besides those found in requests and responses,
+// e.g. the expressions sensitive values are rendered from. It returns the Redactor.
+func (r *Redactor) WithValues(values ...string) *Redactor {
+	r.values = append(r.values, values...)
+	return r
+}
+
+// sensitiveHeader checks whether the values of the header with the given name are masked.
+func (r *Redactor) sensitiveHeader(name string) bool {
+	canonical := http.CanonicalHeaderKey(name)
+	return defaultHeaders[canonical] || (r != nil && r.headers[canonical])
+}
+
+// Headers returns a copy of the given headers with the values of the sensitive ones masked.
+func (r *Redactor) Headers(headers map[string][]string) map[string][]string {
+	if headers == nil {
+		return nil
+	}
+
+	redacted := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		if !r.sensitiveHeader(name) {
+			redacted[name] = values
+			continue
+		}
+
+		masked := make([]string, len(values))
+		for i := range values {
+			masked[i] = Mask
+		}
+		redacted[name] = masked
+	}
+	return redacted
+}
+
+// Body returns the given body with its sensitive fields masked. Bodies that are not JSON, or have no sensitive
+// field, are returned as they are.
+func (r *Redactor) Body(body string) string {
+	if r == nil || len(r.bodyFields) == 0 {
+		return body
+	}
+
+	var document interface{}
+	if err := json.Unmarshal([]byte(body), &document); err != nil {
+		return body
+	}
+
+	masked := false
+	for _, field := range r.bodyFields {
+		if len(r.fieldValues(field, document)) == 0 {
+			continue
+		}
+
+		result, err := jq.Parse(fmt.Sprintf(`reduce path((%s)?) as $p (.; if getpath($p) == null then . else setpath($p; %q) end)`, field, Mask), document)
+		if err != nil {
+			continue
+		}
+		document, masked = result, true
+	}
+	if !masked {
+		return body
+	}
+
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return body
+	}
+	return string(encoded)
+}
+
+// fieldValues returns the values of the given JSON body field of the document, nothing when it is missing or
+// cannot be evaluated.
+func (r *Redactor) fieldValues(field string, document interface{}) []interface{} {
+	values, err := jq.ParseArray(fmt.Sprintf(`[path((%s)?) as $p | getpath($p) | select(. != null)]`, field), document)
+	if err != nil {
+		return nil
+	}
+	return values
+}
+
+// Values returns the sensitive values of the given headers and body, to mask them in messages with Message.
+func (r *Redactor) Values(headers map[string][]string, body string) []string {
+	var values []string
+	if r != nil {
+		values = append(values, r.values...)
+	}
+	for name, headerValues := range headers {
+		if r.sensitiveHeader(name) {
+			values = append(values, headerValues...)
+		}
+	}
+
+	if r != nil && len(r.bodyFields) > 0 {
+		var document interface{}
+		if err := json.Unmarshal([]byte(body), &document); err == nil {
+			for _, field := range r.bodyFields {
+				for _, value := range r.fieldValues(field, document) {
+					if s, ok := value.(string); ok {
+						values = append(values, s)
+						continue
+					}
+					if encoded, err := json.Marshal(value); err == nil {
+						values = append(values, string(encoded))
+					}
+				}
+			}
+		}
+	}
+
+	return values
+}
+
+// Message returns the given message with the given sensitive values masked.
+func Message(message string, values []string) string {
+	// Longer values are masked first, so that the values they contain do not leave parts of them unmasked.
+	sorted := append([]string(nil), values...)
+	sort.SliceStable(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	for _, value := range sorted {
+		if len(value) < minMaskedLength || value == Mask {
+			continue
+		}
+		message = strings.ReplaceAll(message, value, Mask)
+	}
+	return message
+}
+
+// Error returns the given error with the given sensitive values masked in its message. The error is returned as it
+// is when its message holds none of them, and remains available through errors.Unwrap otherwise.
+func Error(err error, values []string) error {
+	if err == nil {
+		return nil
+	}
+
+	message := Message(err.Error(), values)
+	if message == err.Error() {
+		return err
+	}
+	return &redactedError{message: message, err: err}
+}
+
+// A redactedError is an error whose message has its sensitive values masked.
+type redactedError struct {
+	message string
+	err     error
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,82 @@
+package redact
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func TestHeaders(t *testing.T) {
+	headers := map[string][]string{
+		"Authorization":   {"Bearer s3cr3t"},
+		"x-tenant-secret": {"tenant"},
+		"Content-Type":    {"application/json"},
+	}
+	want := map[string][]string{
+		"Authorization":   {Mask},
+		"x-tenant-secret": {Mask},
+		"Content-Type":    {"application/json"},
+	}
+
+	got := New([]string{"X-Tenant-Secret"}, nil).Headers(headers)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Headers(...): -want, +got: %s", diff)
+	}
+}
+
+func TestBody(t *testing.T) {
+	cases := map[string]struct {
+		fields []string
+		body   string
+		want   string
+	}{
+		"Masked": {
+			fields: []string{".credentials.password", ".keys[].value"},
+			body:   `{"id":"123","credentials":{"username":"admin","password":"s3cr3t"},"keys":[{"value":"k1"},{"value":"k2"}]}`,
+			want:   `{"credentials":{"password":"REDACTED","username":"admin"},"id":"123","keys":[{"value":"REDACTED"},{"value":"REDACTED"}]}`,
+		},
+		"MissingField": {
+			fields: []string{".credentials.password", ".keys[].value"},
+			body:   `{"id": "123"}`,
+			want:   `{"id": "123"}`,
+		},
+		"NotJSON": {
+			fields: []string{".password"},
+			body:   "password=s3cr3t",
+			want:   "password=s3cr3t",
+		},
+		"NoFields": {
+			body: `{"password": "s3cr3t"}`,
+			want: `{"password": "s3cr3t"}`,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := New(nil, tc.fields).Body(tc.body)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Body(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	r := New(nil, []string{".token"})
+	values := r.Values(map[string][]string{"Authorization": {"Bearer s3cr3t"}}, `{"token":"t0k3n","id":1}`)
+
+	cause := errors.New(`{"token":"t0k3n"} is not valid, sent with Bearer s3cr3t`)
+	err := Error(cause, values)
+	if diff := cmp.Diff(`{"token":"REDACTED"} is not valid, sent with REDACTED`, err.Error()); diff != "" {
+		t.Errorf("Error(...): -want message, +got message: %s", diff)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("Error(...): the masked error does not wrap %v", cause)
+	}
+
+	unrelated := errors.New("boom")
+	if got := Error(unrelated, values); got != unrelated {
+		t.Errorf("Error(...): want the error as it is, got %v", got)
+	}
+}
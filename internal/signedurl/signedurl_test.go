@@ -0,0 +1,94 @@
+package signedurl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+)
+
+func sign(key string, canonicalRequest string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = mac.Write([]byte(canonicalRequest))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func Test_Sign(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	type args struct {
+		method string
+		url    string
+		opts   Options
+	}
+	type want struct {
+		url string
+		err error
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Defaults": {
+			args: args{
+				method: "get",
+				url:    "https://api.example.com/files/report.csv?version=2",
+			},
+			want: want{
+				url: "https://api.example.com/files/report.csv?expires=1700000900&signature=" +
+					sign("key", "GET\n/files/report.csv\nexpires=1700000900&version=2") + "&version=2",
+			},
+		},
+		"CustomParams": {
+			args: args{
+				method: "PUT",
+				url:    "https://api.example.com/upload",
+				opts: Options{
+					Expiry:         time.Hour,
+					ExpiresParam:   "X-Expires",
+					SignatureParam: "X-Signature",
+				},
+			},
+			want: want{
+				url: "https://api.example.com/upload?X-Expires=1700003600&X-Signature=" +
+					sign("key", "PUT\n/upload\nX-Expires=1700003600"),
+			},
+		},
+		"UnknownAlgorithm": {
+			args: args{
+				method: "GET",
+				url:    "https://api.example.com",
+				opts:   Options{Algorithm: "MD5"},
+			},
+			want: want{
+				err: errors.Errorf(errUnknownAlgorithm, "MD5"),
+			},
+		},
+		"AlreadySigned": {
+			args: args{
+				method: "GET",
+				url:    "https://api.example.com?signature=abc",
+			},
+			want: want{
+				err: errors.Errorf(errSignatureParamUsed, "signature"),
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, gotErr := Sign(tc.args.method, tc.args.url, []byte("key"), tc.args.opts, now)
+			if diff := cmp.Diff(tc.want.err, gotErr, test.EquateErrors()); diff != "" {
+				t.Fatalf("Sign(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.url, got); diff != "" {
+				t.Errorf("Sign(...): -want url, +got url: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing records OpenTelemetry spans for the reconciles of managed resources and the HTTP requests they
+// send, and propagates their context to remote APIs in W3C traceparent headers.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	instrumentationName = "github.com/arielsepton/provider-http"
+	serviceName         = "provider-http"
+	traceparentHeader   = "traceparent"
+
+	errCreateExporter = "cannot create OTLP trace exporter"
+)
+
+// Options configure where spans are exported.
+type Options struct {
+	// Endpoint is the host and port of the OTLP gRPC collector spans are exported to. Spans are not recorded
+	// when it is empty.
+	Endpoint string
+
+	// Insecure exports spans without TLS.
+	Insecure bool
+
+	// SampleRatio is the ratio of the traces started by the provider that are recorded. The traces of incoming
+	// requests are recorded when their caller sampled them.
+	SampleRatio float64
+}
+
+// Setup propagates the context of spans in W3C traceparent headers and, when an endpoint is configured, exports
+// the spans recorded by the provider to it. The returned function flushes and stops the export.
+func Setup(ctx context.Context, o Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	if o.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(o.Endpoint)}
+	if o.Insecure {
+		exporterOpts = append(exporterOpts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateExporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of the provider, which does not record spans unless Setup exported them.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Inject sets the traceparent header of the given headers to the span of the given context, unless it is set
+// already.
+func Inject(ctx context.Context, headers http.Header) {
+	if headers.Get(traceparentHeader) != "" {
+		return
+	}
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(headers))
+}
+
+// StartRequest starts the span of an HTTP request sent with the given method to the given host.
+func StartRequest(ctx context.Context, method, host string) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "HTTP "+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.HTTPMethodKey.String(method),
+		semconv.NetPeerNameKey.String(host),
+	))
+}
+
+// EndRequest ends the span of an HTTP request with the status code of its response, 0 if there is none, and the
+// error it failed with, if any.
+func EndRequest(span trace.Span, statusCode int, err error) {
+	if statusCode != 0 {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(statusCode))
+	}
+	if err == nil && statusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(statusCode))
+	}
+
+	End(span, err)
+}
+
+// End ends the given span, marking it as failed with the given error, if any.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+type reconcileIDKey struct{}
+
+// ReconcileID returns the ID of the reconcile of the given context, or an empty string outside of a reconcile.
+func ReconcileID(ctx context.Context) string {
+	id, _ := ctx.Value(reconcileIDKey{}).(string)
+	return id
+}
+
+// A Reconciler records a span for every reconcile of the wrapped reconciler. The requests sent while reconciling
+// are recorded as its children. Every reconcile is given an ID, returned by ReconcileID.
+type Reconciler struct {
+	name  string
+	inner reconcile.Reconciler
+}
+
+// NewReconciler returns a Reconciler recording the reconciles of the supplied reconciler, named after the given
+// controller.
+func NewReconciler(name string, inner reconcile.Reconciler) *Reconciler {
+	return &Reconciler{name: name, inner: inner}
+}
+
+// Reconcile the supplied request using the wrapped reconciler, within a span.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	id := string(uuid.NewUUID())
+	ctx, span := Tracer().Start(context.WithValue(ctx, reconcileIDKey{}, id), "Reconcile "+r.name, trace.WithAttributes(
+		attribute.String("controller", r.name),
+		attribute.String("name", req.Name),
+		attribute.String("reconcile.id", id),
+	))
+
+	result, err := r.inner.Reconcile(ctx, req)
+	End(span, err)
+	return result, err
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var errBoom = errors.New("boom")
+
+type reconcilerFn func(ctx context.Context, req reconcile.Request) (reconcile.Result, error)
+
+func (fn reconcilerFn) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return fn(ctx, req)
+}
+
+// record records the spans ended while running the supplied function.
+func record(t *testing.T, fn func()) tracetest.SpanStubs {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTracerProvider(previous)
+
+	fn()
+	return tracetest.SpanStubsFromReadOnlySpans(recorder.Ended())
+}
+
+func Test_Reconciler(t *testing.T) {
+	type want struct {
+		names  []string
+		status codes.Code
+	}
+	cases := map[string]struct {
+		inner reconcile.Reconciler
+		want  want
+	}{
+		"Success": {
+			inner: reconcilerFn(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				_, span := StartRequest(ctx, http.MethodGet, "api.example.com")
+				EndRequest(span, http.StatusOK, nil)
+				return reconcile.Result{}, nil
+			}),
+			want: want{
+				names:  []string{"HTTP GET", "Reconcile request"},
+				status: codes.Unset,
+			},
+		},
+		"Failure": {
+			inner: reconcilerFn(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				_, span := StartRequest(ctx, http.MethodPost, "api.example.com")
+				EndRequest(span, 0, errBoom)
+				return reconcile.Result{}, errBoom
+			}),
+			want: want{
+				names:  []string{"HTTP POST", "Reconcile request"},
+				status: codes.Error,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			spans := record(t, func() {
+				_, _ = NewReconciler("request", tc.inner).Reconcile(context.Background(), reconcile.Request{})
+			})
+
+			var names []string
+			for _, span := range spans {
+				names = append(names, span.Name)
+			}
+			if diff := cmp.Diff(tc.want.names, names); diff != "" {
+				t.Fatalf("Reconcile(...): -want spans, +got spans: %s", diff)
+			}
+			if diff := cmp.Diff(spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID()); diff != "" {
+				t.Errorf("Reconcile(...): -want parent of the request span, +got parent: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.status, spans[1].Status.Code); diff != "" {
+				t.Errorf("Reconcile(...): -want status, +got status: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_Inject(t *testing.T) {
+	cases := map[string]struct {
+		headers http.Header
+		want    bool
+	}{
+		"Injected": {
+			headers: http.Header{},
+			want:    true,
+		},
+		"AlreadySet": {
+			headers: http.Header{"Traceparent": {"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}},
+			want:    false,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			set := tc.headers.Get(traceparentHeader)
+			record(t, func() {
+				ctx, span := StartRequest(context.Background(), http.MethodGet, "api.example.com")
+				Inject(ctx, tc.headers)
+				span.End()
+			})
+
+			got := tc.headers.Get(traceparentHeader) != set
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Inject(...): -want injected, +got injected: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CacheExpiry returns the time at which a response received at the given time stops being fresh, as indicated
+// by its Cache-Control max-age or Expires header. Responses with Cache-Control no-cache or no-store expire
+// right away. It returns false if the response carries no freshness information.
+func CacheExpiry(headers map[string][]string, received time.Time) (time.Time, bool) {
+	header := http.Header(headers)
+
+	if maxAge, ok := cacheControlMaxAge(header.Values("Cache-Control")); ok {
+		age, _ := strconv.Atoi(header.Get("Age"))
+		return received.Add(time.Duration(maxAge-age) * time.Second), true
+	}
+
+	value := header.Get("Expires")
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	expires, err := http.ParseTime(value)
+	if err != nil {
+		// Invalid dates, such as "0", represent a time in the past.
+		return received, true
+	}
+
+	// Compare against the Date of the response to be independent of clock skew.
+	if date, err := http.ParseTime(header.Get("Date")); err == nil {
+		return received.Add(expires.Sub(date)), true
+	}
+
+	return expires, true
+}
+
+// cacheControlMaxAge returns the max-age of the given Cache-Control headers, or zero if they forbid reusing
+// the response without revalidating it.
+func cacheControlMaxAge(values []string) (int, bool) {
+	maxAge, found := 0, false
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ",") {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			switch strings.ToLower(name) {
+			case "no-cache", "no-store":
+				return 0, true
+			case "max-age":
+				if seconds, err := strconv.Atoi(strings.Trim(arg, `"`)); err == nil && seconds >= 0 {
+					maxAge, found = seconds, true
+				}
+			}
+		}
+	}
+
+	return maxAge, found
+}
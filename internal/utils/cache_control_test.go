@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_CacheExpiry(t *testing.T) {
+	received := time.Date(2023, time.November, 16, 18, 0, 0, 0, time.UTC)
+
+	type args struct {
+		headers map[string][]string
+	}
+	type want struct {
+		expiry time.Time
+		ok     bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"NoHeaders": {
+			args: args{},
+			want: want{
+				ok: false,
+			},
+		},
+		"MaxAge": {
+			args: args{
+				headers: map[string][]string{"Cache-Control": {"public, max-age=60"}},
+			},
+			want: want{
+				expiry: received.Add(time.Minute),
+				ok:     true,
+			},
+		},
+		"MaxAgeWithAge": {
+			args: args{
+				headers: map[string][]string{"Cache-Control": {"max-age=60"}, "Age": {"20"}},
+			},
+			want: want{
+				expiry: received.Add(40 * time.Second),
+				ok:     true,
+			},
+		},
+		"NoStore": {
+			args: args{
+				headers: map[string][]string{"Cache-Control": {"max-age=60, no-store"}},
+			},
+			want: want{
+				expiry: received,
+				ok:     true,
+			},
+		},
+		"MaxAgeOverridesExpires": {
+			args: args{
+				headers: map[string][]string{
+					"Cache-Control": {"max-age=10"},
+					"Expires":       {"Thu, 16 Nov 2023 19:00:00 GMT"},
+				},
+			},
+			want: want{
+				expiry: received.Add(10 * time.Second),
+				ok:     true,
+			},
+		},
+		"ExpiresRelativeToDate": {
+			args: args{
+				headers: map[string][]string{
+					"Date":    {"Thu, 16 Nov 2023 17:59:00 GMT"},
+					"Expires": {"Thu, 16 Nov 2023 18:04:00 GMT"},
+				},
+			},
+			want: want{
+				expiry: received.Add(5 * time.Minute),
+				ok:     true,
+			},
+		},
+		"ExpiresWithoutDate": {
+			args: args{
+				headers: map[string][]string{"Expires": {"Thu, 16 Nov 2023 18:30:00 GMT"}},
+			},
+			want: want{
+				expiry: received.Add(30 * time.Minute),
+				ok:     true,
+			},
+		},
+		"InvalidExpires": {
+			args: args{
+				headers: map[string][]string{"Expires": {"0"}},
+			},
+			want: want{
+				expiry: received,
+				ok:     true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			expiry, ok := CacheExpiry(tc.args.headers, received)
+			if diff := cmp.Diff(tc.want.ok, ok); diff != "" {
+				t.Fatalf("CacheExpiry(...): -want ok, +got ok: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.expiry, expiry); diff != "" {
+				t.Errorf("CacheExpiry(...): -want expiry, +got expiry: %s", diff)
+			}
+		})
+	}
+}
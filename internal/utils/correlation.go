@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/tracing"
+)
+
+// CorrelationID returns the ID correlating the requests sent by the given resource in the reconcile of the given
+// context, as <uid>/<reconcile ID>, or an empty string when no correlation header is set.
+func CorrelationID(ctx context.Context, header string, mg resource.Managed) string {
+	if header == "" {
+		return ""
+	}
+
+	id := string(mg.GetUID())
+	if reconcileID := tracing.ReconcileID(ctx); reconcileID != "" {
+		id += "/" + reconcileID
+	}
+
+	return id
+}
+
+// CorrelationOptions returns the client options setting the given correlation header to the given ID on the
+// requests. Nothing is set when the header is empty.
+func CorrelationOptions(header, id string) []httpClient.ClientOption {
+	if header == "" {
+		return nil
+	}
+
+	return []httpClient.ClientOption{httpClient.WithHeader(header, id)}
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/tracing"
+)
+
+type reconcilerFn func(ctx context.Context, req reconcile.Request) (reconcile.Result, error)
+
+func (fn reconcilerFn) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	return fn(ctx, req)
+}
+
+// reconcileContext returns the context of a reconcile.
+func reconcileContext() context.Context {
+	var reconcileCtx context.Context
+	_, _ = tracing.NewReconciler("request", reconcilerFn(func(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+		reconcileCtx = ctx
+		return reconcile.Result{}, nil
+	})).Reconcile(context.Background(), reconcile.Request{})
+	return reconcileCtx
+}
+
+func Test_CorrelationID(t *testing.T) {
+	type want struct {
+		prefix      string
+		reconcileID bool
+	}
+	cases := map[string]struct {
+		ctx    context.Context
+		header string
+		want   want
+	}{
+		"NoHeader": {
+			ctx:  reconcileContext(),
+			want: want{prefix: ""},
+		},
+		"OutsideReconcile": {
+			ctx:    context.Background(),
+			header: "X-Request-ID",
+			want:   want{prefix: "uid"},
+		},
+		"Reconcile": {
+			ctx:    reconcileContext(),
+			header: "X-Request-ID",
+			want:   want{prefix: "uid/", reconcileID: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Request{ObjectMeta: metav1.ObjectMeta{UID: "uid"}}
+			cr.Status.CorrelationID = "stale"
+
+			id := CorrelationID(tc.ctx, tc.header, cr)
+			if !strings.HasPrefix(id, tc.want.prefix) || (tc.header == "") != (id == "") {
+				t.Errorf("CorrelationID(...): unexpected correlation ID %q", id)
+			}
+			if diff := cmp.Diff(tc.want.reconcileID, len(id) > len("uid/")); diff != "" {
+				t.Errorf("CorrelationID(...): -want reconcile ID, +got reconcile ID: %s", diff)
+			}
+			if diff := cmp.Diff("stale", cr.Status.CorrelationID); diff != "" {
+				t.Errorf("CorrelationID(...): -want status unchanged, +got status: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_CorrelationOptions(t *testing.T) {
+	cases := map[string]struct {
+		header string
+		want   int
+	}{
+		"NoHeader": {
+			want: 0,
+		},
+		"Header": {
+			header: "X-Request-ID",
+			want:   1,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := CorrelationOptions(tc.header, "uid/reconcile")
+			if diff := cmp.Diff(tc.want, len(got)); diff != "" {
+				t.Errorf("CorrelationOptions(...): -want options, +got options: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/json"
+)
+
+// maxDriftFields is the number of fields recorded in each list of a drift, so that the status of a resource
+// stays small whatever the size of its remote object.
+const maxDriftFields = 10
+
+// Drift returns the supplied diff as the drift recorded in the status of a resource, with its lists truncated,
+// or nil if the diff is empty.
+func Drift(diff json.Diff) *apisv1alpha1.Drift {
+	if diff.Empty() {
+		return nil
+	}
+
+	drift := &apisv1alpha1.Drift{}
+	drift.Added, drift.Truncated = truncateFields(diff.Added, drift.Truncated)
+	drift.Changed, drift.Truncated = truncateFields(diff.Changed, drift.Truncated)
+	drift.Removed, drift.Truncated = truncateFields(diff.Removed, drift.Truncated)
+
+	return drift
+}
+
+func truncateFields(fields []string, truncated bool) ([]string, bool) {
+	if len(fields) > maxDriftFields {
+		return fields[:maxDriftFields], true
+	}
+
+	return fields, truncated
+}
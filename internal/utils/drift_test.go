@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	"github.com/arielsepton/provider-http/internal/json"
+)
+
+func Test_Drift(t *testing.T) {
+	var many []string
+	for i := 0; i < 12; i++ {
+		many = append(many, fmt.Sprintf("/field%02d", i))
+	}
+
+	cases := map[string]struct {
+		diff json.Diff
+		want *apisv1alpha1.Drift
+	}{
+		"NoDrift": {
+			diff: json.Diff{},
+			want: nil,
+		},
+		"Drift": {
+			diff: json.Diff{Changed: []string{"/name"}, Removed: []string{"/email"}},
+			want: &apisv1alpha1.Drift{Changed: []string{"/name"}, Removed: []string{"/email"}},
+		},
+		"Truncated": {
+			diff: json.Diff{Changed: many, Removed: []string{"/email"}},
+			want: &apisv1alpha1.Drift{Changed: many[:10], Removed: []string{"/email"}, Truncated: true},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := Drift(tc.diff)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Drift(...): -want drift, +got drift: %s", diff)
+			}
+		})
+	}
+}
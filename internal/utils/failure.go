@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+// renderError is an error that occurred while generating a request from its mapping.
+type renderError struct {
+	err error
+}
+
+func (e *renderError) Error() string {
+	return e.err.Error()
+}
+
+func (e *renderError) Unwrap() error {
+	return e.err
+}
+
+// NewRenderError marks the supplied error as a failure to generate a request.
+func NewRenderError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &renderError{err: err}
+}
+
+// ClassifyFailure returns the reason of a failed request, given the status code of its response (0 if there is
+// none) and the error it failed with.
+func ClassifyFailure(statusCode int, err error) apisv1alpha1.FailureReason {
+	var render *renderError
+	if errors.As(err, &render) {
+		return apisv1alpha1.FailureReasonRenderError
+	}
+
+	var circuitOpen *httpClient.CircuitOpenError
+	if errors.As(err, &circuitOpen) {
+		return apisv1alpha1.FailureReasonCircuitOpen
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return apisv1alpha1.FailureReasonTimeout
+	}
+
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return apisv1alpha1.FailureReasonAuth
+	case statusCode >= 400 && statusCode < 500:
+		return apisv1alpha1.FailureReasonClientError
+	case statusCode >= 500 && statusCode < 600:
+		return apisv1alpha1.FailureReasonServerError
+	case statusCode != 0:
+		return apisv1alpha1.FailureReasonUnexpectedResponse
+	case isTLSError(err):
+		return apisv1alpha1.FailureReasonTLS
+	}
+
+	return apisv1alpha1.FailureReasonConnection
+}
+
+// isTLSError returns whether the supplied error is a failure to establish a TLS connection, either because the
+// certificate of the remote API could not be verified or because the handshake was rejected.
+func isTLSError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var unknownAuthority x509.UnknownAuthorityError
+	var hostname x509.HostnameError
+	var invalid x509.CertificateInvalidError
+	var recordHeader tls.RecordHeaderError
+	if errors.As(err, &unknownAuthority) || errors.As(err, &hostname) || errors.As(err, &invalid) ||
+		errors.As(err, &recordHeader) {
+		return true
+	}
+
+	// The alerts the remote API sends to reject a handshake, e.g. "remote error: tls: bad certificate", have an
+	// unexported type. crypto/tls returns them wrapped in a net.OpError whose operation is "remote error".
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "remote error"
+}
@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+func Test_ClassifyFailure(t *testing.T) {
+	type args struct {
+		statusCode int
+		err        error
+	}
+	type want struct {
+		reason apisv1alpha1.FailureReason
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RenderError": {
+			args: args{
+				err: errors.Wrap(NewRenderError(errBoom), "cannot generate request"),
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonRenderError,
+			},
+		},
+		"Timeout": {
+			args: args{
+				err: errors.Wrap(context.DeadlineExceeded, "request failed"),
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonTimeout,
+			},
+		},
+		"Unauthorized": {
+			args: args{
+				statusCode: 401,
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonAuth,
+			},
+		},
+		"Forbidden": {
+			args: args{
+				statusCode: 403,
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonAuth,
+			},
+		},
+		"ClientError": {
+			args: args{
+				statusCode: 422,
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonClientError,
+			},
+		},
+		"ServerError": {
+			args: args{
+				statusCode: 502,
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonServerError,
+			},
+		},
+		"UnexpectedResponse": {
+			args: args{
+				statusCode: 200,
+				err:        errBoom,
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonUnexpectedResponse,
+			},
+		},
+		"CircuitOpen": {
+			args: args{
+				err: errors.Wrap(&httpClient.CircuitOpenError{Host: "api.example.com"}, "cannot send request"),
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonCircuitOpen,
+			},
+		},
+		"UntrustedCertificate": {
+			args: args{
+				err: errors.Wrap(x509.UnknownAuthorityError{}, "cannot send request"),
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonTLS,
+			},
+		},
+		"HandshakeRejected": {
+			args: args{
+				err: errors.Wrap(&net.OpError{Op: "remote error", Err: errors.New("tls: bad certificate")}, "cannot send request"),
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonTLS,
+			},
+		},
+		"ConnectionRefused": {
+			args: args{
+				err: errors.Wrap(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused")}, "cannot send request"),
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonConnection,
+			},
+		},
+		"Connection": {
+			args: args{
+				err: errBoom,
+			},
+			want: want{
+				reason: apisv1alpha1.FailureReasonConnection,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := ClassifyFailure(tc.args.statusCode, tc.args.err)
+			if diff := cmp.Diff(tc.want.reason, got); diff != "" {
+				t.Errorf("ClassifyFailure(...): -want reason, +got reason: %s", diff)
+			}
+		})
+	}
+}
@@ -1,13 +1,22 @@
 package utils
 
 import (
+	"math"
+	"net/http"
+	"strconv"
 	"time"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 )
 
 const (
 	defaultWaitTimeout = 5 * time.Minute
+
+	defaultRetryBase   = 10 * time.Second
+	defaultRetryFactor = 2.0
+	defaultRetryCap    = 5 * time.Minute
 )
 
 func ShouldRetry(rollbackRetriesLimit *int32, statusFailed int32) bool {
@@ -29,6 +38,31 @@ func WaitTimeout(timeout *v1.Duration) time.Duration {
 	return defaultWaitTimeout
 }
 
+// RetryDelay returns the delay before retrying a request that failed the given number of times. The delay grows
+// exponentially from the base of the backoff by its factor, up to its cap. The given jitter, in [0, 1), spreads it
+// over its upper half.
+func RetryDelay(backoff *apisv1alpha1.RetryBackoff, failures int32, jitter float64) time.Duration {
+	base, factor, ceiling := defaultRetryBase, defaultRetryFactor, defaultRetryCap
+	if backoff != nil {
+		if backoff.Base != nil {
+			base = backoff.Base.Duration
+		}
+		if f, err := strconv.ParseFloat(backoff.Factor, 64); err == nil && f >= 1 {
+			factor = f
+		}
+		if backoff.Cap != nil {
+			ceiling = backoff.Cap.Duration
+		}
+	}
+
+	if failures < 1 {
+		failures = 1
+	}
+
+	delay := math.Min(float64(base)*math.Pow(factor, float64(failures-1)), float64(ceiling))
+	return time.Duration(delay/2 + delay/2*jitter)
+}
+
 func GetRollbackRetriesLimit(rollbackRetriesLimit *int32) int32 {
 	limit := int32(1)
 	if rollbackRetriesLimit != nil {
@@ -36,3 +70,29 @@ func GetRollbackRetriesLimit(rollbackRetriesLimit *int32) int32 {
 	}
 	return limit
 }
+
+// RetryAfter returns the time at which a throttled request may be retried, as indicated by the Retry-After
+// header of 429 and 503 responses. The header may either hold a number of seconds or an HTTP date.
+// It returns nil if the response does not ask to retry later.
+func RetryAfter(statusCode int, headers map[string][]string, now time.Time) *v1.Time {
+	if statusCode != http.StatusTooManyRequests && statusCode != http.StatusServiceUnavailable {
+		return nil
+	}
+
+	value := http.Header(headers).Get("Retry-After")
+	if value == "" {
+		return nil
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		retryAt := v1.NewTime(now.Add(time.Duration(seconds) * time.Second))
+		return &retryAt
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		retryAt := v1.NewTime(date)
+		return &retryAt
+	}
+
+	return nil
+}
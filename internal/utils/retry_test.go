@@ -6,6 +6,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 )
 
 var limit int32 = 3
@@ -186,3 +188,137 @@ func Test_WaitTimeout(t *testing.T) {
 		})
 	}
 }
+
+func Test_RetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	in30Seconds := v1.NewTime(now.Add(30 * time.Second))
+	date := v1.NewTime(time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC))
+
+	type args struct {
+		statusCode int
+		headers    map[string][]string
+	}
+	type want struct {
+		result *v1.Time
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"TooManyRequestsSeconds": {
+			args: args{
+				statusCode: 429,
+				headers:    map[string][]string{"Retry-After": {"30"}},
+			},
+			want: want{
+				result: &in30Seconds,
+			},
+		},
+		"ServiceUnavailableDate": {
+			args: args{
+				statusCode: 503,
+				headers:    map[string][]string{"Retry-After": {"Mon, 01 Jan 2024 12:05:00 GMT"}},
+			},
+			want: want{
+				result: &date,
+			},
+		},
+		"NoHeader": {
+			args: args{
+				statusCode: 429,
+				headers:    map[string][]string{},
+			},
+			want: want{
+				result: nil,
+			},
+		},
+		"InvalidHeader": {
+			args: args{
+				statusCode: 503,
+				headers:    map[string][]string{"Retry-After": {"soon"}},
+			},
+			want: want{
+				result: nil,
+			},
+		},
+		"NotThrottlingStatusCode": {
+			args: args{
+				statusCode: 200,
+				headers:    map[string][]string{"Retry-After": {"30"}},
+			},
+			want: want{
+				result: nil,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := RetryAfter(tc.args.statusCode, tc.args.headers, now)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("RetryAfter(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_RetryDelay(t *testing.T) {
+	type args struct {
+		backoff  *apisv1alpha1.RetryBackoff
+		failures int32
+		jitter   float64
+	}
+	type want struct {
+		result time.Duration
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"FirstRetryWithDefaults": {
+			args: args{
+				failures: 1,
+			},
+			want: want{
+				result: 5 * time.Second,
+			},
+		},
+		"GrowsByFactor": {
+			args: args{
+				backoff:  &apisv1alpha1.RetryBackoff{Base: &v1.Duration{Duration: time.Second}, Factor: "3"},
+				failures: 3,
+			},
+			want: want{
+				result: 4500 * time.Millisecond,
+			},
+		},
+		"Capped": {
+			args: args{
+				backoff:  &apisv1alpha1.RetryBackoff{Cap: &v1.Duration{Duration: time.Minute}},
+				failures: 20,
+			},
+			want: want{
+				result: 30 * time.Second,
+			},
+		},
+		"Jittered": {
+			args: args{
+				backoff:  &apisv1alpha1.RetryBackoff{Base: &v1.Duration{Duration: 10 * time.Second}, Factor: "1.5"},
+				failures: 2,
+				jitter:   0.5,
+			},
+			want: want{
+				result: 11250 * time.Millisecond,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := RetryDelay(tc.args.backoff, tc.args.failures, tc.args.jitter)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("RetryDelay(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
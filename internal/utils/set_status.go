@@ -2,7 +2,14 @@ package utils
 
 import (
 	"context"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
 	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -19,6 +26,9 @@ type RequestResource struct {
 	HttpResponse   httpClient.HttpResponse
 	HttpRequest    httpClient.HttpRequest
 	LocalClient    client.Client
+
+	// Timings are the timings of the request, nil when it was not sent.
+	Timings *httpClient.Timings
 }
 
 func (rr *RequestResource) SetStatusCode() SetRequestStatusFunc {
@@ -82,6 +92,10 @@ func (rr *RequestResource) SetError(err error) SetRequestStatusFunc {
 		if resourceSetErr, ok := rr.Resource.(ErrorSetter); ok {
 			resourceSetErr.SetError(err)
 		}
+
+		if recorder, ok := rr.Resource.(FailureRecorder); ok {
+			recorder.RecordFailure(ClassifyFailure(rr.HttpResponse.StatusCode, err))
+		}
 	}
 }
 
@@ -93,6 +107,103 @@ func (rr *RequestResource) ResetFailures() SetRequestStatusFunc {
 	}
 }
 
+func (rr *RequestResource) SetThrottledUntil() SetRequestStatusFunc {
+	return func() {
+		if throttled, ok := rr.Resource.(ThrottleSetter); ok {
+			throttled.SetThrottledUntil(RetryAfter(rr.HttpResponse.StatusCode, rr.HttpResponse.Headers, time.Now()))
+		}
+	}
+}
+
+// SetTerminal records whether the response failed with a terminal status code according to the given policy.
+// SetNextRetry records when the failed request is retried next, according to the supplied backoff.
+func (rr *RequestResource) SetNextRetry(backoff *apisv1alpha1.RetryBackoff) SetRequestStatusFunc {
+	return func() {
+		if retried, ok := rr.Resource.(RetrySetter); ok {
+			next := v1.NewTime(time.Now().Add(RetryDelay(backoff, retried.GetFailed(), rand.Float64()))) //nolint:gosec // jitter does not need a secure source.
+			retried.SetNextRetryTime(&next)
+		}
+	}
+}
+
+// SetTimings records the timings of the request, or clears them when it was not sent.
+func (rr *RequestResource) SetTimings() SetRequestStatusFunc {
+	return func() {
+		if timed, ok := rr.Resource.(TimingsSetter); ok {
+			timed.SetTimings(Timings(rr.Timings))
+		}
+	}
+}
+
+// SetCorrelationID records the ID set in the correlation header of the request.
+func (rr *RequestResource) SetCorrelationID(id string) SetRequestStatusFunc {
+	return func() {
+		if correlated, ok := rr.Resource.(CorrelationSetter); ok {
+			correlated.SetCorrelationID(id)
+		}
+	}
+}
+
+func (rr *RequestResource) SetTerminal(policy *apisv1alpha1.StatusCodePolicy) SetRequestStatusFunc {
+	return func() {
+		if terminal, ok := rr.Resource.(TerminalSetter); ok {
+			terminal.SetTerminal(rr.HttpResponse.StatusCode, IsTerminal(policy, rr.HttpResponse.StatusCode))
+		}
+	}
+}
+
+// SetRun records the time a request was sent, and when it is sent next if it is scheduled.
+func (rr *RequestResource) SetRun(last time.Time, next *time.Time) SetRequestStatusFunc {
+	return func() {
+		if recorder, ok := rr.Resource.(RunRecorder); ok {
+			var nextRun *v1.Time
+			if next != nil {
+				t := v1.NewTime(*next)
+				nextRun = &t
+			}
+			recorder.SetRun(v1.NewTime(last), nextRun)
+		}
+	}
+}
+
+// SetLocation records the Location header of the response, resolved against the request URL.
+func (rr *RequestResource) SetLocation() SetRequestStatusFunc {
+	return func() {
+		if located, ok := rr.Resource.(LocationSetter); ok {
+			located.SetLocation(rr.HttpResponse.StatusCode, ResolveLocation(rr.HttpRequest.URL, http.Header(rr.HttpResponse.Headers).Get("Location")))
+		}
+	}
+}
+
+// SetRemoteRequestID records the ID the remote API assigned to the request.
+func (rr *RequestResource) SetRemoteRequestID() SetRequestStatusFunc {
+	return func() {
+		if identified, ok := rr.Resource.(RemoteRequestIDSetter); ok {
+			identified.SetRemoteRequestID(rr.HttpRequest.Method, rr.HttpResponse.Headers)
+		}
+	}
+}
+
+// ResolveLocation resolves a possibly relative URL, e.g. of a Location header, against the URL of the request it
+// answered.
+func ResolveLocation(requestURL string, location string) string {
+	if location == "" {
+		return ""
+	}
+
+	base, err := url.Parse(requestURL)
+	if err != nil {
+		return location
+	}
+
+	ref, err := url.Parse(location)
+	if err != nil {
+		return location
+	}
+
+	return base.ResolveReference(ref).String()
+}
+
 type ResponseSetter interface {
 	SetStatusCode(statusCode int)
 	SetHeaders(headers map[string][]string)
@@ -115,6 +226,43 @@ type ResetFailures interface {
 	ResetFailures()
 }
 
+type ThrottleSetter interface {
+	SetThrottledUntil(until *v1.Time)
+}
+
+type TerminalSetter interface {
+	SetTerminal(statusCode int, terminal bool)
+}
+
+type RetrySetter interface {
+	GetFailed() int32
+	SetNextRetryTime(next *v1.Time)
+}
+
+type TimingsSetter interface {
+	SetTimings(timings *apisv1alpha1.Timings)
+}
+
+type CorrelationSetter interface {
+	SetCorrelationID(id string)
+}
+
+type FailureRecorder interface {
+	RecordFailure(reason apisv1alpha1.FailureReason)
+}
+
+type RunRecorder interface {
+	SetRun(last v1.Time, next *v1.Time)
+}
+
+type LocationSetter interface {
+	SetLocation(statusCode int, location string)
+}
+
+type RemoteRequestIDSetter interface {
+	SetRemoteRequestID(method string, headers map[string][]string)
+}
+
 type RequestDetailsSetter interface {
 	SetRequestDetails(url, method, body string, headers map[string][]string)
 }
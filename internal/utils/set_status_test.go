@@ -275,3 +275,53 @@ func Test_DesposibleRequest_SetRequestResourceStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_ResolveLocation(t *testing.T) {
+	type args struct {
+		requestURL string
+		location   string
+	}
+	type want struct {
+		result string
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Empty": {
+			args: args{
+				requestURL: "https://api.example.com/users",
+			},
+			want: want{
+				result: "",
+			},
+		},
+		"Absolute": {
+			args: args{
+				requestURL: "https://api.example.com/users",
+				location:   "https://other.example.com/users/123",
+			},
+			want: want{
+				result: "https://other.example.com/users/123",
+			},
+		},
+		"Relative": {
+			args: args{
+				requestURL: "https://api.example.com/users",
+				location:   "/users/123",
+			},
+			want: want{
+				result: "https://api.example.com/users/123",
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := ResolveLocation(tc.args.requestURL, tc.args.location)
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("ResolveLocation(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}
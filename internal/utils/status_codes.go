@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+// StatusCodeClass is the interpretation of a response status code.
+type StatusCodeClass int
+
+const (
+	// StatusCodeUnknown is a status code that is neither a success nor a failure, e.g. a 3xx.
+	StatusCodeUnknown StatusCodeClass = iota
+	// StatusCodeSuccess is the status code of a successful response.
+	StatusCodeSuccess
+	// StatusCodeRetryable is the status code of a failed response that may succeed when retried.
+	StatusCodeRetryable
+	// StatusCodeTerminal is the status code of a failed response that will not succeed when retried.
+	StatusCodeTerminal
+)
+
+// StatusCodePolicy returns the status code policy of a resource, falling back to the ProviderConfig default.
+func StatusCodePolicy(resourcePolicy, providerConfigPolicy *apisv1alpha1.StatusCodePolicy) *apisv1alpha1.StatusCodePolicy {
+	if resourcePolicy != nil {
+		return resourcePolicy
+	}
+
+	return providerConfigPolicy
+}
+
+// ClassifyStatusCode interprets a status code according to the given policy. Status codes that are not listed
+// in the policy are interpreted by IsHTTPSuccess and IsHTTPError, the latter being retryable.
+func ClassifyStatusCode(policy *apisv1alpha1.StatusCodePolicy, statusCode int) StatusCodeClass {
+	if policy != nil {
+		switch {
+		case matchesAny(policy.Success, statusCode):
+			return StatusCodeSuccess
+		case matchesAny(policy.Terminal, statusCode):
+			return StatusCodeTerminal
+		case matchesAny(policy.Retryable, statusCode):
+			return StatusCodeRetryable
+		}
+	}
+
+	switch {
+	case IsHTTPSuccess(statusCode):
+		return StatusCodeSuccess
+	case IsHTTPError(statusCode):
+		return StatusCodeRetryable
+	}
+
+	return StatusCodeUnknown
+}
+
+// IsSuccess checks if a status code indicates success according to the given policy.
+func IsSuccess(policy *apisv1alpha1.StatusCodePolicy, statusCode int) bool {
+	return ClassifyStatusCode(policy, statusCode) == StatusCodeSuccess
+}
+
+// IsFailure checks if a status code indicates a failure, retryable or not, according to the given policy.
+func IsFailure(policy *apisv1alpha1.StatusCodePolicy, statusCode int) bool {
+	class := ClassifyStatusCode(policy, statusCode)
+	return class == StatusCodeRetryable || class == StatusCodeTerminal
+}
+
+// IsTerminal checks if a status code indicates a failure that should not be retried according to the given policy.
+func IsTerminal(policy *apisv1alpha1.StatusCodePolicy, statusCode int) bool {
+	return ClassifyStatusCode(policy, statusCode) == StatusCodeTerminal
+}
+
+func matchesAny(ranges []apisv1alpha1.StatusCodeRange, statusCode int) bool {
+	for _, r := range ranges {
+		if matches(r, statusCode) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches checks if a status code matches a single code ("404"), a class ("5xx") or a range ("500-504").
+func matches(r apisv1alpha1.StatusCodeRange, statusCode int) bool {
+	value := string(r)
+
+	if strings.HasSuffix(value, "xx") {
+		class, err := strconv.Atoi(strings.TrimSuffix(value, "xx"))
+		return err == nil && statusCode/100 == class
+	}
+
+	if from, to, ok := strings.Cut(value, "-"); ok {
+		low, errLow := strconv.Atoi(from)
+		high, errHigh := strconv.Atoi(to)
+		return errLow == nil && errHigh == nil && statusCode >= low && statusCode <= high
+	}
+
+	code, err := strconv.Atoi(value)
+	return err == nil && statusCode == code
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func Test_ClassifyStatusCode(t *testing.T) {
+	policy := &apisv1alpha1.StatusCodePolicy{
+		Success:   []apisv1alpha1.StatusCodeRange{"409"},
+		Terminal:  []apisv1alpha1.StatusCodeRange{"400", "422"},
+		Retryable: []apisv1alpha1.StatusCodeRange{"4xx", "500-504"},
+	}
+
+	type args struct {
+		policy     *apisv1alpha1.StatusCodePolicy
+		statusCode int
+	}
+	type want struct {
+		class StatusCodeClass
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"DefaultSuccess": {
+			args: args{
+				statusCode: 201,
+			},
+			want: want{
+				class: StatusCodeSuccess,
+			},
+		},
+		"DefaultRetryable": {
+			args: args{
+				statusCode: 500,
+			},
+			want: want{
+				class: StatusCodeRetryable,
+			},
+		},
+		"DefaultUnknown": {
+			args: args{
+				statusCode: 302,
+			},
+			want: want{
+				class: StatusCodeUnknown,
+			},
+		},
+		"SingleCodeSuccess": {
+			args: args{
+				policy:     policy,
+				statusCode: 409,
+			},
+			want: want{
+				class: StatusCodeSuccess,
+			},
+		},
+		"TerminalBeforeRetryable": {
+			args: args{
+				policy:     policy,
+				statusCode: 422,
+			},
+			want: want{
+				class: StatusCodeTerminal,
+			},
+		},
+		"ClassRetryable": {
+			args: args{
+				policy:     policy,
+				statusCode: 429,
+			},
+			want: want{
+				class: StatusCodeRetryable,
+			},
+		},
+		"RangeRetryable": {
+			args: args{
+				policy:     policy,
+				statusCode: 503,
+			},
+			want: want{
+				class: StatusCodeRetryable,
+			},
+		},
+		"NotListedFallsBackToDefault": {
+			args: args{
+				policy:     policy,
+				statusCode: 505,
+			},
+			want: want{
+				class: StatusCodeRetryable,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := ClassifyStatusCode(tc.args.policy, tc.args.statusCode)
+			if diff := cmp.Diff(tc.want.class, got); diff != "" {
+				t.Errorf("ClassifyStatusCode(...): -want class, +got class: %s", diff)
+			}
+		})
+	}
+}
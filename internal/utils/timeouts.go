@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+// RequestTimeout returns the timeout of the requests of a resource: its wait timeout when set, otherwise the overall
+// timeout of its ProviderConfig when set, otherwise the default wait timeout.
+func RequestTimeout(waitTimeout *v1.Duration, timeouts *apisv1alpha1.HTTPTimeouts) time.Duration {
+	if waitTimeout == nil && timeouts != nil && timeouts.Overall != nil {
+		return timeouts.Overall.Duration
+	}
+	return WaitTimeout(waitTimeout)
+}
+
+// TransportTimeoutOptions returns the client options bounding the phases of requests with the given timeouts of a
+// ProviderConfig, none when it sets none.
+func TransportTimeoutOptions(timeouts *apisv1alpha1.HTTPTimeouts) []httpClient.ClientOption {
+	if timeouts == nil || (timeouts.Dial == nil && timeouts.TLSHandshake == nil && timeouts.ResponseHeader == nil) {
+		return nil
+	}
+
+	return []httpClient.ClientOption{httpClient.WithTransportTimeouts(duration(timeouts.Dial), duration(timeouts.TLSHandshake), duration(timeouts.ResponseHeader))}
+}
+
+// duration returns the given duration, zero when it is not set.
+func duration(d *v1.Duration) time.Duration {
+	if d == nil {
+		return 0
+	}
+	return d.Duration
+}
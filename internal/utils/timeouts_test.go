@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+)
+
+func Test_RequestTimeout(t *testing.T) {
+	type args struct {
+		waitTimeout *v1.Duration
+		timeouts    *apisv1alpha1.HTTPTimeouts
+	}
+	cases := map[string]struct {
+		args args
+		want time.Duration
+	}{
+		"Default": {
+			args: args{},
+			want: defaultWaitTimeout,
+		},
+		"NoOverallTimeout": {
+			args: args{
+				timeouts: &apisv1alpha1.HTTPTimeouts{Dial: &v1.Duration{Duration: time.Second}},
+			},
+			want: defaultWaitTimeout,
+		},
+		"OverallTimeout": {
+			args: args{
+				timeouts: &apisv1alpha1.HTTPTimeouts{Overall: &v1.Duration{Duration: time.Minute}},
+			},
+			want: time.Minute,
+		},
+		"WaitTimeoutOverridesOverallTimeout": {
+			args: args{
+				waitTimeout: &v1.Duration{Duration: 10 * time.Second},
+				timeouts:    &apisv1alpha1.HTTPTimeouts{Overall: &v1.Duration{Duration: time.Minute}},
+			},
+			want: 10 * time.Second,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := RequestTimeout(tc.args.waitTimeout, tc.args.timeouts)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RequestTimeout(...): -want, +got: %s", diff)
+			}
+		})
+	}
+}
+
+func Test_TransportTimeoutOptions(t *testing.T) {
+	cases := map[string]struct {
+		timeouts *apisv1alpha1.HTTPTimeouts
+		want     int
+	}{
+		"Unset": {
+			want: 0,
+		},
+		"OverallOnly": {
+			timeouts: &apisv1alpha1.HTTPTimeouts{Overall: &v1.Duration{Duration: time.Minute}},
+			want:     0,
+		},
+		"ResponseHeader": {
+			timeouts: &apisv1alpha1.HTTPTimeouts{ResponseHeader: &v1.Duration{Duration: time.Second}},
+			want:     1,
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := TransportTimeoutOptions(tc.timeouts)
+			if diff := cmp.Diff(tc.want, len(got)); diff != "" {
+				t.Errorf("TransportTimeoutOptions(...): -want options, +got options: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"time"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+// timingPrecision is the precision of the recorded timings.
+const timingPrecision = time.Microsecond
+
+// Timings returns the given timings of a request as recorded in the status of resources, or nil when the request
+// was not sent.
+func Timings(timings *httpClient.Timings) *apisv1alpha1.Timings {
+	if timings == nil {
+		return nil
+	}
+
+	return &apisv1alpha1.Timings{
+		DNS:       phase(timings.DNS),
+		Connect:   phase(timings.Connect),
+		TLS:       phase(timings.TLS),
+		FirstByte: phase(timings.FirstByte),
+		Total:     v1.Duration{Duration: timings.Total.Round(timingPrecision)},
+	}
+}
+
+// phase returns the given duration of a phase of a request, or nil when the phase did not happen.
+func phase(d time.Duration) *v1.Duration {
+	if d <= 0 {
+		return nil
+	}
+	return &v1.Duration{Duration: d.Round(timingPrecision)}
+}
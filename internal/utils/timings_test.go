@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/arielsepton/provider-http/apis/v1alpha1"
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+)
+
+func Test_Timings(t *testing.T) {
+	cases := map[string]struct {
+		timings *httpClient.Timings
+		want    *apisv1alpha1.Timings
+	}{
+		"NotSent": {
+			timings: nil,
+			want:    nil,
+		},
+		"PlainHTTP": {
+			timings: &httpClient.Timings{Connect: 1500 * time.Nanosecond, FirstByte: 20 * time.Millisecond, Total: 21*time.Millisecond + 400*time.Nanosecond},
+			want: &apisv1alpha1.Timings{
+				Connect:   &v1.Duration{Duration: 2 * time.Microsecond},
+				FirstByte: &v1.Duration{Duration: 20 * time.Millisecond},
+				Total:     v1.Duration{Duration: 21 * time.Millisecond},
+			},
+		},
+		"HTTPS": {
+			timings: &httpClient.Timings{DNS: time.Millisecond, Connect: 2 * time.Millisecond, TLS: 5 * time.Millisecond, FirstByte: 30 * time.Millisecond, Total: 40 * time.Millisecond},
+			want: &apisv1alpha1.Timings{
+				DNS:       &v1.Duration{Duration: time.Millisecond},
+				Connect:   &v1.Duration{Duration: 2 * time.Millisecond},
+				TLS:       &v1.Duration{Duration: 5 * time.Millisecond},
+				FirstByte: &v1.Duration{Duration: 30 * time.Millisecond},
+				Total:     v1.Duration{Duration: 40 * time.Millisecond},
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := Timings(tc.timings)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Timings(...): -want timings, +got timings: %s", diff)
+			}
+		})
+	}
+}
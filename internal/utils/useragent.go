@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"fmt"
+
+	httpClient "github.com/arielsepton/provider-http/internal/clients/http"
+	"github.com/arielsepton/provider-http/internal/version"
+)
+
+const headerUserAgent = "User-Agent"
+
+// UserAgent returns the User-Agent of the requests of resources of the given kind: the given one of their
+// ProviderConfig, or provider-http/<version> (<kind>) when it sets none.
+func UserAgent(userAgent, kind string) string {
+	if userAgent != "" {
+		return userAgent
+	}
+
+	return fmt.Sprintf("provider-http/%s (%s)", version.Version, kind)
+}
+
+// UserAgentOptions returns the client options setting the User-Agent of the requests of resources of the given
+// kind, on the requests that do not set it explicitly.
+func UserAgentOptions(userAgent, kind string) []httpClient.ClientOption {
+	return []httpClient.ClientOption{httpClient.WithHeader(headerUserAgent, UserAgent(userAgent, kind))}
+}
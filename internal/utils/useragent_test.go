@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_UserAgent(t *testing.T) {
+	cases := map[string]struct {
+		userAgent string
+		kind      string
+		want      string
+	}{
+		"Default": {
+			kind: "Request",
+			want: "provider-http/dev (Request)",
+		},
+		"Override": {
+			userAgent: "acme-platform/1.0",
+			kind:      "Request",
+			want:      "acme-platform/1.0",
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got := UserAgent(tc.userAgent, tc.kind)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("UserAgent(...): -want user agent, +got user agent: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook receives the calls of external systems asking for a Request to be reconciled right away,
+// e.g. when the remote API notifies a change, instead of at its next poll.
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/arielsepton/provider-http/apis/request/v1alpha1"
+)
+
+const (
+	// SecretHeader is the header holding the shared secret of the calls.
+	SecretHeader = "X-Webhook-Secret"
+
+	// RequestsPath is the path prefix of the calls, followed by the name of the Request to reconcile.
+	RequestsPath = "/requests/"
+
+	errServe    = "cannot serve webhooks"
+	errShutdown = "cannot shut down webhook server"
+
+	eventBufferSize   = 128
+	readHeaderTimeout = 10 * time.Second
+	shutdownTimeout   = 5 * time.Second
+)
+
+// A Receiver is an HTTP server triggering the reconcile of the Requests named by its calls. Calls must hold the
+// shared secret of the Receiver in their X-Webhook-Secret header.
+type Receiver struct {
+	address string
+	secret  []byte
+	kube    client.Reader
+	logger  logging.Logger
+	events  chan event.GenericEvent
+}
+
+// New returns a Receiver listening on the given address, accepting calls holding the given secret and looking up
+// the Requests they name with the given reader.
+func New(address, secret string, kube client.Reader, logger logging.Logger) *Receiver {
+	return &Receiver{
+		address: address,
+		secret:  []byte(secret),
+		kube:    kube,
+		logger:  logger,
+		events:  make(chan event.GenericEvent, eventBufferSize),
+	}
+}
+
+// Events returns the events of the Requests to reconcile, to be watched by the Request controller.
+func (r *Receiver) Events() <-chan event.GenericEvent {
+	return r.events
+}
+
+// ServeHTTP handles a POST to /requests/<name> by triggering the reconcile of the named Request.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(req.Header.Get(SecretHeader)), r.secret) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	name := strings.TrimPrefix(req.URL.Path, RequestsPath)
+	if !strings.HasPrefix(req.URL.Path, RequestsPath) || name == "" || strings.Contains(name, "/") {
+		http.NotFound(w, req)
+		return
+	}
+
+	cr := &v1alpha1.Request{}
+	if err := r.kube.Get(req.Context(), types.NamespacedName{Name: name}, cr); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			http.NotFound(w, req)
+			return
+		}
+		r.logger.Info("Cannot get the Request of a webhook call", "request", name, "error", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	select {
+	case r.events <- event.GenericEvent{Object: &v1alpha1.Request{ObjectMeta: metav1.ObjectMeta{Name: name}}}:
+		r.logger.Debug("Webhook call triggered the reconcile of a Request", "request", name)
+		w.WriteHeader(http.StatusAccepted)
+	case <-req.Context().Done():
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+	}
+}
+
+// Start serves webhook calls until the given context is done. It implements the Runnable of the controller
+// manager, which starts it once the provider is the leader, along with the controllers.
+func (r *Receiver) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              r.address,
+		Handler:           r,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+	r.logger.Info("Serving webhooks", "address", r.address)
+
+	select {
+	case err := <-errs:
+		return errors.Wrap(err, errServe)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return errors.Wrap(server.Shutdown(shutdownCtx), errShutdown)
+}
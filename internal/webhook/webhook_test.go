@@ -0,0 +1,114 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const testSecret = "s3cr3t"
+
+func Test_ServeHTTP(t *testing.T) {
+	type want struct {
+		statusCode int
+		triggered  string
+	}
+	cases := map[string]struct {
+		method string
+		path   string
+		secret string
+		get    test.MockGetFn
+		want   want
+	}{
+		"Triggered": {
+			method: http.MethodPost,
+			path:   "/requests/user",
+			secret: testSecret,
+			get:    test.NewMockGetFn(nil),
+			want:   want{statusCode: http.StatusAccepted, triggered: "user"},
+		},
+		"WrongSecret": {
+			method: http.MethodPost,
+			path:   "/requests/user",
+			secret: "guess",
+			get:    test.NewMockGetFn(nil),
+			want:   want{statusCode: http.StatusUnauthorized},
+		},
+		"WrongMethod": {
+			method: http.MethodGet,
+			path:   "/requests/user",
+			secret: testSecret,
+			get:    test.NewMockGetFn(nil),
+			want:   want{statusCode: http.StatusMethodNotAllowed},
+		},
+		"UnknownPath": {
+			method: http.MethodPost,
+			path:   "/users/user",
+			secret: testSecret,
+			get:    test.NewMockGetFn(nil),
+			want:   want{statusCode: http.StatusNotFound},
+		},
+		"UnknownRequest": {
+			method: http.MethodPost,
+			path:   "/requests/user",
+			secret: testSecret,
+			get:    test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{Resource: "requests"}, "user")),
+			want:   want{statusCode: http.StatusNotFound},
+		},
+		"GetFailed": {
+			method: http.MethodPost,
+			path:   "/requests/user",
+			secret: testSecret,
+			get:    test.NewMockGetFn(errors.New("boom")),
+			want:   want{statusCode: http.StatusInternalServerError},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			r := New(":0", testSecret, &test.MockClient{MockGet: tc.get}, logging.NewNopLogger())
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set(SecretHeader, tc.secret)
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			if diff := cmp.Diff(tc.want.statusCode, rec.Code); diff != "" {
+				t.Errorf("ServeHTTP(...): -want status code, +got status code: %s", diff)
+			}
+
+			var triggered string
+			select {
+			case e := <-r.Events():
+				triggered = e.Object.GetName()
+			default:
+			}
+			if diff := cmp.Diff(tc.want.triggered, triggered); diff != "" {
+				t.Errorf("ServeHTTP(...): -want triggered Request, +got triggered Request: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package xml converts XML documents to the structure of decoded JSON documents, so that they can be compared and
+// queried like JSON documents.
+package xml
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errDecode    = "cannot decode XML document"
+	errNoElement = "XML document has no root element"
+
+	attributePrefix = "@"
+)
+
+// TextKey is the key holding the text of elements that also hold attributes or child elements.
+const TextKey = "#text"
+
+// ToMap returns the given XML document as a map holding its root element by name. An element without attributes
+// and child elements is converted to its text. Other elements are converted to maps holding their attributes,
+// prefixed with @, their child elements by name, as lists when an element has several children of the same name,
+// and their text, if any, as #text. Namespaces are ignored and text is trimmed.
+func ToMap(document string) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, errors.New(errNoElement)
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, errDecode)
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeElement(decoder, start)
+			if err != nil {
+				return nil, errors.Wrap(err, errDecode)
+			}
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+// IsXML checks whether the given string is an XML document.
+func IsXML(document string) bool {
+	_, err := ToMap(document)
+	return err == nil
+}
+
+func decodeElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	element := map[string]interface{}{}
+	for _, attribute := range start.Attr {
+		if attribute.Name.Space == "xmlns" || attribute.Name.Local == "xmlns" {
+			continue
+		}
+		element[attributePrefix+attribute.Name.Local] = attribute.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			child, err := decodeElement(decoder, token)
+			if err != nil {
+				return nil, err
+			}
+			addChild(element, token.Name.Local, child)
+		case xml.CharData:
+			text.Write(token)
+		case xml.EndElement:
+			trimmed := strings.TrimSpace(text.String())
+			if len(element) == 0 {
+				return trimmed, nil
+			}
+			if trimmed != "" {
+				element[TextKey] = trimmed
+			}
+			return element, nil
+		}
+	}
+}
+
+// addChild adds the given child element to element, turning the children of the same name into a list.
+func addChild(element map[string]interface{}, name string, child interface{}) {
+	existing, exists := element[name]
+	if !exists {
+		element[name] = child
+		return
+	}
+
+	if list, ok := existing.([]interface{}); ok {
+		element[name] = append(list, child)
+		return
+	}
+
+	element[name] = []interface{}{existing, child}
+}
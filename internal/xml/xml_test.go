@@ -0,0 +1,81 @@
+package xml
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_ToMap(t *testing.T) {
+	type args struct {
+		document string
+	}
+	type want struct {
+		result map[string]interface{}
+		err    bool
+	}
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"Text": {
+			args: args{
+				document: `<username> john_doe </username>`,
+			},
+			want: want{
+				result: map[string]interface{}{"username": "john_doe"},
+			},
+		},
+		"Document": {
+			args: args{
+				document: `<?xml version="1.0" encoding="UTF-8"?>
+<user xmlns="http://example.com/users" id="123">
+	<username>john_doe</username>
+	<email primary="true">john.doe@example.com</email>
+	<role>admin</role>
+	<role>editor</role>
+	<address/>
+</user>`,
+			},
+			want: want{
+				result: map[string]interface{}{
+					"user": map[string]interface{}{
+						"@id":      "123",
+						"username": "john_doe",
+						"email":    map[string]interface{}{"@primary": "true", "#text": "john.doe@example.com"},
+						"role":     []interface{}{"admin", "editor"},
+						"address":  "",
+					},
+				},
+			},
+		},
+		"NotXML": {
+			args: args{
+				document: `{"username":"john_doe"}`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+		"Malformed": {
+			args: args{
+				document: `<user><username>john_doe</user>`,
+			},
+			want: want{
+				err: true,
+			},
+		},
+	}
+	for name, tc := range cases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			got, err := ToMap(tc.args.document)
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Fatalf("ToMap(...): -want error, +got error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("ToMap(...): -want result, +got result: %s", diff)
+			}
+		})
+	}
+}